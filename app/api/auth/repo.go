@@ -52,6 +52,24 @@ func CheckRepo(
 	return Check(ctx, authorizer, session, scope, resource, permission)
 }
 
+// CheckRepoForPrincipal checks if a repo specific permission is granted to the principal itself,
+// independent of the current auth session (e.g. to verify that a reviewer has access to the repo).
+// Returns nil if the permission is granted, otherwise returns an error.
+func CheckRepoForPrincipal(
+	ctx context.Context,
+	authorizer authz.Authorizer,
+	principal *types.Principal,
+	repo *types.Repository,
+	permission enum.Permission,
+) error {
+	session := &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}
+
+	return CheckRepo(ctx, authorizer, session, repo, permission)
+}
+
 func IsRepoOwner(
 	ctx context.Context,
 	authorizer authz.Authorizer,
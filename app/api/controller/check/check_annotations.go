@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// maxAnnotationsPerCheck is the maximum number of annotations that can be attached to a status check.
+	maxAnnotationsPerCheck = 1000
+
+	maxAnnotationTitleLength   = 256
+	maxAnnotationMessageLength = 64 * 1024
+)
+
+type AnnotationInput struct {
+	Path      string                       `json:"path"`
+	StartLine int                          `json:"start_line"`
+	EndLine   int                          `json:"end_line"`
+	Severity  enum.CheckAnnotationSeverity `json:"severity"`
+	Title     string                       `json:"title"`
+	Message   string                       `json:"message"`
+}
+
+// Sanitize validates and sanitizes the AnnotationInput data.
+func (in *AnnotationInput) Sanitize() error {
+	p, ok := sanitizeAnnotationPath(in.Path)
+	if !ok {
+		return usererror.BadRequestf("Invalid annotation path: %q", in.Path)
+	}
+	in.Path = p
+
+	if in.StartLine < 1 {
+		return usererror.BadRequest("Annotation start line must be a positive number")
+	}
+
+	if in.EndLine == 0 {
+		in.EndLine = in.StartLine
+	}
+
+	if in.EndLine < in.StartLine {
+		return usererror.BadRequest("Annotation end line must not be before the start line")
+	}
+
+	severity, ok := in.Severity.Sanitize()
+	if !ok {
+		return usererror.BadRequest("Invalid value provided for annotation severity")
+	}
+	in.Severity = severity
+
+	in.Title = strings.TrimSpace(in.Title)
+	if len(in.Title) > maxAnnotationTitleLength {
+		return usererror.BadRequestf("Annotation title can be at most %d characters long", maxAnnotationTitleLength)
+	}
+
+	in.Message = strings.TrimSpace(in.Message)
+	if in.Message == "" {
+		return usererror.BadRequest("Annotation message is missing")
+	}
+
+	if len(in.Message) > maxAnnotationMessageLength {
+		return usererror.BadRequestf("Annotation message can be at most %d bytes long", maxAnnotationMessageLength)
+	}
+
+	return nil
+}
+
+// sanitizeAnnotationPath converts the path to a clean path relative to the repository root.
+func sanitizeAnnotationPath(p string) (string, bool) {
+	p = strings.TrimPrefix(strings.TrimSpace(p), "./")
+	if p == "" || strings.HasPrefix(p, "/") {
+		return "", false
+	}
+
+	p = path.Clean(p)
+	if p == "." || p == ".." || strings.HasPrefix(p, "../") {
+		return "", false
+	}
+
+	return p, true
+}
+
+type ReportAnnotationsInput struct {
+	Annotations []AnnotationInput `json:"annotations"`
+}
+
+// Sanitize validates and sanitizes the ReportAnnotationsInput data.
+func (in *ReportAnnotationsInput) Sanitize() error {
+	if len(in.Annotations) > maxAnnotationsPerCheck {
+		return usererror.BadRequestf("At most %d annotations can be reported for a status check",
+			maxAnnotationsPerCheck)
+	}
+
+	for i := range in.Annotations {
+		if err := in.Annotations[i].Sanitize(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReportAnnotations replaces annotations of an existing status check of a specific commit.
+func (c *Controller) ReportAnnotations(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	identifier string,
+	in *ReportAnnotationsInput,
+) ([]types.CheckAnnotation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReportCommitCheck)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err = in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	check, err := c.checkStore.FindByIdentifier(ctx, repo.ID, commitSHA, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find status check %q: %w", identifier, err)
+	}
+
+	return c.replaceAnnotations(ctx, check.ID, in.Annotations)
+}
+
+func (c *Controller) replaceAnnotations(
+	ctx context.Context,
+	checkID int64,
+	in []AnnotationInput,
+) ([]types.CheckAnnotation, error) {
+	now := time.Now().UnixMilli()
+
+	annotations := make([]*types.CheckAnnotation, len(in))
+	for i := range in {
+		annotations[i] = &types.CheckAnnotation{
+			CheckID:   checkID,
+			Path:      in[i].Path,
+			StartLine: in[i].StartLine,
+			EndLine:   in[i].EndLine,
+			Severity:  in[i].Severity,
+			Title:     in[i].Title,
+			Message:   in[i].Message,
+			Created:   now,
+		}
+	}
+
+	var result []types.CheckAnnotation
+
+	err := c.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := c.checkAnnotationStore.ReplaceForCheck(ctx, checkID, annotations)
+		if err != nil {
+			return fmt.Errorf("failed to replace status check annotations: %w", err)
+		}
+
+		result, err = c.checkAnnotationStore.ListForCheck(ctx, checkID)
+		if err != nil {
+			return fmt.Errorf("failed to list status check annotations: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListAnnotations returns annotations of a status check of a specific commit.
+func (c *Controller) ListAnnotations(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	identifier string,
+) ([]types.CheckAnnotation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	check, err := c.checkStore.FindByIdentifier(ctx, repo.ID, commitSHA, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find status check %q: %w", identifier, err)
+	}
+
+	annotations, err := c.checkAnnotationStore.ListForCheck(ctx, check.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check annotations: %w", err)
+	}
+
+	return annotations, nil
+}
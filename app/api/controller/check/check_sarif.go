@@ -0,0 +1,318 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SARIFLog is the subset of a SARIF 2.1.0 log file that is required to produce status check annotations.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html for the full format specification.
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     *sarifMessage      `json:"shortDescription"`
+	DefaultConfiguration *sarifRuleDefaults `json:"defaultConfiguration"`
+}
+
+type sarifRuleDefaults struct {
+	Level string `json:"level"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex *int            `json:"ruleIndex"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
+const sarifVersion = "2.1.0"
+
+type ReportSARIFInput struct {
+	Identifier string
+	Link       string
+	Log        SARIFLog
+}
+
+var regexpInvalidCheckIdentifierChars = regexp.MustCompile("[^0-9a-zA-Z-_.]+")
+
+// Sanitize validates and sanitizes the ReportSARIFInput data.
+func (in *ReportSARIFInput) Sanitize() error {
+	if in.Log.Version != sarifVersion {
+		return usererror.BadRequestf("Unsupported SARIF version %q, only version %s is supported",
+			in.Log.Version, sarifVersion)
+	}
+
+	if in.Identifier == "" && len(in.Log.Runs) > 0 {
+		// default to the name of the tool that produced the report
+		in.Identifier = strings.ToLower(strings.Trim(
+			regexpInvalidCheckIdentifierChars.ReplaceAllString(in.Log.Runs[0].Tool.Driver.Name, "-"), "-"))
+	}
+
+	return nil
+}
+
+// sarifSummary holds the number of reported results per severity.
+type sarifSummary struct {
+	total     int
+	failures  int
+	warnings  int
+	notices   int
+	truncated bool
+}
+
+// parseSARIF converts the results of a SARIF log to status check annotations.
+// Results that aren't failures or that aren't attached to a file in the repository are skipped.
+func parseSARIF(log *SARIFLog) ([]AnnotationInput, sarifSummary) {
+	var annotations []AnnotationInput
+	var summary sarifSummary
+
+	for _, run := range log.Runs {
+		for _, result := range run.Results {
+			if result.Kind != "" && result.Kind != "fail" {
+				continue
+			}
+
+			rule := findSARIFRule(&run.Tool.Driver, &result)
+			severity := sarifSeverity(rule, &result)
+
+			summary.total++
+			switch severity {
+			case enum.CheckAnnotationSeverityFailure:
+				summary.failures++
+			case enum.CheckAnnotationSeverityWarning:
+				summary.warnings++
+			case enum.CheckAnnotationSeverityNotice:
+				summary.notices++
+			}
+
+			annotation, ok := sarifAnnotation(rule, &result)
+			if !ok {
+				continue
+			}
+
+			if len(annotations) >= maxAnnotationsPerCheck {
+				summary.truncated = true
+				continue
+			}
+
+			annotation.Severity = severity
+			annotations = append(annotations, annotation)
+		}
+	}
+
+	return annotations, summary
+}
+
+func findSARIFRule(driver *sarifDriver, result *sarifResult) *sarifRule {
+	if result.RuleIndex != nil && *result.RuleIndex >= 0 && *result.RuleIndex < len(driver.Rules) {
+		return &driver.Rules[*result.RuleIndex]
+	}
+
+	if result.RuleID == "" {
+		return nil
+	}
+
+	for i := range driver.Rules {
+		if driver.Rules[i].ID == result.RuleID {
+			return &driver.Rules[i]
+		}
+	}
+
+	return nil
+}
+
+func sarifSeverity(rule *sarifRule, result *sarifResult) enum.CheckAnnotationSeverity {
+	level := result.Level
+	if level == "" && rule != nil && rule.DefaultConfiguration != nil {
+		level = rule.DefaultConfiguration.Level
+	}
+
+	switch level {
+	case "error":
+		return enum.CheckAnnotationSeverityFailure
+	case "note", "none":
+		return enum.CheckAnnotationSeverityNotice
+	default: // "warning" is the default level according to the specification.
+		return enum.CheckAnnotationSeverityWarning
+	}
+}
+
+func sarifAnnotation(rule *sarifRule, result *sarifResult) (AnnotationInput, bool) {
+	var location *sarifPhysicalLocation
+	for _, l := range result.Locations {
+		if l.PhysicalLocation != nil && l.PhysicalLocation.ArtifactLocation.URI != "" {
+			location = l.PhysicalLocation
+			break
+		}
+	}
+	if location == nil {
+		return AnnotationInput{}, false
+	}
+
+	uri, err := url.Parse(location.ArtifactLocation.URI)
+	if err != nil || uri.Scheme != "" {
+		// only the URIs relative to the repository root can be mapped to a file
+		return AnnotationInput{}, false
+	}
+
+	p, ok := sanitizeAnnotationPath(uri.Path)
+	if !ok {
+		return AnnotationInput{}, false
+	}
+
+	startLine, endLine := 1, 1
+	if location.Region != nil && location.Region.StartLine > 0 {
+		startLine = location.Region.StartLine
+		endLine = startLine
+		if location.Region.EndLine > startLine {
+			endLine = location.Region.EndLine
+		}
+	}
+
+	message := strings.TrimSpace(result.Message.Text)
+	if message == "" && rule != nil && rule.ShortDescription != nil {
+		message = strings.TrimSpace(rule.ShortDescription.Text)
+	}
+	if message == "" {
+		message = result.RuleID
+	}
+	if message == "" {
+		return AnnotationInput{}, false
+	}
+	if len(message) > maxAnnotationMessageLength {
+		message = message[:maxAnnotationMessageLength]
+	}
+
+	title := result.RuleID
+	if len(title) > maxAnnotationTitleLength {
+		title = title[:maxAnnotationTitleLength]
+	}
+
+	return AnnotationInput{
+		Path:      p,
+		StartLine: startLine,
+		EndLine:   endLine,
+		Title:     title,
+		Message:   message,
+	}, true
+}
+
+// ReportSARIF creates or updates a status check of a specific commit from a SARIF report
+// and replaces its annotations with the results of the report.
+func (c *Controller) ReportSARIF(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *ReportSARIFInput,
+) (*types.Check, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	annotations, summary := parseSARIF(&in.Log)
+
+	status := enum.CheckStatusSuccess
+	if summary.failures > 0 {
+		status = enum.CheckStatusFailure
+	}
+
+	var details strings.Builder
+	for _, run := range in.Log.Runs {
+		fmt.Fprintf(&details, "- **%s**: %d result(s)\n", run.Tool.Driver.Name, len(run.Results))
+	}
+	if summary.truncated {
+		fmt.Fprintf(&details, "\nOnly the first %d results are shown as annotations.\n", maxAnnotationsPerCheck)
+	}
+
+	payloadData, err := json.Marshal(types.CheckPayloadText{Details: details.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal status check payload: %w", err)
+	}
+
+	check, err := c.Report(ctx, session, repoRef, commitSHA, &ReportInput{
+		Identifier: in.Identifier,
+		Status:     status,
+		Summary: fmt.Sprintf("%d result(s): %d error(s), %d warning(s), %d note(s)",
+			summary.total, summary.failures, summary.warnings, summary.notices),
+		Link: in.Link,
+		Payload: types.CheckPayload{
+			Kind: enum.CheckPayloadKindMarkdown,
+			Data: payloadData,
+		},
+	}, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = c.replaceAnnotations(ctx, check.ID, annotations); err != nil {
+		return nil, err
+	}
+
+	return check, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func Test_parseSARIF(t *testing.T) {
+	const report = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "scanner", "rules": [
+      {"id": "R1", "shortDescription": {"text": "rule one"}, "defaultConfiguration": {"level": "error"}},
+      {"id": "R2"}
+    ]}},
+    "results": [
+      {"ruleId": "R1", "message": {"text": "first"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/a.go"},
+         "region": {"startLine": 3, "endLine": 5}}}]},
+      {"ruleId": "R2", "ruleIndex": 1, "level": "note", "message": {"text": "second"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "./src/b%20c.go"}}}]},
+      {"ruleId": "R1", "message": {},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/d.go"},
+         "region": {"startLine": 7}}}]},
+      {"ruleId": "R2", "message": {"text": "outside"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "file:///tmp/x.go"}}}]},
+      {"ruleId": "R2", "message": {"text": "no location"}},
+      {"ruleId": "R2", "kind": "pass", "message": {"text": "passed"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/a.go"}}}]}
+    ]
+  }]
+}`
+
+	log := &SARIFLog{}
+	if err := json.Unmarshal([]byte(report), log); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+
+	annotations, summary := parseSARIF(log)
+
+	want := []AnnotationInput{
+		{Path: "src/a.go", StartLine: 3, EndLine: 5, Severity: enum.CheckAnnotationSeverityFailure,
+			Title: "R1", Message: "first"},
+		{Path: "src/b c.go", StartLine: 1, EndLine: 1, Severity: enum.CheckAnnotationSeverityNotice,
+			Title: "R2", Message: "second"},
+		{Path: "src/d.go", StartLine: 7, EndLine: 7, Severity: enum.CheckAnnotationSeverityFailure,
+			Title: "R1", Message: "rule one"},
+	}
+
+	if len(annotations) != len(want) {
+		t.Fatalf("got %d annotations, want %d: %+v", len(annotations), len(want), annotations)
+	}
+
+	for i := range want {
+		if annotations[i] != want[i] {
+			t.Errorf("annotation %d: got %+v, want %+v", i, annotations[i], want[i])
+		}
+	}
+
+	wantSummary := sarifSummary{total: 5, failures: 2, warnings: 2, notices: 1}
+	if summary != wantSummary {
+		t.Errorf("got summary %+v, want %+v", summary, wantSummary)
+	}
+}
+
+func Test_sanitizeAnnotationPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{path: "a/b.go", want: "a/b.go", ok: true},
+		{path: "./a//b.go", want: "a/b.go", ok: true},
+		{path: "a/../b.go", want: "b.go", ok: true},
+		{path: "", ok: false},
+		{path: "/etc/passwd", ok: false},
+		{path: "../b.go", ok: false},
+		{path: ".", ok: false},
+	}
+
+	for _, test := range tests {
+		got, ok := sanitizeAnnotationPath(test.path)
+		if got != test.want || ok != test.ok {
+			t.Errorf("sanitizeAnnotationPath(%q) = (%q, %t), want (%q, %t)", test.path, got, ok, test.want, test.ok)
+		}
+	}
+}
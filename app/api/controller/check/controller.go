@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -36,6 +37,10 @@ type Controller struct {
 	checkStore store.CheckStore
 	git        git.Interface
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error
+
+	checkEvReporter *checkevents.Reporter
+
+	checkAnnotationStore store.CheckAnnotationStore
 }
 
 func NewController(
@@ -45,14 +50,19 @@ func NewController(
 	checkStore store.CheckStore,
 	git git.Interface,
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error,
+	checkEvReporter *checkevents.Reporter,
+	checkAnnotationStore store.CheckAnnotationStore,
 ) *Controller {
 	return &Controller{
-		tx:         tx,
-		authorizer: authorizer,
-		repoStore:  repoStore,
-		checkStore: checkStore,
-		git:        git,
-		sanitizers: sanitizers,
+		tx:              tx,
+		authorizer:      authorizer,
+		repoStore:       repoStore,
+		checkStore:      checkStore,
+		git:             git,
+		sanitizers:      sanitizers,
+		checkEvReporter: checkEvReporter,
+
+		checkAnnotationStore: checkAnnotationStore,
 	}
 }
 
@@ -24,9 +24,12 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 var (
@@ -87,6 +90,17 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("connector creation failed: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeConnector, connector.Identifier),
+		audit.ActionCreated,
+		parentSpace.Path,
+		audit.WithNewObject(connector),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create connector operation: %s", err)
+	}
+
 	return connector, nil
 }
 
@@ -59,7 +59,7 @@ func (c *Controller) Cancel(
 	}
 
 	// Write to the checks store, log and ignore on errors
-	err = checks.Write(ctx, c.checkStore, execution, pipeline)
+	err = checks.Write(ctx, c.checkStore, c.checkEvReporter, execution, pipeline)
 	if err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("could not update status check")
 	}
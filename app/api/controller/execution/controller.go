@@ -16,6 +16,7 @@ package execution
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
@@ -24,16 +25,17 @@ import (
 )
 
 type Controller struct {
-	tx             dbtx.Transactor
-	authorizer     authz.Authorizer
-	executionStore store.ExecutionStore
-	checkStore     store.CheckStore
-	canceler       canceler.Canceler
-	commitService  commit.Service
-	triggerer      triggerer.Triggerer
-	repoStore      store.RepoStore
-	stageStore     store.StageStore
-	pipelineStore  store.PipelineStore
+	tx              dbtx.Transactor
+	authorizer      authz.Authorizer
+	executionStore  store.ExecutionStore
+	checkStore      store.CheckStore
+	canceler        canceler.Canceler
+	commitService   commit.Service
+	triggerer       triggerer.Triggerer
+	repoStore       store.RepoStore
+	stageStore      store.StageStore
+	pipelineStore   store.PipelineStore
+	checkEvReporter *checkevents.Reporter
 }
 
 func NewController(
@@ -47,17 +49,19 @@ func NewController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	checkEvReporter *checkevents.Reporter,
 ) *Controller {
 	return &Controller{
-		tx:             tx,
-		authorizer:     authorizer,
-		executionStore: executionStore,
-		checkStore:     checkStore,
-		canceler:       canceler,
-		commitService:  commitService,
-		triggerer:      triggerer,
-		repoStore:      repoStore,
-		stageStore:     stageStore,
-		pipelineStore:  pipelineStore,
+		tx:              tx,
+		authorizer:      authorizer,
+		executionStore:  executionStore,
+		checkStore:      checkStore,
+		canceler:        canceler,
+		commitService:   commitService,
+		triggerer:       triggerer,
+		repoStore:       repoStore,
+		stageStore:      stageStore,
+		pipelineStore:   pipelineStore,
+		checkEvReporter: checkEvReporter,
 	}
 }
@@ -16,6 +16,7 @@ package execution
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
@@ -41,7 +42,8 @@ func ProvideController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	checkEvReporter *checkevents.Reporter,
 ) *Controller {
 	return NewController(tx, authorizer, executionStore, checkStore,
-		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore)
+		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore, checkEvReporter)
 }
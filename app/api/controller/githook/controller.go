@@ -25,9 +25,11 @@ import (
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/api"
@@ -52,6 +54,9 @@ type Controller struct {
 	preReceiveExtender  PreReceiveExtender
 	updateExtender      UpdateExtender
 	postReceiveExtender PostReceiveExtender
+	auditService        audit.Service
+	publicKeyService    publickey.Service
+	repoMirrorStore     store.RepoMirrorStore
 }
 
 func NewController(
@@ -69,6 +74,9 @@ func NewController(
 	preReceiveExtender PreReceiveExtender,
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
+	auditService audit.Service,
+	publicKeyService publickey.Service,
+	repoMirrorStore store.RepoMirrorStore,
 ) *Controller {
 	return &Controller{
 		authorizer:          authorizer,
@@ -85,6 +93,9 @@ func NewController(
 		preReceiveExtender:  preReceiveExtender,
 		updateExtender:      updateExtender,
 		postReceiveExtender: postReceiveExtender,
+		auditService:        auditService,
+		publicKeyService:    publicKeyService,
+		repoMirrorStore:     repoMirrorStore,
 	}
 }
 
@@ -36,4 +36,5 @@ type RestrictedGIT interface {
 		ctx context.Context,
 		params *git.FindOversizeFilesParams,
 	) (*git.FindOversizeFilesOutput, error)
+	ListCommits(ctx context.Context, params *git.ListCommitsParams) (*git.ListCommitsOutput, error)
 }
@@ -16,6 +16,7 @@ package githook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git/hook"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -61,6 +63,16 @@ func (c *Controller) PreReceive(
 		return output, nil
 	}
 
+	// Branches and tags of a mirror are updated only by synchronizing with the source repository.
+	blocked, err := c.blockMirrorRefUpdate(ctx, repo, refUpdates)
+	if err != nil {
+		return hook.Output{}, err
+	}
+	if blocked {
+		output.Error = ptr.String(usererror.ErrMirrorRefsCantBeModified.Error())
+		return output, nil
+	}
+
 	// For external calls (git pushes) block modification of pullreq references.
 	if !in.Internal && c.blockPullReqRefUpdate(refUpdates) {
 		output.Error = ptr.String(usererror.ErrPullReqRefsCantBeModified.Error())
@@ -68,16 +80,18 @@ func (c *Controller) PreReceive(
 	}
 
 	// For internal calls - through the application interface (API) - no need to verify protection rules.
+	var principal *types.Principal
+	var ruleViolations []types.RuleViolations
 	if !in.Internal {
 		// TODO: use store.PrincipalInfoCache once we abstracted principals.
-		principal, err := c.principalStore.Find(ctx, in.PrincipalID)
+		principal, err = c.principalStore.Find(ctx, in.PrincipalID)
 		if err != nil {
 			return hook.Output{}, fmt.Errorf("failed to find inner principal with id %d: %w", in.PrincipalID, err)
 		}
 
 		dummySession := &auth.Session{Principal: *principal, Metadata: nil}
 
-		err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+		ruleViolations, err = c.checkProtectionRules(ctx, rgit, dummySession, repo, in, refUpdates, &output)
 		if err != nil {
 			return hook.Output{}, fmt.Errorf("failed to check protection rules: %w", err)
 		}
@@ -101,6 +115,11 @@ func (c *Controller) PreReceive(
 		return hook.Output{}, err
 	}
 
+	// Audit only pushes that are accepted (internal calls are audited as part of the API operation).
+	if principal != nil && output.Error == nil {
+		c.auditPush(ctx, rgit, principal, repo, in, ruleViolations)
+	}
+
 	return output, nil
 }
 
@@ -114,21 +133,43 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs) bool {
 		slices.ContainsFunc(refUpdates.other.updated, fn)
 }
 
+func (c *Controller) blockMirrorRefUpdate(
+	ctx context.Context,
+	repo *types.Repository,
+	refUpdates changedRefs,
+) (bool, error) {
+	if refUpdates.branches.isEmpty() && refUpdates.tags.isEmpty() {
+		return false, nil
+	}
+
+	_, err := c.repoMirrorStore.Find(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo mirror: %w", err)
+	}
+
+	return true, nil
+}
+
 func (c *Controller) checkProtectionRules(
 	ctx context.Context,
+	rgit RestrictedGIT,
 	session *auth.Session,
 	repo *types.Repository,
+	in types.GithookPreReceiveInput,
 	refUpdates changedRefs,
 	output *hook.Output,
-) error {
+) ([]types.RuleViolations, error) {
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
 	if err != nil {
-		return fmt.Errorf("failed to determine if user is repo owner: %w", err)
+		return nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
 	}
 
 	protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+		return nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
 	}
 
 	var ruleViolations []types.RuleViolations
@@ -147,6 +188,8 @@ func (c *Controller) checkProtectionRules(
 			RefAction:   refAction,
 			RefType:     refType,
 			RefNames:    names,
+
+			VerifyCommitSignatures: c.verifyCommitSignaturesFunc(rgit, repo, in),
 		})
 		if err != nil {
 			errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
@@ -159,9 +202,12 @@ func (c *Controller) checkProtectionRules(
 	checkAction(protection.RefActionCreate, protection.RefTypeBranch, refUpdates.branches.created)
 	checkAction(protection.RefActionDelete, protection.RefTypeBranch, refUpdates.branches.deleted)
 	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, refUpdates.branches.updated)
+	checkAction(protection.RefActionCreate, protection.RefTypeTag, refUpdates.tags.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeTag, refUpdates.tags.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeTag, refUpdates.tags.updated)
 
 	if errCheckAction != nil {
-		return errCheckAction
+		return nil, errCheckAction
 	}
 
 	var criticalViolation bool
@@ -183,7 +229,7 @@ func (c *Controller) checkProtectionRules(
 		output.Error = ptr.String("Blocked by protection rules.")
 	}
 
-	return nil
+	return ruleViolations, nil
 }
 
 type changes struct {
@@ -203,6 +249,10 @@ func (c *changes) groupByAction(refUpdate hook.ReferenceUpdate, name string) {
 	}
 }
 
+func (c *changes) isEmpty() bool {
+	return len(c.created) == 0 && len(c.deleted) == 0 && len(c.updated) == 0
+}
+
 type changedRefs struct {
 	branches changes
 	tags     changes
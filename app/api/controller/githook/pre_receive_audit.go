@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// auditPush inserts audit events for security relevant parts of an accepted git push,
+// which are bypassed protection rules and force pushes of branches.
+// NOTE: Audit is best effort and doesn't change the outcome of the git operation.
+func (c *Controller) auditPush(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	principal *types.Principal,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	ruleViolations []types.RuleViolations,
+) {
+	spacePath := paths.Parent(repo.Path)
+
+	for i := range ruleViolations {
+		if !ruleViolations[i].IsBypassed() {
+			continue
+		}
+
+		err := c.auditService.Log(ctx,
+			*principal,
+			audit.NewResource(audit.ResourceTypeBranchRule, ruleViolations[i].Rule.Identifier),
+			audit.ActionBypassed,
+			spacePath,
+			audit.WithNewObject(ruleViolations[i]),
+			audit.WithData("repo_path", repo.Path),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for bypassing rules during push: %s", err)
+		}
+	}
+
+	for _, refUpdate := range in.RefUpdates {
+		if !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) ||
+			refUpdate.Old.IsNil() || refUpdate.New.IsNil() {
+			continue
+		}
+
+		result, err := rgit.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams: git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			AncestorCommitSHA:   refUpdate.Old,
+			DescendantCommitSHA: refUpdate.New,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("ref", refUpdate.Ref).
+				Msg("failed to check ancestor for audit of force push")
+			continue
+		}
+		if result.Ancestor {
+			continue
+		}
+
+		err = c.auditService.Log(ctx,
+			*principal,
+			audit.NewResource(audit.ResourceTypeBranch, refUpdate.Ref[len(gitReferenceNamePrefixBranch):]),
+			audit.ActionForcePushed,
+			spacePath,
+			audit.WithData(
+				"repo_path", repo.Path,
+				"old_sha", refUpdate.Old.String(),
+				"new_sha", refUpdate.New.String(),
+			),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for force push: %s", err)
+		}
+	}
+}
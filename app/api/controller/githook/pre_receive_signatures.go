@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// verifyCommitSignaturesFunc returns the function used by protection rules to verify the signatures
+// of the commits that are pushed to a branch. New branches are compared against the default branch.
+func (c *Controller) verifyCommitSignaturesFunc(
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) protection.VerifyCommitSignaturesFunc {
+	return func(ctx context.Context, branch string) (protection.VerifyCommitSignaturesOutput, error) {
+		ref := gitReferenceNamePrefixBranch + branch
+
+		for _, refUpdate := range in.RefUpdates {
+			if refUpdate.Ref != ref || refUpdate.New.IsNil() {
+				continue
+			}
+
+			baseSHA, baseAvailable, err := GetBaseSHAForScanningChanges(
+				ctx,
+				rgit,
+				repo,
+				in.Environment,
+				in.RefUpdates,
+				refUpdate,
+			)
+			if err != nil {
+				return protection.VerifyCommitSignaturesOutput{}, fmt.Errorf("failed to get base sha: %w", err)
+			}
+
+			var afterRef string
+			if baseAvailable {
+				afterRef = baseSHA.String()
+			}
+
+			return controller.VerifyNewCommitSignatures(
+				ctx,
+				rgit,
+				c.publicKeyService,
+				git.ReadParams{
+					RepoUID:             repo.GitUID,
+					AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+				},
+				refUpdate.New.String(),
+				afterRef,
+			)
+		}
+
+		return protection.VerifyCommitSignaturesOutput{}, nil
+	}
+}
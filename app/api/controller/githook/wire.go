@@ -20,9 +20,11 @@ import (
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 
@@ -56,6 +58,9 @@ func ProvideController(
 	preReceiveExtender PreReceiveExtender,
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
+	auditService audit.Service,
+	publicKeyService publickey.Service,
+	repoMirrorStore store.RepoMirrorStore,
 ) *Controller {
 	ctrl := NewController(
 		authorizer,
@@ -72,6 +77,9 @@ func ProvideController(
 		preReceiveExtender,
 		updateExtender,
 		postReceiveExtender,
+		auditService,
+		publicKeyService,
+		repoMirrorStore,
 	)
 
 	// TODO: improve wiring if possible
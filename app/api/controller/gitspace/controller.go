@@ -17,6 +17,7 @@ package gitspace
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
 )
 
 type Controller struct {
@@ -25,6 +26,8 @@ type Controller struct {
 	gitspaceConfigStore        store.GitspaceConfigStore
 	gitspaceInstanceStore      store.GitspaceInstanceStore
 	spaceStore                 store.SpaceStore
+	repoStore                  store.RepoStore
+	urlProvider                url.Provider
 }
 
 // TODO Stubbed Impl
@@ -34,6 +37,8 @@ func NewController(
 	gitspaceConfigStore store.GitspaceConfigStore,
 	gitspaceInstanceStore store.GitspaceInstanceStore,
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	urlProvider url.Provider,
 ) *Controller {
 	return &Controller{
 		authorizer:                 authorizer,
@@ -41,5 +46,7 @@ func NewController(
 		gitspaceConfigStore:        gitspaceConfigStore,
 		gitspaceInstanceStore:      gitspaceInstanceStore,
 		spaceStore:                 spaceStore,
+		repoStore:                  repoStore,
+		urlProvider:                urlProvider,
 	}
 }
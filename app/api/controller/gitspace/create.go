@@ -17,7 +17,9 @@ package gitspace
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -39,9 +41,30 @@ type CreateInput struct {
 
 // Create creates a new gitspace.
 func (c *Controller) Create(
-	_ context.Context,
-	_ *auth.Session,
-	_ *CreateInput,
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateInput,
 ) (*types.GitspaceConfig, error) {
+	err := c.checkCodeRepoAccess(ctx, session, in.CodeRepoURL)
+	if err != nil {
+		return nil, err
+	}
+
 	return nil, errors.New("unimplemented")
 }
+
+// checkCodeRepoAccess checks that the principal is allowed to view the code repository
+// if it's hosted by this Gitness instance.
+func (c *Controller) checkCodeRepoAccess(ctx context.Context, session *auth.Session, codeRepoURL string) error {
+	repoPath, ok := c.urlProvider.GetRepoPathFromGITCloneURL(codeRepoURL)
+	if !ok {
+		return nil
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to find repository %s: %w", repoPath, err)
+	}
+
+	return apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView)
+}
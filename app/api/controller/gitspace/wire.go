@@ -17,6 +17,7 @@ package gitspace
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
 
 	"github.com/google/wire"
 )
@@ -32,6 +33,8 @@ func ProvideController(
 	configStore store.GitspaceConfigStore,
 	instanceStore store.GitspaceInstanceStore,
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	urlProvider url.Provider,
 ) *Controller {
-	return NewController(authorizer, resourceStore, configStore, instanceStore, spaceStore)
+	return NewController(authorizer, resourceStore, configStore, instanceStore, spaceStore, repoStore, urlProvider)
 }
@@ -0,0 +1,196 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Operation string
+
+const (
+	OperationDownload Operation = "download"
+	OperationUpload   Operation = "upload"
+)
+
+// Reference identifies the git ref an LFS request refers to.
+type Reference struct {
+	Name string `json:"name"`
+}
+
+// Pointer identifies an LFS object.
+type Pointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// BatchRequest is the body of an LFS batch API request.
+type BatchRequest struct {
+	Operation Operation  `json:"operation"`
+	Transfers []string   `json:"transfers,omitempty"`
+	Ref       *Reference `json:"ref,omitempty"`
+	Objects   []Pointer  `json:"objects"`
+	HashAlgo  string     `json:"hash_algo,omitempty"`
+}
+
+// Action describes how the client should transfer an LFS object.
+type Action struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+// ObjectError describes why an individual LFS object can't be transferred.
+type ObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ObjectResponse is the batch API response for a single LFS object.
+type ObjectResponse struct {
+	Pointer
+	Authenticated bool               `json:"authenticated,omitempty"`
+	Actions       map[string]*Action `json:"actions,omitempty"`
+	Error         *ObjectError       `json:"error,omitempty"`
+}
+
+// BatchResponse is the body of an LFS batch API response.
+type BatchResponse struct {
+	Transfer string           `json:"transfer"`
+	Objects  []ObjectResponse `json:"objects"`
+	HashAlgo string           `json:"hash_algo"`
+}
+
+func (in *BatchRequest) sanitize() error {
+	if in.Operation != OperationDownload && in.Operation != OperationUpload {
+		return usererror.BadRequestf("Unsupported LFS operation %q.", in.Operation)
+	}
+
+	if in.HashAlgo != "" && in.HashAlgo != HashAlgorithmSHA256 {
+		return usererror.New(http.StatusConflict, "Only the sha256 hash algorithm is supported.")
+	}
+
+	if len(in.Transfers) == 0 {
+		return nil
+	}
+	for _, transfer := range in.Transfers {
+		if transfer == TransferBasic {
+			return nil
+		}
+	}
+
+	return usererror.New(http.StatusConflict, "Only the basic transfer adapter is supported.")
+}
+
+// Batch handles an LFS batch API request and returns the actions the client has to take for each object.
+func (c *Controller) Batch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *BatchRequest,
+) (*BatchResponse, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	reqPermission := enum.PermissionRepoView
+	if in.Operation == OperationUpload {
+		reqPermission = enum.PermissionRepoPush
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, reqPermission)
+	if err != nil {
+		return nil, err
+	}
+
+	oids := make([]string, 0, len(in.Objects))
+	for _, obj := range in.Objects {
+		oids = append(oids, obj.OID)
+	}
+
+	existing, err := c.lfsObjectStore.FindMany(ctx, repo.ID, oids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs objects: %w", err)
+	}
+
+	existingMap := make(map[string]*types.LFSObject, len(existing))
+	for _, obj := range existing {
+		existingMap[obj.OID] = obj
+	}
+
+	objects := make([]ObjectResponse, len(in.Objects))
+	for i, obj := range in.Objects {
+		objects[i] = c.batchObject(repo, in.Operation, obj, existingMap[obj.OID])
+	}
+
+	return &BatchResponse{
+		Transfer: TransferBasic,
+		Objects:  objects,
+		HashAlgo: HashAlgorithmSHA256,
+	}, nil
+}
+
+func (c *Controller) batchObject(
+	repo *types.Repository,
+	operation Operation,
+	pointer Pointer,
+	existing *types.LFSObject,
+) ObjectResponse {
+	res := ObjectResponse{Pointer: pointer}
+
+	if validateOID(pointer.OID) != nil || pointer.Size < 0 {
+		res.Error = &ObjectError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: "Invalid object id or size.",
+		}
+		return res
+	}
+
+	switch operation {
+	case OperationDownload:
+		if existing == nil {
+			res.Error = &ObjectError{
+				Code:    http.StatusNotFound,
+				Message: "Object does not exist.",
+			}
+			return res
+		}
+
+		res.Size = existing.Size
+		res.Authenticated = true
+		res.Actions = map[string]*Action{
+			"download": {Href: c.objectURL(repo, pointer.OID)},
+		}
+	case OperationUpload:
+		// objects that are already stored don't need to be uploaded again.
+		if existing != nil {
+			return res
+		}
+
+		res.Authenticated = true
+		res.Actions = map[string]*Action{
+			"upload": {Href: c.uploadURL(repo, pointer)},
+			"verify": {Href: c.verifyURL(repo)},
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/uuid"
+)
+
+const (
+	blobPathPrefixFmt = "lfs/%d/"
+	blobTmpDir        = "tmp/"
+
+	// TransferBasic is the only LFS transfer adapter supported by the server.
+	TransferBasic = "basic"
+
+	// HashAlgorithmSHA256 is the only hash algorithm supported by the server.
+	HashAlgorithmSHA256 = "sha256"
+)
+
+// oidRegex matches a valid LFS object id (hex encoded sha256 hash).
+var oidRegex = regexp.MustCompile("^[a-f0-9]{64}$")
+
+type Controller struct {
+	authorizer         authz.Authorizer
+	urlProvider        url.Provider
+	repoStore          store.RepoStore
+	principalInfoCache store.PrincipalInfoCache
+	lfsObjectStore     store.LFSObjectStore
+	lfsLockStore       store.LFSLockStore
+	blobStore          blob.Store
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	urlProvider url.Provider,
+	repoStore store.RepoStore,
+	principalInfoCache store.PrincipalInfoCache,
+	lfsObjectStore store.LFSObjectStore,
+	lfsLockStore store.LFSLockStore,
+	blobStore blob.Store,
+) *Controller {
+	return &Controller{
+		authorizer:         authorizer,
+		urlProvider:        urlProvider,
+		repoStore:          repoStore,
+		principalInfoCache: principalInfoCache,
+		lfsObjectStore:     lfsObjectStore,
+		lfsLockStore:       lfsLockStore,
+		blobStore:          blobStore,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return repo, nil
+}
+
+// GetBlobPathPrefix returns the blob store path prefix under which all LFS objects of a repo are stored.
+func GetBlobPathPrefix(repoID int64) string {
+	return fmt.Sprintf(blobPathPrefixFmt, repoID)
+}
+
+func getBlobPath(repoID int64, oid string) string {
+	return GetBlobPathPrefix(repoID) + oid
+}
+
+// getTmpBlobPath returns a unique blob store path an upload of the object is written to
+// before its content is verified.
+func getTmpBlobPath(repoID int64, oid string) string {
+	return GetBlobPathPrefix(repoID) + blobTmpDir + oid + "-" + uuid.NewString()
+}
+
+func validateOID(oid string) error {
+	if !oidRegex.MatchString(oid) {
+		return usererror.BadRequestf("Invalid LFS object id %q.", oid)
+	}
+	return nil
+}
+
+func (c *Controller) objectURL(repo *types.Repository, oid string) string {
+	return c.urlProvider.GenerateGITCloneURL(repo.Path) + "/info/lfs/objects/" + oid
+}
+
+func (c *Controller) uploadURL(repo *types.Repository, pointer Pointer) string {
+	return c.objectURL(repo, pointer.OID) + "?" + request.QueryParamLFSSize + "=" + strconv.FormatInt(pointer.Size, 10)
+}
+
+func (c *Controller) verifyURL(repo *types.Repository) string {
+	return c.urlProvider.GenerateGITCloneURL(repo.Path) + "/info/lfs/verify"
+}
+
+func formatLockID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
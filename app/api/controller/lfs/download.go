@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Download returns either a signed URL or a reader for the content of an LFS object.
+func (c *Controller) Download(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+) (string, io.ReadCloser, error) {
+	if err := validateOID(oid); err != nil {
+		return "", nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return "", nil, err
+	}
+
+	obj, err := c.lfsObjectStore.Find(ctx, repo.ID, oid)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	blobPath := getBlobPath(repo.ID, obj.OID)
+
+	signedURL, err := c.blobStore.GetSignedURL(ctx, blobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return signedURL, nil, nil
+	}
+
+	file, err := c.blobStore.Download(ctx, blobPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download lfs object from blobstore: %w", err)
+	}
+
+	return "", file, nil
+}
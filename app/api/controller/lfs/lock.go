@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultLockLimit = 100
+	maxLockLimit     = 100
+)
+
+// LockOwner describes the owner of an LFS lock.
+type LockOwner struct {
+	Name string `json:"name"`
+}
+
+// Lock is the LFS API representation of a file lock.
+type Lock struct {
+	ID       string     `json:"id"`
+	Path     string     `json:"path"`
+	LockedAt string     `json:"locked_at"`
+	Owner    *LockOwner `json:"owner,omitempty"`
+}
+
+// LockResponse is the LFS API response for a single lock operation.
+type LockResponse struct {
+	Lock *Lock `json:"lock"`
+}
+
+func (c *Controller) mapLock(ctx context.Context, lock *types.LFSLock) *Lock {
+	res := &Lock{
+		ID:       formatLockID(lock.ID),
+		Path:     lock.Path,
+		LockedAt: time.UnixMilli(lock.Created).UTC().Format(time.RFC3339),
+	}
+
+	owner, err := c.principalInfoCache.Get(ctx, lock.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to find owner of lfs lock %d", lock.ID)
+		return res
+	}
+	res.Owner = &LockOwner{Name: owner.DisplayName}
+
+	return res
+}
+
+func (c *Controller) mapLocks(ctx context.Context, locks []*types.LFSLock) []*Lock {
+	res := make([]*Lock, len(locks))
+	for i := range locks {
+		res[i] = c.mapLock(ctx, locks[i])
+	}
+	return res
+}
+
+// parseCursor converts an LFS pagination cursor and limit into a page and page size.
+func parseCursor(cursor string, limit int) (int, int, error) {
+	if limit <= 0 {
+		limit = defaultLockLimit
+	}
+	if limit > maxLockLimit {
+		limit = maxLockLimit
+	}
+
+	if cursor == "" {
+		return 1, limit, nil
+	}
+
+	page, err := strconv.Atoi(cursor)
+	if err != nil || page < 1 {
+		return 0, 0, usererror.BadRequestf("Invalid cursor %q.", cursor)
+	}
+
+	return page, limit, nil
+}
+
+// nextCursor returns the cursor of the next page, or an empty string if the current page is the last one.
+func nextCursor(page, size, count int) string {
+	if count < size {
+		return ""
+	}
+	return strconv.Itoa(page + 1)
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CreateLockInput is the body of an LFS create lock request.
+type CreateLockInput struct {
+	Path string     `json:"path"`
+	Ref  *Reference `json:"ref,omitempty"`
+}
+
+func (in *CreateLockInput) sanitize() error {
+	in.Path = strings.TrimSpace(in.Path)
+	if in.Path == "" {
+		return usererror.BadRequest("Lock path must be provided.")
+	}
+	return nil
+}
+
+// CreateLock locks a file path of a repository for the calling principal.
+func (c *Controller) CreateLock(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateLockInput,
+) (*LockResponse, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &types.LFSLock{
+		RepoID:    repo.ID,
+		Path:      in.Path,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+	}
+	if in.Ref != nil {
+		lock.Ref = in.Ref.Name
+	}
+
+	err = c.lfsLockStore.Create(ctx, lock)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, c.lockConflict(ctx, repo.ID, in.Path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lfs lock: %w", err)
+	}
+
+	return &LockResponse{Lock: c.mapLock(ctx, lock)}, nil
+}
+
+func (c *Controller) lockConflict(ctx context.Context, repoID int64, path string) error {
+	locks, err := c.lfsLockStore.List(ctx, repoID, &types.LFSLockFilter{Path: path, Size: 1})
+	if err != nil {
+		return fmt.Errorf("failed to find existing lfs lock: %w", err)
+	}
+	if len(locks) == 0 {
+		return usererror.Conflict("Lock already exists.")
+	}
+
+	return usererror.ConflictWithPayload("Lock already exists.", map[string]any{
+		"lock": c.mapLock(ctx, locks[0]),
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListLocksFilter contains the query parameters of an LFS list locks request.
+type ListLocksFilter struct {
+	ID      int64
+	Path    string
+	RefSpec string
+	Cursor  string
+	Limit   int
+}
+
+// ListLocksResponse is the LFS API response for listing locks.
+type ListLocksResponse struct {
+	Locks      []*Lock `json:"locks"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// ListLocks lists the LFS locks of a repository.
+func (c *Controller) ListLocks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *ListLocksFilter,
+) (*ListLocksResponse, error) {
+	page, size, err := parseCursor(filter.Cursor, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	locks, err := c.lfsLockStore.List(ctx, repo.ID, &types.LFSLockFilter{
+		Page: page,
+		Size: size,
+		ID:   filter.ID,
+		Path: filter.Path,
+		Ref:  filter.RefSpec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lfs locks: %w", err)
+	}
+
+	return &ListLocksResponse{
+		Locks:      c.mapLocks(ctx, locks),
+		NextCursor: nextCursor(page, size, len(locks)),
+	}, nil
+}
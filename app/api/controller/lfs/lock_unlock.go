@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UnlockInput is the body of an LFS unlock request.
+type UnlockInput struct {
+	Force bool       `json:"force,omitempty"`
+	Ref   *Reference `json:"ref,omitempty"`
+}
+
+// Unlock removes an LFS lock. Locks owned by other principals can only be removed
+// with force by principals that are allowed to edit the repository.
+func (c *Controller) Unlock(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	id int64,
+	in *UnlockInput,
+) (*LockResponse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := c.lfsLockStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs lock: %w", err)
+	}
+	if lock.RepoID != repo.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	if lock.CreatedBy != session.Principal.ID {
+		if !in.Force {
+			return nil, usererror.Forbidden("Lock is owned by another user, use force to unlock it.")
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit); err != nil {
+			return nil, err
+		}
+	}
+
+	// map the lock before deleting it, the API returns the removed lock.
+	res := &LockResponse{Lock: c.mapLock(ctx, lock)}
+
+	err = c.lfsLockStore.Delete(ctx, lock.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete lfs lock: %w", err)
+	}
+
+	return res, nil
+}
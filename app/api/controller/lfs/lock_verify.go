@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// VerifyLocksInput is the body of an LFS verify locks request.
+type VerifyLocksInput struct {
+	Cursor string     `json:"cursor,omitempty"`
+	Limit  int        `json:"limit,omitempty"`
+	Ref    *Reference `json:"ref,omitempty"`
+}
+
+// VerifyLocksResponse lists the locks of a repository split by whether
+// they are owned by the calling principal or somebody else.
+type VerifyLocksResponse struct {
+	Ours       []*Lock `json:"ours"`
+	Theirs     []*Lock `json:"theirs"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// VerifyLocks is called by LFS clients before a push to find locks that would block it.
+func (c *Controller) VerifyLocks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *VerifyLocksInput,
+) (*VerifyLocksResponse, error) {
+	page, size, err := parseCursor(in.Cursor, in.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	locks, err := c.lfsLockStore.List(ctx, repo.ID, &types.LFSLockFilter{
+		Page: page,
+		Size: size,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lfs locks: %w", err)
+	}
+
+	res := &VerifyLocksResponse{
+		Ours:       []*Lock{},
+		Theirs:     []*Lock{},
+		NextCursor: nextCursor(page, size, len(locks)),
+	}
+	for _, lock := range locks {
+		if lock.CreatedBy == session.Principal.ID {
+			res.Ours = append(res.Ours, c.mapLock(ctx, lock))
+		} else {
+			res.Theirs = append(res.Theirs, c.mapLock(ctx, lock))
+		}
+	}
+
+	return res, nil
+}
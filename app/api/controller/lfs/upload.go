@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Upload stores the content of an LFS object in the blob store.
+// The content is written to a temporary path first and only moved to the path of the object
+// once its size and hash are verified, so a bad upload never replaces a stored object.
+func (c *Controller) Upload(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+	size int64,
+	file io.Reader,
+) error {
+	if err := validateOID(oid); err != nil {
+		return err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return err
+	}
+
+	if file == nil {
+		return usererror.BadRequest("No file provided.")
+	}
+
+	_, err = c.lfsObjectStore.Find(ctx, repo.ID, oid)
+	if err == nil {
+		// the object is already stored and content addressed - nothing to do.
+		return nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	hasher := sha256.New()
+	// read at most one byte more than declared to detect oversized uploads without storing them fully.
+	counter := &countingReader{r: io.TeeReader(io.LimitReader(file, size+1), hasher)}
+
+	tmpPath := getTmpBlobPath(repo.ID, oid)
+
+	err = c.blobStore.Upload(ctx, counter, tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to upload lfs object: %w", err)
+	}
+
+	moved := false
+	defer func() {
+		if moved {
+			return
+		}
+		if err := c.blobStore.DeletePrefix(ctx, tmpPath); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete temporary lfs object upload %s", tmpPath)
+		}
+	}()
+
+	if counter.n != size {
+		return usererror.BadRequestf("The size of the object doesn't match the declared size of %d bytes.", size)
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != oid {
+		return usererror.BadRequest("The content of the object doesn't match its object id.")
+	}
+
+	// the verified content replaces any (verified) content of a concurrent upload of the same object.
+	err = c.blobStore.Move(ctx, tmpPath, getBlobPath(repo.ID, oid))
+	if err != nil {
+		return fmt.Errorf("failed to move lfs object to its final path: %w", err)
+	}
+	moved = true
+
+	err = c.lfsObjectStore.Create(ctx, &types.LFSObject{
+		OID:       oid,
+		Size:      counter.n,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+		RepoID:    repo.ID,
+	})
+	if err != nil && !errors.Is(err, store.ErrDuplicate) {
+		return fmt.Errorf("failed to create lfs object: %w", err)
+	}
+
+	return nil
+}
+
+// countingReader counts the number of bytes read from the wrapped reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Verify confirms that an uploaded LFS object is stored with the expected size.
+func (c *Controller) Verify(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *Pointer,
+) error {
+	if err := validateOID(in.OID); err != nil {
+		return err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return err
+	}
+
+	obj, err := c.lfsObjectStore.Find(ctx, repo.ID, in.OID)
+	if err != nil {
+		return fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	if obj.Size != in.Size {
+		return usererror.UnprocessableEntityf("Object size mismatch: expected %d, stored %d.", in.Size, obj.Size)
+	}
+
+	return nil
+}
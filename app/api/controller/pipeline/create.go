@@ -91,6 +91,7 @@ func (c *Controller) Create(
 	// We log an error on failure but don't fail the op.
 	trigger := &types.Trigger{
 		Description: "auto-created trigger on pipeline creation",
+		Type:        enum.TriggerTypeHook,
 		Created:     now,
 		Updated:     now,
 		PipelineID:  pipeline.ID,
@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var errAutoMergeNotEnabled = errors.New("auto-merge is not enabled")
+
+type AutoMergeInput struct {
+	Method enum.MergeMethod `json:"method"`
+}
+
+func (in *AutoMergeInput) sanitize() error {
+	method, ok := in.Method.Sanitize()
+	if !ok || in.Method == "" {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	return nil
+}
+
+// AutoMergeEnable enables auto-merge of a pull request. The pull request will be merged using the provided
+// merge method, on behalf of the current user, as soon as all protection rules are satisfied.
+func (c *Controller) AutoMergeEnable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeInput,
+) (*types.PullReq, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Auto-merge can be enabled only for open pull requests.")
+	}
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.State != enum.PullReqStateOpen {
+			return usererror.BadRequest("Pull request must be open")
+		}
+
+		pr.AutoMergeMethod = &in.Method
+		pr.AutoMergeBy = &session.Principal.ID
+		pr.ActivitySeq++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable auto-merge of pull request: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadAutoMerge{
+		Action:      enum.PullReqAutoMergeActionEnabled,
+		MergeMethod: in.Method,
+		SourceSHA:   pr.SourceSHA,
+	}
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request activity after enabling auto-merge")
+	}
+
+	c.eventReporter.AutoMergeEnabled(ctx, &pullreqevents.AutoMergeEnabledPayload{
+		Base:        eventBase(pr, &session.Principal),
+		MergeMethod: in.Method,
+	})
+
+	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return pr, nil
+}
+
+// AutoMergeDisable disables auto-merge of a pull request.
+func (c *Controller) AutoMergeDisable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReq, error) {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.AutoMergeMethod == nil {
+		return pr, nil // no changes are necessary: auto-merge isn't enabled
+	}
+
+	var method enum.MergeMethod
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.AutoMergeMethod == nil {
+			return errAutoMergeNotEnabled
+		}
+
+		method = *pr.AutoMergeMethod
+		pr.AutoMergeMethod = nil
+		pr.AutoMergeBy = nil
+		pr.ActivitySeq++
+		return nil
+	})
+	if errors.Is(err, errAutoMergeNotEnabled) {
+		return c.pullreqStore.Find(ctx, pr.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to disable auto-merge of pull request: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadAutoMerge{
+		Action:      enum.PullReqAutoMergeActionDisabled,
+		MergeMethod: method,
+		SourceSHA:   pr.SourceSHA,
+	}
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request activity after disabling auto-merge")
+	}
+
+	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return pr, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListCheckAnnotations returns status check annotations of the pull request's source commit
+// that overlap the lines changed by the pull request.
+func (c *Controller) ListCheckAnnotations(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) (types.PullReqCheckAnnotations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return types.PullReqCheckAnnotations{}, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return types.PullReqCheckAnnotations{}, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	result := types.PullReqCheckAnnotations{
+		CommitSHA:   pr.SourceSHA,
+		Annotations: []types.CheckAnnotation{},
+	}
+
+	annotations, err := c.checkAnnotationStore.ListForCommit(ctx, repo.ID, pr.SourceSHA)
+	if err != nil {
+		return types.PullReqCheckAnnotations{}, fmt.Errorf("failed to list status check annotations: %w", err)
+	}
+
+	if len(annotations) == 0 || pr.MergeBaseSHA == "" {
+		return result, nil
+	}
+
+	diff, err := c.git.GetDiffHunkHeaders(ctx, git.GetDiffHunkHeadersParams{
+		ReadParams:      git.CreateReadParams(repo),
+		SourceCommitSHA: pr.MergeBaseSHA,
+		TargetCommitSHA: pr.SourceSHA,
+	})
+	if err != nil {
+		return types.PullReqCheckAnnotations{}, fmt.Errorf("failed to get pull request diff hunk headers: %w", err)
+	}
+
+	result.Annotations = filterAnnotationsByHunks(annotations, diff.Files)
+
+	return result, nil
+}
+
+// filterAnnotationsByHunks returns only the annotations that overlap lines added or modified by the diff.
+func filterAnnotationsByHunks(
+	annotations []types.CheckAnnotation,
+	files []git.DiffFileHunkHeaders,
+) []types.CheckAnnotation {
+	hunksByPath := make(map[string][]git.HunkHeader, len(files))
+	for _, file := range files {
+		hunksByPath[file.FileHeader.NewName] = file.HunkHeaders
+	}
+
+	result := make([]types.CheckAnnotation, 0)
+
+	for _, annotation := range annotations {
+		for _, hunk := range hunksByPath[annotation.Path] {
+			if hunk.NewSpan == 0 {
+				continue // the hunk only removes lines
+			}
+
+			hunkStart := hunk.NewLine
+			hunkEnd := hunk.NewLine + hunk.NewSpan - 1
+
+			if annotation.StartLine <= hunkEnd && annotation.EndLine >= hunkStart {
+				result = append(result, annotation)
+				break
+			}
+		}
+	}
+
+	return result
+}
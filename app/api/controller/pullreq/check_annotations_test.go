@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+func TestFilterAnnotationsByHunks(t *testing.T) {
+	files := []git.DiffFileHunkHeaders{
+		{
+			FileHeader: git.DiffFileHeader{OldName: "a.go", NewName: "a.go"},
+			HunkHeaders: []git.HunkHeader{
+				{OldLine: 10, OldSpan: 1, NewLine: 10, NewSpan: 3},
+				{OldLine: 30, OldSpan: 2, NewLine: 31, NewSpan: 0},
+			},
+		},
+		{
+			FileHeader:  git.DiffFileHeader{OldName: "old.go", NewName: "new.go"},
+			HunkHeaders: []git.HunkHeader{{OldLine: 1, OldSpan: 1, NewLine: 1, NewSpan: 1}},
+		},
+	}
+
+	annotations := []types.CheckAnnotation{
+		{ID: 1, Path: "a.go", StartLine: 5, EndLine: 9},   // before the hunk
+		{ID: 2, Path: "a.go", StartLine: 8, EndLine: 10},  // overlaps the hunk start
+		{ID: 3, Path: "a.go", StartLine: 12, EndLine: 20}, // overlaps the hunk end
+		{ID: 4, Path: "a.go", StartLine: 13, EndLine: 13}, // after the hunk
+		{ID: 5, Path: "a.go", StartLine: 31, EndLine: 31}, // at a removal only hunk
+		{ID: 6, Path: "new.go", StartLine: 1, EndLine: 1}, // renamed file
+		{ID: 7, Path: "old.go", StartLine: 1, EndLine: 1}, // old name of a renamed file
+		{ID: 8, Path: "b.go", StartLine: 1, EndLine: 1},   // unchanged file
+	}
+
+	got := filterAnnotationsByHunks(annotations, files)
+
+	want := []int64{2, 3, 6}
+	if len(got) != len(want) {
+		t.Fatalf("got %d annotations, want %d: %+v", len(got), len(want), got)
+	}
+
+	for i := range want {
+		if got[i].ID != want[i] {
+			t.Errorf("annotation %d: got ID %d, want %d", i, got[i].ID, want[i])
+		}
+	}
+}
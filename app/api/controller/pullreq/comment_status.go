@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -66,8 +67,11 @@ func (c *Controller) CommentStatus(
 
 	var pr *types.PullReq
 	var act *types.PullReqActivity
+	var changed bool
 
 	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		changed = false
+
 		pr, err = c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
 		if err != nil {
 			return fmt.Errorf("failed to find pull request by number: %w", err)
@@ -86,6 +90,8 @@ func (c *Controller) CommentStatus(
 			return nil
 		}
 
+		changed = true
+
 		act.Resolved = nil
 		act.ResolvedBy = nil
 
@@ -125,6 +131,20 @@ func (c *Controller) CommentStatus(
 		return nil, err
 	}
 
+	if changed {
+		c.eventReporter.CommentStatusUpdated(ctx, &events.CommentStatusUpdatedPayload{
+			Base: events.Base{
+				PullReqID:    pr.ID,
+				SourceRepoID: pr.SourceRepoID,
+				TargetRepoID: pr.TargetRepoID,
+				PrincipalID:  session.Principal.ID,
+				Number:       pr.Number,
+			},
+			ActivityID: act.ID,
+			Resolved:   act.Resolved != nil,
+		})
+	}
+
 	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
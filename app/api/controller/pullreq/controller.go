@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
@@ -27,41 +28,50 @@ import (
 	"github.com/harness/gitness/app/services/codeowners"
 	locker "github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
 type Controller struct {
-	tx                  dbtx.Transactor
-	urlProvider         url.Provider
-	authorizer          authz.Authorizer
-	pullreqStore        store.PullReqStore
-	activityStore       store.PullReqActivityStore
-	codeCommentView     store.CodeCommentView
-	reviewStore         store.PullReqReviewStore
-	reviewerStore       store.PullReqReviewerStore
-	repoStore           store.RepoStore
-	principalStore      store.PrincipalStore
-	principalInfoCache  store.PrincipalInfoCache
-	fileViewStore       store.PullReqFileViewStore
-	membershipStore     store.MembershipStore
-	checkStore          store.CheckStore
-	git                 git.Interface
-	eventReporter       *pullreqevents.Reporter
-	codeCommentMigrator *codecomments.Migrator
-	pullreqService      *pullreq.Service
-	protectionManager   *protection.Manager
-	sseStreamer         sse.Streamer
-	codeOwners          *codeowners.Service
-	locker              *locker.Locker
+	tx                   dbtx.Transactor
+	urlProvider          url.Provider
+	authorizer           authz.Authorizer
+	pullreqStore         store.PullReqStore
+	activityStore        store.PullReqActivityStore
+	codeCommentView      store.CodeCommentView
+	reviewStore          store.PullReqReviewStore
+	reviewerStore        store.PullReqReviewerStore
+	repoStore            store.RepoStore
+	principalStore       store.PrincipalStore
+	principalInfoCache   store.PrincipalInfoCache
+	fileViewStore        store.PullReqFileViewStore
+	membershipStore      store.MembershipStore
+	checkStore           store.CheckStore
+	git                  git.Interface
+	eventReporter        *pullreqevents.Reporter
+	codeCommentMigrator  *codecomments.Migrator
+	pullreqService       *pullreq.Service
+	protectionManager    *protection.Manager
+	sseStreamer          sse.Streamer
+	codeOwners           *codeowners.Service
+	locker               *locker.Locker
+	auditService         audit.Service
+	mergeQueueStore      store.MergeQueueStore
+	publicKeyService     publickey.Service
+	checkAnnotationStore store.CheckAnnotationStore
+	spaceStore           store.SpaceStore
+	userGroupStore       store.UserGroupStore
 }
 
 func NewController(
@@ -87,30 +97,42 @@ func NewController(
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
 	locker *locker.Locker,
+	auditService audit.Service,
+	mergeQueueStore store.MergeQueueStore,
+	publicKeyService publickey.Service,
+	checkAnnotationStore store.CheckAnnotationStore,
+	spaceStore store.SpaceStore,
+	userGroupStore store.UserGroupStore,
 ) *Controller {
 	return &Controller{
-		tx:                  tx,
-		urlProvider:         urlProvider,
-		authorizer:          authorizer,
-		pullreqStore:        pullreqStore,
-		activityStore:       pullreqActivityStore,
-		codeCommentView:     codeCommentView,
-		reviewStore:         pullreqReviewStore,
-		reviewerStore:       pullreqReviewerStore,
-		repoStore:           repoStore,
-		principalStore:      principalStore,
-		principalInfoCache:  principalInfoCache,
-		fileViewStore:       fileViewStore,
-		membershipStore:     membershipStore,
-		checkStore:          checkStore,
-		git:                 git,
-		codeCommentMigrator: codeCommentMigrator,
-		eventReporter:       eventReporter,
-		pullreqService:      pullreqService,
-		protectionManager:   protectionManager,
-		sseStreamer:         sseStreamer,
-		codeOwners:          codeowners,
-		locker:              locker,
+		tx:                   tx,
+		urlProvider:          urlProvider,
+		authorizer:           authorizer,
+		pullreqStore:         pullreqStore,
+		activityStore:        pullreqActivityStore,
+		codeCommentView:      codeCommentView,
+		reviewStore:          pullreqReviewStore,
+		reviewerStore:        pullreqReviewerStore,
+		repoStore:            repoStore,
+		principalStore:       principalStore,
+		principalInfoCache:   principalInfoCache,
+		fileViewStore:        fileViewStore,
+		membershipStore:      membershipStore,
+		checkStore:           checkStore,
+		git:                  git,
+		codeCommentMigrator:  codeCommentMigrator,
+		eventReporter:        eventReporter,
+		pullreqService:       pullreqService,
+		protectionManager:    protectionManager,
+		sseStreamer:          sseStreamer,
+		codeOwners:           codeowners,
+		locker:               locker,
+		auditService:         auditService,
+		mergeQueueStore:      mergeQueueStore,
+		publicKeyService:     publicKeyService,
+		checkAnnotationStore: checkAnnotationStore,
+		spaceStore:           spaceStore,
+		userGroupStore:       userGroupStore,
 	}
 }
 
@@ -140,6 +162,48 @@ func (c *Controller) verifyBranchExistence(ctx context.Context,
 	return ref.SHA.String(), nil
 }
 
+// fetchSourceCommit makes the source commit of a pull request from a fork available in the target repository.
+func (c *Controller) fetchSourceCommit(
+	ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+	targetRepo *types.Repository,
+	sourceSHA string,
+) error {
+	if sourceRepo.ID == targetRepo.ID {
+		return nil
+	}
+
+	commitSHA, err := sha.New(sourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to parse source commit sha: %w", err)
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHAs:    []sha.SHA{commitSHA},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch source commit into the target repository: %w", err)
+	}
+
+	return nil
+}
+
+// isForkRelated returns true if one of the repositories is a fork of the other one,
+// or if both repositories are forks of the same repository.
+func isForkRelated(repo1, repo2 *types.Repository) bool {
+	return repo1.ForkID == repo2.ID ||
+		repo2.ForkID == repo1.ID ||
+		(repo1.ForkID != 0 && repo1.ForkID == repo2.ForkID)
+}
+
 func (c *Controller) getRepoCheckAccess(ctx context.Context,
 	session *auth.Session, repoRef string, reqPermission enum.Permission,
 ) (*types.Repository, error) {
@@ -17,6 +17,7 @@ package pullreq
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,8 +27,10 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/contextutil"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
@@ -75,6 +78,10 @@ func (in *MergeInput) sanitize() error {
 		return usererror.BadRequest("rebase doesn't support customizing commit title and message")
 	}
 
+	if in.Method == enum.MergeMethodFastForward && (in.Title != "" || in.Message != "") {
+		return usererror.BadRequest("fast-forward doesn't support customizing commit title and message")
+	}
+
 	return nil
 }
 
@@ -170,14 +177,14 @@ func (c *Controller) Merge(
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
 		}
 
-		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 		}
 	}
 
@@ -196,7 +203,7 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
 	}
 
-	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
 	// check for error and ignore if it is codeowners file not found else throw error
 	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
 		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
@@ -213,11 +220,20 @@ func (c *Controller) Merge(
 		Method:       in.Method,
 		CheckResults: checkResults,
 		CodeOwners:   codeOwnerWithApproval,
+
+		VerifyCommitSignatures: c.verifyCommitSignaturesFunc(targetRepo, pr),
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
 
+	deleteSourceBranch := ruleOut.DeleteSourceBranch
+	if deleteSourceBranch && sourceRepo.ID != targetRepo.ID {
+		// the source branch of a fork is deleted only if the user is allowed to push to the fork.
+		errAuth := apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush)
+		deleteSourceBranch = errAuth == nil
+	}
+
 	// we want to complete the merge independent of request cancel - start with new, time restricted context.
 	// TODO: This is a small change to reduce likelihood of dirty state.
 	// We still require a proper solution to handle an application crash or very slow execution times
@@ -285,12 +301,13 @@ func (c *Controller) Merge(
 
 		// With in.DryRun=true this function never returns types.MergeViolations
 		out := &types.MergeResponse{
-			BranchDeleted:  ruleOut.DeleteSourceBranch,
+			BranchDeleted:  deleteSourceBranch,
 			RuleViolations: violations,
 
 			// values only retured by dry run
 			DryRun:                              true,
 			ConflictFiles:                       pr.MergeConflicts,
+			RequiresRebase:                      requiresRebase(pr),
 			AllowedMethods:                      ruleOut.AllowedMethods,
 			RequiresCodeOwnersApproval:          ruleOut.RequiresCodeOwnersApproval,
 			RequiresCodeOwnersApprovalLatest:    ruleOut.RequiresCodeOwnersApprovalLatest,
@@ -318,6 +335,8 @@ func (c *Controller) Merge(
 		author = identityFromPrincipalInfo(pr.Author)
 	case enum.MergeMethodRebase:
 		author = nil // Not important for the rebase merge: the author info in the commits will be preserved.
+	case enum.MergeMethodFastForward:
+		author = nil // Not important for the fast-forward merge: no new commits are created.
 	}
 
 	var committer *git.Identity
@@ -327,6 +346,8 @@ func (c *Controller) Merge(
 		committer = identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
 	case enum.MergeMethodRebase:
 		committer = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+	case enum.MergeMethodFastForward:
+		committer = nil // Not important for the fast-forward merge: no new commits are created.
 	}
 
 	// backfill commit title if none provided
@@ -336,7 +357,7 @@ func (c *Controller) Merge(
 			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
 		case enum.MergeMethodSquash:
 			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-		case enum.MergeMethodRebase:
+		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
 			// Not used.
 		}
 	}
@@ -421,7 +442,7 @@ func (c *Controller) Merge(
 		pr.ActivitySeq++
 		activitySeqMerge = pr.ActivitySeq
 
-		if ruleOut.DeleteSourceBranch {
+		if deleteSourceBranch {
 			pr.ActivitySeq++
 			activitySeqBranchDeleted = pr.ActivitySeq
 		}
@@ -445,6 +466,23 @@ func (c *Controller) Merge(
 		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
 	}
 
+	if activityPayload.RulesBypassed {
+		err = c.auditService.Log(ctx,
+			session.Principal,
+			audit.NewResource(audit.ResourceTypePullRequest, strconv.FormatInt(pr.Number, 10)),
+			audit.ActionBypassed,
+			paths.Parent(targetRepo.Path),
+			audit.WithNewObject(bypassedViolations(violations)),
+			audit.WithData(
+				"repo_path", targetRepo.Path,
+				"merge_sha", mergeOutput.MergeSHA.String(),
+			),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for bypassing rules during merge: %s", err)
+		}
+	}
+
 	c.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
 		Base:        eventBase(pr, &session.Principal),
 		MergeMethod: in.Method,
@@ -454,7 +492,7 @@ func (c *Controller) Merge(
 	})
 
 	var branchDeleted bool
-	if ruleOut.DeleteSourceBranch {
+	if deleteSourceBranch {
 		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
 			WriteParams: sourceWriteParams,
 			BranchName:  pr.SourceBranch,
@@ -487,3 +525,42 @@ func (c *Controller) Merge(
 		RuleViolations: violations,
 	}, nil, nil
 }
+
+// bypassedViolations returns only rule violations that were bypassed.
+func bypassedViolations(violations []types.RuleViolations) []types.RuleViolations {
+	bypassed := make([]types.RuleViolations, 0, len(violations))
+	for i := range violations {
+		if violations[i].IsBypassed() {
+			bypassed = append(bypassed, violations[i])
+		}
+	}
+	return bypassed
+}
+
+// requiresRebase returns true if the target branch of the pull request has diverged from the source branch,
+// in which case the pull request can't be merged using the fast-forward merge method.
+func requiresRebase(pr *types.PullReq) bool {
+	if pr.MergeCheckStatus == enum.MergeCheckStatusUnchecked || pr.MergeTargetSHA == nil {
+		return false
+	}
+
+	return pr.MergeBaseSHA != *pr.MergeTargetSHA
+}
+
+// verifyCommitSignaturesFunc returns the function used by protection rules to verify the signatures
+// of the commits of the pull request.
+func (c *Controller) verifyCommitSignaturesFunc(
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+) protection.VerifyCommitSignaturesFunc {
+	return func(ctx context.Context, _ string) (protection.VerifyCommitSignaturesOutput, error) {
+		return controller.VerifyNewCommitSignatures(
+			ctx,
+			c.git,
+			c.publicKeyService,
+			git.CreateReadParams(targetRepo),
+			pr.SourceSHA,
+			pr.MergeBaseSHA,
+		)
+	}
+}
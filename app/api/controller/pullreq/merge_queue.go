@@ -0,0 +1,313 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MergeQueueAddInput struct {
+	SourceSHA   string `json:"source_sha"`
+	BypassRules bool   `json:"bypass_rules"`
+}
+
+func (in *MergeQueueAddInput) sanitize() error {
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("source SHA must be provided")
+	}
+
+	return nil
+}
+
+// MergeQueueAdd adds a pull request to the end of the merge queue of its target branch.
+// The pull request must satisfy all protection rules, except the required status checks,
+// which are verified on the merge ref built by the merge queue.
+func (c *Controller) MergeQueueAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *MergeQueueAddInput,
+) (*types.MergeQueueEntry, []types.RuleViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Only open pull requests can be added to the merge queue.")
+	}
+
+	if pr.IsDraft {
+		return nil, nil, usererror.BadRequest(
+			"Draft pull requests can't be added to the merge queue. Clear the draft flag first.")
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, nil,
+			usererror.BadRequest("A newer commit is available. Only the latest commit can be merged.")
+	}
+
+	if pr.MergeCheckStatus == enum.MergeCheckStatusConflict {
+		return nil, nil, usererror.BadRequest("Pull requests with merge conflicts can't be added to the merge queue.")
+	}
+
+	entry, err := c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if err == nil {
+		return entry, nil, nil // no changes are necessary: the pull request is already in the merge queue
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil, fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, targetRepo.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	queueConfig, err := protectionRules.MergeQueueConfig(ctx, protection.MergeQueueInput{
+		Repo:         targetRepo,
+		TargetBranch: pr.TargetBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get merge queue configuration: %w", err)
+	}
+
+	if !queueConfig.Enabled {
+		return nil, nil, usererror.BadRequestf("The merge queue isn't enabled for the branch %q.", pr.TargetBranch)
+	}
+
+	violations, err := c.mergeQueueVerify(ctx, session, targetRepo, pr, protectionRules, queueConfig.Method,
+		in.BypassRules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	now := time.Now().UnixMilli()
+	entry = &types.MergeQueueEntry{
+		RepoID:       targetRepo.ID,
+		PullReqID:    pr.ID,
+		TargetBranch: pr.TargetBranch,
+		Method:       queueConfig.Method,
+		State:        enum.MergeQueueEntryStateQueued,
+		CreatedBy:    session.Principal.ID,
+		Created:      now,
+		Updated:      now,
+	}
+
+	err = c.mergeQueueStore.Create(ctx, entry)
+	if errors.Is(err, store.ErrDuplicate) {
+		entry, err = c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find merge queue entry: %w", err)
+		}
+
+		return entry, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to add pull request to the merge queue: %w", err)
+	}
+
+	c.reportMergeQueueUpdated(ctx, session, pr, &types.PullRequestActivityPayloadMergeQueue{
+		Action:       enum.PullReqMergeQueueActionAdded,
+		TargetBranch: pr.TargetBranch,
+		SourceSHA:    pr.SourceSHA,
+	})
+
+	return entry, nil, nil
+}
+
+// MergeQueueRemove removes a pull request from the merge queue of its target branch.
+func (c *Controller) MergeQueueRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	entry, err := c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil // no changes are necessary: the pull request isn't in the merge queue
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	err = c.mergeQueueStore.Delete(ctx, entry.ID)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove pull request from the merge queue: %w", err)
+	}
+
+	if entry.MergeSHA != "" {
+		c.deleteMergeQueueRef(ctx, session, targetRepo, pr)
+	}
+
+	c.reportMergeQueueUpdated(ctx, session, pr, &types.PullRequestActivityPayloadMergeQueue{
+		Action:       enum.PullReqMergeQueueActionRemoved,
+		TargetBranch: entry.TargetBranch,
+		SourceSHA:    pr.SourceSHA,
+	})
+
+	return nil
+}
+
+// mergeQueueVerify verifies the protection rules for a pull request that is being added to the merge queue.
+func (c *Controller) mergeQueueVerify(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+	protectionRules protection.Protection,
+	method enum.MergeMethod,
+	bypassRules bool,
+) ([]types.RuleViolations, error) {
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		var err error
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, targetRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
+	// check for error and ignore if it is codeowners file not found else throw error
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	_, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: bypassRules,
+		IsRepoOwner: isRepoOwner,
+		TargetRepo:  targetRepo,
+		SourceRepo:  sourceRepo,
+		PullReq:     pr,
+		Reviewers:   reviewers,
+		Method:      method,
+		CodeOwners:  codeOwnerWithApproval,
+		MergeQueue:  true,
+
+		VerifyCommitSignatures: c.verifyCommitSignaturesFunc(targetRepo, pr),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	return violations, nil
+}
+
+// deleteMergeQueueRef deletes the merge ref the merge queue has built for the pull request.
+func (c *Controller) deleteMergeQueueRef(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pr *types.PullReq,
+) {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create RPC write params")
+		return
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        strconv.FormatInt(pr.Number, 10),
+		Type:        gitenum.RefTypePullReqQueue,
+		NewValue:    sha.None, // when NewValue is empty will delete the ref.
+		OldValue:    sha.None, // we don't care about the old value
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue ref of pull request")
+	}
+}
+
+func (c *Controller) reportMergeQueueUpdated(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+	payload *types.PullRequestActivityPayloadMergeQueue,
+) {
+	prUpdated, err := c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to update activity sequence of pull request")
+	} else if _, errAct := c.activityStore.CreateWithPayload(ctx, prUpdated, session.Principal.ID,
+		payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request merge queue activity")
+	}
+
+	c.eventReporter.MergeQueueUpdated(ctx, &pullreqevents.MergeQueueUpdatedPayload{
+		Base:         eventBase(pr, &session.Principal),
+		TargetBranch: payload.TargetBranch,
+	})
+}
@@ -67,5 +67,10 @@ func (c *Controller) Commits(
 		commits[i] = *commit
 	}
 
+	err = controller.VerifyCommitSignatures(ctx, c.publicKeyService, output.Commits, commits)
+	if err != nil {
+		return nil, err
+	}
+
 	return commits, nil
 }
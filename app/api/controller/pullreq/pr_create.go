@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
@@ -53,7 +54,8 @@ func (c *Controller) Create(
 		return nil, usererror.BadRequest("pull request title can't be empty")
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	// pull requests from forks only require read access to the target repository.
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
@@ -66,6 +68,14 @@ func (c *Controller) Create(
 		}
 	}
 
+	if sourceRepo.ID == targetRepo.ID {
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo, enum.PermissionRepoPush); err != nil {
+			return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+		}
+	} else if !isForkRelated(sourceRepo, targetRepo) {
+		return nil, usererror.BadRequest("Pull requests are only supported between a repository and its forks.")
+	}
+
 	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
 		return nil, usererror.BadRequest("target and source branch can't be the same")
 	}
@@ -84,9 +94,13 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	if err = c.fetchSourceCommit(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+		return nil, err
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA,
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
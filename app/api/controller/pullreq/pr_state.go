@@ -120,9 +120,13 @@ func (c *Controller) State(ctx context.Context,
 			return nil, err
 		}
 
+		if err = c.fetchSourceCommit(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+			return nil, err
+		}
+
 		mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-			ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-			Ref1:       pr.SourceBranch,
+			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+			Ref1:       sourceSHA,
 			Ref2:       pr.TargetBranch,
 		})
 		if err != nil {
@@ -148,6 +152,8 @@ func (c *Controller) State(ctx context.Context,
 			pr.MergeSHA = nil
 			pr.MergeConflicts = nil
 			pr.MergeTargetSHA = nil
+			pr.AutoMergeMethod = nil
+			pr.AutoMergeBy = nil
 			pr.Closed = &pr.Edited
 		case changeReopen:
 			pr.SourceSHA = sourceSHA
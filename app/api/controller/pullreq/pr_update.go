@@ -23,6 +23,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -85,6 +86,7 @@ func (c *Controller) Update(ctx context.Context,
 
 	needToWriteActivity := in.Title != pr.Title
 	oldTitle := pr.Title
+	oldDescription := pr.Description
 
 	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		pr.Title = in.Title
@@ -110,6 +112,14 @@ func (c *Controller) Update(ctx context.Context,
 		}
 	}
 
+	c.eventReporter.Updated(ctx, &events.UpdatedPayload{
+		Base:           eventBase(pr, &session.Principal),
+		OldTitle:       oldTitle,
+		NewTitle:       pr.Title,
+		OldDescription: oldDescription,
+		NewDescription: pr.Description,
+	})
+
 	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
@@ -83,11 +83,8 @@ func (c *Controller) ReviewerAdd(
 
 		reviewerInfo = reviewerPrincipal.ToPrincipalInfo()
 
-		// TODO: To check the reviewer's access to the repo we create a dummy session object. Fix it.
-		if err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{
-			Principal: *reviewerPrincipal,
-			Metadata:  nil,
-		}, repo, enum.PermissionRepoView); err != nil {
+		err = apiauth.CheckRepoForPrincipal(ctx, c.authorizer, reviewerPrincipal, repo, enum.PermissionRepoView)
+		if err != nil {
 			log.Ctx(ctx).Info().Msgf("Reviewer principal: %s access error: %s", reviewerInfo.UID, err)
 			return nil, usererror.BadRequest("The reviewer doesn't have enough permissions for the repository.")
 		}
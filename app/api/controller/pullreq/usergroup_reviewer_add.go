@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UserGroupReviewerAddInput struct {
+	UserGroupID int64 `json:"usergroup_id"`
+}
+
+// UserGroupReviewerAdd adds all members of a user group as reviewers to the pull request.
+// The pull request author, members without access to the repository and existing reviewers are skipped.
+func (c *Controller) UserGroupReviewerAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *UserGroupReviewerAddInput,
+) ([]*types.PullReqReviewer, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if in.UserGroupID == 0 {
+		return nil, usererror.BadRequest("Must specify user group ID.")
+	}
+
+	userGroup, err := c.userGroupStore.Find(ctx, in.UserGroupID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User group with ID %d not found.", in.UserGroupID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	userGroupSpace, err := c.spaceStore.Find(ctx, userGroup.SpaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space of the user group: %w", err)
+	}
+
+	if !paths.IsAncesterOf(userGroupSpace.Path, repo.Path) {
+		return nil, usererror.BadRequest("The user group doesn't belong to any of the parent spaces of the repository.")
+	}
+
+	members, err := c.userGroupStore.ListMemberPrincipals(ctx, userGroup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members: %w", err)
+	}
+
+	addedByInfo := session.Principal.ToPrincipalInfo()
+
+	reviewerType := enum.PullReqReviewerTypeAssigned
+	if session.Principal.ID == pr.CreatedBy {
+		reviewerType = enum.PullReqReviewerTypeRequested
+	}
+
+	added := make([]*types.PullReqReviewer, 0, len(members))
+
+	for _, member := range members {
+		if member.ID == pr.CreatedBy {
+			continue
+		}
+
+		var memberPrincipal *types.Principal
+		memberPrincipal, err = c.principalStore.Find(ctx, member.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user group member: %w", err)
+		}
+
+		err = apiauth.CheckRepoForPrincipal(ctx, c.authorizer, memberPrincipal, repo, enum.PermissionRepoView)
+		if err != nil {
+			log.Ctx(ctx).Info().Msgf("User group member principal: %s access error: %s", member.UID, err)
+			continue
+		}
+
+		var reviewer *types.PullReqReviewer
+
+		err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+			existing, errFind := c.reviewerStore.Find(ctx, pr.ID, member.ID)
+			if errFind != nil && !errors.Is(errFind, store.ErrResourceNotFound) {
+				return errFind
+			}
+
+			if existing != nil {
+				return nil
+			}
+
+			memberReviewerType := reviewerType
+			if member.ID == session.Principal.ID {
+				memberReviewerType = enum.PullReqReviewerTypeSelfAssigned
+			}
+
+			reviewer = newPullReqReviewer(session, pr, repo, member, addedByInfo, memberReviewerType,
+				&ReviewerAddInput{ReviewerID: member.ID})
+
+			return c.reviewerStore.Create(ctx, reviewer)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pull request reviewer: %w", err)
+		}
+
+		if reviewer == nil {
+			continue
+		}
+
+		c.reportReviewerAddition(ctx, session, pr, reviewer)
+		added = append(added, reviewer)
+	}
+
+	return added, nil
+}
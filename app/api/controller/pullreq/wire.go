@@ -21,10 +21,12 @@ import (
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -45,7 +47,12 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 	checkStore store.CheckStore,
 	rpcClient git.Interface, eventReporter *pullreqevents.Reporter, codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service, ruleManager *protection.Manager, sseStreamer sse.Streamer,
-	codeOwners *codeowners.Service, locker *locker.Locker,
+	codeOwners *codeowners.Service, locker *locker.Locker, auditService audit.Service,
+	mergeQueueStore store.MergeQueueStore,
+	publicKeyService publickey.Service,
+	checkAnnotationStore store.CheckAnnotationStore,
+	spaceStore store.SpaceStore,
+	userGroupStore store.UserGroupStore,
 ) *Controller {
 	return NewController(tx, urlProvider, authorizer,
 		pullReqStore, pullReqActivityStore,
@@ -56,5 +63,7 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 		checkStore,
 		rpcClient, eventReporter,
 		codeCommentMigrator,
-		pullreqService, ruleManager, sseStreamer, codeOwners, locker)
+		pullreqService, ruleManager, sseStreamer, codeOwners, locker, auditService,
+		mergeQueueStore, publicKeyService, checkAnnotationStore,
+		spaceStore, userGroupStore)
 }
@@ -33,10 +33,14 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pushmirror"
+	"github.com/harness/gitness/app/services/repomirror"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -50,6 +54,9 @@ var errPublicRepoCreationDisabled = usererror.BadRequestf("Public repository cre
 type RepositoryOutput struct {
 	types.Repository
 	IsPublic bool `json:"is_public" yaml:"is_public"`
+
+	// Mirror is set if the repository is a pull mirror of an external repository.
+	Mirror *types.RepoMirror `json:"mirror,omitempty" yaml:"-"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -91,6 +98,14 @@ type Controller struct {
 	identifierCheck    check.RepoIdentifier
 	repoCheck          Check
 	publicAccess       publicaccess.Service
+	blobStore          blob.Store
+	publicKeyService   publickey.Service
+	repoMirrorStore    store.RepoMirrorStore
+	connectorStore     store.ConnectorStore
+	repoMirror         *repomirror.Service
+	pushMirrorStore    store.PushMirrorStore
+	secretStore        store.SecretStore
+	pushMirror         *pushmirror.Service
 }
 
 func NewController(
@@ -118,6 +133,14 @@ func NewController(
 	identifierCheck check.RepoIdentifier,
 	repoCheck Check,
 	publicAccess publicaccess.Service,
+	blobStore blob.Store,
+	publicKeyService publickey.Service,
+	repoMirrorStore store.RepoMirrorStore,
+	connectorStore store.ConnectorStore,
+	repoMirror *repomirror.Service,
+	pushMirrorStore store.PushMirrorStore,
+	secretStore store.SecretStore,
+	pushMirror *pushmirror.Service,
 ) *Controller {
 	return &Controller{
 		defaultBranch:      config.Git.DefaultBranch,
@@ -144,6 +167,14 @@ func NewController(
 		identifierCheck:    identifierCheck,
 		repoCheck:          repoCheck,
 		publicAccess:       publicAccess,
+		blobStore:          blobStore,
+		publicKeyService:   publicKeyService,
+		repoMirrorStore:    repoMirrorStore,
+		connectorStore:     connectorStore,
+		repoMirror:         repoMirror,
+		pushMirrorStore:    pushMirrorStore,
+		secretStore:        secretStore,
+		pushMirror:         pushMirror,
 	}
 }
 
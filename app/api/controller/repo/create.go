@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/audit"
@@ -53,7 +54,7 @@ type CreateInput struct {
 	DefaultBranch string `json:"default_branch"`
 	Description   string `json:"description"`
 	IsPublic      bool   `json:"is_public"`
-	ForkID        int64  `json:"fork_id"`
+	ForkID        int64  `json:"fork_id" deprecated:"true"` // ignored, forks are created using the fork API.
 	Readme        bool   `json:"readme"`
 	License       string `json:"license"`
 	GitIgnore     string `json:"git_ignore"`
@@ -116,7 +117,6 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 			CreatedBy:     session.Principal.ID,
 			Created:       now,
 			Updated:       now,
-			ForkID:        in.ForkID,
 			DefaultBranch: in.DefaultBranch,
 			IsEmpty:       isEmpty,
 		}
@@ -168,6 +168,11 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create repository operation: %s", err)
 	}
 
+	c.eventReporter.Created(ctx, &repoevents.CreatedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+	})
+
 	// index repository if files are created
 	if !repo.IsEmpty {
 		err = c.indexer.Index(ctx, repo)
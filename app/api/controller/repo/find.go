@@ -16,9 +16,12 @@ package repo
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -38,5 +41,15 @@ func (c *Controller) Find(ctx context.Context, session *auth.Session, repoRef st
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
 	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(repo.Path)
 
-	return GetRepoOutput(ctx, c.publicAccess, repo)
+	repoOut, err := GetRepoOutput(ctx, c.publicAccess, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	repoOut.Mirror, err = c.repoMirrorStore.Find(ctx, repo.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find repo mirror: %w", err)
+	}
+
+	return repoOut, nil
 }
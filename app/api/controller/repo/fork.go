@@ -0,0 +1,263 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ForkInput struct {
+	// ParentRef is the space in which the fork is created.
+	ParentRef string `json:"parent_ref"`
+	// Identifier of the fork (optional, default: identifier of the upstream repository).
+	Identifier string `json:"identifier"`
+	// Description of the fork (optional, default: description of the upstream repository).
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// Fork creates a fork of a repository in the provided space.
+// The fork shares the git objects of the upstream repository and starts with all of its branches and tags.
+func (c *Controller) Fork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ForkInput,
+) (*RepositoryOutput, error) {
+	upstream, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if upstream.Importing {
+		return nil, usererror.BadRequest("Repository can't be forked while it's being imported.")
+	}
+
+	if err = c.sanitizeForkInput(in, upstream); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	if in.IsPublic {
+		// read access to a private repository must not allow publishing its content.
+		var isUpstreamPublic bool
+		isUpstreamPublic, err = c.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, upstream.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check public access of the upstream repository: %w", err)
+		}
+		if !isUpstreamPublic {
+			return nil, usererror.BadRequest("A fork of a private repository can't be public.")
+		}
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	isPublicAccessSupported, err := c.publicAccess.IsPublicAccessSupported(ctx, parentSpace.Path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to check if public access is supported for parent space %q: %w",
+			parentSpace.Path,
+			err,
+		)
+	}
+	if in.IsPublic && !isPublicAccessSupported {
+		return nil, errPublicRepoCreationDisabled
+	}
+
+	err = c.repoCheck.Create(ctx, session, &CreateInput{
+		ParentRef:     in.ParentRef,
+		Identifier:    in.Identifier,
+		DefaultBranch: upstream.DefaultBranch,
+		Description:   in.Description,
+		IsPublic:      in.IsPublic,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	gitResp, err := c.forkGitRepository(ctx, session, upstream)
+	if err != nil {
+		return nil, fmt.Errorf("error forking repository on git: %w", err)
+	}
+
+	var repo *types.Repository
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		// lock the space for update during repo creation to prevent racing conditions with space soft delete.
+		parentSpace, err = c.spaceStore.FindForUpdate(ctx, parentSpace.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find the parent space: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		repo = &types.Repository{
+			Version:       0,
+			ParentID:      parentSpace.ID,
+			Identifier:    in.Identifier,
+			GitUID:        gitResp.UID,
+			Description:   in.Description,
+			CreatedBy:     session.Principal.ID,
+			Created:       now,
+			Updated:       now,
+			ForkID:        upstream.ID,
+			DefaultBranch: gitResp.DefaultBranch,
+			IsEmpty:       upstream.IsEmpty,
+		}
+
+		if err = c.repoStore.Create(ctx, repo); err != nil {
+			return err
+		}
+
+		_, err = c.repoStore.UpdateOptLock(ctx, upstream, func(r *types.Repository) error {
+			r.NumForks++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update number of forks of the upstream repository: %w", err)
+		}
+
+		return nil
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		// best effort cleanup
+		if dErr := c.DeleteGitRepository(ctx, session, gitResp.UID); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete forked repo for cleanup")
+		}
+		return nil, err
+	}
+
+	err = c.publicAccess.Set(ctx, enum.PublicResourceTypeRepo, repo.Path, in.IsPublic)
+	if err != nil {
+		if dErr := c.publicAccess.Delete(ctx, enum.PublicResourceTypeRepo, repo.Path); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and public access cleanup: %w): %w", dErr, err)
+		}
+
+		// only cleanup repo itself if cleanup of public access succeeded (to avoid leaking public access)
+		if dErr := c.PurgeNoAuth(ctx, session, repo); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and repo purge: %w): %w", dErr, err)
+		}
+
+		return nil, fmt.Errorf("failed to set repo public access (succesfull cleanup): %w", err)
+	}
+
+	// backfil GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(repo.Path)
+
+	repoOutput := &RepositoryOutput{
+		Repository: *repo,
+		IsPublic:   in.IsPublic,
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(audit.RepositoryObject{
+			Repository: repoOutput.Repository,
+			IsPublic:   repoOutput.IsPublic,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for fork repository operation: %s", err)
+	}
+
+	if !repo.IsEmpty {
+		err = c.indexer.Index(ctx, repo)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repo")
+		}
+	}
+
+	return repoOutput, nil
+}
+
+func (c *Controller) sanitizeForkInput(in *ForkInput, upstream *types.Repository) error {
+	if err := c.validateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if in.Identifier == "" {
+		in.Identifier = upstream.Identifier
+	}
+
+	if err := c.identifierCheck(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if in.Description == "" {
+		in.Description = upstream.Description
+	}
+
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) forkGitRepository(
+	ctx context.Context,
+	session *auth.Session,
+	upstream *types.Repository,
+) (*git.ForkRepositoryOutput, error) {
+	// generate envars (add everything githook CLI needs for execution)
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(),
+		0,
+		session.Principal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	resp, err := c.git.ForkRepository(ctx, &git.ForkRepositoryParams{
+		Actor:           *identityFromPrincipal(session.Principal),
+		EnvVars:         envVars,
+		UpstreamRepoUID: upstream.GitUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fork repo: %w", err)
+	}
+
+	return resp, nil
+}
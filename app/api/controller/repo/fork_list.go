@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListForks lists the forks of a repository.
+// Forks the caller isn't allowed to view are omitted from the result, but are included in the total count.
+func (c *Controller) ListForks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.RepoFilter,
+) ([]*RepositoryOutput, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var forks []*types.Repository
+	var count int64
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		count, err = c.repoStore.CountForks(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count forks: %w", err)
+		}
+
+		forks, err = c.repoStore.ListForks(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list forks: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	forksOut := make([]*RepositoryOutput, 0, len(forks))
+	for _, fork := range forks {
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, fork, enum.PermissionRepoView)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to check access to fork %q: %w", fork.Path, err)
+		}
+
+		// backfill URLs
+		fork.GitURL = c.urlProvider.GenerateGITCloneURL(fork.Path)
+		fork.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(fork.Path)
+
+		forkOut, err := GetRepoOutput(ctx, c.publicAccess, fork)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get fork %q output: %w", fork.Path, err)
+		}
+
+		forksOut = append(forksOut, forkOut)
+	}
+
+	return forksOut, count, nil
+}
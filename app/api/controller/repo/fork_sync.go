@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type SyncForkInput struct {
+	// Branch is the branch of the fork that is synced with the same branch of the upstream repository
+	// (optional, default: default branch of the fork).
+	Branch string `json:"branch"`
+
+	BypassRules bool `json:"bypass_rules"`
+}
+
+type SyncForkOutput struct {
+	Branch string  `json:"branch"`
+	OldSHA sha.SHA `json:"old_sha"`
+	NewSHA sha.SHA `json:"new_sha"`
+}
+
+// SyncFork fast-forwards a branch of a fork to the same branch of its upstream repository.
+// If the branch doesn't exist in the fork yet, it's created.
+func (c *Controller) SyncFork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *SyncForkInput,
+) (*SyncForkOutput, []types.RuleViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if repo.ForkID == 0 {
+		return nil, nil, usererror.BadRequest("Repository is not a fork.")
+	}
+
+	upstream, err := c.repoStore.Find(ctx, repo.ForkID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find upstream repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, upstream, enum.PermissionRepoView); err != nil {
+		return nil, nil, fmt.Errorf("access check on upstream repository failed: %w", err)
+	}
+
+	if in.Branch == "" {
+		in.Branch = repo.DefaultBranch
+	}
+
+	upstreamBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(upstream),
+		BranchName: in.Branch,
+	})
+	if errors.IsNotFound(err) {
+		return nil, nil, usererror.BadRequestf("Branch %q doesn't exist in the upstream repository.", in.Branch)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get upstream branch: %w", err)
+	}
+
+	newSHA := upstreamBranch.Branch.SHA
+	oldSHA := sha.None
+	refAction := protection.RefActionCreate
+
+	forkBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: in.Branch,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("failed to get fork branch: %w", err)
+	}
+	if err == nil {
+		oldSHA = forkBranch.Branch.SHA
+		refAction = protection.RefActionUpdate
+	}
+
+	out := &SyncForkOutput{
+		Branch: in.Branch,
+		OldSHA: oldSHA,
+		NewSHA: newSHA,
+	}
+
+	if oldSHA.Equal(newSHA) {
+		return out, nil, nil
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   refAction,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{in.Branch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// the objects are usually already available via alternates, this is for forks of forks with diverged objects.
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: upstream.GitUID,
+		ObjectSHAs:    []sha.SHA{newSHA},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch upstream commit: %w", err)
+	}
+
+	if !oldSHA.IsEmpty() {
+		ancestorOut, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.CreateReadParams(repo),
+			AncestorCommitSHA:   oldSHA,
+			DescendantCommitSHA: newSHA,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check if fork branch is behind upstream branch: %w", err)
+		}
+		if !ancestorOut.Ancestor {
+			return nil, nil, usererror.Conflict(fmt.Sprintf(
+				"Branch %q of the fork has diverged from the upstream repository and can't be fast-forwarded.",
+				in.Branch))
+		}
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        in.Branch,
+		Type:        gitenum.RefTypeBranch,
+		NewValue:    newSHA,
+		OldValue:    oldSHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update fork branch: %w", err)
+	}
+
+	return out, nil, nil
+}
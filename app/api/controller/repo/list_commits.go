@@ -68,6 +68,11 @@ func (c *Controller) ListCommits(ctx context.Context,
 		commits[i] = *commit
 	}
 
+	err = controller.VerifyCommitSignatures(ctx, c.publicKeyService, rpcOut.Commits, commits)
+	if err != nil {
+		return types.ListCommitResponse{}, err
+	}
+
 	renameDetailList := make([]types.RenameDetails, len(rpcOut.RenameDetails))
 	for i := range rpcOut.RenameDetails {
 		renameDetails := controller.MapRenameDetails(rpcOut.RenameDetails[i])
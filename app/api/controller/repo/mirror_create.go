@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// mirrorMinInterval is the minimal number of minutes between two scheduled synchronizations of a mirror.
+const mirrorMinInterval = 5
+
+type MirrorCreateInput struct {
+	SourceURL string `json:"source_url"`
+	Connector string `json:"connector"`
+	Interval  int64  `json:"interval"`
+}
+
+func (in *MirrorCreateInput) sanitize() error {
+	var err error
+
+	if in.SourceURL, err = sanitizeRemoteURL("Source URL", in.SourceURL); err != nil {
+		return err
+	}
+
+	if in.Connector, err = sanitizeMirrorConnector(in.Connector); err != nil {
+		return err
+	}
+
+	return sanitizeMirrorInterval(in.Interval)
+}
+
+// MirrorCreate converts an empty repository into a pull mirror of an external repository
+// and schedules its initial synchronization.
+func (c *Controller) MirrorCreate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MirrorCreateInput,
+) (*types.RepoMirror, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if !repo.IsEmpty {
+		return nil, usererror.BadRequest("Only an empty repository can be converted to a mirror.")
+	}
+
+	if err = c.checkMirrorConnector(ctx, session, repo, in.Connector); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	mirror := &types.RepoMirror{
+		RepoID:     repo.ID,
+		SourceURL:  in.SourceURL,
+		Connector:  in.Connector,
+		Interval:   in.Interval,
+		NextSync:   mirrorNextSync(in.Interval, now),
+		SyncStatus: enum.RepoMirrorSyncStatusPending,
+		CreatedBy:  session.Principal.ID,
+		Created:    now.UnixMilli(),
+		Updated:    now.UnixMilli(),
+	}
+
+	err = c.repoMirrorStore.Create(ctx, mirror)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("The repository is already a mirror.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repo mirror: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepositoryMirror, repo.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(mirror),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create repository mirror operation: %s", err)
+	}
+
+	if err = c.repoMirror.Sync(ctx, mirror); err != nil {
+		return nil, fmt.Errorf("failed to schedule initial sync of repo mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// checkMirrorConnector verifies that the connector exists in the parent space of the repo
+// and that the caller is allowed to use it.
+func (c *Controller) checkMirrorConnector(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	connector string,
+) error {
+	if connector == "" {
+		return nil
+	}
+
+	_, err := c.connectorStore.FindByIdentifier(ctx, repo.ParentID, connector)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return usererror.BadRequestf("Connector %q not found in the parent space of the repository.", connector)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find connector: %w", err)
+	}
+
+	err = apiauth.CheckConnector(ctx, c.authorizer, session, paths.Parent(repo.Path), connector,
+		enum.PermissionConnectorAccess)
+	if err != nil {
+		return fmt.Errorf("access check failed for connector %q: %w", connector, err)
+	}
+
+	return nil
+}
+
+// sanitizeRemoteURL validates the URL of an external git repository.
+func sanitizeRemoteURL(name string, remoteURL string) (string, error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if remoteURL == "" {
+		return "", usererror.BadRequestf("%s is required.", name)
+	}
+
+	u, err := url.Parse(remoteURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", usererror.BadRequestf("%s must be a valid HTTP or HTTPS URL.", name)
+	}
+
+	if u.User != nil {
+		return "", usererror.BadRequestf("%s must not contain credentials.", name)
+	}
+
+	return remoteURL, nil
+}
+
+func sanitizeMirrorConnector(connector string) (string, error) {
+	connector = strings.TrimSpace(connector)
+	if connector == "" {
+		return "", nil
+	}
+
+	if err := check.Identifier(connector); err != nil {
+		return "", err
+	}
+
+	return connector, nil
+}
+
+func sanitizeMirrorInterval(interval int64) error {
+	if interval != 0 && interval < mirrorMinInterval {
+		return usererror.BadRequestf("Sync interval must be either 0 (manual sync only) or at least %d minutes.",
+			mirrorMinInterval)
+	}
+
+	return nil
+}
+
+// mirrorNextSync returns the time of the next scheduled sync, or zero if the mirror is synced only on request.
+func mirrorNextSync(interval int64, now time.Time) int64 {
+	if interval == 0 {
+		return 0
+	}
+
+	return now.Add(time.Duration(interval) * time.Minute).UnixMilli()
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/repomirror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var errMirrorSyncInProgress = errors.New("mirror sync in progress")
+
+// MirrorSync schedules an immediate synchronization of a repository mirror.
+func (c *Controller) MirrorSync(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepoMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.repoMirrorStore.Find(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo mirror: %w", err)
+	}
+
+	mirror, err = c.repoMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.RepoMirror) error {
+		now := time.Now()
+		if repomirror.IsSyncInProgress(mirror, now) {
+			return errMirrorSyncInProgress
+		}
+
+		mirror.SyncStatus = enum.RepoMirrorSyncStatusPending
+		mirror.Updated = now.UnixMilli()
+
+		return nil
+	})
+	if errors.Is(err, errMirrorSyncInProgress) {
+		return nil, usererror.Conflict("Synchronization of the repository mirror is already in progress.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update repo mirror: %w", err)
+	}
+
+	if err = c.repoMirror.Sync(ctx, mirror); err != nil {
+		return nil, fmt.Errorf("failed to schedule repo mirror sync: %w", err)
+	}
+
+	return mirror, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MirrorUpdateInput struct {
+	SourceURL *string `json:"source_url"`
+	Connector *string `json:"connector"`
+	Interval  *int64  `json:"interval"`
+}
+
+func (in *MirrorUpdateInput) sanitize() error {
+	if in.SourceURL != nil {
+		sourceURL, err := sanitizeRemoteURL("Source URL", *in.SourceURL)
+		if err != nil {
+			return err
+		}
+		in.SourceURL = &sourceURL
+	}
+
+	if in.Connector != nil {
+		connector, err := sanitizeMirrorConnector(*in.Connector)
+		if err != nil {
+			return err
+		}
+		in.Connector = &connector
+	}
+
+	if in.Interval != nil {
+		if err := sanitizeMirrorInterval(*in.Interval); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MirrorUpdate updates the mirror configuration of a repository.
+func (c *Controller) MirrorUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MirrorUpdateInput,
+) (*types.RepoMirror, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Connector != nil {
+		if err = c.checkMirrorConnector(ctx, session, repo, *in.Connector); err != nil {
+			return nil, err
+		}
+	}
+
+	mirror, err := c.repoMirrorStore.Find(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo mirror: %w", err)
+	}
+
+	oldMirror := *mirror
+
+	mirror, err = c.repoMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.RepoMirror) error {
+		now := time.Now()
+		mirror.Updated = now.UnixMilli()
+
+		if in.SourceURL != nil {
+			mirror.SourceURL = *in.SourceURL
+		}
+		if in.Connector != nil {
+			mirror.Connector = *in.Connector
+		}
+		if in.Interval != nil && *in.Interval != mirror.Interval {
+			mirror.Interval = *in.Interval
+			mirror.NextSync = mirrorNextSync(mirror.Interval, now)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update repo mirror: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepositoryMirror, repo.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(oldMirror),
+		audit.WithNewObject(mirror),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update repository mirror operation: %s", err)
+	}
+
+	return mirror, nil
+}
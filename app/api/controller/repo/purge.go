@@ -17,14 +17,18 @@ package repo
 import (
 	"context"
 	"fmt"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -76,17 +80,30 @@ func (c *Controller) PurgeNoAuth(
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
 
+	c.PurgeForkRelations(ctx, session, repo)
+
 	if err := c.DeleteGitRepository(ctx, session, repo.GitUID); err != nil {
 		log.Ctx(ctx).Err(err).Msg("failed to remove git repository")
 	}
 
+	if err := c.DeleteLFSObjects(ctx, repo.ID); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to remove lfs objects")
+	}
+
+	c.ReportDeleted(ctx, repo.ID)
+
+	return nil
+}
+
+// ReportDeleted reports the permanent deletion of a repository,
+// which allows services to remove any data they keep for the repository.
+func (c *Controller) ReportDeleted(ctx context.Context, repoID int64) {
 	c.eventReporter.Deleted(
 		ctx,
 		&repoevents.DeletedPayload{
-			RepoID: repo.ID,
+			RepoID: repoID,
 		},
 	)
-	return nil
 }
 
 func (c *Controller) DeleteGitRepository(
@@ -128,3 +145,107 @@ func (c *Controller) DeleteGitRepository(
 	}
 	return nil
 }
+
+// DeleteLFSObjects removes all LFS objects of the repository from the blob store.
+// NOTE: the object records themselves are removed together with the repository.
+func (c *Controller) DeleteLFSObjects(ctx context.Context, repoID int64) error {
+	if err := c.blobStore.DeletePrefix(ctx, lfs.GetBlobPathPrefix(repoID)); err != nil {
+		return fmt.Errorf("failed to remove lfs objects of repository %d: %w", repoID, err)
+	}
+	return nil
+}
+
+// PurgeForkRelations removes the fork relations of a repository that is being purged (best effort).
+// It has to be called before the git repository is deleted.
+func (c *Controller) PurgeForkRelations(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) {
+	if err := c.dissociateForks(ctx, session, repo); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).
+			Msg("failed to dissociate forks of the repository")
+	}
+
+	if repo.ForkID != 0 {
+		c.decrementNumForks(ctx, repo.ForkID)
+	}
+}
+
+// dissociateForks makes all forks of the repository independent of the repository's git objects
+// and removes their link to the repository, so the repository can be deleted safely.
+func (c *Controller) dissociateForks(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) error {
+	const largeLimit = 1000000
+
+	if repo.NumForks == 0 {
+		return nil
+	}
+
+	// soft deleted forks could be restored, so they have to be dissociated as well.
+	now := time.Now().UnixMilli()
+	filters := []*types.RepoFilter{
+		{Size: largeLimit},
+		{Size: largeLimit, DeletedBeforeOrAt: &now},
+	}
+
+	for _, filter := range filters {
+		forks, err := c.repoStore.ListForks(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list forks: %w", err)
+		}
+
+		for _, fork := range forks {
+			writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, fork)
+			if err != nil {
+				return fmt.Errorf("failed to create RPC write params: %w", err)
+			}
+
+			err = c.git.DissociateRepository(ctx, &git.DissociateRepositoryParams{
+				WriteParams: writeParams,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to dissociate fork %d: %w", fork.ID, err)
+			}
+
+			if fork.Deleted != nil {
+				continue
+			}
+
+			_, err = c.repoStore.UpdateOptLock(ctx, fork, func(r *types.Repository) error {
+				r.ForkID = 0
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to unlink fork %d: %w", fork.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// decrementNumForks decrements the number of forks of the upstream repository (best effort).
+func (c *Controller) decrementNumForks(ctx context.Context, upstreamID int64) {
+	upstream, err := c.repoStore.Find(ctx, upstreamID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find upstream repository of purged fork")
+		return
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, upstream, func(r *types.Repository) error {
+		if r.NumForks > 0 {
+			r.NumForks--
+		}
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to decrement number of forks of upstream repository")
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/pushmirror"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
+)
+
+type PushMirrorCreateInput struct {
+	Identifier string   `json:"identifier"`
+	URL        string   `json:"url"`
+	Connector  string   `json:"connector"`
+	Username   string   `json:"username"`
+	Secret     string   `json:"secret"`
+	RefFilter  []string `json:"ref_filter"`
+	Force      bool     `json:"force"`
+	Enabled    *bool    `json:"enabled"`
+}
+
+func (in *PushMirrorCreateInput) sanitize() error {
+	var err error
+
+	if err = check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if in.URL, err = sanitizeRemoteURL("URL", in.URL); err != nil {
+		return err
+	}
+
+	if in.Connector, err = sanitizeMirrorConnector(in.Connector); err != nil {
+		return err
+	}
+
+	if in.Secret, err = sanitizePushMirrorSecret(in.Secret); err != nil {
+		return err
+	}
+
+	in.Username = strings.TrimSpace(in.Username)
+
+	if in.Connector != "" && in.Secret != "" {
+		return errPushMirrorConnectorAndSecret
+	}
+
+	if in.RefFilter, err = sanitizePushMirrorRefFilter(in.RefFilter); err != nil {
+		return err
+	}
+
+	if in.Enabled == nil {
+		in.Enabled = ptr.Bool(true)
+	}
+
+	return nil
+}
+
+var errPushMirrorConnectorAndSecret = usererror.BadRequest(
+	"Push mirror credentials can be provided either by a connector or by a secret, not both.")
+
+// PushMirrorCreate creates a new push mirror of a repository.
+// If the repository isn't empty, its branches and tags are pushed to the mirror right away.
+func (c *Controller) PushMirrorCreate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *PushMirrorCreateInput,
+) (*types.PushMirror, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.checkMirrorConnector(ctx, session, repo, in.Connector); err != nil {
+		return nil, err
+	}
+
+	if err = c.checkPushMirrorSecret(ctx, session, repo, in.Secret); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	mirror := &types.PushMirror{
+		RepoID:     repo.ID,
+		Identifier: in.Identifier,
+		URL:        in.URL,
+		Connector:  in.Connector,
+		Username:   in.Username,
+		Secret:     in.Secret,
+		RefFilter:  in.RefFilter,
+		Force:      in.Force,
+		Enabled:    *in.Enabled,
+		CreatedBy:  session.Principal.ID,
+		Created:    now,
+		Updated:    now,
+	}
+
+	err = c.pushMirrorStore.Create(ctx, mirror)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push mirror: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypePushMirror, mirror.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(mirror),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create push mirror operation: %s", err)
+	}
+
+	if mirror.Enabled && !repo.IsEmpty {
+		if err = c.pushMirror.Push(ctx, mirror); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to schedule initial push to push mirror")
+		}
+	}
+
+	return mirror, nil
+}
+
+// checkPushMirrorSecret verifies that the secret exists in the parent space of the repo
+// and that the caller is allowed to use it.
+func (c *Controller) checkPushMirrorSecret(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	secret string,
+) error {
+	if secret == "" {
+		return nil
+	}
+
+	_, err := c.secretStore.FindByIdentifier(ctx, repo.ParentID, secret)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return usererror.BadRequestf("Secret %q not found in the parent space of the repository.", secret)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find secret: %w", err)
+	}
+
+	err = apiauth.CheckSecret(ctx, c.authorizer, session, paths.Parent(repo.Path), secret, enum.PermissionSecretAccess)
+	if err != nil {
+		return fmt.Errorf("access check failed for secret %q: %w", secret, err)
+	}
+
+	return nil
+}
+
+func sanitizePushMirrorSecret(secret string) (string, error) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", nil
+	}
+
+	if err := check.Identifier(secret); err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+func sanitizePushMirrorRefFilter(refFilter []string) ([]string, error) {
+	result := make([]string, 0, len(refFilter))
+	for _, pattern := range refFilter {
+		pattern = strings.TrimSpace(pattern)
+		if err := pushmirror.ValidateRefPattern(pattern); err != nil {
+			return nil, usererror.BadRequestf("Invalid ref filter pattern %q: %s.", pattern, err)
+		}
+
+		if !slices.Contains(result, pattern) {
+			result = append(result, pattern)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result, nil
+}
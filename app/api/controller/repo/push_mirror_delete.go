@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PushMirrorDelete deletes a push mirror of a repository.
+func (c *Controller) PushMirrorDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	mirror, err := c.pushMirrorStore.FindByIdentifier(ctx, repo.ID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	if err = c.pushMirrorStore.Delete(ctx, mirror.ID); err != nil {
+		return fmt.Errorf("failed to delete push mirror: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypePushMirror, mirror.Identifier),
+		audit.ActionDeleted,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(mirror),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete push mirror operation: %s", err)
+	}
+
+	return nil
+}
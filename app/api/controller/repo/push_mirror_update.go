@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type PushMirrorUpdateInput struct {
+	Identifier *string   `json:"identifier"`
+	URL        *string   `json:"url"`
+	Connector  *string   `json:"connector"`
+	Username   *string   `json:"username"`
+	Secret     *string   `json:"secret"`
+	RefFilter  *[]string `json:"ref_filter"`
+	Force      *bool     `json:"force"`
+	Enabled    *bool     `json:"enabled"`
+}
+
+func (in *PushMirrorUpdateInput) sanitize() error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.URL != nil {
+		remoteURL, err := sanitizeRemoteURL("URL", *in.URL)
+		if err != nil {
+			return err
+		}
+		in.URL = &remoteURL
+	}
+
+	if in.Connector != nil {
+		connector, err := sanitizeMirrorConnector(*in.Connector)
+		if err != nil {
+			return err
+		}
+		in.Connector = &connector
+	}
+
+	if in.Secret != nil {
+		secret, err := sanitizePushMirrorSecret(*in.Secret)
+		if err != nil {
+			return err
+		}
+		in.Secret = &secret
+	}
+
+	if in.Username != nil {
+		username := strings.TrimSpace(*in.Username)
+		in.Username = &username
+	}
+
+	if in.RefFilter != nil {
+		refFilter, err := sanitizePushMirrorRefFilter(*in.RefFilter)
+		if err != nil {
+			return err
+		}
+		in.RefFilter = &refFilter
+	}
+
+	return nil
+}
+
+// PushMirrorUpdate updates a push mirror of a repository.
+func (c *Controller) PushMirrorUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+	in *PushMirrorUpdateInput,
+) (*types.PushMirror, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Connector != nil {
+		if err = c.checkMirrorConnector(ctx, session, repo, *in.Connector); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.Secret != nil {
+		if err = c.checkPushMirrorSecret(ctx, session, repo, *in.Secret); err != nil {
+			return nil, err
+		}
+	}
+
+	mirror, err := c.pushMirrorStore.FindByIdentifier(ctx, repo.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	oldMirror := *mirror
+
+	mirror, err = c.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+		if in.Identifier != nil {
+			mirror.Identifier = *in.Identifier
+		}
+		if in.URL != nil {
+			mirror.URL = *in.URL
+		}
+		if in.Connector != nil {
+			mirror.Connector = *in.Connector
+		}
+		if in.Username != nil {
+			mirror.Username = *in.Username
+		}
+		if in.Secret != nil {
+			mirror.Secret = *in.Secret
+		}
+		if in.RefFilter != nil {
+			mirror.RefFilter = *in.RefFilter
+		}
+		if in.Force != nil {
+			mirror.Force = *in.Force
+		}
+		if in.Enabled != nil {
+			mirror.Enabled = *in.Enabled
+		}
+
+		if mirror.Connector != "" && mirror.Secret != "" {
+			return errPushMirrorConnectorAndSecret
+		}
+
+		mirror.Updated = time.Now().UnixMilli()
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update push mirror: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypePushMirror, mirror.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(oldMirror),
+		audit.WithNewObject(mirror),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update push mirror operation: %s", err)
+	}
+
+	return mirror, nil
+}
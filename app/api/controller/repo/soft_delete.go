@@ -22,6 +22,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
@@ -105,5 +106,11 @@ func (c *Controller) SoftDeleteNoAuth(
 		return fmt.Errorf("failed to soft delete repo from db: %w", err)
 	}
 
+	c.eventReporter.SoftDeleted(ctx, &repoevents.SoftDeletedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		Deleted:     deletedAt,
+	})
+
 	return nil
 }
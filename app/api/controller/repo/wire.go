@@ -24,10 +24,14 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pushmirror"
+	"github.com/harness/gitness/app/services/repomirror"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -67,13 +71,22 @@ func ProvideController(
 	identifierCheck check.RepoIdentifier,
 	repoChecks Check,
 	publicAccess publicaccess.Service,
+	blobStore blob.Store,
+	publicKeyService publickey.Service,
+	repoMirrorStore store.RepoMirrorStore,
+	connectorStore store.ConnectorStore,
+	repoMirror *repomirror.Service,
+	pushMirrorStore store.PushMirrorStore,
+	secretStore store.SecretStore,
+	pushMirror *pushmirror.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer,
 		repoStore, spaceStore, pipelineStore,
 		principalStore, ruleStore, settings, principalInfoCache, protectionManager, rpcClient, importer,
 		codeOwners, reporeporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck,
-		repoChecks, publicAccess)
+		repoChecks, publicAccess, blobStore, publicKeyService, repoMirrorStore, connectorStore, repoMirror,
+		pushMirrorStore, secretStore, pushMirror)
 }
 
 func ProvideRepoCheck() Check {
@@ -17,14 +17,16 @@ package secret
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 )
 
 type Controller struct {
-	encrypter   encrypt.Encrypter
-	secretStore store.SecretStore
-	authorizer  authz.Authorizer
-	spaceStore  store.SpaceStore
+	encrypter    encrypt.Encrypter
+	secretStore  store.SecretStore
+	authorizer   authz.Authorizer
+	spaceStore   store.SpaceStore
+	auditService audit.Service
 }
 
 func NewController(
@@ -32,11 +34,13 @@ func NewController(
 	encrypter encrypt.Encrypter,
 	secretStore store.SecretStore,
 	spaceStore store.SpaceStore,
+	auditService audit.Service,
 ) *Controller {
 	return &Controller{
-		encrypter:   encrypter,
-		secretStore: secretStore,
-		authorizer:  authorizer,
-		spaceStore:  spaceStore,
+		encrypter:    encrypter,
+		secretStore:  secretStore,
+		authorizer:   authorizer,
+		spaceStore:   spaceStore,
+		auditService: auditService,
 	}
 }
@@ -20,7 +20,10 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 func (c *Controller) Delete(ctx context.Context, session *auth.Session, spaceRef string, identifier string) error {
@@ -34,9 +37,26 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session, spaceRef
 		return fmt.Errorf("failed to authorize: %w", err)
 	}
 
-	err = c.secretStore.DeleteByIdentifier(ctx, space.ID, identifier)
+	secret, err := c.secretStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find secret: %w", err)
+	}
+
+	err = c.secretStore.Delete(ctx, secret.ID)
 	if err != nil {
 		return fmt.Errorf("could not delete secret: %w", err)
 	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSecret, secret.Identifier),
+		audit.ActionDeleted,
+		space.Path,
+		audit.WithOldObject(secret),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete secret operation: %s", err)
+	}
+
 	return nil
 }
@@ -21,9 +21,12 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // UpdateInput is used for updating a repo.
@@ -61,7 +64,9 @@ func (c *Controller) Update(
 		return nil, fmt.Errorf("failed to find secret: %w", err)
 	}
 
-	return c.secretStore.UpdateOptLock(ctx, secret, func(original *types.Secret) error {
+	oldSecret := secret.CopyWithoutData()
+
+	secret, err = c.secretStore.UpdateOptLock(ctx, secret, func(original *types.Secret) error {
 		if in.Identifier != nil {
 			original.Identifier = *in.Identifier
 		}
@@ -78,6 +83,23 @@ func (c *Controller) Update(
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSecret, secret.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(oldSecret),
+		audit.WithNewObject(secret),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update secret operation: %s", err)
+	}
+
+	return secret, nil
 }
 
 func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
@@ -16,11 +16,17 @@ package serviceaccount
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type Controller struct {
@@ -30,11 +36,12 @@ type Controller struct {
 	spaceStore        store.SpaceStore
 	repoStore         store.RepoStore
 	tokenStore        store.TokenStore
+	auditService      audit.Service
 }
 
 func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
 	principalStore store.PrincipalStore, spaceStore store.SpaceStore, repoStore store.RepoStore,
-	tokenStore store.TokenStore) *Controller {
+	tokenStore store.TokenStore, auditService audit.Service) *Controller {
 	return &Controller{
 		principalUIDCheck: principalUIDCheck,
 		authorizer:        authorizer,
@@ -42,6 +49,47 @@ func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Author
 		spaceStore:        spaceStore,
 		repoStore:         repoStore,
 		tokenStore:        tokenStore,
+		auditService:      auditService,
+	}
+}
+
+// getParentSpacePath returns the path of the space the service account belongs to.
+// For service accounts of a repository, the path of the parent space of the repository is returned.
+func (c *Controller) getParentSpacePath(ctx context.Context, sa *types.ServiceAccount) (string, error) {
+	switch sa.ParentType {
+	case enum.ParentResourceTypeSpace:
+		space, err := c.spaceStore.Find(ctx, sa.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent space: %w", err)
+		}
+		return space.Path, nil
+	case enum.ParentResourceTypeRepo:
+		repo, err := c.repoStore.Find(ctx, sa.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent repo: %w", err)
+		}
+		return paths.Parent(repo.Path), nil
+	default:
+		return "", fmt.Errorf("unknown parent type %q", sa.ParentType)
+	}
+}
+
+// logAuditEvent inserts an audit event for an operation on a service account (or its tokens).
+// The operation already succeeded at this point, hence failures are only logged.
+func (c *Controller) logAuditEvent(
+	ctx context.Context,
+	principal types.Principal,
+	sa *types.ServiceAccount,
+	resource audit.Resource,
+	action audit.Action,
+	options ...audit.Option,
+) {
+	spacePath, err := c.getParentSpacePath(ctx, sa)
+	if err == nil {
+		err = c.auditService.Log(ctx, principal, resource, action, spacePath, options...)
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for %s %s operation: %s", resource.Type, action, err)
 	}
 }
 
@@ -20,8 +20,10 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -32,6 +34,8 @@ type CreateTokenInput struct {
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+
+	controller.TokenScopeInput
 }
 
 // CreateToken creates a new service account access token.
@@ -56,6 +60,12 @@ func (c *Controller) CreateToken(
 		return nil, err
 	}
 
+	scope, err := controller.ResolveTokenScope(ctx, c.authorizer, session, c.spaceStore, c.repoStore,
+		&in.TokenScopeInput)
+	if err != nil {
+		return nil, err
+	}
+
 	token, jwtToken, err := token.CreateSAT(
 		ctx,
 		c.tokenStore,
@@ -63,11 +73,18 @@ func (c *Controller) CreateToken(
 		sa,
 		in.Identifier,
 		in.Lifetime,
+		scope,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	c.logAuditEvent(ctx, session.Principal, sa,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		audit.ActionCreated,
+		audit.WithNewObject(token),
+	)
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
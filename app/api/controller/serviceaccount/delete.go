@@ -19,6 +19,7 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -36,5 +37,16 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session,
 		return err
 	}
 
-	return c.principalStore.DeleteServiceAccount(ctx, sa.ID)
+	err = c.principalStore.DeleteServiceAccount(ctx, sa.ID)
+	if err != nil {
+		return err
+	}
+
+	c.logAuditEvent(ctx, session.Principal, sa,
+		audit.NewResource(audit.ResourceTypeServiceAccount, sa.UID),
+		audit.ActionDeleted,
+		audit.WithOldObject(sa),
+	)
+
+	return nil
 }
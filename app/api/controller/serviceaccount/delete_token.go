@@ -20,6 +20,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
@@ -56,5 +57,16 @@ func (c *Controller) DeleteToken(
 		return usererror.ErrNotFound
 	}
 
-	return c.tokenStore.Delete(ctx, token.ID)
+	err = c.tokenStore.Delete(ctx, token.ID)
+	if err != nil {
+		return err
+	}
+
+	c.logAuditEvent(ctx, session.Principal, sa,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		audit.ActionDeleted,
+		audit.WithOldObject(token),
+	)
+
+	return nil
 }
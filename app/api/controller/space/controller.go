@@ -82,6 +82,7 @@ type Controller struct {
 	publicAccess    publicaccess.Service
 	auditService    audit.Service
 	gitspaceStore   store.GitspaceConfigStore
+	auditStore      audit.Store
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -91,7 +92,7 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
 	membershipStore store.MembershipStore, importer *importer.Repository, exporter *exporter.Repository,
 	limiter limiter.ResourceLimiter, publicAccess publicaccess.Service, auditService audit.Service,
-	gitspaceStore store.GitspaceConfigStore,
+	gitspaceStore store.GitspaceConfigStore, auditStore audit.Store,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
@@ -116,5 +117,6 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		publicAccess:        publicAccess,
 		auditService:        auditService,
 		gitspaceStore:       gitspaceStore,
+		auditStore:          auditStore,
 	}
 }
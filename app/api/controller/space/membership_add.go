@@ -22,11 +22,13 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 )
 
 type MembershipAddInput struct {
@@ -106,5 +108,16 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		AddedBy:    *session.Principal.ToPrincipalInfo(),
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeMembership, user.UID),
+		audit.ActionCreated,
+		space.Path,
+		audit.WithNewObject(result),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for add membership operation: %s", err)
+	}
+
 	return result, nil
 }
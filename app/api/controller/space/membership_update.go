@@ -21,8 +21,11 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type MembershipUpdateInput struct {
@@ -84,6 +87,7 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return membership, nil
 	}
 
+	oldMembership := *membership
 	membership.Role = in.Role
 
 	err = c.membershipStore.Update(ctx, &membership.Membership)
@@ -91,5 +95,17 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, fmt.Errorf("failed to update membership")
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeMembership, user.UID),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(oldMembership),
+		audit.WithNewObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update membership operation: %s", err)
+	}
+
 	return membership, nil
 }
@@ -80,6 +80,8 @@ func (c *Controller) PurgeNoAuth(
 	// permanently purge all repositories in the space and its subspaces after successful space purge tnx.
 	// cleanup will handle failed repository deletions.
 	for _, repo := range toBeDeletedRepos {
+		c.repoCtrl.PurgeForkRelations(ctx, session, repo)
+
 		err := c.repoCtrl.DeleteGitRepository(ctx, session, repo.GitUID)
 		if err != nil {
 			log.Ctx(ctx).Warn().Err(err).
@@ -88,6 +90,16 @@ func (c *Controller) PurgeNoAuth(
 				Int64("repo_parent_id", repo.ParentID).
 				Msg("failed to delete repository")
 		}
+
+		err = c.repoCtrl.DeleteLFSObjects(ctx, repo.ID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("repo_identifier", repo.Identifier).
+				Int64("repo_id", repo.ID).
+				Msg("failed to delete repository lfs objects")
+		}
+
+		c.repoCtrl.ReportDeleted(ctx, repo.ID)
 	}
 
 	return nil
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// maxTokenScopeResources is the maximum number of spaces and repositories a token can be restricted to.
+const maxTokenScopeResources = 50
+
+// TokenScopeInput optionally restricts a new access token
+// to a subset of permissions and to a set of spaces and repositories.
+type TokenScopeInput struct {
+	Permissions []enum.Permission `json:"permissions"`
+	Spaces      []string          `json:"spaces"`
+	Repos       []string          `json:"repos"`
+}
+
+// ResolveTokenScope validates the token scope input and resolves the space and repository references.
+// The current session must have view access to all spaces and repositories of the scope.
+func ResolveTokenScope(
+	ctx context.Context,
+	authorizer authz.Authorizer,
+	session *auth.Session,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	in *TokenScopeInput,
+) (types.TokenScope, error) {
+	// tokens with a restricted scope aren't allowed to create tokens to prevent widening of the scope.
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok && tokenMetadata.IsRestricted() {
+		return types.TokenScope{}, usererror.Forbidden("Tokens with a restricted scope can't create access tokens.")
+	}
+
+	if len(in.Spaces)+len(in.Repos) > maxTokenScopeResources {
+		return types.TokenScope{}, usererror.BadRequestf(
+			"A token can be restricted to at most %d spaces and repositories.", maxTokenScopeResources)
+	}
+
+	scope := types.TokenScope{}
+
+	for _, p := range in.Permissions {
+		permission, ok := p.Sanitize()
+		if !ok || permission == "" {
+			return types.TokenScope{}, usererror.BadRequestf("Invalid permission: %q", p)
+		}
+
+		if !slices.Contains(scope.Permissions, permission) {
+			scope.Permissions = append(scope.Permissions, permission)
+		}
+	}
+
+	slices.Sort(scope.Permissions)
+
+	for _, spaceRef := range in.Spaces {
+		space, err := spaceStore.FindByRef(ctx, spaceRef)
+		if err != nil {
+			return types.TokenScope{}, fmt.Errorf("failed to find space %q: %w", spaceRef, err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, authorizer, session, space, enum.PermissionSpaceView); err != nil {
+			return types.TokenScope{}, fmt.Errorf("access check failed for space %q: %w", spaceRef, err)
+		}
+
+		if !slices.Contains(scope.SpaceIDs, space.ID) {
+			scope.SpaceIDs = append(scope.SpaceIDs, space.ID)
+		}
+	}
+
+	for _, repoRef := range in.Repos {
+		repo, err := repoStore.FindByRef(ctx, repoRef)
+		if err != nil {
+			return types.TokenScope{}, fmt.Errorf("failed to find repository %q: %w", repoRef, err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, authorizer, session, repo, enum.PermissionRepoView); err != nil {
+			return types.TokenScope{}, fmt.Errorf("access check failed for repository %q: %w", repoRef, err)
+		}
+
+		if !slices.Contains(scope.RepoIDs, repo.ID) {
+			scope.RepoIDs = append(scope.RepoIDs, repo.ID)
+		}
+	}
+
+	return scope, nil
+}
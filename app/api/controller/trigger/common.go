@@ -48,6 +48,33 @@ func checkActions(actions []enum.TriggerAction) error {
 	return nil
 }
 
+// checkCron validates the cron configuration of a trigger.
+// Cron triggers require a cron expression and don't support actions,
+// while hook triggers don't support any cron configuration.
+func checkCron(
+	triggerType enum.TriggerType,
+	actions []enum.TriggerAction,
+	expression string,
+	timezone string,
+	branch string,
+) error {
+	if triggerType != enum.TriggerTypeCron {
+		if expression != "" || timezone != "" || branch != "" {
+			return check.NewValidationError("Cron configuration is only supported for cron triggers.")
+		}
+		return nil
+	}
+
+	if len(actions) > 0 {
+		return check.NewValidationError("Cron triggers don't support actions.")
+	}
+	if expression == "" {
+		return check.NewValidationError("Cron triggers require a cron expression.")
+	}
+
+	return nil
+}
+
 // deduplicateActions de-duplicates the actions provided by in the trigger.
 func deduplicateActions(in []enum.TriggerAction) []enum.TriggerAction {
 	if len(in) == 0 {
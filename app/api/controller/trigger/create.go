@@ -17,10 +17,13 @@ package trigger
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	triggerservice "github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -35,6 +38,11 @@ type CreateInput struct {
 	Secret     string               `json:"secret"`
 	Disabled   bool                 `json:"disabled"`
 	Actions    []enum.TriggerAction `json:"actions"`
+
+	Type           enum.TriggerType `json:"trigger_type"`
+	CronExpression string           `json:"cron_expression"`
+	CronTimezone   string           `json:"cron_timezone"`
+	CronBranch     string           `json:"cron_branch"`
 }
 
 func (c *Controller) Create(
@@ -64,8 +72,17 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to find pipeline: %w", err)
 	}
 
-	now := time.Now().UnixMilli()
+	now := time.Now()
+	var cronNext int64
+	if in.Type == enum.TriggerTypeCron {
+		cronNext, err = triggerservice.NextCronRun(in.CronExpression, in.CronTimezone, now)
+		if err != nil {
+			return nil, usererror.BadRequestf("Invalid cron configuration: %s", err)
+		}
+	}
+
 	trigger := &types.Trigger{
+		Type:        in.Type,
 		Description: in.Description,
 		Disabled:    in.Disabled,
 		Secret:      in.Secret,
@@ -74,9 +91,14 @@ func (c *Controller) Create(
 		Actions:     deduplicateActions(in.Actions),
 		Identifier:  in.Identifier,
 		PipelineID:  pipeline.ID,
-		Created:     now,
-		Updated:     now,
+		Created:     now.UnixMilli(),
+		Updated:     now.UnixMilli(),
 		Version:     0,
+
+		CronExpression: in.CronExpression,
+		CronTimezone:   in.CronTimezone,
+		CronBranch:     in.CronBranch,
+		CronNext:       cronNext,
 	}
 	err = c.triggerStore.Create(ctx, trigger)
 	if err != nil {
@@ -101,9 +123,18 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 	if err := checkActions(in.Actions); err != nil {
 		return err
 	}
-	if err := check.Identifier(in.Identifier); err != nil { //nolint:revive
+	if err := check.Identifier(in.Identifier); err != nil {
 		return err
 	}
 
-	return nil
+	var ok bool
+	if in.Type, ok = in.Type.Sanitize(); !ok {
+		return check.NewValidationErrorf("The provided trigger type '%s' is invalid.", in.Type)
+	}
+
+	in.CronExpression = strings.TrimSpace(in.CronExpression)
+	in.CronTimezone = strings.TrimSpace(in.CronTimezone)
+	in.CronBranch = strings.TrimSpace(in.CronBranch)
+
+	return checkCron(in.Type, in.Actions, in.CronExpression, in.CronTimezone, in.CronBranch)
 }
@@ -18,9 +18,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	triggerservice "github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -35,6 +38,10 @@ type UpdateInput struct {
 	Actions    []enum.TriggerAction `json:"actions"`
 	Secret     *string              `json:"secret"`
 	Disabled   *bool                `json:"disabled"` // can be nil, so keeping it a pointer
+
+	CronExpression *string `json:"cron_expression"`
+	CronTimezone   *string `json:"cron_timezone"`
+	CronBranch     *string `json:"cron_branch"`
 }
 
 func (c *Controller) Update(
@@ -72,6 +79,7 @@ func (c *Controller) Update(
 
 	return c.triggerStore.UpdateOptLock(ctx,
 		trigger, func(original *types.Trigger) error {
+			cronChanged := false
 			if in.Identifier != nil {
 				original.Identifier = *in.Identifier
 			}
@@ -87,6 +95,31 @@ func (c *Controller) Update(
 			if in.Disabled != nil {
 				original.Disabled = *in.Disabled
 			}
+			if in.CronExpression != nil {
+				cronChanged = cronChanged || original.CronExpression != *in.CronExpression
+				original.CronExpression = *in.CronExpression
+			}
+			if in.CronTimezone != nil {
+				cronChanged = cronChanged || original.CronTimezone != *in.CronTimezone
+				original.CronTimezone = *in.CronTimezone
+			}
+			if in.CronBranch != nil {
+				original.CronBranch = *in.CronBranch
+			}
+
+			err := checkCron(original.Type, original.Actions,
+				original.CronExpression, original.CronTimezone, original.CronBranch)
+			if err != nil {
+				return err
+			}
+
+			if original.Type == enum.TriggerTypeCron && (cronChanged || original.CronNext == 0) {
+				original.CronNext, err = triggerservice.NextCronRun(
+					original.CronExpression, original.CronTimezone, time.Now())
+				if err != nil {
+					return usererror.BadRequestf("Invalid cron configuration: %s", err)
+				}
+			}
 
 			return nil
 		})
@@ -123,5 +156,15 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.CronExpression != nil {
+		*in.CronExpression = strings.TrimSpace(*in.CronExpression)
+	}
+	if in.CronTimezone != nil {
+		*in.CronTimezone = strings.TrimSpace(*in.CronTimezone)
+	}
+	if in.CronBranch != nil {
+		*in.CronBranch = strings.TrimSpace(*in.CronBranch)
+	}
+
 	return nil
 }
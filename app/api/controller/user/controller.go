@@ -19,6 +19,7 @@ import (
 
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -35,6 +36,10 @@ type Controller struct {
 	tokenStore        store.TokenStore
 	membershipStore   store.MembershipStore
 	publicKeyStore    store.PublicKeyStore
+	auditService      audit.Service
+	spaceStore        store.SpaceStore
+	repoStore         store.RepoStore
+	auditStore        audit.Store
 }
 
 func NewController(
@@ -45,6 +50,10 @@ func NewController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	auditService audit.Service,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	auditStore audit.Store,
 ) *Controller {
 	return &Controller{
 		tx:                tx,
@@ -54,6 +63,10 @@ func NewController(
 		tokenStore:        tokenStore,
 		membershipStore:   membershipStore,
 		publicKeyStore:    publicKeyStore,
+		auditService:      auditService,
+		spaceStore:        spaceStore,
+		repoStore:         repoStore,
+		auditStore:        auditStore,
 	}
 }
 
@@ -20,11 +20,15 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type CreateTokenInput struct {
@@ -32,6 +36,8 @@ type CreateTokenInput struct {
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+
+	controller.TokenScopeInput
 }
 
 /*
@@ -57,6 +63,12 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
+	scope, err := controller.ResolveTokenScope(ctx, c.authorizer, session, c.spaceStore, c.repoStore,
+		&in.TokenScopeInput)
+	if err != nil {
+		return nil, err
+	}
+
 	token, jwtToken, err := token.CreatePAT(
 		ctx,
 		c.tokenStore,
@@ -64,11 +76,23 @@ func (c *Controller) CreateAccessToken(
 		user,
 		in.Identifier,
 		in.Lifetime,
+		scope,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		audit.ActionCreated,
+		"",
+		audit.WithNewObject(token),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create token operation: %s", err)
+	}
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
@@ -20,6 +20,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
@@ -63,5 +64,21 @@ func (c *Controller) DeleteToken(
 		return usererror.ErrNotFound
 	}
 
-	return c.tokenStore.Delete(ctx, token.ID)
+	err = c.tokenStore.Delete(ctx, token.ID)
+	if err != nil {
+		return err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		audit.ActionDeleted,
+		"",
+		audit.WithOldObject(token),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete token operation: %s", err)
+	}
+
+	return nil
 }
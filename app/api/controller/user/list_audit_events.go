@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListAuditEvents lists the audit events of principal scoped resources (users, tokens and public keys),
+// which don't belong to any space.
+func (c *Controller) ListAuditEvents(
+	ctx context.Context,
+	session *auth.Session,
+	filter *audit.ListFilter,
+) ([]audit.Event, int64, error) {
+	// audit events can contain sensitive data, so only allow admins to see them.
+	scope := &types.Scope{}
+	resource := &types.Resource{
+		Type: enum.ResourceTypeUser,
+	}
+	if err := apiauth.Check(ctx, c.authorizer, session, scope, resource, enum.PermissionUserEditAdmin); err != nil {
+		return nil, 0, err
+	}
+
+	if filter.CreatedGt > 0 && filter.CreatedLt > 0 && filter.CreatedGt >= filter.CreatedLt {
+		return nil, 0, usererror.BadRequest("The start of the time range has to be before its end.")
+	}
+
+	// principal scoped events are stored without space.
+	filter.SpaceID = 0
+	filter.SpacePath = ""
+	filter.Recursive = false
+
+	var events []audit.Event
+	var count int64
+
+	err := c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		count, err = c.auditStore.Count(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count audit events: %w", err)
+		}
+
+		events, err = c.auditStore.List(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
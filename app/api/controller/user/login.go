@@ -24,6 +24,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 
@@ -78,6 +79,16 @@ func (c *Controller) Login(
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		*user.ToPrincipal(),
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionLogin,
+		"",
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for login operation: %s", err)
+	}
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
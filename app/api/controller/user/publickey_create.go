@@ -23,10 +23,13 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type CreatePublicKeyInput struct {
@@ -54,9 +57,9 @@ func (c *Controller) CreatePublicKey(
 		return nil, err
 	}
 
-	key, comment, err := publickey.ParseString(in.Content)
+	key, comment, err := parsePublicKey(in)
 	if err != nil {
-		return nil, errors.InvalidArgument("could not parse public key")
+		return nil, err
 	}
 
 	now := time.Now().UnixMilli()
@@ -96,9 +99,44 @@ func (c *Controller) CreatePublicKey(
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypePublicKey, k.Identifier),
+		audit.ActionCreated,
+		"",
+		audit.WithNewObject(k),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create public key operation: %s", err)
+	}
+
 	return k, nil
 }
 
+// parsePublicKey parses the public key content. SSH keys can be used for authentication and signing,
+// while PGP keys can only be used for signing.
+func parsePublicKey(in *CreatePublicKeyInput) (publickey.Key, string, error) {
+	if !publickey.IsPGP(in.Content) {
+		key, comment, err := publickey.ParseString(in.Content)
+		if err != nil {
+			return nil, "", errors.InvalidArgument("could not parse public key")
+		}
+
+		return key, comment, nil
+	}
+
+	if in.Usage != enum.PublicKeyUsageSign {
+		return nil, "", errors.InvalidArgument("PGP keys can only be used for signing")
+	}
+
+	key, comment, err := publickey.ParsePGP(in.Content)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, comment, nil
+}
+
 func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) error {
 	if err := check.Identifier(in.Identifier); err != nil {
 		return err
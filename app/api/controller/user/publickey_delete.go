@@ -20,7 +20,10 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 func (c *Controller) DeletePublicKey(
@@ -43,5 +46,16 @@ func (c *Controller) DeletePublicKey(
 		return fmt.Errorf("failed to delete public key by id: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypePublicKey, identifier),
+		audit.ActionDeleted,
+		"",
+		audit.WithData("user_uid", user.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete public key operation: %s", err)
+	}
+
 	return nil
 }
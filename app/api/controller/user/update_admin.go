@@ -17,13 +17,17 @@ package user
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type UpdateAdminInput struct {
@@ -55,6 +59,8 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 		}
 	}
 
+	oldUser := *user
+
 	user.Admin = request.Admin
 	user.Updated = time.Now().UnixMilli()
 
@@ -63,5 +69,18 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionUpdated,
+		"",
+		audit.WithOldObject(oldUser.ToPrincipal()),
+		audit.WithNewObject(user.ToPrincipal()),
+		audit.WithData("admin", strconv.FormatBool(user.Admin)),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update admin operation: %s", err)
+	}
+
 	return user, nil
 }
@@ -17,6 +17,7 @@ package user
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
 
@@ -36,6 +37,10 @@ func ProvideController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	auditService audit.Service,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	auditStore audit.Store,
 ) *Controller {
 	return NewController(
 		tx,
@@ -44,5 +49,9 @@ func ProvideController(
 		principalStore,
 		tokenStore,
 		membershipStore,
-		publicKeyStore)
+		publicKeyStore,
+		auditService,
+		spaceStore,
+		repoStore,
+		auditStore)
 }
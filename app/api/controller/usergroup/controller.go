@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	tx                       dbtx.Transactor
+	authorizer               authz.Authorizer
+	spaceStore               store.SpaceStore
+	principalStore           store.PrincipalStore
+	userGroupStore           store.UserGroupStore
+	userGroupMembershipStore store.UserGroupMembershipStore
+	auditService             audit.Service
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	spaceStore store.SpaceStore,
+	principalStore store.PrincipalStore,
+	userGroupStore store.UserGroupStore,
+	userGroupMembershipStore store.UserGroupMembershipStore,
+	auditService audit.Service,
+) *Controller {
+	return &Controller{
+		tx:                       tx,
+		authorizer:               authorizer,
+		spaceStore:               spaceStore,
+		principalStore:           principalStore,
+		userGroupStore:           userGroupStore,
+		userGroupMembershipStore: userGroupMembershipStore,
+		auditService:             auditService,
+	}
+}
+
+func (c *Controller) getSpaceCheckAuth(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	permission enum.Permission,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission); err != nil {
+		return nil, err
+	}
+
+	return space, nil
+}
+
+func (c *Controller) getUserGroupCheckAuth(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	permission enum.Permission,
+) (*types.Space, *types.UserGroup, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, permission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userGroup, err := c.userGroupStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	return space, userGroup, nil
+}
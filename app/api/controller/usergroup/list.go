@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List lists the user groups of a space.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.ListQueryFilter,
+) ([]*types.UserGroup, int64, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var userGroups []*types.UserGroup
+	var count int64
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		userGroups, err = c.userGroupStore.List(ctx, space.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list user groups: %w", err)
+		}
+
+		if filter.Page == 1 && len(userGroups) < filter.Size {
+			count = int64(len(userGroups))
+			return nil
+		}
+
+		count, err = c.userGroupStore.Count(ctx, space.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count user groups: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return userGroups, count, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MemberAddInput struct {
+	UserUID string `json:"user_uid"`
+}
+
+func (in *MemberAddInput) Validate() error {
+	if in.UserUID == "" {
+		return usererror.BadRequest("UserUID must be provided")
+	}
+
+	return nil
+}
+
+// MemberAdd adds a user to a user group.
+func (c *Controller) MemberAdd(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *MemberAddInput,
+) (*types.UserGroupMember, error) {
+	space, userGroup, err := c.getUserGroupCheckAuth(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the user: %w", err)
+	}
+
+	member := &types.UserGroupMember{
+		UserGroupID: userGroup.ID,
+		PrincipalID: user.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+		Principal:   *user.ToPrincipalInfo(),
+		AddedBy:     *session.Principal.ToPrincipalInfo(),
+	}
+
+	err = c.userGroupStore.AddMember(ctx, member)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add user group member: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUserGroup, userGroup.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithData("added_member", user.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for add user group member operation: %s", err)
+	}
+
+	return member, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MembershipAddInput struct {
+	UserGroupID int64               `json:"usergroup_id"`
+	Role        enum.MembershipRole `json:"role"`
+}
+
+func (in *MembershipAddInput) Validate() error {
+	if in.UserGroupID <= 0 {
+		return usererror.BadRequest("User group ID must be provided")
+	}
+
+	role, err := sanitizeRole(in.Role)
+	if err != nil {
+		return err
+	}
+
+	in.Role = role
+
+	return nil
+}
+
+// MembershipAdd makes a user group a member of a space.
+// All members of the user group get the role of the membership in the space.
+// Only user groups of the space or one of its ancestors can become members of the space.
+func (c *Controller) MembershipAdd(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *MembershipAddInput,
+) (*types.UserGroupMembershipInfo, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	userGroup, err := c.userGroupStore.Find(ctx, in.UserGroupID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User group with ID %d not found", in.UserGroupID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the user group: %w", err)
+	}
+
+	userGroupSpace, err := c.spaceStore.Find(ctx, userGroup.SpaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the space of the user group: %w", err)
+	}
+
+	if !paths.IsAncesterOf(userGroupSpace.Path, space.Path) {
+		return nil, usererror.BadRequest(
+			"Only user groups of the space or one of its parent spaces can become members of the space")
+	}
+
+	now := time.Now().UnixMilli()
+
+	membership := types.UserGroupMembership{
+		UserGroupMembershipKey: types.UserGroupMembershipKey{
+			SpaceID:     space.ID,
+			UserGroupID: userGroup.ID,
+		},
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+		Role:      in.Role,
+	}
+
+	err = c.userGroupMembershipStore.Create(ctx, &membership)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new user group membership: %w", err)
+	}
+
+	result := &types.UserGroupMembershipInfo{
+		UserGroupMembership: membership,
+		UserGroup:           *userGroup,
+		AddedBy:             *session.Principal.ToPrincipalInfo(),
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeMembership, userGroup.Identifier),
+		audit.ActionCreated,
+		space.Path,
+		audit.WithNewObject(result),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for add user group membership operation: %s", err)
+	}
+
+	return result, nil
+}
+
+func sanitizeRole(role enum.MembershipRole) (enum.MembershipRole, error) {
+	if role == "" {
+		return "", usererror.BadRequest("Role must be provided")
+	}
+
+	sanitized, ok := role.Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Provided role '%s' is not suppored. Valid values are: %v",
+			role, enum.MembershipRoles)
+	}
+
+	return sanitized, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MembershipDelete removes a user group membership from a space.
+func (c *Controller) MembershipDelete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	userGroupID int64,
+) error {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	key := types.UserGroupMembershipKey{
+		SpaceID:     space.ID,
+		UserGroupID: userGroupID,
+	}
+
+	userGroup, err := c.userGroupStore.Find(ctx, userGroupID)
+	if err != nil {
+		return fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	membership, err := c.userGroupMembershipStore.Find(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to find user group membership: %w", err)
+	}
+
+	err = c.userGroupMembershipStore.Delete(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete user group membership: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeMembership, userGroup.Identifier),
+		audit.ActionDeleted,
+		space.Path,
+		audit.WithOldObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete user group membership operation: %s", err)
+	}
+
+	return nil
+}
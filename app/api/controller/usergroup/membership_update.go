@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MembershipUpdateInput struct {
+	Role enum.MembershipRole `json:"role"`
+}
+
+func (in *MembershipUpdateInput) Validate() error {
+	role, err := sanitizeRole(in.Role)
+	if err != nil {
+		return err
+	}
+
+	in.Role = role
+
+	return nil
+}
+
+// MembershipUpdate changes the role of an existing user group membership of a space.
+func (c *Controller) MembershipUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	userGroupID int64,
+	in *MembershipUpdateInput,
+) (*types.UserGroupMembership, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	userGroup, err := c.userGroupStore.Find(ctx, userGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	membership, err := c.userGroupMembershipStore.Find(ctx, types.UserGroupMembershipKey{
+		SpaceID:     space.ID,
+		UserGroupID: userGroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user group membership for update: %w", err)
+	}
+
+	if membership.Role == in.Role {
+		return membership, nil
+	}
+
+	oldMembership := *membership
+	membership.Role = in.Role
+	membership.Updated = time.Now().UnixMilli()
+
+	err = c.userGroupMembershipStore.Update(ctx, membership)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user group membership: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeMembership, userGroup.Identifier),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(oldMembership),
+		audit.WithNewObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update user group membership operation: %s", err)
+	}
+
+	return membership, nil
+}
@@ -20,9 +20,12 @@ import (
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 // createRPCWriteParams creates base write parameters for git write operations.
@@ -111,6 +114,77 @@ func MapCommit(c *git.Commit) (*types.Commit, error) {
 		nil
 }
 
+// VerifyCommitSignatures sets the result of the signature verification of the git commits on the mapped commits.
+func VerifyCommitSignatures(
+	ctx context.Context,
+	publicKeyService publickey.Service,
+	gitCommits []git.Commit,
+	commits []types.Commit,
+) error {
+	verifications, err := publicKeyService.VerifyCommitSignatures(ctx, gitCommits)
+	if err != nil {
+		return err
+	}
+
+	for i := range verifications {
+		commits[i].Verification = verifications[i]
+	}
+
+	return nil
+}
+
+// maxSignatureVerifiedCommits is the max number of commits whose signatures are verified
+// when protection rules require signed commits.
+const maxSignatureVerifiedCommits = 1000
+
+// CommitLister lists commits. Both git.Interface and the restricted git client of the git hooks implement it.
+type CommitLister interface {
+	ListCommits(ctx context.Context, params *git.ListCommitsParams) (*git.ListCommitsOutput, error)
+}
+
+// VerifyNewCommitSignatures verifies the signatures of the commits that are reachable from gitRef,
+// but not from afterRef. If afterRef is empty, all commits reachable from gitRef are verified.
+func VerifyNewCommitSignatures(
+	ctx context.Context,
+	commitLister CommitLister,
+	publicKeyService publickey.Service,
+	readParams git.ReadParams,
+	gitRef string,
+	afterRef string,
+) (protection.VerifyCommitSignaturesOutput, error) {
+	out, err := commitLister.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		After:      afterRef,
+		Page:       1,
+		Limit:      maxSignatureVerifiedCommits + 1,
+	})
+	if err != nil {
+		return protection.VerifyCommitSignaturesOutput{}, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	var result protection.VerifyCommitSignaturesOutput
+
+	commits := out.Commits
+	if len(commits) > maxSignatureVerifiedCommits {
+		commits = commits[:maxSignatureVerifiedCommits]
+		result.TooManyCommits = true
+	}
+
+	verifications, err := publicKeyService.VerifyCommitSignatures(ctx, commits)
+	if err != nil {
+		return protection.VerifyCommitSignaturesOutput{}, err
+	}
+
+	for i, verification := range verifications {
+		if verification == nil || verification.Status != enum.CommitSignatureStatusVerified {
+			result.Unverified = append(result.Unverified, commits[i].SHA.String())
+		}
+	}
+
+	return result, nil
+}
+
 func mapStats(c *git.Commit) *types.CommitStats {
 	if len(c.FileStats) == 0 {
 		return nil
@@ -19,6 +19,7 @@ import (
 	"net/url"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
@@ -96,6 +97,38 @@ func checkTriggers(triggers []enum.WebhookTrigger) error {
 	return nil
 }
 
+// checkPayloadFormat validates the payload format of a webhook and returns its sanitized value.
+func checkPayloadFormat(format enum.WebhookPayloadFormat) (enum.WebhookPayloadFormat, error) {
+	format, ok := format.Sanitize()
+	if !ok {
+		return "", check.NewValidationErrorf("The provided webhook payload format '%s' is invalid.", format)
+	}
+
+	return format, nil
+}
+
+// checkPayloadTemplate validates the payload template of a webhook.
+func checkPayloadTemplate(format enum.WebhookPayloadFormat, payloadTemplate string) error {
+	if len(payloadTemplate) > webhook.MaxPayloadTemplateLength {
+		return check.NewValidationErrorf("The payload template of a webhook can be at most %d characters long.",
+			webhook.MaxPayloadTemplateLength)
+	}
+
+	if format != enum.WebhookPayloadFormatTemplate {
+		return nil
+	}
+
+	if payloadTemplate == "" {
+		return check.NewValidationErrorf("A payload template is required for the payload format '%s'.", format)
+	}
+
+	if _, err := webhook.ParsePayloadTemplate(payloadTemplate); err != nil {
+		return check.NewValidationErrorf("The provided webhook payload template is invalid: %s", err)
+	}
+
+	return nil
+}
+
 // deduplicateTriggers de-duplicates the triggers provided by the user.
 func deduplicateTriggers(in []enum.WebhookTrigger) []enum.WebhookTrigger {
 	if len(in) == 0 {
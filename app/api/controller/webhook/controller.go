@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -39,6 +40,7 @@ type Controller struct {
 	repoStore             store.RepoStore
 	webhookService        *webhook.Service
 	encrypter             encrypt.Encrypter
+	auditService          audit.Service
 }
 
 func NewController(
@@ -50,6 +52,7 @@ func NewController(
 	repoStore store.RepoStore,
 	webhookService *webhook.Service,
 	encrypter encrypt.Encrypter,
+	auditService audit.Service,
 ) *Controller {
 	return &Controller{
 		allowLoopback:         allowLoopback,
@@ -60,6 +63,7 @@ func NewController(
 		repoStore:             repoStore,
 		webhookService:        webhookService,
 		encrypter:             encrypter,
+		auditService:          auditService,
 	}
 }
 
@@ -22,7 +22,9 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -43,6 +45,9 @@ type CreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	PayloadFormat   enum.WebhookPayloadFormat `json:"payload_format"`
+	PayloadTemplate string                    `json:"payload_template"`
 }
 
 // Create creates a new webhook.
@@ -93,6 +98,8 @@ func (c *Controller) Create(
 		Enabled:               in.Enabled,
 		Insecure:              in.Insecure,
 		Triggers:              deduplicateTriggers(in.Triggers),
+		PayloadFormat:         in.PayloadFormat,
+		PayloadTemplate:       in.PayloadTemplate,
 		LatestExecutionResult: nil,
 	}
 
@@ -118,6 +125,17 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to store webhook: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeWebhook, hook.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(hook),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create webhook operation: %s", err)
+	}
+
 	return hook, nil
 }
 
@@ -155,7 +173,15 @@ func sanitizeCreateInput(in *CreateInput, allowLoopback bool, allowPrivateNetwor
 	if err := checkSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := checkTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := checkTriggers(in.Triggers); err != nil {
+		return err
+	}
+
+	var err error
+	if in.PayloadFormat, err = checkPayloadFormat(in.PayloadFormat); err != nil {
+		return err
+	}
+	if err = checkPayloadTemplate(in.PayloadFormat, in.PayloadTemplate); err != nil { //nolint:revive
 		return err
 	}
 
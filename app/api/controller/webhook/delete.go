@@ -18,7 +18,11 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Delete deletes an existing webhook.
@@ -43,5 +47,21 @@ func (c *Controller) Delete(
 		return ErrInternalWebhookOperationNotAllowed
 	}
 	// delete webhook
-	return c.webhookStore.Delete(ctx, webhook.ID)
+	err = c.webhookStore.Delete(ctx, webhook.ID)
+	if err != nil {
+		return err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeWebhook, webhook.Identifier),
+		audit.ActionDeleted,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(webhook),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete webhook operation: %s", err)
+	}
+
+	return nil
 }
@@ -19,9 +19,13 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type UpdateInput struct {
@@ -36,6 +40,9 @@ type UpdateInput struct {
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	PayloadFormat   *enum.WebhookPayloadFormat `json:"payload_format"`
+	PayloadTemplate *string                    `json:"payload_template"`
 }
 
 // Update updates an existing webhook.
@@ -66,6 +73,8 @@ func (c *Controller) Update(
 		return nil, ErrInternalWebhookOperationNotAllowed
 	}
 
+	oldHook := *hook
+
 	// update webhook struct (only for values that are provided)
 	if in.Identifier != nil {
 		hook.Identifier = *in.Identifier
@@ -95,11 +104,34 @@ func (c *Controller) Update(
 	if in.Triggers != nil {
 		hook.Triggers = deduplicateTriggers(in.Triggers)
 	}
+	if in.PayloadFormat != nil {
+		hook.PayloadFormat = *in.PayloadFormat
+	}
+	if in.PayloadTemplate != nil {
+		hook.PayloadTemplate = *in.PayloadTemplate
+	}
+
+	// the template is required depending on the format - validate the combination of new and existing values.
+	if err = checkPayloadTemplate(hook.PayloadFormat, hook.PayloadTemplate); err != nil {
+		return nil, err
+	}
 
 	if err = c.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeWebhook, hook.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(oldHook),
+		audit.WithNewObject(hook),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update webhook operation: %s", err)
+	}
+
 	return hook, nil
 }
 
@@ -139,6 +171,13 @@ func sanitizeUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwor
 			return err
 		}
 	}
+	if in.PayloadFormat != nil {
+		format, err := checkPayloadFormat(*in.PayloadFormat)
+		if err != nil {
+			return err
+		}
+		in.PayloadFormat = &format
+	}
 
 	return nil
 }
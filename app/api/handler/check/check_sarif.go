@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// maxSARIFReportSize is the maximum accepted size of an uploaded SARIF report.
+const maxSARIFReportSize = 32 << 20
+
+// HandleCheckReportSARIF is an HTTP handler for reporting a status check from a SARIF report.
+func HandleCheckReportSARIF(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		commitSHA, err := request.GetCommitSHAFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := &check.ReportSARIFInput{
+			Identifier: r.URL.Query().Get(request.QueryParamCheckIdentifier),
+			Link:       r.URL.Query().Get(request.QueryParamCheckLink),
+		}
+
+		err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSARIFReportSize)).Decode(&in.Log)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid SARIF report: %s.", err)
+			return
+		}
+
+		statusCheck, err := checkCtrl.ReportSARIF(ctx, session, repoRef, commitSHA, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, statusCheck)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/lfs"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/url"
+)
+
+// HandleLockList returns a http.HandlerFunc that lists LFS locks.
+func HandleLockList(lfsCtrl *lfs.Controller, urlProvider url.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		id, _, err := request.QueryParamAsPositiveInt64(r, request.QueryParamLFSID)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		limit, err := request.QueryParamAsPositiveInt64OrDefault(r, request.QueryParamLFSLimit, 0)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out, err := lfsCtrl.ListLocks(ctx, session, repoRef, &lfs.ListLocksFilter{
+			ID:      id,
+			Path:    request.QueryParamOrDefault(r, request.QueryParamLFSPath, ""),
+			RefSpec: request.QueryParamOrDefault(r, request.QueryParamLFSRefSpec, ""),
+			Cursor:  request.QueryParamOrDefault(r, request.QueryParamLFSCursor, ""),
+			Limit:   int(limit),
+		})
+		if err != nil {
+			renderError(ctx, w, urlProvider, session, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/audit"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterAuditResourceType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamResourceType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The types of the resources the audit events are filtered by."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: []interface{}{
+							audit.ResourceTypeRepository,
+							audit.ResourceTypeBranchRule,
+							audit.ResourceTypeRepositorySettings,
+							audit.ResourceTypeRepositoryMirror,
+							audit.ResourceTypePushMirror,
+							audit.ResourceTypeBranch,
+							audit.ResourceTypePullRequest,
+							audit.ResourceTypeMembership,
+							audit.ResourceTypeSecret,
+							audit.ResourceTypeConnector,
+							audit.ResourceTypeWebhook,
+							audit.ResourceTypeServiceAccount,
+							audit.ResourceTypeToken,
+							audit.ResourceTypePublicKey,
+							audit.ResourceTypeUser,
+							audit.ResourceTypeUserGroup,
+						},
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterAuditAction = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAction,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The actions the audit events are filtered by."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: []interface{}{
+							audit.ActionCreated,
+							audit.ActionUpdated,
+							audit.ActionDeleted,
+							audit.ActionBypassed,
+							audit.ActionLogin,
+							audit.ActionForcePushed,
+						},
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterAuditPrincipalID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPrincipalID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The ID of the principal who triggered the audit events."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterAuditRecursive = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRecursive,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The boolean used to include audit events of all subspaces."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+func auditOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("space")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listAuditEvents"})
+	opList.WithParameters(
+		queryParameterAuditResourceType, queryParameterAuditAction, queryParameterAuditPrincipalID,
+		queryParameterAuditRecursive, queryParameterCreatedLt, queryParameterCreatedGt,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, []audit.Event{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/audit-events", opList)
+
+	opAdminList := openapi3.Operation{}
+	opAdminList.WithTags("admin")
+	opAdminList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListAuditEvents"})
+	opAdminList.WithParameters(
+		queryParameterAuditResourceType, queryParameterAuditAction, queryParameterAuditPrincipalID,
+		queryParameterCreatedLt, queryParameterCreatedGt,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opAdminList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAdminList, []audit.Event{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAdminList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAdminList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAdminList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAdminList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit-events", opAdminList)
+}
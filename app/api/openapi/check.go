@@ -54,6 +54,34 @@ var queryParameterStatusCheckSince = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterStatusCheckIdentifier = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckIdentifier,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The identifier of the status check. Defaults to the name of the tool in the report."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterStatusCheckLink = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckLink,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The link to the details of the status check."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 func checkOperations(reflector *openapi3.Reflector) {
 	const tag = "status_checks"
 
@@ -90,6 +118,59 @@ func checkOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/commits/{commit_sha}",
 		listStatusCheckResults)
 
+	reportStatusCheckSARIF := openapi3.Operation{}
+	reportStatusCheckSARIF.WithTags(tag)
+	reportStatusCheckSARIF.WithParameters(queryParameterStatusCheckIdentifier, queryParameterStatusCheckLink)
+	reportStatusCheckSARIF.WithMapOfAnything(map[string]interface{}{"operationId": "reportStatusCheckSARIF"})
+	_ = reflector.SetRequest(&reportStatusCheckSARIF, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+		check.SARIFLog
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&reportStatusCheckSARIF, new(types.Check), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reportStatusCheckSARIF, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reportStatusCheckSARIF, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reportStatusCheckSARIF, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reportStatusCheckSARIF, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/checks/commits/{commit_sha}/sarif",
+		reportStatusCheckSARIF)
+
+	reportStatusCheckAnnotations := openapi3.Operation{}
+	reportStatusCheckAnnotations.WithTags(tag)
+	reportStatusCheckAnnotations.WithMapOfAnything(
+		map[string]interface{}{"operationId": "reportStatusCheckAnnotations"})
+	_ = reflector.SetRequest(&reportStatusCheckAnnotations, struct {
+		repoRequest
+		CommitSHA       string `path:"commit_sha"`
+		CheckIdentifier string `path:"check_identifier"`
+		check.ReportAnnotationsInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&reportStatusCheckAnnotations, new([]types.CheckAnnotation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reportStatusCheckAnnotations, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reportStatusCheckAnnotations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reportStatusCheckAnnotations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reportStatusCheckAnnotations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reportStatusCheckAnnotations, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/checks/commits/{commit_sha}/{check_identifier}/annotations", reportStatusCheckAnnotations)
+
+	listStatusCheckAnnotations := openapi3.Operation{}
+	listStatusCheckAnnotations.WithTags(tag)
+	listStatusCheckAnnotations.WithMapOfAnything(map[string]interface{}{"operationId": "listStatusCheckAnnotations"})
+	_ = reflector.SetRequest(&listStatusCheckAnnotations, struct {
+		repoRequest
+		CommitSHA       string `path:"commit_sha"`
+		CheckIdentifier string `path:"check_identifier"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new([]types.CheckAnnotation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/checks/commits/{commit_sha}/{check_identifier}/annotations", listStatusCheckAnnotations)
+
 	listStatusCheckRecent := openapi3.Operation{}
 	listStatusCheckRecent.WithTags(tag)
 	listStatusCheckRecent.WithParameters(
@@ -71,6 +71,8 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	checkOperations(&reflector)
 	uploadOperations(&reflector)
 	gitspaceOperations(&reflector)
+	auditOperations(&reflector)
+	userGroupOperations(&reflector)
 
 	//
 	// define security scheme
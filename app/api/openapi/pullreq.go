@@ -66,6 +66,16 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type autoMergeEnablePullReq struct {
+	pullReqRequest
+	pullreq.AutoMergeInput
+}
+
+type mergeQueueAddPullReq struct {
+	pullReqRequest
+	pullreq.MergeQueueAddInput
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -109,6 +119,11 @@ type reviewerAddPullReqRequest struct {
 	pullreq.ReviewerAddInput
 }
 
+type userGroupReviewerAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.UserGroupReviewerAddInput
+}
+
 type reviewSubmitPullReqRequest struct {
 	pullreq.ReviewSubmitInput
 	pullReqRequest
@@ -466,6 +481,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPut,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers", reviewerAdd)
 
+	userGroupReviewerAdd := openapi3.Operation{}
+	userGroupReviewerAdd.WithTags("pullreq")
+	userGroupReviewerAdd.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupReviewerAddPullReq"})
+	_ = reflector.SetRequest(&userGroupReviewerAdd, new(userGroupReviewerAddPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&userGroupReviewerAdd, new([]*types.PullReqReviewer), http.StatusOK)
+	_ = reflector.SetJSONResponse(&userGroupReviewerAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&userGroupReviewerAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&userGroupReviewerAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&userGroupReviewerAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/usergroups", userGroupReviewerAdd)
+
 	reviewerList := openapi3.Operation{}
 	reviewerList.WithTags("pullreq")
 	reviewerList.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerListPullReq"})
@@ -517,6 +544,58 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	autoMergeEnableOp := openapi3.Operation{}
+	autoMergeEnableOp.WithTags("pullreq")
+	autoMergeEnableOp.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeEnablePullReq"})
+	_ = reflector.SetRequest(&autoMergeEnableOp, new(autoMergeEnablePullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", autoMergeEnableOp)
+
+	autoMergeDisableOp := openapi3.Operation{}
+	autoMergeDisableOp.WithTags("pullreq")
+	autoMergeDisableOp.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeDisablePullReq"})
+	_ = reflector.SetRequest(&autoMergeDisableOp, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&autoMergeDisableOp, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&autoMergeDisableOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&autoMergeDisableOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeDisableOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeDisableOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", autoMergeDisableOp)
+
+	mergeQueueAddOp := openapi3.Operation{}
+	mergeQueueAddOp.WithTags("pullreq")
+	mergeQueueAddOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueAddPullReq"})
+	_ = reflector.SetRequest(&mergeQueueAddOp, new(mergeQueueAddPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&mergeQueueAddOp, new(types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeQueueAddOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueAddOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&mergeQueueAddOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueAddOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueAddOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&mergeQueueAddOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&mergeQueueAddOp, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueAddOp)
+
+	mergeQueueRemoveOp := openapi3.Operation{}
+	mergeQueueRemoveOp.WithTags("pullreq")
+	mergeQueueRemoveOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueRemovePullReq"})
+	_ = reflector.SetRequest(&mergeQueueRemoveOp, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&mergeQueueRemoveOp, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&mergeQueueRemoveOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&mergeQueueRemoveOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueRemoveOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueRemoveOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueRemoveOp)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
@@ -624,4 +703,16 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	panicOnErr(reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusForbidden))
 	panicOnErr(reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusNotFound))
 	panicOnErr(reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/checks", opChecks))
+
+	opCheckAnnotations := openapi3.Operation{}
+	opCheckAnnotations.WithTags("pullreq")
+	opCheckAnnotations.WithMapOfAnything(map[string]interface{}{"operationId": "checkAnnotationsPullReq"})
+	_ = reflector.SetRequest(&opCheckAnnotations, new(getPullReqChecksRequest), http.MethodGet)
+	panicOnErr(reflector.SetJSONResponse(&opCheckAnnotations, new(types.PullReqCheckAnnotations), http.StatusOK))
+	panicOnErr(reflector.SetJSONResponse(&opCheckAnnotations, new(usererror.Error), http.StatusInternalServerError))
+	panicOnErr(reflector.SetJSONResponse(&opCheckAnnotations, new(usererror.Error), http.StatusUnauthorized))
+	panicOnErr(reflector.SetJSONResponse(&opCheckAnnotations, new(usererror.Error), http.StatusForbidden))
+	panicOnErr(reflector.SetJSONResponse(&opCheckAnnotations, new(usererror.Error), http.StatusNotFound))
+	panicOnErr(reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/checks/annotations", opCheckAnnotations))
 }
@@ -174,14 +174,14 @@ type codeOwnersValidate struct {
 type ruleType string
 
 func (ruleType) Enum() []interface{} {
-	return []interface{}{protection.TypeBranch}
+	return []interface{}{protection.TypeBranch, protection.TypeTag}
 }
 
 // ruleDefinition is a plugin for types.Rule Definition to allow using oneof.
 type ruleDefinition struct{}
 
 func (ruleDefinition) JSONSchemaOneOf() []interface{} {
-	return []interface{}{protection.Branch{}}
+	return []interface{}{protection.Branch{}, protection.Tag{}}
 }
 
 type rule struct {
@@ -205,6 +205,41 @@ type updateRepoPublicAccessRequest struct {
 	repo.UpdatePublicAccessInput
 }
 
+type forkRepoRequest struct {
+	repoRequest
+	repo.ForkInput
+}
+
+type syncForkRequest struct {
+	repoRequest
+	repo.SyncForkInput
+}
+
+type mirrorCreateRequest struct {
+	repoRequest
+	repo.MirrorCreateInput
+}
+
+type mirrorUpdateRequest struct {
+	repoRequest
+	repo.MirrorUpdateInput
+}
+
+type pushMirrorCreateRequest struct {
+	repoRequest
+	repo.PushMirrorCreateInput
+}
+
+type pushMirrorRequest struct {
+	repoRequest
+	Identifier string `path:"push_mirror_identifier"`
+}
+
+type pushMirrorUpdateRequest struct {
+	pushMirrorRequest
+	repo.PushMirrorUpdateInput
+}
+
 type securitySettingsRequest struct {
 	repoRequest
 	reposettings.SecuritySettings
@@ -714,6 +749,166 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(
 		http.MethodPost, "/repos/{repo_ref}/public-access", opUpdatePublicAccess)
 
+	opFork := openapi3.Operation{}
+	opFork.WithTags("repository")
+	opFork.WithMapOfAnything(map[string]interface{}{"operationId": "forkRepository"})
+	_ = reflector.SetRequest(&opFork, new(forkRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFork, new(repo.RepositoryOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork", opFork)
+
+	opListForks := openapi3.Operation{}
+	opListForks.WithTags("repository")
+	opListForks.WithMapOfAnything(map[string]interface{}{"operationId": "listForks"})
+	opListForks.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListForks, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListForks, []repo.RepositoryOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/forks", opListForks)
+
+	opSyncFork := openapi3.Operation{}
+	opSyncFork.WithTags("repository")
+	opSyncFork.WithMapOfAnything(map[string]interface{}{"operationId": "syncFork"})
+	_ = reflector.SetRequest(&opSyncFork, new(syncForkRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(repo.SyncForkOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/upstream/sync", opSyncFork)
+
+	opMirrorCreate := openapi3.Operation{}
+	opMirrorCreate.WithTags("repository")
+	opMirrorCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRepoMirror"})
+	_ = reflector.SetRequest(&opMirrorCreate, new(mirrorCreateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(types.RepoMirror), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/mirror", opMirrorCreate)
+
+	opMirrorFind := openapi3.Operation{}
+	opMirrorFind.WithTags("repository")
+	opMirrorFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepoMirror"})
+	_ = reflector.SetRequest(&opMirrorFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(types.RepoMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/mirror", opMirrorFind)
+
+	opMirrorUpdate := openapi3.Operation{}
+	opMirrorUpdate.WithTags("repository")
+	opMirrorUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoMirror"})
+	_ = reflector.SetRequest(&opMirrorUpdate, new(mirrorUpdateRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(types.RepoMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/mirror", opMirrorUpdate)
+
+	opMirrorDelete := openapi3.Operation{}
+	opMirrorDelete.WithTags("repository")
+	opMirrorDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoMirror"})
+	_ = reflector.SetRequest(&opMirrorDelete, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/mirror", opMirrorDelete)
+
+	opMirrorSync := openapi3.Operation{}
+	opMirrorSync.WithTags("repository")
+	opMirrorSync.WithMapOfAnything(map[string]interface{}{"operationId": "syncRepoMirror"})
+	_ = reflector.SetRequest(&opMirrorSync, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(types.RepoMirror), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/mirror/sync", opMirrorSync)
+
+	opPushMirrorCreate := openapi3.Operation{}
+	opPushMirrorCreate.WithTags("repository")
+	opPushMirrorCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createPushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorCreate, new(pushMirrorCreateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(types.PushMirror), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/push-mirrors", opPushMirrorCreate)
+
+	opPushMirrorList := openapi3.Operation{}
+	opPushMirrorList.WithTags("repository")
+	opPushMirrorList.WithMapOfAnything(map[string]interface{}{"operationId": "listPushMirrors"})
+	_ = reflector.SetRequest(&opPushMirrorList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, []types.PushMirror{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/push-mirrors", opPushMirrorList)
+
+	opPushMirrorFind := openapi3.Operation{}
+	opPushMirrorFind.WithTags("repository")
+	opPushMirrorFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorFind, new(pushMirrorRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opPushMirrorFind)
+
+	opPushMirrorUpdate := openapi3.Operation{}
+	opPushMirrorUpdate.WithTags("repository")
+	opPushMirrorUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updatePushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorUpdate, new(pushMirrorUpdateRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opPushMirrorUpdate)
+
+	opPushMirrorDelete := openapi3.Operation{}
+	opPushMirrorDelete.WithTags("repository")
+	opPushMirrorDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deletePushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorDelete, new(pushMirrorRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opPushMirrorDelete)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("repository")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryServiceAccounts"})
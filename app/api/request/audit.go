@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/audit"
+)
+
+const (
+	QueryParamResourceType = "resource_type"
+	QueryParamAction       = "action"
+	QueryParamPrincipalID  = "principal_id"
+)
+
+// ParseAuditListFilter extracts the audit event query parameters from the url.
+func ParseAuditListFilter(r *http.Request) (*audit.ListFilter, error) {
+	createdFilter, err := ParseCreated(r)
+	if err != nil {
+		return nil, err
+	}
+
+	recursive, err := ParseRecursiveFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	principalID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamPrincipalID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTypes, err := parseAuditResourceTypes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := parseAuditActions(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &audit.ListFilter{
+		Pagination:    ParsePaginationFromRequest(r),
+		CreatedFilter: createdFilter,
+		Recursive:     recursive,
+		ResourceTypes: resourceTypes,
+		Actions:       actions,
+		PrincipalID:   principalID,
+	}, nil
+}
+
+func parseAuditResourceTypes(r *http.Request) ([]audit.ResourceType, error) {
+	values, _ := QueryParamList(r, QueryParamResourceType)
+
+	resourceTypes := make([]audit.ResourceType, len(values))
+	for i, value := range values {
+		resourceType := audit.ResourceType(value)
+		if err := resourceType.Validate(); err != nil {
+			return nil, usererror.BadRequestf("Invalid resource type %q.", value)
+		}
+		resourceTypes[i] = resourceType
+	}
+
+	return resourceTypes, nil
+}
+
+func parseAuditActions(r *http.Request) ([]audit.Action, error) {
+	values, _ := QueryParamList(r, QueryParamAction)
+
+	actions := make([]audit.Action, len(values))
+	for i, value := range values {
+		action := audit.Action(value)
+		if err := action.Validate(); err != nil {
+			return nil, usererror.BadRequestf("Invalid action %q.", value)
+		}
+		actions[i] = action
+	}
+
+	return actions, nil
+}
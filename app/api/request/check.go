@@ -20,6 +20,13 @@ import (
 	"github.com/harness/gitness/types"
 )
 
+const (
+	PathParamCheckIdentifier = "check_identifier"
+
+	QueryParamCheckIdentifier = "identifier"
+	QueryParamCheckLink       = "link"
+)
+
 // ParseCheckListOptions extracts the status check list API options from the url.
 func ParseCheckListOptions(r *http.Request) types.CheckListOptions {
 	return types.CheckListOptions{
@@ -39,3 +46,8 @@ func ParseCheckRecentOptions(r *http.Request) (types.CheckRecentOptions, error)
 		Since: since,
 	}, nil
 }
+
+// GetCheckIdentifierFromPath extracts the status check identifier from the URL.
+func GetCheckIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCheckIdentifier)
+}
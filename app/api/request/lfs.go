@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	PathParamLFSObjectID = "lfs_oid"
+	PathParamLFSLockID   = "lfs_lock_id"
+
+	QueryParamLFSID      = "id"
+	QueryParamLFSPath    = "path"
+	QueryParamLFSRefSpec = "refspec"
+	QueryParamLFSCursor  = "cursor"
+	QueryParamLFSLimit   = "limit"
+	QueryParamLFSSize    = "size"
+)
+
+func GetLFSObjectIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamLFSObjectID)
+}
+
+// GetLFSObjectSizeFromQuery returns the size of the LFS object that was declared in the batch request.
+func GetLFSObjectSizeFromQuery(r *http.Request) (int64, error) {
+	value, err := QueryParamOrError(r, QueryParamLFSSize)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size < 0 {
+		return 0, usererror.BadRequestf("Parameter '%s' must be a non-negative integer.", QueryParamLFSSize)
+	}
+
+	return size, nil
+}
+
+func GetLFSLockIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamLFSLockID)
+}
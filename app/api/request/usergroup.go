@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+)
+
+const (
+	PathParamUserGroupIdentifier = "usergroup_identifier"
+	PathParamUserGroupID         = "usergroup_id"
+)
+
+// GetUserGroupIdentifierFromPath extracts the user group identifier from the url path.
+func GetUserGroupIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamUserGroupIdentifier)
+}
+
+// GetUserGroupIDFromPath extracts the user group ID from the url path.
+func GetUserGroupIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamUserGroupID)
+}
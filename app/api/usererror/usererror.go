@@ -70,6 +70,10 @@ var (
 	// ErrPullReqRefsCantBeModified is returned if a user tries to tinker with a pull request git ref.
 	ErrPullReqRefsCantBeModified = New(http.StatusBadRequest, "The pull request git refs can't be modified")
 
+	// ErrMirrorRefsCantBeModified is returned if a user tries to modify a branch or a tag of a pull mirror.
+	ErrMirrorRefsCantBeModified = New(http.StatusBadRequest,
+		"Repository is a pull mirror and its branches and tags are read-only")
+
 	// ErrRequestTooLarge is returned if the request it too large.
 	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "The request is too large")
 
@@ -20,18 +20,24 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 
 	gojwt "github.com/golang-jwt/jwt"
+	"github.com/rs/zerolog/log"
 )
 
 var _ Authenticator = (*JWTAuthenticator)(nil)
 
+// tokenLastUsedUpdateInterval is the minimal interval between two updates of the token's last used time.
+const tokenLastUsedUpdateInterval = time.Minute
+
 // JWTAuthenticator uses the provided JWT to authenticate the caller.
 type JWTAuthenticator struct {
 	cookieName     string
@@ -84,7 +90,7 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 	var metadata auth.Metadata
 	switch {
 	case claims.Token != nil:
-		metadata, err = a.metadataFromTokenClaims(ctx, principal, claims.Token)
+		metadata, err = a.metadataFromTokenClaims(ctx, r, principal, claims.Token)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get metadata from token claims: %w", err)
 		}
@@ -102,6 +108,7 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 
 func (a *JWTAuthenticator) metadataFromTokenClaims(
 	ctx context.Context,
+	r *http.Request,
 	principal *types.Principal,
 	tknClaims *jwt.SubClaimsToken,
 ) (auth.Metadata, error) {
@@ -117,12 +124,31 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 			principal.ID, tkn.PrincipalID)
 	}
 
+	a.updateLastUsed(ctx, tkn, audit.RealIP(r))
+
 	return &auth.TokenMetadata{
-		TokenType: tkn.Type,
-		TokenID:   tkn.ID,
+		TokenType:  tkn.Type,
+		TokenID:    tkn.ID,
+		TokenScope: tkn.TokenScope,
 	}, nil
 }
 
+// updateLastUsed stores the time and the client IP address of the token usage.
+// To avoid a database write for every request, the usage is stored only periodically or if the client IP changed.
+func (a *JWTAuthenticator) updateLastUsed(ctx context.Context, tkn *types.Token, clientIP string) {
+	now := time.Now()
+
+	if tkn.LastUsed != nil && tkn.LastUsedIP == clientIP &&
+		now.Sub(time.UnixMilli(*tkn.LastUsed)) < tokenLastUsedUpdateInterval {
+		return
+	}
+
+	err := a.tokenStore.UpdateLastUsed(ctx, tkn.ID, now.UnixMilli(), clientIP)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to update last used of token %d", tkn.ID)
+	}
+}
+
 func (a *JWTAuthenticator) metadataFromMembershipClaims(
 	mbsClaims *jwt.SubClaimsMembership,
 ) auth.Metadata {
@@ -34,17 +34,20 @@ type MembershipAuthorizer struct {
 	permissionCache PermissionCache
 	spaceStore      store.SpaceStore
 	publicAccess    publicaccess.Service
+	scopePathCache  ScopePathCache
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
 	spaceStore store.SpaceStore,
 	publicAccess publicaccess.Service,
+	scopePathCache ScopePathCache,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
 		spaceStore:      spaceStore,
 		publicAccess:    publicAccess,
+		scopePathCache:  scopePathCache,
 	}
 }
 
@@ -75,6 +78,19 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
+	// the token scope restricts access on top of anything else (including the system admin)
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok && tokenMetadata.IsRestricted() {
+		allowed, err := a.checkTokenScope(ctx, &tokenMetadata.TokenScope, scope, resource, permission)
+		if err != nil {
+			return false, fmt.Errorf("failed to check token scope: %w", err)
+		}
+
+		if !allowed {
+			log.Ctx(ctx).Debug().Msgf("[MembershipAuthorizer] %s is outside of the token scope", permission)
+			return false, nil
+		}
+	}
+
 	if session.Principal.Admin {
 		return true, nil // system admin can call any API
 	}
@@ -136,7 +152,9 @@ func (a *MembershipAuthorizer) Check(
 	}
 
 	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+	// (the token scope has already been enforced above)
+	_, isTokenMetadata := session.Metadata.(*auth.TokenMetadata)
+	if !isTokenMetadata && session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
 		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
 	}
 
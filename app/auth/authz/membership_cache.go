@@ -40,17 +40,20 @@ type PermissionCache cache.Cache[PermissionCacheKey, bool]
 func NewPermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	userGroupMembershipStore store.UserGroupMembershipStore,
 	cacheDuration time.Duration,
 ) PermissionCache {
 	return cache.New[PermissionCacheKey, bool](permissionCacheGetter{
-		spaceStore:      spaceStore,
-		membershipStore: membershipStore,
+		spaceStore:               spaceStore,
+		membershipStore:          membershipStore,
+		userGroupMembershipStore: userGroupMembershipStore,
 	}, cacheDuration)
 }
 
 type permissionCacheGetter struct {
-	spaceStore      store.SpaceStore
-	membershipStore store.MembershipStore
+	spaceStore               store.SpaceStore
+	membershipStore          store.MembershipStore
+	userGroupMembershipStore store.UserGroupMembershipStore
 }
 
 func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey) (bool, error) {
@@ -86,6 +89,18 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 			return true, nil
 		}
 
+		// The principal might also get a role in the current space through the user groups it's a member of.
+		groupRoles, err := g.userGroupMembershipStore.ListRolesForPrincipal(ctx, space.ID, principalID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find user group memberships: %w", err)
+		}
+
+		for _, role := range groupRoles {
+			if roleHasPermission(role, key.Permission) {
+				return true, nil
+			}
+		}
+
 		// If membership with the requested permission has not been found in the current space,
 		// move to the parent space, if any.
 
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// checkTokenScope checks whether the permission on the resource is within the scope of the token.
+// The token scope can only restrict access, the membership of the principal must still grant the permission.
+// Spaces and repositories of the token scope that have been deleted are ignored.
+func (a *MembershipAuthorizer) checkTokenScope(
+	ctx context.Context,
+	tokenScope *types.TokenScope,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	if !tokenScope.HasPermission(permission) {
+		return false, nil
+	}
+
+	if !tokenScope.IsResourceRestricted() {
+		return true, nil
+	}
+
+	var spacePath, repoPath string
+
+	//nolint:exhaustive // we want to fail on anything else
+	switch resource.Type {
+	case enum.ResourceTypeSpace:
+		spacePath = paths.Concatenate(scope.SpacePath, resource.Identifier)
+
+	case enum.ResourceTypeRepo:
+		if resource.Identifier == "" {
+			spacePath = scope.SpacePath // e.g. creation of a repository
+		} else {
+			repoPath = paths.Concatenate(scope.SpacePath, resource.Identifier)
+		}
+
+	case enum.ResourceTypeUser:
+		// tokens restricted to a set of resources must not be used to manage users or create new tokens.
+		return permission == enum.PermissionUserView, nil
+
+	case enum.ResourceTypeService:
+		return false, nil
+
+	default:
+		// resources within a repository (e.g. pipelines) or within a space (e.g. secrets)
+		if scope.Repo != "" {
+			repoPath = paths.Concatenate(scope.SpacePath, scope.Repo)
+		} else {
+			spacePath = scope.SpacePath
+		}
+	}
+
+	requestedPath := spacePath
+	if repoPath != "" {
+		requestedPath = repoPath
+	}
+
+	for _, spaceID := range tokenScope.SpaceIDs {
+		scopeSpacePath, err := a.scopePathCache.Get(ctx,
+			ScopePathCacheKey{ResourceType: enum.ResourceTypeSpace, ID: spaceID})
+		if err != nil {
+			return false, err
+		}
+
+		if scopeSpacePath != "" && isPathWithin(scopeSpacePath, requestedPath) {
+			return true, nil
+		}
+	}
+
+	if repoPath == "" {
+		return false, nil
+	}
+
+	for _, repoID := range tokenScope.RepoIDs {
+		scopeRepoPath, err := a.scopePathCache.Get(ctx,
+			ScopePathCacheKey{ResourceType: enum.ResourceTypeRepo, ID: repoID})
+		if err != nil {
+			return false, err
+		}
+
+		if scopeRepoPath != "" && strings.EqualFold(scopeRepoPath, repoPath) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// isPathWithin returns true if the path is the same as or is a descendant of the parent path.
+func isPathWithin(parent, path string) bool {
+	parent = strings.ToLower(strings.Trim(parent, types.PathSeparator))
+	path = strings.ToLower(strings.Trim(path, types.PathSeparator))
+
+	return parent == path || strings.HasPrefix(path, parent+types.PathSeparator)
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ScopePathCacheKey struct {
+	ResourceType enum.ResourceType
+	ID           int64
+}
+
+// ScopePathCache caches the paths of the spaces and repositories that tokens are restricted to by their IDs.
+// Spaces and repositories that don't exist anymore have an empty path.
+type ScopePathCache cache.Cache[ScopePathCacheKey, string]
+
+func NewScopePathCache(
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	cacheDuration time.Duration,
+) ScopePathCache {
+	return cache.New[ScopePathCacheKey, string](scopePathCacheGetter{
+		spaceStore: spaceStore,
+		repoStore:  repoStore,
+	}, cacheDuration)
+}
+
+type scopePathCacheGetter struct {
+	spaceStore store.SpaceStore
+	repoStore  store.RepoStore
+}
+
+func (g scopePathCacheGetter) Find(ctx context.Context, key ScopePathCacheKey) (string, error) {
+	var path string
+	var err error
+
+	//nolint:exhaustive // token scopes contain only spaces and repositories
+	switch key.ResourceType {
+	case enum.ResourceTypeSpace:
+		var space *types.Space
+		space, err = g.spaceStore.Find(ctx, key.ID)
+		if err == nil {
+			path = space.Path
+		}
+
+	case enum.ResourceTypeRepo:
+		var repo *types.Repository
+		repo, err = g.repoStore.Find(ctx, key.ID)
+		if err == nil {
+			path = repo.Path
+		}
+
+	default:
+		return "", fmt.Errorf("unsupported token scope resource type %q", key.ResourceType)
+	}
+
+	// a deleted space or repository doesn't grant access to anything.
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find %s %d of the token scope: %w", key.ResourceType, key.ID, err)
+	}
+
+	return path, nil
+}
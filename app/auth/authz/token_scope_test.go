@@ -0,0 +1,308 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMembershipAuthorizer_TokenScope(t *testing.T) {
+	scopeTeam := types.TokenScope{SpaceIDs: []int64{2}}
+	scopeApp := types.TokenScope{RepoIDs: []int64{10}}
+
+	tests := []struct {
+		name       string
+		admin      bool
+		tokenScope *types.TokenScope
+		scope      types.Scope
+		resource   types.Resource
+		permission enum.Permission
+		expAllowed bool
+	}{
+		{
+			name:       "repo-in-scoped-space",
+			tokenScope: &scopeTeam,
+			scope:      types.Scope{SpacePath: "acme/team"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+			permission: enum.PermissionRepoView,
+			expAllowed: true,
+		},
+		{
+			name:       "repo-outside-scoped-space",
+			tokenScope: &scopeTeam,
+			scope:      types.Scope{SpacePath: "acme"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "website"},
+			permission: enum.PermissionRepoView,
+			expAllowed: false,
+		},
+		{
+			name:       "scoped-space",
+			tokenScope: &scopeTeam,
+			scope:      types.Scope{SpacePath: "acme"},
+			resource:   types.Resource{Type: enum.ResourceTypeSpace, Identifier: "team"},
+			permission: enum.PermissionSpaceView,
+			expAllowed: true,
+		},
+		{
+			name:       "parent-of-scoped-space",
+			tokenScope: &scopeTeam,
+			scope:      types.Scope{SpacePath: ""},
+			resource:   types.Resource{Type: enum.ResourceTypeSpace, Identifier: "acme"},
+			permission: enum.PermissionSpaceView,
+			expAllowed: false,
+		},
+		{
+			name:       "space-with-scoped-space-prefix",
+			tokenScope: &scopeTeam,
+			scope:      types.Scope{SpacePath: "acme"},
+			resource:   types.Resource{Type: enum.ResourceTypeSpace, Identifier: "team-b"},
+			permission: enum.PermissionSpaceView,
+			expAllowed: false,
+		},
+		{
+			name:       "scoped-repo",
+			tokenScope: &scopeApp,
+			scope:      types.Scope{SpacePath: "acme/team"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "APP"},
+			permission: enum.PermissionRepoView,
+			expAllowed: true,
+		},
+		{
+			name:       "pipeline-in-scoped-repo",
+			tokenScope: &scopeApp,
+			scope:      types.Scope{SpacePath: "acme/team", Repo: "app"},
+			resource:   types.Resource{Type: enum.ResourceTypePipeline, Identifier: "build"},
+			permission: enum.PermissionPipelineView,
+			expAllowed: true,
+		},
+		{
+			name:       "secret-in-space-of-scoped-repo",
+			tokenScope: &scopeApp,
+			scope:      types.Scope{SpacePath: "acme/team"},
+			resource:   types.Resource{Type: enum.ResourceTypeSecret, Identifier: "token"},
+			permission: enum.PermissionSecretView,
+			expAllowed: false,
+		},
+		{
+			name:       "deleted-scoped-space",
+			tokenScope: &types.TokenScope{SpaceIDs: []int64{3}},
+			scope:      types.Scope{SpacePath: "acme/team"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+			permission: enum.PermissionRepoView,
+			expAllowed: false,
+		},
+		{
+			name:       "deleted-scoped-repo",
+			tokenScope: &types.TokenScope{RepoIDs: []int64{12}},
+			scope:      types.Scope{SpacePath: "acme/team"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+			permission: enum.PermissionRepoView,
+			expAllowed: false,
+		},
+		{
+			name:       "granted-permission",
+			tokenScope: &types.TokenScope{Permissions: []enum.Permission{enum.PermissionRepoView}},
+			scope:      types.Scope{SpacePath: "other"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "lib"},
+			permission: enum.PermissionRepoView,
+			expAllowed: true,
+		},
+		{
+			name:       "not-granted-permission",
+			tokenScope: &types.TokenScope{Permissions: []enum.Permission{enum.PermissionRepoView}},
+			scope:      types.Scope{SpacePath: "other"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "lib"},
+			permission: enum.PermissionRepoEdit,
+			expAllowed: false,
+		},
+		{
+			name:       "view-user-with-scoped-token",
+			tokenScope: &scopeTeam,
+			resource:   types.Resource{Type: enum.ResourceTypeUser, Identifier: "jane"},
+			permission: enum.PermissionUserView,
+			expAllowed: true,
+		},
+		{
+			name:       "edit-user-with-scoped-token",
+			tokenScope: &scopeTeam,
+			resource:   types.Resource{Type: enum.ResourceTypeUser, Identifier: "jane"},
+			permission: enum.PermissionUserEdit,
+			expAllowed: false,
+		},
+		{
+			name:       "admin-outside-of-token-scope",
+			admin:      true,
+			tokenScope: &scopeTeam,
+			scope:      types.Scope{SpacePath: "other"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "lib"},
+			permission: enum.PermissionRepoEdit,
+			expAllowed: false,
+		},
+		{
+			name:       "admin-in-token-scope",
+			admin:      true,
+			tokenScope: &scopeTeam,
+			scope:      types.Scope{SpacePath: "acme/team"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "app"},
+			permission: enum.PermissionRepoEdit,
+			expAllowed: true,
+		},
+		{
+			name:       "admin-without-token-scope",
+			admin:      true,
+			scope:      types.Scope{SpacePath: "other"},
+			resource:   types.Resource{Type: enum.ResourceTypeRepo, Identifier: "lib"},
+			permission: enum.PermissionRepoEdit,
+			expAllowed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authorizer, _, _ := newTestAuthorizer()
+
+			session := &auth.Session{
+				Principal: types.Principal{ID: 1, UID: "jane", Admin: test.admin},
+			}
+			if test.tokenScope != nil {
+				session.Metadata = &auth.TokenMetadata{TokenType: enum.TokenTypePAT, TokenScope: *test.tokenScope}
+			}
+
+			allowed, err := authorizer.Check(context.Background(), session, &test.scope, &test.resource,
+				test.permission)
+			if err != nil {
+				t.Fatalf("got error: %s", err.Error())
+			}
+
+			if want, got := test.expAllowed, allowed; want != got {
+				t.Errorf("allowed: want=%t got=%t", want, got)
+			}
+		})
+	}
+}
+
+func TestMembershipAuthorizer_TokenScopeCached(t *testing.T) {
+	authorizer, spaceStore, repoStore := newTestAuthorizer()
+
+	session := &auth.Session{
+		Principal: types.Principal{ID: 1, UID: "jane"},
+		Metadata: &auth.TokenMetadata{
+			TokenType:  enum.TokenTypePAT,
+			TokenScope: types.TokenScope{SpaceIDs: []int64{2, 3}, RepoIDs: []int64{10, 12}},
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := authorizer.Check(context.Background(), session,
+			&types.Scope{SpacePath: "other"},
+			&types.Resource{Type: enum.ResourceTypeRepo, Identifier: "lib"},
+			enum.PermissionRepoView)
+		if err != nil {
+			t.Fatalf("got error: %s", err.Error())
+		}
+	}
+
+	if want, got := 2, spaceStore.calls; want != got {
+		t.Errorf("space lookups: want=%d got=%d", want, got)
+	}
+
+	if want, got := 2, repoStore.calls; want != got {
+		t.Errorf("repo lookups: want=%d got=%d", want, got)
+	}
+}
+
+func newTestAuthorizer() (*MembershipAuthorizer, *countingSpaceStore, *countingRepoStore) {
+	spaceStore := &countingSpaceStore{
+		spaces: map[int64]*types.Space{
+			1: {ID: 1, Path: "acme"},
+			2: {ID: 2, Path: "acme/team"},
+		},
+	}
+	repoStore := &countingRepoStore{
+		repos: map[int64]*types.Repository{
+			10: {ID: 10, Path: "acme/team/app"},
+			11: {ID: 11, Path: "other/lib"},
+		},
+	}
+
+	authorizer := NewMembershipAuthorizer(
+		allowingPermissionCache{},
+		spaceStore,
+		privatePublicAccess{},
+		NewScopePathCache(spaceStore, repoStore, time.Minute),
+	)
+
+	return authorizer, spaceStore, repoStore
+}
+
+// allowingPermissionCache grants every permission, so the tests only depend on the token scope.
+type allowingPermissionCache struct{}
+
+func (allowingPermissionCache) Stats() (int64, int64) { return 0, 0 }
+
+func (allowingPermissionCache) Get(context.Context, PermissionCacheKey) (bool, error) {
+	return true, nil
+}
+
+type privatePublicAccess struct {
+	publicaccess.Service
+}
+
+func (privatePublicAccess) Get(context.Context, enum.PublicResourceType, string) (bool, error) {
+	return false, nil
+}
+
+type countingSpaceStore struct {
+	store.SpaceStore
+	spaces map[int64]*types.Space
+	calls  int
+}
+
+func (s *countingSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	s.calls++
+
+	space, ok := s.spaces[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	return space, nil
+}
+
+type countingRepoStore struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+	calls int
+}
+
+func (s *countingRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	s.calls++
+
+	repo, ok := s.repos[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	return repo, nil
+}
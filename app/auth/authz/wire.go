@@ -27,20 +27,31 @@ import (
 var WireSet = wire.NewSet(
 	ProvideAuthorizer,
 	ProvidePermissionCache,
+	ProvideScopePathCache,
 )
 
 func ProvideAuthorizer(
 	pCache PermissionCache,
 	spaceStore store.SpaceStore,
 	publicAccess publicaccess.Service,
+	scopePathCache ScopePathCache,
 ) Authorizer {
-	return NewMembershipAuthorizer(pCache, spaceStore, publicAccess)
+	return NewMembershipAuthorizer(pCache, spaceStore, publicAccess, scopePathCache)
 }
 
 func ProvidePermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	userGroupMembershipStore store.UserGroupMembershipStore,
 ) PermissionCache {
 	const permissionCacheTimeout = time.Second * 15
-	return NewPermissionCache(spaceStore, membershipStore, permissionCacheTimeout)
+	return NewPermissionCache(spaceStore, membershipStore, userGroupMembershipStore, permissionCacheTimeout)
+}
+
+func ProvideScopePathCache(
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+) ScopePathCache {
+	const scopePathCacheTimeout = time.Second * 15
+	return NewScopePathCache(spaceStore, repoStore, scopePathCacheTimeout)
 }
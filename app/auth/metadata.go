@@ -14,7 +14,10 @@
 
 package auth
 
-import "github.com/harness/gitness/types/enum"
+import (
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
 
 type Metadata interface {
 	ImpactsAuthorization() bool
@@ -31,10 +34,13 @@ func (m *EmptyMetadata) ImpactsAuthorization() bool {
 type TokenMetadata struct {
 	TokenType enum.TokenType
 	TokenID   int64
+
+	// TokenScope restricts the access granted to the principal by its memberships.
+	types.TokenScope
 }
 
 func (m *TokenMetadata) ImpactsAuthorization() bool {
-	return false
+	return m.IsRestricted()
 }
 
 // MembershipMetadata contains information about an ephemeral membership grant.
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const AutoMergeEnabledEvent events.EventType = "auto-merge-enabled"
+
+type AutoMergeEnabledPayload struct {
+	Base
+	MergeMethod enum.MergeMethod `json:"merge_method"`
+}
+
+func (r *Reporter) AutoMergeEnabled(ctx context.Context, payload *AutoMergeEnabledPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, AutoMergeEnabledEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request auto-merge enabled event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request auto-merge enabled event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterAutoMergeEnabled(
+	fn events.HandlerFunc[*AutoMergeEnabledPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, AutoMergeEnabledEvent, fn, opts...)
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const UpdatedEvent events.EventType = "updated"
+
+type UpdatedPayload struct {
+	Base
+	OldTitle       string `json:"old_title"`
+	NewTitle       string `json:"new_title"`
+	OldDescription string `json:"old_description"`
+	NewDescription string `json:"new_description"`
+}
+
+func (r *Reporter) Updated(
+	ctx context.Context,
+	payload *UpdatedPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, UpdatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request updated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request updated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterUpdated(
+	fn events.HandlerFunc[*UpdatedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, UpdatedEvent, fn, opts...)
+}
@@ -22,6 +22,84 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+const CreatedEvent events.EventType = "created"
+
+type CreatedPayload struct {
+	RepoID      int64 `json:"repo_id"`
+	PrincipalID int64 `json:"principal_id"`
+}
+
+func (r *Reporter) Created(ctx context.Context, payload *CreatedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, CreatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send repo created event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported repo created event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterCreated(fn events.HandlerFunc[*CreatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, CreatedEvent, fn, opts...)
+}
+
+const SoftDeletedEvent events.EventType = "soft-deleted"
+
+type SoftDeletedPayload struct {
+	RepoID      int64 `json:"repo_id"`
+	PrincipalID int64 `json:"principal_id"`
+	Deleted     int64 `json:"deleted"`
+}
+
+func (r *Reporter) SoftDeleted(ctx context.Context, payload *SoftDeletedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, SoftDeletedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send repo soft deleted event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported repo soft deleted event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterSoftDeleted(fn events.HandlerFunc[*SoftDeletedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, SoftDeletedEvent, fn, opts...)
+}
+
+const RenamedEvent events.EventType = "renamed"
+
+type RenamedPayload struct {
+	RepoID        int64  `json:"repo_id"`
+	PrincipalID   int64  `json:"principal_id"`
+	OldIdentifier string `json:"old_identifier"`
+	NewIdentifier string `json:"new_identifier"`
+}
+
+func (r *Reporter) Renamed(ctx context.Context, payload *RenamedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, RenamedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send repo renamed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported repo renamed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterRenamed(fn events.HandlerFunc[*RenamedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, RenamedEvent, fn, opts...)
+}
+
 const DeletedEvent events.EventType = "deleted"
 
 type DeletedPayload struct {
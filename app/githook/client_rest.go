@@ -26,8 +26,12 @@ import (
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/tracing"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/version"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -41,6 +45,8 @@ const (
 	HTTPRequestPathUpdate = "update"
 )
 
+var tracer = otel.Tracer("github.com/harness/gitness/app/githook")
+
 // RestClientFactory creates clients that make rest api calls to gitness to execute githooks.
 type RestClientFactory struct{}
 
@@ -64,20 +70,22 @@ func (f *RestClientFactory) NewClient(envVars map[string]string) (hook.Client, e
 
 // RestClient is the hook.Client used to call the githooks api of gitness api server.
 type RestClient struct {
-	httpClient *http.Client
-	baseURL    string
-	requestID  string
-	baseInput  types.GithookInputBase
+	httpClient   *http.Client
+	baseURL      string
+	requestID    string
+	traceContext map[string]string
+	baseInput    types.GithookInputBase
 }
 
 func NewRestClient(
 	payload Payload,
 ) hook.Client {
 	return &RestClient{
-		httpClient: http.DefaultClient,
-		baseURL:    strings.TrimRight(payload.BaseURL, "/"),
-		requestID:  payload.RequestID,
-		baseInput:  GetInputBaseFromPayload(payload),
+		httpClient:   http.DefaultClient,
+		baseURL:      strings.TrimRight(payload.BaseURL, "/"),
+		requestID:    payload.RequestID,
+		traceContext: payload.TraceContext,
+		baseInput:    GetInputBaseFromPayload(payload),
 	}
 }
 
@@ -115,7 +123,19 @@ func (c *RestClient) PostReceive(
 }
 
 // githook executes the requested githook type using the provided input.
-func (c *RestClient) githook(ctx context.Context, githookType string, payload interface{}) (hook.Output, error) {
+func (c *RestClient) githook(
+	ctx context.Context,
+	githookType string,
+	payload interface{},
+) (_ hook.Output, err error) {
+	// continue the trace of the operation that triggered the githook, unless the call is part of a trace already.
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = tracing.ExtractMap(ctx, c.traceContext)
+	}
+
+	ctx, span := tracer.Start(ctx, "githook "+githookType, trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.End(span, err) }()
+
 	uri := c.baseURL + "/" + githookType
 	bodyBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -129,6 +149,7 @@ func (c *RestClient) githook(ctx context.Context, githookType string, payload in
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add(request.HeaderUserAgent, fmt.Sprintf("Gitness/%s", version.Version))
 	req.Header.Add(request.HeaderRequestID, c.requestID)
+	tracing.InjectHTTPHeader(ctx, req.Header)
 
 	// Execute the request
 	resp, err := c.httpClient.Do(req)
@@ -22,6 +22,7 @@ import (
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/tracing"
 
 	"github.com/rs/zerolog/log"
 )
@@ -52,13 +53,18 @@ func GenerateEnvironmentVariables(
 	// generate githook base url
 	baseURL := strings.TrimLeft(apiBaseURL, "/") + "/v1/internal/git-hooks"
 
+	// propagate the trace context to link the githook calls to the operation.
+	traceContext := map[string]string{}
+	tracing.InjectMap(ctx, traceContext)
+
 	payload := Payload{
-		BaseURL:     baseURL,
-		RepoID:      repoID,
-		PrincipalID: principalID,
-		RequestID:   requestID,
-		Disabled:    disabled,
-		Internal:    internal,
+		BaseURL:      baseURL,
+		RepoID:       repoID,
+		PrincipalID:  principalID,
+		RequestID:    requestID,
+		Disabled:     disabled,
+		Internal:     internal,
+		TraceContext: traceContext,
 	}
 
 	if err := payload.Validate(); err != nil {
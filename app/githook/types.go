@@ -28,6 +28,8 @@ type Payload struct {
 	RequestID   string
 	Disabled    bool
 	Internal    bool // Internal calls originate from Gitness, and external calls are direct git pushes.
+	// TraceContext is the trace context of the operation that triggered the githook (used for tracing).
+	TraceContext map[string]string
 }
 
 func (p Payload) Validate() error {
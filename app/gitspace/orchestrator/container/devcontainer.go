@@ -23,17 +23,44 @@ import (
 	"github.com/docker/docker/client"
 )
 
+const rootUser = "root"
+
 type Devcontainer struct {
 	ContainerName string
 	WorkingDir    string
 	DockerClient  *client.Client
+	// RemoteUser is the user used to execute the lifecycle commands. If empty, root is used.
+	RemoteUser string
+	// Env holds the environment variables (in KEY=VALUE form) set for every executed command.
+	Env []string
 }
 
+// ExecuteCommand executes the command inside the container as root.
 func (d *Devcontainer) ExecuteCommand(ctx context.Context, command string, detach bool) (*[]byte, error) {
+	return d.executeCommand(ctx, rootUser, command, detach)
+}
+
+// ExecuteCommandAsRemoteUser executes the command inside the container as the remote user.
+func (d *Devcontainer) ExecuteCommandAsRemoteUser(ctx context.Context, command string, detach bool) (*[]byte, error) {
+	user := d.RemoteUser
+	if user == "" {
+		user = rootUser
+	}
+
+	return d.executeCommand(ctx, user, command, detach)
+}
+
+func (d *Devcontainer) executeCommand(
+	ctx context.Context,
+	user string,
+	command string,
+	detach bool,
+) (*[]byte, error) {
 	cmd := []string{"/bin/bash", "-c", command}
 
 	execConfig := dockerTypes.ExecConfig{
-		User:         "root",
+		User:         user,
+		Env:          d.Env,
 		AttachStdout: true,
 		AttachStderr: true,
 		Cmd:          cmd,
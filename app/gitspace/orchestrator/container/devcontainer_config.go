@@ -0,0 +1,267 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"github.com/rs/zerolog/log"
+)
+
+// envList converts the environment variables map into a sorted list of KEY=VALUE entries.
+func envList(env map[string]string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+
+	list := make([]string, 0, len(env))
+	for key, value := range env {
+		list = append(list, key+"="+value)
+	}
+	sort.Strings(list)
+
+	return list
+}
+
+// forwardedPorts returns the container ports which should be forwarded.
+// Ports of other hosts (e.g. "db:5432" from a docker compose setup) are ignored.
+func forwardedPorts(ctx context.Context, ports []types.DevcontainerPort) ([]nat.Port, error) {
+	result := make([]nat.Port, 0, len(ports))
+	for _, p := range ports {
+		host, port, found := strings.Cut(string(p), ":")
+		if !found {
+			port = host
+		} else if host != "localhost" && host != "127.0.0.1" {
+			log.Ctx(ctx).Warn().Msgf("ignoring forwarded port %q of another host", p)
+			continue
+		}
+
+		number, err := strconv.Atoi(port)
+		if err != nil || number < 1 || number > 65535 {
+			return nil, fmt.Errorf("invalid forwarded port %q", p)
+		}
+
+		result = append(result, nat.Port(strconv.Itoa(number)+"/tcp"))
+	}
+
+	return result, nil
+}
+
+// additionalMounts converts the mounts from the devcontainer config into docker mounts.
+// The devcontainer config is repository content, hence host directories can only be bind mounted
+// if they are inside of one of the allowed bind mount sources configured by the admin.
+func additionalMounts(mounts []types.DevcontainerMount, allowedBindSources []string) ([]mount.Mount, error) {
+	result := make([]mount.Mount, 0, len(mounts))
+	for _, m := range mounts {
+		if m.Target == "" {
+			return nil, fmt.Errorf("mount of %q is missing the target", m.Source)
+		}
+
+		mountType := mount.TypeVolume
+		if m.Type != "" {
+			mountType = mount.Type(m.Type)
+		}
+
+		switch mountType {
+		case mount.TypeVolume, mount.TypeTmpfs:
+		case mount.TypeBind:
+			if !isBindSourceAllowed(m.Source, allowedBindSources) {
+				return nil, fmt.Errorf("bind mount of %q isn't allowed by the server configuration", m.Source)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported mount type %q", m.Type)
+		}
+
+		result = append(result, mount.Mount{
+			Type:     mountType,
+			Source:   m.Source,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
+	}
+
+	return result, nil
+}
+
+// isBindSourceAllowed returns true if the host path is inside of one of the allowed directories.
+func isBindSourceAllowed(source string, allowedSources []string) bool {
+	if !path.IsAbs(source) {
+		return false
+	}
+
+	source = path.Clean(source)
+	for _, allowed := range allowedSources {
+		allowed = path.Clean(allowed)
+		if source == allowed || strings.HasPrefix(source, strings.TrimSuffix(allowed, "/")+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// restrictedRunArgs are the docker run arguments which grant the container access to the host.
+// As the devcontainer config is repository content, they can only be used if allowed by the admin.
+var restrictedRunArgs = map[string]bool{
+	"--privileged":   true,
+	"--cap-add":      true,
+	"--security-opt": true,
+	"--network":      true,
+}
+
+// isRunArgAllowed returns true if the restricted run argument is allowed by the server configuration.
+// An allowed entry either contains only the flag (e.g. "--cap-add") to allow any value,
+// or the flag and the value (e.g. "--cap-add=SYS_PTRACE") to allow only the specific value.
+func isRunArgAllowed(flag string, value string, allowedRunArgs []string) bool {
+	for _, allowed := range allowedRunArgs {
+		allowedFlag, allowedValue, hasValue := strings.Cut(allowed, "=")
+		if allowedFlag == "--net" {
+			allowedFlag = "--network"
+		}
+		if allowedFlag == flag && (!hasValue || allowedValue == value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyRunArgs applies the supported docker run arguments to the container configuration.
+// Unsupported arguments are ignored, restricted arguments which aren't allowed result in an error.
+//
+//nolint:gocognit,cyclop // it's a flat switch over all supported flags
+func applyRunArgs(
+	ctx context.Context,
+	args []string,
+	allowedRunArgs []string,
+	config *container.Config,
+	hostConfig *container.HostConfig,
+) error {
+	for i := 0; i < len(args); i++ {
+		flag, value, hasValue := strings.Cut(args[i], "=")
+		if flag == "--net" {
+			flag = "--network"
+		}
+
+		// nextValue returns the value of the flag which is either provided inline or as the next argument.
+		nextValue := func() (string, error) {
+			if hasValue {
+				return value, nil
+			}
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("missing value for run argument %q", flag)
+			}
+			i++
+			return args[i], nil
+		}
+
+		disablesPrivileged := flag == "--privileged" && hasValue && value != "true"
+		if restrictedRunArgs[flag] && !disablesPrivileged {
+			restrictedValue := value
+			if flag != "--privileged" && !hasValue && i+1 < len(args) {
+				restrictedValue = args[i+1]
+			}
+			if !isRunArgAllowed(flag, restrictedValue, allowedRunArgs) {
+				return fmt.Errorf("run argument %q isn't allowed by the server configuration", args[i])
+			}
+		}
+
+		var err error
+		switch flag {
+		case "--privileged":
+			hostConfig.Privileged = !hasValue || value == "true"
+		case "--init":
+			useInit := !hasValue || value == "true"
+			hostConfig.Init = &useInit
+		case "--cap-add":
+			value, err = nextValue()
+			hostConfig.CapAdd = append(hostConfig.CapAdd, value)
+		case "--cap-drop":
+			value, err = nextValue()
+			hostConfig.CapDrop = append(hostConfig.CapDrop, value)
+		case "--security-opt":
+			value, err = nextValue()
+			hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, value)
+		case "--add-host":
+			value, err = nextValue()
+			hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, value)
+		case "--network":
+			value, err = nextValue()
+			hostConfig.NetworkMode = container.NetworkMode(value)
+		case "-e", "--env":
+			value, err = nextValue()
+			config.Env = append(config.Env, value)
+		case "-l", "--label":
+			value, err = nextValue()
+			if config.Labels == nil {
+				config.Labels = map[string]string{}
+			}
+			key, labelValue, _ := strings.Cut(value, "=")
+			config.Labels[key] = labelValue
+		case "-h", "--hostname":
+			value, err = nextValue()
+			config.Hostname = value
+		case "-u", "--user":
+			value, err = nextValue()
+			config.User = value
+		default:
+			log.Ctx(ctx).Warn().Msgf("ignoring unsupported run argument %q", args[i])
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildContext returns the build context directory of the devcontainer image inside the repository
+// and the path of the Dockerfile relative to the build context.
+// Both the context and the Dockerfile of the build are relative to the directory of the devcontainer.json file.
+func buildContext(configDir string, build *types.DevcontainerBuild) (string, string, error) {
+	dockerfile := build.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+
+	contextDir := path.Join(configDir, build.Context)
+	dockerfilePath, err := filepath.Rel(contextDir, path.Join(configDir, dockerfile))
+	if err != nil || strings.HasPrefix(contextDir, "..") || strings.HasPrefix(dockerfilePath, "..") {
+		return "", "", fmt.Errorf("dockerfile %q must be inside of the build context %q", dockerfile, build.Context)
+	}
+
+	return contextDir, filepath.ToSlash(dockerfilePath), nil
+}
+
+// repoDir returns the directory inside the working directory into which the repository is cloned.
+func repoDir(workingDir string, repoURL string) string {
+	return path.Join(workingDir, strings.TrimSuffix(path.Base(repoURL), ".git"))
+}
+
+// shellQuote quotes the value so it can be safely used as a single argument in a shell.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
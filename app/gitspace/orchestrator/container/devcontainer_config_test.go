@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestApplyRunArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		allowed []string
+		wantErr bool
+		check   func(t *testing.T, config *container.Config, hostConfig *container.HostConfig)
+	}{
+		{
+			name: "unrestricted",
+			args: []string{"--env", "A=B", "--cap-drop=ALL", "--privileged=false"},
+			check: func(t *testing.T, config *container.Config, hostConfig *container.HostConfig) {
+				if len(config.Env) != 1 || config.Env[0] != "A=B" {
+					t.Errorf("unexpected env: %v", config.Env)
+				}
+				if len(hostConfig.CapDrop) != 1 || hostConfig.CapDrop[0] != "ALL" {
+					t.Errorf("unexpected dropped capabilities: %v", hostConfig.CapDrop)
+				}
+				if hostConfig.Privileged {
+					t.Error("expected container not to be privileged")
+				}
+			},
+		},
+		{
+			name:    "privileged rejected",
+			args:    []string{"--privileged"},
+			wantErr: true,
+		},
+		{
+			name:    "cap add rejected",
+			args:    []string{"--cap-add", "SYS_ADMIN"},
+			allowed: []string{"--cap-add=SYS_PTRACE"},
+			wantErr: true,
+		},
+		{
+			name:    "security opt rejected",
+			args:    []string{"--security-opt=seccomp=unconfined"},
+			wantErr: true,
+		},
+		{
+			name:    "host network rejected",
+			args:    []string{"--net=host"},
+			wantErr: true,
+		},
+		{
+			name:    "cap add allowed value",
+			args:    []string{"--cap-add", "SYS_PTRACE"},
+			allowed: []string{"--cap-add=SYS_PTRACE"},
+			check: func(t *testing.T, _ *container.Config, hostConfig *container.HostConfig) {
+				if len(hostConfig.CapAdd) != 1 || hostConfig.CapAdd[0] != "SYS_PTRACE" {
+					t.Errorf("unexpected added capabilities: %v", hostConfig.CapAdd)
+				}
+			},
+		},
+		{
+			name:    "network allowed flag",
+			args:    []string{"--network=host"},
+			allowed: []string{"--net"},
+			check: func(t *testing.T, _ *container.Config, hostConfig *container.HostConfig) {
+				if hostConfig.NetworkMode != "host" {
+					t.Errorf("unexpected network mode: %s", hostConfig.NetworkMode)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &container.Config{}
+			hostConfig := &container.HostConfig{}
+			err := applyRunArgs(context.Background(), test.args, test.allowed, config, hostConfig)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("applyRunArgs() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.check != nil {
+				test.check(t, config, hostConfig)
+			}
+		})
+	}
+}
+
+func TestAdditionalMounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		mount   types.DevcontainerMount
+		allowed []string
+		wantErr bool
+	}{
+		{
+			name:  "volume",
+			mount: types.DevcontainerMount{Source: "cache", Target: "/cache"},
+		},
+		{
+			name:  "tmpfs",
+			mount: types.DevcontainerMount{Type: "tmpfs", Target: "/tmp"},
+		},
+		{
+			name:    "bind rejected",
+			mount:   types.DevcontainerMount{Type: "bind", Source: "/var/run/docker.sock", Target: "/var/run/docker.sock"},
+			wantErr: true,
+		},
+		{
+			name:    "bind allowed",
+			mount:   types.DevcontainerMount{Type: "bind", Source: "/data/shared/cache", Target: "/cache"},
+			allowed: []string{"/data/shared/"},
+		},
+		{
+			name:    "bind escaping allowed source",
+			mount:   types.DevcontainerMount{Type: "bind", Source: "/data/shared/../../etc", Target: "/etc-host"},
+			allowed: []string{"/data/shared"},
+			wantErr: true,
+		},
+		{
+			name:    "bind with allowed prefix",
+			mount:   types.DevcontainerMount{Type: "bind", Source: "/data/shared-secrets", Target: "/secrets"},
+			allowed: []string{"/data/shared"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := additionalMounts([]types.DevcontainerMount{test.mount}, test.allowed)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("additionalMounts() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildContext(t *testing.T) {
+	tests := []struct {
+		name           string
+		configDir      string
+		build          types.DevcontainerBuild
+		wantContext    string
+		wantDockerfile string
+		wantErr        bool
+	}{
+		{
+			name:           "default",
+			configDir:      ".devcontainer",
+			build:          types.DevcontainerBuild{},
+			wantContext:    ".devcontainer",
+			wantDockerfile: "Dockerfile",
+		},
+		{
+			name:           "repository root",
+			configDir:      ".devcontainer",
+			build:          types.DevcontainerBuild{Context: "..", Dockerfile: "Dockerfile"},
+			wantContext:    ".",
+			wantDockerfile: ".devcontainer/Dockerfile",
+		},
+		{
+			name:           "root config",
+			configDir:      ".",
+			build:          types.DevcontainerBuild{Dockerfile: "build/Dockerfile"},
+			wantContext:    ".",
+			wantDockerfile: "build/Dockerfile",
+		},
+		{
+			name:           "named config",
+			configDir:      ".devcontainer/python",
+			build:          types.DevcontainerBuild{Context: "..", Dockerfile: "Dockerfile"},
+			wantContext:    ".devcontainer",
+			wantDockerfile: "python/Dockerfile",
+		},
+		{
+			name:      "context outside of repository",
+			configDir: ".devcontainer",
+			build:     types.DevcontainerBuild{Context: "../.."},
+			wantErr:   true,
+		},
+		{
+			name:      "root config context outside of repository",
+			configDir: ".",
+			build:     types.DevcontainerBuild{Context: ".."},
+			wantErr:   true,
+		},
+		{
+			name:      "dockerfile outside of context",
+			configDir: ".devcontainer",
+			build:     types.DevcontainerBuild{Context: ".", Dockerfile: "../Dockerfile"},
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			contextDir, dockerfile, err := buildContext(test.configDir, &test.build)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("buildContext() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if contextDir != test.wantContext || dockerfile != test.wantDockerfile {
+				t.Errorf("buildContext() = %q, %q, want %q, %q",
+					contextDir, dockerfile, test.wantContext, test.wantDockerfile)
+			}
+		})
+	}
+}
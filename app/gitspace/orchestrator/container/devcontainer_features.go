@@ -0,0 +1,284 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	dockerTypes "github.com/docker/docker/api/types"
+)
+
+const (
+	featuresDir             = "/tmp/devcontainer-features"
+	featureMediaTypeOCI     = "application/vnd.oci.image.manifest.v1+json"
+	featureMaxArchiveSize   = 100 << 20 // 100 MiB
+	featureDownloadTimeout  = 5 * time.Minute
+	featureDefaultReference = "latest"
+)
+
+var (
+	featureOptionEnvReplacer = regexp.MustCompile(`[^A-Z0-9_]`)
+	challengeParamRegex      = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// installFeatures downloads the devcontainer features (published as OCI artifacts) and runs
+// their install scripts inside the container. Features are installed in the order of their IDs.
+func (e *EmbeddedDockerOrchestrator) installFeatures(
+	ctx context.Context,
+	devcontainerConfig *types.DevcontainerConfig,
+	devcontainer *Devcontainer,
+) error {
+	if len(devcontainerConfig.Features) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(devcontainerConfig.Features))
+	for id := range devcontainerConfig.Features {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	client := &http.Client{Timeout: featureDownloadTimeout}
+
+	for i, id := range ids {
+		archive, err := fetchFeatureArchive(ctx, client, id)
+		if err != nil {
+			return fmt.Errorf("failed to download feature %q: %w", id, err)
+		}
+
+		dir := fmt.Sprintf("%s/%d", featuresDir, i)
+		_, err = devcontainer.ExecuteCommand(ctx, "mkdir -p "+shellQuote(dir), false)
+		if err != nil {
+			return fmt.Errorf("failed to create directory for feature %q: %w", id, err)
+		}
+
+		err = devcontainer.DockerClient.CopyToContainer(ctx, devcontainer.ContainerName, dir,
+			bytes.NewReader(archive), dockerTypes.CopyToContainerOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to copy feature %q into container: %w", id, err)
+		}
+
+		_, err = devcontainer.ExecuteCommand(ctx,
+			featureInstallScript(dir, devcontainerConfig.Features[id], devcontainer.RemoteUser), false)
+		if err != nil {
+			return fmt.Errorf("failed to install feature %q: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// featureInstallScript returns the script which runs the install script of a feature with its options.
+// Following the spec, options are provided as upper-cased environment variables.
+func featureInstallScript(dir string, options types.DevcontainerFeatureOptions, remoteUser string) string {
+	if remoteUser == "" {
+		remoteUser = rootUser
+	}
+
+	env := []string{
+		"_CONTAINER_USER=" + shellQuote(rootUser),
+		"_REMOTE_USER=" + shellQuote(remoteUser),
+	}
+	for key, value := range options {
+		name := featureOptionEnvReplacer.ReplaceAllString(strings.ToUpper(key), "_")
+		env = append(env, name+"="+shellQuote(value))
+	}
+	sort.Strings(env[2:])
+
+	return fmt.Sprintf("cd %s && chmod +x ./install.sh && env %s ./install.sh",
+		shellQuote(dir), strings.Join(env, " "))
+}
+
+// fetchFeatureArchive downloads the archive of a feature published as an OCI artifact,
+// e.g. "ghcr.io/devcontainers/features/node:1".
+func fetchFeatureArchive(ctx context.Context, client *http.Client, id string) ([]byte, error) {
+	registry, repository, reference, err := parseFeatureID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := "https://" + registry + "/v2/" + repository
+
+	manifestData, err := registryGet(ctx, client, baseURL+"/manifests/"+reference, featureMediaTypeOCI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+
+	var manifest struct {
+		Layers []struct {
+			Digest string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err = json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, errors.New("manifest doesn't contain any layers")
+	}
+
+	archive, err := registryGet(ctx, client, baseURL+"/blobs/"+manifest.Layers[0].Digest, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch archive: %w", err)
+	}
+
+	return archive, nil
+}
+
+// parseFeatureID splits the OCI reference of a feature into registry, repository and tag or digest.
+func parseFeatureID(id string) (string, string, string, error) {
+	registry, name, found := strings.Cut(id, "/")
+	if !found || !strings.Contains(registry, ".") {
+		return "", "", "", fmt.Errorf("unsupported feature %q, only features published to an OCI registry are supported",
+			id)
+	}
+
+	reference := featureDefaultReference
+	if repository, digest, ok := strings.Cut(name, "@"); ok {
+		name, reference = repository, digest
+	} else if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		name, reference = name[:idx], name[idx+1:]
+	}
+
+	if name == "" || reference == "" {
+		return "", "", "", fmt.Errorf("invalid feature %q", id)
+	}
+
+	return registry, name, reference, nil
+}
+
+// registryGet executes a GET request against an OCI registry. If the registry requires
+// a bearer token, an anonymous token is requested and the request is repeated.
+func registryGet(ctx context.Context, client *http.Client, rawURL string, accept string) ([]byte, error) {
+	resp, err := doRegistryRequest(ctx, client, rawURL, accept, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+
+		token, tokenErr := registryToken(ctx, client, challenge)
+		if tokenErr != nil {
+			return nil, tokenErr
+		}
+
+		resp, err = doRegistryRequest(ctx, client, rawURL, accept, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry responded with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, featureMaxArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry response: %w", err)
+	}
+	if len(data) > featureMaxArchiveSize {
+		return nil, errors.New("registry response is too large")
+	}
+
+	return data, nil
+}
+
+func doRegistryRequest(
+	ctx context.Context,
+	client *http.Client,
+	rawURL string,
+	accept string,
+	token string,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry request: %w", err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("registry request failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// registryToken requests an anonymous bearer token as described by the WWW-Authenticate challenge,
+// e.g. `Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:user/image:pull"`.
+func registryToken(ctx context.Context, client *http.Client, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported registry authentication %q", scheme)
+	}
+
+	values := url.Values{}
+	var realm string
+	for _, match := range challengeParamRegex.FindAllStringSubmatch(params, -1) {
+		if match[1] == "realm" {
+			realm = match[2]
+			continue
+		}
+		values.Set(match[1], match[2])
+	}
+	if realm == "" {
+		return "", errors.New("registry authentication challenge is missing the realm")
+	}
+
+	resp, err := doRegistryRequest(ctx, client, realm+"?"+values.Encode(), "", "")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token request responded with status %d", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("failed to parse registry token response: %w", err)
+	}
+
+	if tokenResp.Token != "" {
+		return tokenResp.Token, nil
+	}
+
+	return tokenResp.AccessToken, nil
+}
@@ -16,10 +16,15 @@ package container
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/harness/gitness/app/gitspace/scm"
 	"github.com/harness/gitness/infraprovider"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -43,6 +48,8 @@ const (
 	catchAllPort           = "0"
 	containerStateRunning  = "running"
 	containerStateRemoved  = "removed"
+	containerStateCreated  = "created"
+	containerStateExited   = "exited"
 	templateCloneGit       = "clone_git.sh"
 	templateSetupSSHServer = "setup_ssh_server.sh"
 )
@@ -51,12 +58,17 @@ type Config struct {
 	DefaultBaseImage               string
 	DefaultBindMountTargetPath     string
 	DefaultBindMountSourceBasePath string
+	// AllowedRunArgs are the restricted docker run arguments the devcontainer config is allowed to use.
+	AllowedRunArgs []string
+	// AllowedBindMountSources are the host directories the devcontainer config is allowed to bind mount.
+	AllowedBindMountSources []string
 }
 
 type EmbeddedDockerOrchestrator struct {
 	dockerClientFactory *infraprovider.DockerClientFactory
 	vsCodeService       *VSCode
 	vsCodeWebService    *VSCodeWeb
+	scm                 scm.SCM
 	config              *Config
 }
 
@@ -64,21 +76,24 @@ func NewEmbeddedDockerOrchestrator(
 	dockerClientFactory *infraprovider.DockerClientFactory,
 	vsCodeService *VSCode,
 	vsCodeWebService *VSCodeWeb,
+	scm scm.SCM,
 	config *Config,
 ) Orchestrator {
 	return &EmbeddedDockerOrchestrator{
 		dockerClientFactory: dockerClientFactory,
 		vsCodeService:       vsCodeService,
 		vsCodeWebService:    vsCodeWebService,
+		scm:                 scm,
 		config:              config,
 	}
 }
 
 // StartGitspace checks if the Gitspace is already running by checking its entry in a map. If is it running,
-// it returns, else, it creates a new Gitspace container by using the provided image. If the provided image is
-// nil, it uses a default image read from Gitness config. Post creation it runs the postCreate command and clones
-// the code inside the container. It uses the IDE service to setup the relevant IDE and also installs SSH server
-// inside the container.
+// it returns, else, it creates a new Gitspace container by using the provided or built image. If no image is
+// provided, it uses a default image read from Gitness config. Post creation it installs the devcontainer features,
+// clones the code inside the container and runs the lifecycle commands. It uses the IDE service to setup
+// the relevant IDE and also installs SSH server inside the container.
+// A stopped Gitspace container is started again, which only runs the postStartCommand and the IDE setup.
 func (e *EmbeddedDockerOrchestrator) StartGitspace(
 	ctx context.Context,
 	gitspaceConfig *types.GitspaceConfig,
@@ -152,6 +167,33 @@ func (e *EmbeddedDockerOrchestrator) StartGitspace(
 		// TODO: Add gitspace status reporting.
 		log.Debug().Msg("started gitspace")
 
+	case containerStateCreated, containerStateExited:
+		log.Debug().Msg("gitspace is stopped, restarting it...")
+
+		ideService, startErr := e.getIDEService(gitspaceConfig)
+		if startErr != nil {
+			return nil, startErr
+		}
+
+		startErr = e.restartGitspace(
+			ctx,
+			gitspaceConfig,
+			devcontainerConfig,
+			containerName,
+			dockerClient,
+			ideService,
+		)
+		if startErr != nil {
+			return nil, fmt.Errorf("failed to restart gitspace %s: %w", containerName, startErr)
+		}
+		ports, startErr := e.getUsedPorts(ctx, containerName, dockerClient, ideService)
+		if startErr != nil {
+			return nil, startErr
+		}
+		usedPorts = ports
+
+		log.Debug().Msg("restarted gitspace")
+
 	default:
 		return nil, fmt.Errorf("gitspace %s is in a bad state: %s", containerName, state)
 	}
@@ -167,17 +209,12 @@ func (e *EmbeddedDockerOrchestrator) startGitspace(
 	dockerClient *client.Client,
 	ideService IDE,
 ) error {
-	var imageName = devcontainerConfig.Image
-	if imageName == "" {
-		imageName = e.config.DefaultBaseImage
-	}
-
-	err := e.pullImage(ctx, imageName, dockerClient)
+	imageName, err := e.prepareImage(ctx, gitspaceConfig, devcontainerConfig, containerName, dockerClient)
 	if err != nil {
 		return err
 	}
 
-	err = e.createContainer(ctx, gitspaceConfig, dockerClient, imageName, containerName, ideService)
+	err = e.createContainer(ctx, gitspaceConfig, devcontainerConfig, dockerClient, imageName, containerName, ideService)
 	if err != nil {
 		return err
 	}
@@ -186,9 +223,11 @@ func (e *EmbeddedDockerOrchestrator) startGitspace(
 		ContainerName: containerName,
 		DockerClient:  dockerClient,
 		WorkingDir:    e.config.DefaultBindMountTargetPath,
+		RemoteUser:    devcontainerConfig.RemoteUser,
+		Env:           envList(devcontainerConfig.RemoteEnv),
 	}
 
-	err = e.executePostCreateCommand(ctx, devcontainerConfig, devcontainer)
+	err = e.installFeatures(ctx, devcontainerConfig, devcontainer)
 	if err != nil {
 		return err
 	}
@@ -198,6 +237,66 @@ func (e *EmbeddedDockerOrchestrator) startGitspace(
 		return err
 	}
 
+	// lifecycle commands are executed inside the cloned repository.
+	workspace := *devcontainer
+	workspace.WorkingDir = repoDir(devcontainer.WorkingDir, gitspaceConfig.CodeRepoURL)
+
+	err = e.executeLifecycleCommand(ctx, "onCreateCommand", devcontainerConfig.OnCreateCommand, &workspace)
+	if err != nil {
+		return err
+	}
+
+	err = e.executeLifecycleCommand(ctx, "postCreateCommand", devcontainerConfig.PostCreateCommand, &workspace)
+	if err != nil {
+		return err
+	}
+
+	return e.setupStartedGitspace(ctx, gitspaceConfig, devcontainerConfig, devcontainer, ideService)
+}
+
+// restartGitspace starts the existing but stopped Gitspace container.
+func (e *EmbeddedDockerOrchestrator) restartGitspace(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	devcontainerConfig *types.DevcontainerConfig,
+	containerName string,
+	dockerClient *client.Client,
+	ideService IDE,
+) error {
+	err := dockerClient.ContainerStart(ctx, containerName, dockerTypes.ContainerStartOptions{})
+	if err != nil {
+		return fmt.Errorf("could not start container %s: %w", containerName, err)
+	}
+
+	var devcontainer = &Devcontainer{
+		ContainerName: containerName,
+		DockerClient:  dockerClient,
+		WorkingDir:    e.config.DefaultBindMountTargetPath,
+		RemoteUser:    devcontainerConfig.RemoteUser,
+		Env:           envList(devcontainerConfig.RemoteEnv),
+	}
+
+	return e.setupStartedGitspace(ctx, gitspaceConfig, devcontainerConfig, devcontainer, ideService)
+}
+
+// setupStartedGitspace runs the steps required every time the Gitspace container is started:
+// the postStartCommand, the SSH server setup and the IDE setup.
+func (e *EmbeddedDockerOrchestrator) setupStartedGitspace(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	devcontainerConfig *types.DevcontainerConfig,
+	devcontainer *Devcontainer,
+	ideService IDE,
+) error {
+	// lifecycle commands are executed inside the cloned repository.
+	workspace := *devcontainer
+	workspace.WorkingDir = repoDir(devcontainer.WorkingDir, gitspaceConfig.CodeRepoURL)
+
+	err := e.executeLifecycleCommand(ctx, "postStartCommand", devcontainerConfig.PostStartCommand, &workspace)
+	if err != nil {
+		return err
+	}
+
 	err = e.setupSSHServer(ctx, gitspaceConfig.GitspaceInstance, devcontainer)
 	if err != nil {
 		return err
@@ -205,7 +304,7 @@ func (e *EmbeddedDockerOrchestrator) startGitspace(
 
 	err = ideService.Setup(ctx, devcontainer, gitspaceConfig.GitspaceInstance)
 	if err != nil {
-		return fmt.Errorf("failed to setup IDE for gitspace %s: %w", containerName, err)
+		return fmt.Errorf("failed to setup IDE for gitspace %s: %w", devcontainer.ContainerName, err)
 	}
 
 	return nil
@@ -281,7 +380,7 @@ func (e *EmbeddedDockerOrchestrator) cloneCode(
 	devcontainer *Devcontainer,
 ) error {
 	var devcontainerPresent = "true"
-	if devcontainerConfig.Image == "" {
+	if devcontainerConfig.Image == "" && devcontainerConfig.Build == nil {
 		devcontainerPresent = "false"
 	}
 
@@ -303,18 +402,121 @@ func (e *EmbeddedDockerOrchestrator) cloneCode(
 	return nil
 }
 
-func (e *EmbeddedDockerOrchestrator) executePostCreateCommand(
+// executeLifecycleCommand executes all commands of a devcontainer lifecycle phase as the remote user.
+func (e *EmbeddedDockerOrchestrator) executeLifecycleCommand(
 	ctx context.Context,
-	devcontainerConfig *types.DevcontainerConfig,
+	phase string,
+	commands types.LifecycleCommand,
 	devcontainer *Devcontainer,
 ) error {
-	if devcontainerConfig.PostCreateCommand == "" {
-		return nil
+	for _, command := range commands {
+		_, err := devcontainer.ExecuteCommandAsRemoteUser(ctx, command, false)
+		if err != nil {
+			return fmt.Errorf("%s failed %q: %w", phase, command, err)
+		}
+	}
+
+	return nil
+}
+
+// prepareImage builds the image if the devcontainer config defines a build, otherwise it pulls
+// the image from the devcontainer config or the default image. It returns the name of the image.
+func (e *EmbeddedDockerOrchestrator) prepareImage(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	devcontainerConfig *types.DevcontainerConfig,
+	containerName string,
+	dockerClient *client.Client,
+) (string, error) {
+	if devcontainerConfig.Build != nil {
+		imageName := strings.ToLower(containerName) + ":latest"
+
+		err := e.buildImage(ctx, gitspaceConfig, devcontainerConfig, imageName, dockerClient)
+		if err != nil {
+			return "", err
+		}
+
+		return imageName, nil
+	}
+
+	var imageName = devcontainerConfig.Image
+	if imageName == "" {
+		imageName = e.config.DefaultBaseImage
+	}
+
+	err := e.pullImage(ctx, imageName, dockerClient)
+	if err != nil {
+		return "", err
+	}
+
+	return imageName, nil
+}
+
+// buildImage builds the devcontainer image using the archive of the build context directory
+// read from the repository of the gitspace.
+func (e *EmbeddedDockerOrchestrator) buildImage(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	devcontainerConfig *types.DevcontainerConfig,
+	imageName string,
+	dockerClient *client.Client,
+) error {
+	build := devcontainerConfig.Build
+	contextDir, dockerfile, err := buildContext(devcontainerConfig.ConfigDir, build)
+	if err != nil {
+		return err
+	}
+
+	buildCtx, err := e.scm.BuildContext(ctx, gitspaceConfig, contextDir)
+	if err != nil {
+		return fmt.Errorf("could not read build context of image %s: %w", imageName, err)
+	}
+	defer func() {
+		closingErr := buildCtx.Close()
+		if closingErr != nil {
+			log.Warn().Err(closingErr).Msg("failed to close image build context")
+		}
+	}()
+
+	buildArgs := make(map[string]*string, len(build.Args))
+	for key, value := range build.Args {
+		value := value
+		buildArgs[key] = &value
 	}
 
-	_, err := devcontainer.ExecuteCommand(ctx, devcontainerConfig.PostCreateCommand, false)
+	resp, err := dockerClient.ImageBuild(ctx, buildCtx, dockerTypes.ImageBuildOptions{
+		Tags:       []string{imageName},
+		Dockerfile: dockerfile,
+		BuildArgs:  buildArgs,
+		Target:     build.Target,
+		Remove:     true,
+	})
 	if err != nil {
-		return fmt.Errorf("post create command failed %q: %w", devcontainerConfig.PostCreateCommand, err)
+		return fmt.Errorf("could not build image %s: %w", imageName, err)
+	}
+	defer func() {
+		closingErr := resp.Body.Close()
+		if closingErr != nil {
+			log.Warn().Err(closingErr).Msg("failed to close image build response")
+		}
+	}()
+
+	// the build is finished once the whole response has been consumed.
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var msg struct {
+			Error string `json:"error"`
+		}
+		err = decoder.Decode(&msg)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("could not read build output of image %s: %w", imageName, err)
+		}
+		if msg.Error != "" {
+			return fmt.Errorf("could not build image %s: %s", imageName, msg.Error)
+		}
 	}
 
 	return nil
@@ -323,6 +525,7 @@ func (e *EmbeddedDockerOrchestrator) executePostCreateCommand(
 func (e *EmbeddedDockerOrchestrator) createContainer(
 	ctx context.Context,
 	gitspaceConfig *types.GitspaceConfig,
+	devcontainerConfig *types.DevcontainerConfig,
 	dockerClient *client.Client,
 	imageName string,
 	containerName string,
@@ -351,6 +554,23 @@ func (e *EmbeddedDockerOrchestrator) createContainer(
 		portBindings[natPort] = hostPortBindings
 	}
 
+	forwardPorts, err := forwardedPorts(ctx, devcontainerConfig.ForwardPorts)
+	if err != nil {
+		return err
+	}
+	for _, natPort := range forwardPorts {
+		if _, ok := exposedPorts[natPort]; ok {
+			continue
+		}
+		exposedPorts[natPort] = struct{}{}
+		portBindings[natPort] = hostPortBindings
+	}
+
+	mounts, err := additionalMounts(devcontainerConfig.Mounts, e.config.AllowedBindMountSources)
+	if err != nil {
+		return err
+	}
+
 	entryPoint := make(strslice.StrSlice, 0)
 	entryPoint = append(entryPoint, "sleep")
 
@@ -363,27 +583,37 @@ func (e *EmbeddedDockerOrchestrator) createContainer(
 			gitspaceConfig.SpacePath,
 			gitspaceConfig.Identifier,
 		)
-	err := os.MkdirAll(bindMountSourcePath, 0600)
+	err = os.MkdirAll(bindMountSourcePath, 0600)
 	if err != nil {
 		return fmt.Errorf(
 			"could not create bind mount source path %s: %w", bindMountSourcePath, err)
 	}
 
-	resp2, err := dockerClient.ContainerCreate(ctx, &container.Config{
+	containerConfig := &container.Config{
 		Image:        imageName,
 		Entrypoint:   entryPoint,
 		Cmd:          commands,
 		ExposedPorts: exposedPorts,
-	}, &container.HostConfig{
+		Env:          envList(devcontainerConfig.ContainerEnv),
+	}
+
+	hostConfig := &container.HostConfig{
 		PortBindings: portBindings,
-		Mounts: []mount.Mount{
+		Mounts: append([]mount.Mount{
 			{
 				Type:   mount.TypeBind,
 				Source: bindMountSourcePath,
 				Target: e.config.DefaultBindMountTargetPath,
 			},
-		},
-	}, nil, containerName)
+		}, mounts...),
+	}
+
+	err = applyRunArgs(ctx, devcontainerConfig.RunArgs, e.config.AllowedRunArgs, containerConfig, hostConfig)
+	if err != nil {
+		return err
+	}
+
+	resp2, err := dockerClient.ContainerCreate(ctx, containerConfig, hostConfig, nil, containerName)
 	if err != nil {
 		return fmt.Errorf("could not create container %s: %w", containerName, err)
 	}
@@ -15,6 +15,7 @@
 package container
 
 import (
+	"github.com/harness/gitness/app/gitspace/scm"
 	"github.com/harness/gitness/infraprovider"
 
 	"github.com/google/wire"
@@ -30,12 +31,14 @@ func ProvideEmbeddedDockerOrchestrator(
 	dockerClientFactory *infraprovider.DockerClientFactory,
 	vsCodeService *VSCode,
 	vsCodeWebService *VSCodeWeb,
+	scm scm.SCM,
 	config *Config,
 ) Orchestrator {
 	return NewEmbeddedDockerOrchestrator(
 		dockerClientFactory,
 		vsCodeService,
 		vsCodeWebService,
+		scm,
 		config,
 	)
 }
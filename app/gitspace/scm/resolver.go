@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scm
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/harness/gitness/types"
+)
+
+// ErrFileNotFound is returned by a Resolver if none of the requested files exist.
+var ErrFileNotFound = errors.New("file not found")
+
+// Resolver reads files from the code repository of a gitspace.
+type Resolver interface {
+	// ResolveFile returns the path and the content of the first of the provided paths
+	// that exists on the branch of the gitspace. It returns ErrFileNotFound if none of them exist.
+	ResolveFile(
+		ctx context.Context,
+		gitspaceConfig *types.GitspaceConfig,
+		filePaths []string,
+	) (string, []byte, error)
+
+	// ArchiveDir returns the directory of the branch of the gitspace as tar archive
+	// with the content of the directory placed at the root of the archive.
+	ArchiveDir(
+		ctx context.Context,
+		gitspaceConfig *types.GitspaceConfig,
+		dir string,
+	) (io.ReadCloser, error)
+}
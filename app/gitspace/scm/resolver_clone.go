@@ -0,0 +1,220 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var _ Resolver = (*CloneResolver)(nil)
+
+const (
+	gitWorkingDirectory = "/tmp/git/"
+	cloneDirName        = "repo"
+	archiveFileName     = "archive.tar"
+)
+
+// CloneResolver reads files of any publicly accessible repository by doing a shallow clone.
+type CloneResolver struct{}
+
+func NewCloneResolver() *CloneResolver {
+	return &CloneResolver{}
+}
+
+func (r *CloneResolver) ResolveFile(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	filePaths []string,
+) (string, []byte, error) {
+	workingDir, cleanup, err := shallowClone(ctx, gitspaceConfig)
+	if err != nil {
+		return "", nil, err
+	}
+	defer cleanup()
+
+	return readClonedFile(ctx, filepath.Join(workingDir, cloneDirName), filePaths)
+}
+
+func (r *CloneResolver) ArchiveDir(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	dir string,
+) (io.ReadCloser, error) {
+	return cloneArchive(ctx, gitspaceConfig, dir)
+}
+
+// shallowClone clones the branch of the gitspace into a new working directory without checking out any files.
+// The returned cleanup function removes the working directory.
+func shallowClone(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	envs ...string,
+) (string, func(), error) {
+	workingDir := gitWorkingDirectory + uuid.New().String()
+	err := os.MkdirAll(workingDir, os.ModePerm)
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating directory %s: %w", workingDir, err)
+	}
+	cleanup := func() {
+		err := os.RemoveAll(workingDir)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("Unable to remove working directory")
+		}
+	}
+
+	err = validateArgs(gitspaceConfig)
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("invalid branch or url: %w", err)
+	}
+
+	log.Info().Msg("Cloning the repository...")
+	cmd := command.New("clone",
+		command.WithFlag("--branch", gitspaceConfig.Branch),
+		command.WithFlag("--no-checkout"),
+		command.WithFlag("--depth", "1"),
+		command.WithArg(gitspaceConfig.CodeRepoURL),
+		command.WithArg(cloneDirName),
+	)
+	err = cmd.Run(
+		ctx,
+		command.WithDir(workingDir),
+		command.WithEnvs(envs...),
+	)
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to clone repository %s: %w", gitspaceConfig.CodeRepoURL, err)
+	}
+
+	return workingDir, cleanup, nil
+}
+
+// readClonedFile returns the path and the content of the first of the provided paths
+// that exists in the cloned repository.
+func readClonedFile(ctx context.Context, cloneDir string, filePaths []string) (string, []byte, error) {
+	for _, filePath := range filePaths {
+		var lsTreeOutput bytes.Buffer
+		lsTreeCmd := command.New("ls-tree",
+			command.WithArg("HEAD"),
+			command.WithArg(filePath),
+		)
+		err := lsTreeCmd.Run(
+			ctx,
+			command.WithDir(cloneDir),
+			command.WithStdout(&lsTreeOutput),
+		)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to list files in repository %s: %w", cloneDir, err)
+		}
+
+		if lsTreeOutput.Len() == 0 {
+			continue
+		}
+
+		fields := strings.Fields(lsTreeOutput.String())
+		blobSHA := fields[2]
+
+		var catFileOutput bytes.Buffer
+		catFileCmd := command.New("cat-file", command.WithFlag("-p"), command.WithArg(blobSHA))
+		err = catFileCmd.Run(
+			ctx,
+			command.WithDir(cloneDir),
+			command.WithStderr(io.Discard),
+			command.WithStdout(&catFileOutput),
+		)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to checkout devcontainer file from path %s: %w", filePath, err)
+		}
+
+		return filePath, catFileOutput.Bytes(), nil
+	}
+
+	return "", nil, ErrFileNotFound
+}
+
+// cloneArchive shallow clones the branch of the gitspace and returns the directory as tar archive.
+// The working directory is removed once the returned archive is closed.
+func cloneArchive(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	dir string,
+	envs ...string,
+) (io.ReadCloser, error) {
+	workingDir, cleanup, err := shallowClone(ctx, gitspaceConfig, envs...)
+	if err != nil {
+		return nil, err
+	}
+
+	archivePath := filepath.Join(workingDir, archiveFileName)
+	cmd := command.New("archive",
+		command.WithFlag("--format", "tar"),
+		command.WithFlag("--output", archivePath),
+		command.WithArg(archiveTreeish("HEAD", dir)),
+	)
+	err = cmd.Run(
+		ctx,
+		command.WithDir(filepath.Join(workingDir, cloneDirName)),
+	)
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to archive directory %s: %w", dir, err)
+	}
+
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to open archive of directory %s: %w", dir, err)
+	}
+
+	return &cleanupReadCloser{ReadCloser: archive, cleanup: cleanup}, nil
+}
+
+// archiveTreeish returns the tree-ish of the directory at the provided revision.
+func archiveTreeish(rev string, dir string) string {
+	if dir == "" || dir == "." {
+		return rev
+	}
+
+	return rev + ":" + dir
+}
+
+// cleanupReadCloser calls the cleanup function after closing the wrapped reader.
+type cleanupReadCloser struct {
+	io.ReadCloser
+	cleanup func()
+}
+
+func (r *cleanupReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.cleanup()
+	return err
+}
+
+func validateArgs(_ *types.GitspaceConfig) error {
+	// TODO Validate the args
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scm
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var _ Resolver = (*GitnessResolver)(nil)
+
+// GitnessResolver reads files of repositories hosted by this Gitness instance directly via the git service.
+// The repository is only read if the owner of the gitspace is allowed to view it.
+type GitnessResolver struct {
+	repoStore      store.RepoStore
+	principalStore store.PrincipalStore
+	authorizer     authz.Authorizer
+	git            git.Interface
+	urlProvider    url.Provider
+}
+
+func NewGitnessResolver(
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
+	git git.Interface,
+	urlProvider url.Provider,
+) *GitnessResolver {
+	return &GitnessResolver{
+		repoStore:      repoStore,
+		principalStore: principalStore,
+		authorizer:     authorizer,
+		git:            git,
+		urlProvider:    urlProvider,
+	}
+}
+
+// IsGitnessRepo returns true if the code repository of the gitspace is hosted by this Gitness instance.
+func (r *GitnessResolver) IsGitnessRepo(gitspaceConfig *types.GitspaceConfig) bool {
+	_, ok := r.urlProvider.GetRepoPathFromGITCloneURL(gitspaceConfig.CodeRepoURL)
+	return ok
+}
+
+func (r *GitnessResolver) ResolveFile(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	filePaths []string,
+) (string, []byte, error) {
+	repo, err := r.findRepo(ctx, gitspaceConfig)
+	if err != nil {
+		return "", nil, err
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	for _, filePath := range filePaths {
+		node, err := r.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     gitspaceConfig.Branch,
+			Path:       filePath,
+		})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read tree node %s: %w", filePath, err)
+		}
+
+		output, err := r.git.GetBlob(ctx, &git.GetBlobParams{
+			ReadParams: readParams,
+			SHA:        node.Node.SHA,
+			SizeLimit:  maxFileSize,
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read blob of %s: %w", filePath, err)
+		}
+
+		content, err := io.ReadAll(output.Content)
+		_ = output.Content.Close()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read blob content of %s: %w", filePath, err)
+		}
+
+		return filePath, content, nil
+	}
+
+	return "", nil, ErrFileNotFound
+}
+
+func (r *GitnessResolver) ArchiveDir(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	dir string,
+) (io.ReadCloser, error) {
+	repo, err := r.findRepo(ctx, gitspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		err := r.git.Archive(ctx, git.ArchiveParams{
+			ReadParams: git.CreateReadParams(repo),
+			ArchiveParams: api.ArchiveParams{
+				Format:  api.ArchiveFormatTar,
+				Treeish: archiveTreeish(gitspaceConfig.Branch, dir),
+			},
+		}, pw)
+		if err != nil {
+			err = fmt.Errorf("failed to archive directory %s: %w", dir, err)
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	return pr, nil
+}
+
+func (r *GitnessResolver) findRepo(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+) (*types.Repository, error) {
+	repoPath, ok := r.urlProvider.GetRepoPathFromGITCloneURL(gitspaceConfig.CodeRepoURL)
+	if !ok {
+		return nil, fmt.Errorf("repository %s is not hosted by gitness", gitspaceConfig.CodeRepoURL)
+	}
+
+	repo, err := r.repoStore.FindByRef(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository %s: %w", repoPath, err)
+	}
+
+	principal, err := r.principalStore.FindByUID(ctx, gitspaceConfig.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find owner %s of the gitspace: %w", gitspaceConfig.UserID, err)
+	}
+
+	session := &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}
+
+	err = apiauth.CheckRepo(ctx, r.authorizer, session, repo, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("owner of the gitspace isn't allowed to access repository %s: %w", repoPath, err)
+	}
+
+	return repo, nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	goscm "github.com/drone/go-scm/scm"
+	"github.com/drone/go-scm/scm/driver/bitbucket"
+	"github.com/drone/go-scm/scm/driver/github"
+	"github.com/drone/go-scm/scm/driver/gitlab"
+	"github.com/drone/go-scm/scm/transport"
+)
+
+var _ Resolver = (*ProviderResolver)(nil)
+
+const (
+	hostGithub    = "github.com"
+	hostGitlab    = "gitlab.com"
+	hostBitbucket = "bitbucket.org"
+)
+
+// ConnectorCredentials are the credentials stored in the data of a connector
+// which is used to access the code repository of a gitspace.
+type ConnectorCredentials struct {
+	// Username is optional. If provided, basic authentication is used with the token as password.
+	Username string `json:"username"`
+	Token    string `json:"token"`
+}
+
+// ProviderResolver reads files of repositories hosted by GitHub, GitLab or Bitbucket using their APIs.
+// If the gitspace references a connector, the credentials of the connector are used.
+type ProviderResolver struct {
+	connectorStore store.ConnectorStore
+}
+
+func NewProviderResolver(connectorStore store.ConnectorStore) *ProviderResolver {
+	return &ProviderResolver{
+		connectorStore: connectorStore,
+	}
+}
+
+func (r *ProviderResolver) ResolveFile(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	filePaths []string,
+) (string, []byte, error) {
+	client, repoName, err := r.client(ctx, gitspaceConfig)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, filePath := range filePaths {
+		content, resp, err := client.Contents.Find(ctx, repoName, filePath, gitspaceConfig.Branch)
+		if resp != nil && resp.Status == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read file %s from %s: %w", filePath, gitspaceConfig.CodeRepoURL, err)
+		}
+
+		if len(content.Data) > maxFileSize {
+			return "", nil, fmt.Errorf("file %s exceeds the maximum size of %d bytes", filePath, maxFileSize)
+		}
+
+		return filePath, content.Data, nil
+	}
+
+	return "", nil, ErrFileNotFound
+}
+
+func (r *ProviderResolver) ArchiveDir(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	dir string,
+) (io.ReadCloser, error) {
+	creds, err := r.credentials(ctx, gitspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if creds == nil {
+		return cloneArchive(ctx, gitspaceConfig, dir)
+	}
+
+	username := creds.Username
+	if username == "" {
+		username = defaultTokenUsername(gitspaceConfig.CodeRepoType)
+	}
+
+	// the credentials are passed via environment to avoid exposing them in the command line arguments.
+	basicAuth := base64.StdEncoding.EncodeToString([]byte(username + ":" + creds.Token))
+	return cloneArchive(ctx, gitspaceConfig, dir,
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.extraHeader",
+		"GIT_CONFIG_VALUE_0=Authorization: Basic "+basicAuth,
+	)
+}
+
+// defaultTokenUsername returns the username used for git operations authenticated with a token.
+func defaultTokenUsername(codeRepoType enum.GitspaceCodeRepoType) string {
+	switch codeRepoType {
+	case enum.CodeRepoTypeGitlab:
+		return "oauth2"
+	case enum.CodeRepoTypeBitbucket:
+		return "x-token-auth"
+	default:
+		return "x-access-token"
+	}
+}
+
+// client returns the SCM client for the code repository of the gitspace and the name of the repository.
+func (r *ProviderResolver) client(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+) (*goscm.Client, string, error) {
+	repoURL, err := url.Parse(gitspaceConfig.CodeRepoURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid code repository URL %s: %w", gitspaceConfig.CodeRepoURL, err)
+	}
+
+	repoName := strings.TrimSuffix(strings.Trim(repoURL.Path, "/"), ".git")
+	if !strings.Contains(repoName, "/") {
+		return nil, "", fmt.Errorf("failed to get repository name from URL %s", gitspaceConfig.CodeRepoURL)
+	}
+
+	var client *goscm.Client
+	switch gitspaceConfig.CodeRepoType {
+	case enum.CodeRepoTypeGithub:
+		if repoURL.Host == hostGithub {
+			client = github.NewDefault()
+		} else {
+			client, err = github.New(repoURL.Scheme + "://" + repoURL.Host + "/api/v3")
+		}
+	case enum.CodeRepoTypeGitlab:
+		if repoURL.Host == hostGitlab {
+			client = gitlab.NewDefault()
+		} else {
+			client, err = gitlab.New(repoURL.Scheme + "://" + repoURL.Host)
+		}
+	case enum.CodeRepoTypeBitbucket:
+		if repoURL.Host != hostBitbucket {
+			return nil, "", fmt.Errorf("only %s is supported for bitbucket repositories", hostBitbucket)
+		}
+		client = bitbucket.NewDefault()
+	default:
+		return nil, "", fmt.Errorf("unsupported code repository type %q", gitspaceConfig.CodeRepoType)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create scm client: %w", err)
+	}
+
+	creds, err := r.credentials(ctx, gitspaceConfig)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch {
+	case creds == nil:
+		client.Client = http.DefaultClient
+	case creds.Username != "":
+		client.Client = &http.Client{Transport: &transport.BasicAuth{
+			Username: creds.Username,
+			Password: creds.Token,
+		}}
+	default:
+		client.Client = &http.Client{Transport: &transport.BearerToken{Token: creds.Token}}
+	}
+
+	return client, repoName, nil
+}
+
+// credentials returns the credentials of the connector referenced by the gitspace, if any.
+func (r *ProviderResolver) credentials(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+) (*ConnectorCredentials, error) {
+	if gitspaceConfig.CodeAuthID == "" {
+		return nil, nil //nolint:nilnil // public repositories don't require credentials
+	}
+
+	connector, err := r.connectorStore.FindByIdentifier(ctx, gitspaceConfig.SpaceID, gitspaceConfig.CodeAuthID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connector %s: %w", gitspaceConfig.CodeAuthID, err)
+	}
+
+	creds := &ConnectorCredentials{}
+	if err = json.Unmarshal([]byte(connector.Data), creds); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials of connector %s: %w", connector.Identifier, err)
+	}
+	if creds.Token == "" {
+		return nil, errors.New("connector " + connector.Identifier + " doesn't contain a token")
+	}
+
+	return creds, nil
+}
@@ -15,117 +15,139 @@
 package scm
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"os"
+	"path"
 	"regexp"
 	"strings"
 
-	"github.com/harness/gitness/git/command"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
-	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
 var _ SCM = (*scm)(nil)
 
+const (
+	// maxFileSize is the maximum size of a devcontainer config file.
+	maxFileSize = 1 << 20 // 1 MiB
+
+	devcontainerDefaultPath = ".devcontainer/devcontainer.json"
+	devcontainerRootPath    = ".devcontainer.json"
+	devcontainerFileName    = "devcontainer.json"
+	devcontainerDir         = ".devcontainer"
+)
+
 type SCM interface {
 	// DevcontainerConfig fetches devcontainer config file from the given repo and branch.
 	DevcontainerConfig(ctx context.Context, gitspaceConfig *types.GitspaceConfig) (*types.DevcontainerConfig, error)
+
+	// BuildContext returns the directory of the given repo and branch as tar archive,
+	// which is used as build context of the devcontainer image.
+	BuildContext(ctx context.Context, gitspaceConfig *types.GitspaceConfig, dir string) (io.ReadCloser, error)
 }
 
-type scm struct{}
+type scm struct {
+	gitnessResolver *GitnessResolver
+	// resolvers contains the resolvers for the supported code repository types.
+	resolvers map[enum.GitspaceCodeRepoType]Resolver
+	// defaultResolver is used for all other code repositories.
+	defaultResolver Resolver
+}
 
-func NewSCM() SCM {
-	return &scm{}
+func NewSCM(
+	gitnessResolver *GitnessResolver,
+	resolvers map[enum.GitspaceCodeRepoType]Resolver,
+	defaultResolver Resolver,
+) SCM {
+	return &scm{
+		gitnessResolver: gitnessResolver,
+		resolvers:       resolvers,
+		defaultResolver: defaultResolver,
+	}
 }
 
 func (s scm) DevcontainerConfig(
 	ctx context.Context,
 	gitspaceConfig *types.GitspaceConfig,
 ) (*types.DevcontainerConfig, error) {
-	gitWorkingDirectory := "/tmp/git/"
-	cloneDir := gitWorkingDirectory + uuid.New().String()
-	err := os.MkdirAll(cloneDir, os.ModePerm)
+	filePaths, err := devcontainerPaths(gitspaceConfig.DevcontainerPath)
 	if err != nil {
-		return nil, fmt.Errorf("error creating directory %s: %w", cloneDir, err)
+		return nil, err
+	}
+
+	filePath, content, err := s.resolver(gitspaceConfig).ResolveFile(ctx, gitspaceConfig, filePaths)
+	if errors.Is(err, ErrFileNotFound) {
+		log.Ctx(ctx).Info().Msg("File not found, returning empty devcontainerConfig")
+		return &types.DevcontainerConfig{}, nil
 	}
-	defer func() {
-		err = os.RemoveAll(cloneDir)
-		if err != nil {
-			log.Ctx(ctx).Warn().Err(err).Msg("Unable to remove working directory")
-		}
-	}()
-	filePath := ".devcontainer/devcontainer.json"
-	err = validateArgs(gitspaceConfig)
 	if err != nil {
-		return nil, fmt.Errorf("invalid branch or url: %w", err)
+		return nil, fmt.Errorf("failed to read devcontainer config: %w", err)
 	}
 
-	log.Info().Msg("Cloning the repository...")
-	cmd := command.New("clone",
-		command.WithFlag("--branch", gitspaceConfig.Branch),
-		command.WithFlag("--no-checkout"),
-		command.WithFlag("--depth", "1"),
-		command.WithArg(gitspaceConfig.CodeRepoURL),
-		command.WithArg(cloneDir),
-	)
-	err = cmd.Run(
-		ctx,
-		command.WithDir(cloneDir),
-	)
+	sanitizedJSON := removeComments(content)
+
+	var config types.DevcontainerConfig
+	err = json.Unmarshal(sanitizedJSON, &config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to clone repository %s: %w", gitspaceConfig.CodeRepoURL, err)
+		return nil, fmt.Errorf("failed to parse devcontainer json %s: %w", filePath, err)
 	}
 
-	var lsTreeOutput bytes.Buffer
-	lsTreeCmd := command.New("ls-tree",
-		command.WithArg("HEAD"),
-		command.WithArg(filePath),
-	)
-	err = lsTreeCmd.Run(
-		ctx,
-		command.WithDir(cloneDir),
-		command.WithStdout(&lsTreeOutput),
-	)
+	config.ConfigDir = path.Dir(filePath)
+
+	return &config, nil
+}
+
+func (s scm) BuildContext(
+	ctx context.Context,
+	gitspaceConfig *types.GitspaceConfig,
+	dir string,
+) (io.ReadCloser, error) {
+	buildContext, err := s.resolver(gitspaceConfig).ArchiveDir(ctx, gitspaceConfig, path.Clean(dir))
 	if err != nil {
-		return nil, fmt.Errorf("failed to list files in repository %s: %w", cloneDir, err)
+		return nil, fmt.Errorf("failed to read build context %s: %w", dir, err)
 	}
 
-	if lsTreeOutput.Len() == 0 {
-		log.Info().Msg("File not found, returning empty devcontainerConfig")
-		emptyConfig := &types.DevcontainerConfig{}
-		return emptyConfig, nil
+	return buildContext, nil
+}
+
+// resolver returns the resolver used for the code repository of the gitspace.
+// Repositories hosted by this Gitness instance are always read directly via the git service.
+func (s scm) resolver(gitspaceConfig *types.GitspaceConfig) Resolver {
+	if s.gitnessResolver != nil && s.gitnessResolver.IsGitnessRepo(gitspaceConfig) {
+		return s.gitnessResolver
 	}
 
-	fields := strings.Fields(lsTreeOutput.String())
-	blobSHA := fields[2]
-
-	var catFileOutput bytes.Buffer
-	catFileCmd := command.New("cat-file", command.WithFlag("-p"), command.WithArg(blobSHA))
-	err = catFileCmd.Run(
-		ctx,
-		command.WithDir(cloneDir),
-		command.WithStderr(io.Discard),
-		command.WithStdout(&catFileOutput),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to checkout devcontainer file from path %s: %w", filePath, err)
+	if resolver, ok := s.resolvers[gitspaceConfig.CodeRepoType]; ok {
+		return resolver
 	}
 
-	sanitizedJSON := removeComments(catFileOutput.Bytes())
+	return s.defaultResolver
+}
 
-	var config types.DevcontainerConfig
-	err = json.Unmarshal(sanitizedJSON, &config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse devcontainer json: %w", err)
+// devcontainerPaths returns the paths at which the devcontainer config is looked up.
+// Following the devcontainer spec, supported paths are .devcontainer/devcontainer.json,
+// .devcontainer.json and .devcontainer/<name>/devcontainer.json.
+func devcontainerPaths(configuredPath string) ([]string, error) {
+	if configuredPath == "" {
+		return []string{devcontainerDefaultPath, devcontainerRootPath}, nil
 	}
 
-	return &config, nil
+	filePath := path.Clean(strings.TrimPrefix(configuredPath, "/"))
+	if filePath == devcontainerDefaultPath || filePath == devcontainerRootPath {
+		return []string{filePath}, nil
+	}
+
+	dir, fileName := path.Split(filePath)
+	if fileName == devcontainerFileName && path.Dir(path.Clean(dir)) == devcontainerDir {
+		return []string{filePath}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported devcontainer path %q", configuredPath)
 }
 
 func removeComments(input []byte) []byte {
@@ -134,8 +156,3 @@ func removeComments(input []byte) []byte {
 	lineCommentRegex := regexp.MustCompile(`//.*`)
 	return lineCommentRegex.ReplaceAll(input, nil)
 }
-
-func validateArgs(_ *types.GitspaceConfig) error {
-	// TODO Validate the args
-	return nil
-}
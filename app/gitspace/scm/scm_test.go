@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDevcontainerPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "default",
+			path: "",
+			want: []string{".devcontainer/devcontainer.json", ".devcontainer.json"},
+		},
+		{
+			name: "root",
+			path: ".devcontainer.json",
+			want: []string{".devcontainer.json"},
+		},
+		{
+			name: "named",
+			path: "/.devcontainer/python/devcontainer.json",
+			want: []string{".devcontainer/python/devcontainer.json"},
+		},
+		{
+			name:    "nested too deep",
+			path:    ".devcontainer/a/b/devcontainer.json",
+			wantErr: true,
+		},
+		{
+			name:    "outside of devcontainer dir",
+			path:    "config/devcontainer.json",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := devcontainerPaths(test.path)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("devcontainerPaths() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("devcontainerPaths() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
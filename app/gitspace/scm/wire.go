@@ -14,13 +14,55 @@
 
 package scm
 
-import "github.com/google/wire"
+import (
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/wire"
+)
 
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
+	ProvideGitnessResolver,
+	ProvideProviderResolver,
+	ProvideCloneResolver,
 	ProvideSCM,
 )
 
-func ProvideSCM() SCM {
-	return NewSCM()
+func ProvideGitnessResolver(
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
+	git git.Interface,
+	urlProvider url.Provider,
+) *GitnessResolver {
+	return NewGitnessResolver(repoStore, principalStore, authorizer, git, urlProvider)
+}
+
+func ProvideProviderResolver(connectorStore store.ConnectorStore) *ProviderResolver {
+	return NewProviderResolver(connectorStore)
+}
+
+func ProvideCloneResolver() *CloneResolver {
+	return NewCloneResolver()
+}
+
+func ProvideSCM(
+	gitnessResolver *GitnessResolver,
+	providerResolver *ProviderResolver,
+	cloneResolver *CloneResolver,
+) SCM {
+	return NewSCM(
+		gitnessResolver,
+		map[enum.GitspaceCodeRepoType]Resolver{
+			enum.CodeRepoTypeHarnessCode: gitnessResolver,
+			enum.CodeRepoTypeGithub:      providerResolver,
+			enum.CodeRepoTypeGitlab:      providerResolver,
+			enum.CodeRepoTypeBitbucket:   providerResolver,
+		},
+		cloneResolver,
+	)
 }
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/livelog"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectTimeout is the maximum time a collector spends on gathering its metrics during a scrape.
+const collectTimeout = 10 * time.Second
+
+// logStreamCollector collects the number of live log streams and their subscribers.
+type logStreamCollector struct {
+	logStream   livelog.LogStream
+	streams     *prometheus.Desc
+	subscribers *prometheus.Desc
+}
+
+func newLogStreamCollector(logStream livelog.LogStream) *logStreamCollector {
+	return &logStreamCollector{
+		logStream: logStream,
+		streams: prometheus.NewDesc("gitness_livelog_streams",
+			"Number of open live log streams.", nil, nil),
+		subscribers: prometheus.NewDesc("gitness_livelog_subscribers",
+			"Number of subscribers tailing live log streams.", nil, nil),
+	}
+}
+
+func (c *logStreamCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.streams
+	ch <- c.subscribers
+}
+
+func (c *logStreamCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	info := c.logStream.Info(ctx)
+
+	subscribers := 0
+	for _, count := range info.Streams {
+		subscribers += count
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.streams, prometheus.GaugeValue, float64(len(info.Streams)))
+	ch <- prometheus.MustNewConstMetric(c.subscribers, prometheus.GaugeValue, float64(subscribers))
+}
+
+// stageCollector collects the number of pipeline stages that are queued or running.
+type stageCollector struct {
+	stageStore store.StageStore
+	stages     *prometheus.Desc
+}
+
+func newStageCollector(stageStore store.StageStore) *stageCollector {
+	return &stageCollector{
+		stageStore: stageStore,
+		stages: prometheus.NewDesc("gitness_pipeline_stages",
+			"Number of incomplete pipeline stages, partitioned by status.", []string{"status"}, nil),
+	}
+}
+
+func (c *stageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.stages
+}
+
+func (c *stageCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	stages, err := c.stageStore.ListIncomplete(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.stages, err)
+		return
+	}
+
+	counts := map[enum.CIStatus]int{
+		enum.CIStatusPending: 0,
+		enum.CIStatusRunning: 0,
+	}
+	for _, stage := range stages {
+		counts[stage.Status]++
+	}
+
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.stages, prometheus.GaugeValue, float64(count), string(status))
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	unmatchedRoute = "unmatched"
+	otherMethod    = "other"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of http requests, partitioned by handler, method, route and status code.",
+	}, []string{"handler", "method", "route", "code"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of http requests, partitioned by handler, method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"handler", "method", "route"})
+)
+
+// Middleware returns an http middleware that records the latency and status of requests by route.
+// NOTE: The middleware is meant to be used with a chi router, as the matched route pattern is used as label.
+func Middleware(handler string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			// the route pattern is only complete after the request got routed all the way down.
+			route := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			method := sanitizeMethod(r.Method)
+
+			httpRequests.WithLabelValues(handler, method, route, strconv.Itoa(status)).Inc()
+			httpRequestDuration.WithLabelValues(handler, method, route).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// sanitizeMethod maps unknown http methods to a single value to keep the cardinality of the labels bounded.
+func sanitizeMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions:
+		return method
+	default:
+		return otherMethod
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddleware(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(Middleware("test"))
+	r.Route("/repos", func(r chi.Router) {
+		r.Get("/{repo_ref}", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+		r.Route("/{repo_ref}/branches", func(r chi.Router) {
+			r.Get("/{branch_name}", func(http.ResponseWriter, *http.Request) {})
+		})
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		route       string
+		labelMethod string
+		code        string
+	}{
+		{
+			name:        "route with parameter",
+			method:      http.MethodGet,
+			path:        "/repos/space%2Frepo",
+			route:       "/repos/{repo_ref}",
+			labelMethod: http.MethodGet,
+			code:        "204",
+		},
+		{
+			name:        "nested route",
+			method:      http.MethodGet,
+			path:        "/repos/repo/branches/main",
+			route:       "/repos/{repo_ref}/branches/{branch_name}",
+			labelMethod: http.MethodGet,
+			code:        "200",
+		},
+		{
+			name:        "unmatched route",
+			method:      http.MethodGet,
+			path:        "/unknown/path",
+			route:       unmatchedRoute,
+			labelMethod: http.MethodGet,
+			code:        "404",
+		},
+		{
+			name:        "unknown method",
+			method:      "PROPFIND",
+			path:        "/repos/repo",
+			route:       unmatchedRoute,
+			labelMethod: otherMethod,
+			code:        "405",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			counter := httpRequests.WithLabelValues("test", test.labelMethod, test.route, test.code)
+			before := testutil.ToFloat64(counter)
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(test.method, test.path, nil))
+
+			if got := testutil.ToFloat64(counter) - before; got != 1 {
+				t.Errorf("want 1 request with route %q and code %s, got %v", test.route, test.code, got)
+			}
+		})
+	}
+}
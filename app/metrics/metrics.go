@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics exposes runtime metrics of the server in the prometheus format.
+package metrics
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler is an abstraction of an http handler that serves the metrics endpoint.
+type Handler interface {
+	http.Handler
+}
+
+// NewHandler returns a new Handler that serves all metrics registered with the prometheus default registry.
+// If a token is provided, scraping the metrics requires it as bearer token.
+func NewHandler(token string) Handler {
+	h := promhttp.Handler()
+	if token == "" {
+		return h
+	}
+
+	expected := []byte("Bearer " + token)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			render.Unauthorized(r.Context(), w)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
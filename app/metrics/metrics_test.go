@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		want          int
+	}{
+		{
+			name: "no token required",
+			want: http.StatusOK,
+		},
+		{
+			name:          "valid token",
+			token:         "secret",
+			authorization: "Bearer secret",
+			want:          http.StatusOK,
+		},
+		{
+			name:  "missing token",
+			token: "secret",
+			want:  http.StatusUnauthorized,
+		},
+		{
+			name:          "invalid token",
+			token:         "secret",
+			authorization: "Bearer other",
+			want:          http.StatusUnauthorized,
+		},
+		{
+			name:          "token without bearer prefix",
+			token:         "secret",
+			authorization: "secret",
+			want:          http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+
+			w := httptest.NewRecorder()
+			NewHandler(test.token).ServeHTTP(w, req)
+
+			if w.Code != test.want {
+				t.Errorf("want status %d, got %d", test.want, w.Code)
+			}
+		})
+	}
+}
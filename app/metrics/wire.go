@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/livelog"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideHandler,
+)
+
+// ProvideHandler provides the handler of the metrics endpoint.
+// In case the metrics endpoint is disabled, no handler is returned.
+func ProvideHandler(
+	config *types.Config,
+	db *sqlx.DB,
+	logStream livelog.LogStream,
+	stageStore store.StageStore,
+) (Handler, error) {
+	if !config.Prometheus.Enabled {
+		return nil, nil
+	}
+
+	for _, collector := range []prometheus.Collector{
+		collectors.NewDBStatsCollector(db.DB, "gitness"),
+		newLogStreamCollector(logStream),
+		newStageCollector(stageStore),
+	} {
+		if err := prometheus.Register(collector); err != nil {
+			return nil, fmt.Errorf("failed to register metrics collector: %w", err)
+		}
+	}
+
+	return NewHandler(config.Prometheus.Token), nil
+}
@@ -20,16 +20,18 @@ import (
 	"fmt"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
 // Write is a util function which writes execution and pipeline state to the
-// check store.
+// check store and reports the check status event.
 func Write(
 	ctx context.Context,
 	checkStore store.CheckStore,
+	checkEvReporter *checkevents.Reporter,
 	execution *types.Execution,
 	pipeline *types.Pipeline,
 ) error {
@@ -67,5 +69,14 @@ func Write(
 	if err != nil {
 		return fmt.Errorf("could not upsert to check store: %w", err)
 	}
+
+	checkEvReporter.StatusReported(ctx, &checkevents.StatusReportedPayload{
+		RepoID:      check.RepoID,
+		PrincipalID: check.CreatedBy,
+		CommitSHA:   check.CommitSHA,
+		Identifier:  check.Identifier,
+		Status:      check.Status,
+	})
+
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -135,6 +136,7 @@ type Manager struct {
 	Pipelines        store.PipelineStore
 	urlProvider      urlprovider.Provider
 	Checks           store.CheckStore
+	CheckEvReporter  *checkevents.Reporter
 	// Converter  store.ConvertService
 	SSEStreamer sse.Streamer
 	// Globals    store.GlobalSecretStore
@@ -172,6 +174,7 @@ func New(
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
 	publicAccess publicaccess.Service,
+	checkEvReporter *checkevents.Reporter,
 ) *Manager {
 	return &Manager{
 		Config:           config,
@@ -191,6 +194,7 @@ func New(
 		Steps:            stepStore,
 		Users:            userStore,
 		publicAccess:     publicAccess,
+		CheckEvReporter:  checkEvReporter,
 	}
 }
 
@@ -456,14 +460,15 @@ func (m *Manager) AfterStep(_ context.Context, step *types.Step) error {
 // BeforeAll signals the build stage is about to start.
 func (m *Manager) BeforeStage(_ context.Context, stage *types.Stage) error {
 	s := &setup{
-		Executions:  m.Executions,
-		Checks:      m.Checks,
-		Pipelines:   m.Pipelines,
-		SSEStreamer: m.SSEStreamer,
-		Repos:       m.Repos,
-		Steps:       m.Steps,
-		Stages:      m.Stages,
-		Users:       m.Users,
+		Executions:      m.Executions,
+		Checks:          m.Checks,
+		CheckEvReporter: m.CheckEvReporter,
+		Pipelines:       m.Pipelines,
+		SSEStreamer:     m.SSEStreamer,
+		Repos:           m.Repos,
+		Steps:           m.Steps,
+		Stages:          m.Stages,
+		Users:           m.Users,
 	}
 
 	return s.do(noContext, stage)
@@ -472,15 +477,16 @@ func (m *Manager) BeforeStage(_ context.Context, stage *types.Stage) error {
 // AfterAll signals the build stage is complete.
 func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 	t := &teardown{
-		Executions:  m.Executions,
-		Pipelines:   m.Pipelines,
-		Checks:      m.Checks,
-		SSEStreamer: m.SSEStreamer,
-		Logs:        m.Logz,
-		Repos:       m.Repos,
-		Scheduler:   m.Scheduler,
-		Steps:       m.Steps,
-		Stages:      m.Stages,
+		Executions:      m.Executions,
+		Pipelines:       m.Pipelines,
+		Checks:          m.Checks,
+		CheckEvReporter: m.CheckEvReporter,
+		SSEStreamer:     m.SSEStreamer,
+		Logs:            m.Logz,
+		Repos:           m.Repos,
+		Scheduler:       m.Scheduler,
+		Steps:           m.Steps,
+		Stages:          m.Stages,
 	}
 	return t.do(noContext, stage)
 }
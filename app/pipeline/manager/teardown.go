@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
@@ -34,15 +35,16 @@ import (
 )
 
 type teardown struct {
-	Executions  store.ExecutionStore
-	Checks      store.CheckStore
-	Pipelines   store.PipelineStore
-	SSEStreamer sse.Streamer
-	Logs        livelog.LogStream
-	Scheduler   scheduler.Scheduler
-	Repos       store.RepoStore
-	Steps       store.StepStore
-	Stages      store.StageStore
+	Executions      store.ExecutionStore
+	Checks          store.CheckStore
+	CheckEvReporter *checkevents.Reporter
+	Pipelines       store.PipelineStore
+	SSEStreamer     sse.Streamer
+	Logs            livelog.LogStream
+	Scheduler       scheduler.Scheduler
+	Repos           store.RepoStore
+	Steps           store.StepStore
+	Stages          store.StageStore
 }
 
 //nolint:gocognit // refactor if needed.
@@ -180,7 +182,7 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 	// try to write to the checks store - if not, log an error and continue
-	err = checks.Write(ctx, t.Checks, execution, pipeline)
+	err = checks.Write(ctx, t.Checks, t.CheckEvReporter, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: could not write to checks store")
 	}
@@ -21,6 +21,7 @@ import (
 	"runtime/debug"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -94,6 +95,7 @@ type triggerer struct {
 	templateStore    store.TemplateStore
 	pluginStore      store.PluginStore
 	publicAccess     publicaccess.Service
+	checkEvReporter  *checkevents.Reporter
 }
 
 func New(
@@ -110,6 +112,7 @@ func New(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
+	checkEvReporter *checkevents.Reporter,
 ) Triggerer {
 	return &triggerer{
 		executionStore:   executionStore,
@@ -125,6 +128,7 @@ func New(
 		templateStore:    templateStore,
 		pluginStore:      pluginStore,
 		publicAccess:     publicAccess,
+		checkEvReporter:  checkEvReporter,
 	}
 }
 
@@ -150,7 +154,7 @@ func (t *triggerer) Trigger(
 		}
 	}()
 
-	event := string(base.Action.GetTriggerEvent())
+	event := triggerEvent(base)
 
 	repo, err := t.repoStore.Find(ctx, pipeline.RepoID)
 	if err != nil {
@@ -365,7 +369,7 @@ func (t *triggerer) Trigger(
 	}
 
 	// try to write to check store. log on failure but don't error out the execution
-	err = checks.Write(ctx, t.checkStore, execution, pipeline)
+	err = checks.Write(ctx, t.checkStore, t.checkEvReporter, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: could not write to check store")
 	}
@@ -384,6 +388,14 @@ func (t *triggerer) Trigger(
 	return execution, nil
 }
 
+// triggerEvent returns the event of the execution created for the hook.
+func triggerEvent(base *Hook) string {
+	if base.Cron != "" {
+		return enum.TriggerEventCron
+	}
+	return string(base.Action.GetTriggerEvent())
+}
+
 func trunc(s string, i int) string {
 	runes := []rune(s)
 	if len(runes) > i {
@@ -557,7 +569,7 @@ func (t *triggerer) createExecutionWithError(
 		Parent:       base.Parent,
 		Status:       enum.CIStatusError,
 		Error:        message,
-		Event:        string(base.Action.GetTriggerEvent()),
+		Event:        triggerEvent(base),
 		Action:       string(base.Action),
 		Link:         base.Link,
 		Title:        base.Title,
@@ -575,6 +587,7 @@ func (t *triggerer) createExecutionWithError(
 		AuthorAvatar: base.AuthorAvatar,
 		Debug:        base.Debug,
 		Sender:       base.Sender,
+		Cron:         base.Cron,
 		Created:      now,
 		Updated:      now,
 		Started:      now,
@@ -588,7 +601,7 @@ func (t *triggerer) createExecutionWithError(
 	}
 
 	// try to write to check store, log on failure
-	err = checks.Write(ctx, t.checkStore, execution, pipeline)
+	err = checks.Write(ctx, t.checkStore, t.checkEvReporter, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: failed to update check")
 	}
@@ -15,6 +15,7 @@
 package triggerer
 
 import (
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
@@ -46,8 +47,9 @@ func ProvideTriggerer(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
+	checkEvReporter *checkevents.Reporter,
 ) Triggerer {
 	return New(executionStore, checkStore, stageStore, pipelineStore,
 		tx, repoStore, urlProvider, scheduler, fileService, converterService,
-		templateStore, pluginStore, publicAccess)
+		templateStore, pluginStore, publicAccess, checkEvReporter)
 }
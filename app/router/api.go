@@ -41,6 +41,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
@@ -65,6 +66,7 @@ import (
 	handlertrigger "github.com/harness/gitness/app/api/handler/trigger"
 	handlerupload "github.com/harness/gitness/app/api/handler/upload"
 	handleruser "github.com/harness/gitness/app/api/handler/user"
+	handlerusergroup "github.com/harness/gitness/app/api/handler/usergroup"
 	"github.com/harness/gitness/app/api/handler/users"
 	handlerwebhook "github.com/harness/gitness/app/api/handler/webhook"
 	"github.com/harness/gitness/app/api/middleware/address"
@@ -73,9 +75,11 @@ import (
 	"github.com/harness/gitness/app/api/middleware/logging"
 	"github.com/harness/gitness/app/api/middleware/nocache"
 	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
+	middlewaretracing "github.com/harness/gitness/app/api/middleware/tracing"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/metrics"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
@@ -127,6 +131,7 @@ func NewAPIHandler(
 	searchCtrl *keywordsearch.Controller,
 	migrateCtrl *migrate.Controller,
 	gitspaceCtrl *gitspace.Controller,
+	userGroupCtrl *usergroup.Controller,
 ) APIHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -134,6 +139,7 @@ func NewAPIHandler(
 	// Apply common api middleware.
 	r.Use(nocache.NoCache)
 	r.Use(middleware.Recoverer)
+	r.Use(middlewaretracing.HTTPHandler("api"))
 
 	// configure logging middleware.
 	r.Use(hlog.URLHandler("http.url"))
@@ -141,6 +147,7 @@ func NewAPIHandler(
 	r.Use(logging.HLogRequestIDHandler())
 	r.Use(logging.HLogAccessLogHandler())
 	r.Use(address.Handler("", ""))
+	r.Use(metrics.Middleware("api"))
 
 	// configure cors middleware
 	r.Use(corsHandler(config))
@@ -154,7 +161,7 @@ func NewAPIHandler(
 		setupRoutesV1(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl, pipelineCtrl,
 			connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 			webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, uploadCtrl,
-			searchCtrl, gitspaceCtrl, migrateCtrl)
+			searchCtrl, gitspaceCtrl, migrateCtrl, userGroupCtrl)
 	})
 
 	// wrap router in terminatedPath encoder.
@@ -202,8 +209,9 @@ func setupRoutesV1(r chi.Router,
 	searchCtrl *keywordsearch.Controller,
 	gitspaceCtrl *gitspace.Controller,
 	migrateCtrl *migrate.Controller,
+	userGroupCtrl *usergroup.Controller,
 ) {
-	setupSpaces(r, appCtx, spaceCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
 		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl)
 	setupConnectors(r, connectorCtrl)
@@ -224,7 +232,12 @@ func setupRoutesV1(r chi.Router,
 }
 
 // nolint: revive // it's the app context, it shouldn't be the first argument
-func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controller) {
+func setupSpaces(
+	r chi.Router,
+	appCtx context.Context,
+	spaceCtrl *space.Controller,
+	userGroupCtrl *usergroup.Controller,
+) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerspace.HandleCreate(spaceCtrl))
@@ -249,6 +262,7 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 			r.Get("/connectors", handlerspace.HandleListConnectors(spaceCtrl))
 			r.Get("/templates", handlerspace.HandleListTemplates(spaceCtrl))
 			r.Get("/gitspaces", handlerspace.HandleListGitspaces(spaceCtrl))
+			r.Get("/audit-events", handlerspace.HandleListAuditEvents(spaceCtrl))
 			r.Post("/export", handlerspace.HandleExport(spaceCtrl))
 			r.Get("/export-progress", handlerspace.HandleExportProgress(spaceCtrl))
 			r.Post("/public-access", handlerspace.HandleUpdatePublicAccess(spaceCtrl))
@@ -261,6 +275,31 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 					r.Patch("/", handlerspace.HandleMembershipUpdate(spaceCtrl))
 				})
 			})
+
+			r.Route("/usergroups", func(r chi.Router) {
+				r.Get("/", handlerusergroup.HandleList(userGroupCtrl))
+				r.Post("/", handlerusergroup.HandleCreate(userGroupCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupIdentifier), func(r chi.Router) {
+					r.Get("/", handlerusergroup.HandleFind(userGroupCtrl))
+					r.Patch("/", handlerusergroup.HandleUpdate(userGroupCtrl))
+					r.Delete("/", handlerusergroup.HandleDelete(userGroupCtrl))
+					r.Route("/members", func(r chi.Router) {
+						r.Get("/", handlerusergroup.HandleMemberList(userGroupCtrl))
+						r.Post("/", handlerusergroup.HandleMemberAdd(userGroupCtrl))
+						r.Delete(fmt.Sprintf("/{%s}", request.PathParamUserUID),
+							handlerusergroup.HandleMemberRemove(userGroupCtrl))
+					})
+				})
+			})
+
+			r.Route("/usergroup-memberships", func(r chi.Router) {
+				r.Get("/", handlerusergroup.HandleMembershipList(userGroupCtrl))
+				r.Post("/", handlerusergroup.HandleMembershipAdd(userGroupCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupID), func(r chi.Router) {
+					r.Patch("/", handlerusergroup.HandleMembershipUpdate(userGroupCtrl))
+					r.Delete("/", handlerusergroup.HandleMembershipDelete(userGroupCtrl))
+				})
+			})
 		})
 	})
 }
@@ -290,6 +329,28 @@ func setupRepos(r chi.Router,
 			r.Post("/restore", handlerrepo.HandleRestore(repoCtrl))
 			r.Post("/public-access", handlerrepo.HandleUpdatePublicAccess(repoCtrl))
 
+			r.Post("/fork", handlerrepo.HandleFork(repoCtrl))
+			r.Get("/forks", handlerrepo.HandleListForks(repoCtrl))
+			r.Post("/upstream/sync", handlerrepo.HandleSyncFork(repoCtrl))
+
+			r.Route("/mirror", func(r chi.Router) {
+				r.Post("/", handlerrepo.HandleMirrorCreate(repoCtrl))
+				r.Get("/", handlerrepo.HandleMirrorFind(repoCtrl))
+				r.Patch("/", handlerrepo.HandleMirrorUpdate(repoCtrl))
+				r.Delete("/", handlerrepo.HandleMirrorDelete(repoCtrl))
+				r.Post("/sync", handlerrepo.HandleMirrorSync(repoCtrl))
+			})
+
+			r.Route("/push-mirrors", func(r chi.Router) {
+				r.Post("/", handlerrepo.HandlePushMirrorCreate(repoCtrl))
+				r.Get("/", handlerrepo.HandlePushMirrorList(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamPushMirrorIdentifier), func(r chi.Router) {
+					r.Get("/", handlerrepo.HandlePushMirrorFind(repoCtrl))
+					r.Patch("/", handlerrepo.HandlePushMirrorUpdate(repoCtrl))
+					r.Delete("/", handlerrepo.HandlePushMirrorDelete(repoCtrl))
+				})
+			})
+
 			r.Route("/settings", func(r chi.Router) {
 				r.Get("/security", handlerreposettings.HandleSecurityFind(repoSettingsCtrl))
 				r.Patch("/security", handlerreposettings.HandleSecurityUpdate(repoSettingsCtrl))
@@ -542,6 +603,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Route("/reviewers", func(r chi.Router) {
 				r.Get("/", handlerpullreq.HandleReviewerList(pullreqCtrl))
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
+				r.Put("/usergroups", handlerpullreq.HandleUserGroupReviewerAdd(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamReviewerID), func(r chi.Router) {
 					r.Delete("/", handlerpullreq.HandleReviewerDelete(pullreqCtrl))
 				})
@@ -550,6 +612,14 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisable(pullreqCtrl))
+			})
+			r.Route("/merge-queue", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleMergeQueueAdd(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMergeQueueRemove(pullreqCtrl))
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 
@@ -562,6 +632,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Post("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Get("/checks", handlerpullreq.HandleCheckList(pullreqCtrl))
+			r.Get("/checks/annotations", handlerpullreq.HandleCheckAnnotationList(pullreqCtrl))
 		})
 	})
 }
@@ -594,6 +665,11 @@ func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 		r.Route(fmt.Sprintf("/commits/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 			r.Put("/", handlercheck.HandleCheckReport(checkCtrl))
 			r.Get("/", handlercheck.HandleCheckList(checkCtrl))
+			r.Post("/sarif", handlercheck.HandleCheckReportSARIF(checkCtrl))
+			r.Route(fmt.Sprintf("/{%s}/annotations", request.PathParamCheckIdentifier), func(r chi.Router) {
+				r.Put("/", handlercheck.HandleCheckAnnotationsReport(checkCtrl))
+				r.Get("/", handlercheck.HandleCheckAnnotationsList(checkCtrl))
+			})
 		})
 	})
 }
@@ -714,6 +790,7 @@ func setupGitspaces(r chi.Router, gitspacesCtrl *gitspace.Controller) {
 func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
+		r.Get("/audit-events", handleruser.HandleListAuditEvents(userCtrl))
 		r.Route("/users", func(r chi.Router) {
 			r.Get("/", users.HandleList(userCtrl))
 			r.Post("/", users.HandleCreate(userCtrl))
@@ -18,14 +18,18 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/repo"
+	handlerlfs "github.com/harness/gitness/app/api/handler/lfs"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	middlewaretracing "github.com/harness/gitness/app/api/middleware/tracing"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/metrics"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/types/enum"
 
@@ -44,6 +48,7 @@ func NewGitHandler(
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
 ) GitHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -51,12 +56,14 @@ func NewGitHandler(
 	// Apply common api middleware.
 	r.Use(middleware.NoCache)
 	r.Use(middleware.Recoverer)
+	r.Use(middlewaretracing.HTTPHandler("git"))
 
 	// configure logging middleware.
 	r.Use(hlog.URLHandler("http.url"))
 	r.Use(hlog.MethodHandler("http.method"))
 	r.Use(logging.HLogRequestIDHandler())
 	r.Use(logging.HLogAccessLogHandler())
+	r.Use(metrics.Middleware("git"))
 
 	// for now always attempt auth - enforced per operation.
 	r.Use(middlewareauthn.Attempt(authenticator))
@@ -88,6 +95,11 @@ func NewGitHandler(
 			r.Get("/objects/{head:[0-9a-f]{2}}/{hash:[0-9a-f]{38}}", stubGitHandler())
 			r.Get("/objects/pack/pack-{file:[0-9a-f]{40}}.pack", stubGitHandler())
 			r.Get("/objects/pack/pack-{file:[0-9a-f]{40}}.idx", stubGitHandler())
+
+			// git lfs
+			r.Route("/info/lfs", func(r chi.Router) {
+				setupLFS(r, urlProvider, lfsCtrl)
+			})
 		})
 	})
 
@@ -95,6 +107,22 @@ func NewGitHandler(
 	return encode.GitPathBefore(r)
 }
 
+func setupLFS(r chi.Router, urlProvider url.Provider, lfsCtrl *lfs.Controller) {
+	r.Route("/objects", func(r chi.Router) {
+		r.Post("/batch", handlerlfs.HandleBatch(lfsCtrl, urlProvider))
+		r.Put(fmt.Sprintf("/{%s}", request.PathParamLFSObjectID), handlerlfs.HandleUpload(lfsCtrl, urlProvider))
+		r.Get(fmt.Sprintf("/{%s}", request.PathParamLFSObjectID), handlerlfs.HandleDownload(lfsCtrl, urlProvider))
+	})
+	r.Post("/verify", handlerlfs.HandleVerify(lfsCtrl, urlProvider))
+
+	r.Route("/locks", func(r chi.Router) {
+		r.Get("/", handlerlfs.HandleLockList(lfsCtrl, urlProvider))
+		r.Post("/", handlerlfs.HandleLockCreate(lfsCtrl, urlProvider))
+		r.Post("/verify", handlerlfs.HandleLockVerify(lfsCtrl, urlProvider))
+		r.Post(fmt.Sprintf("/{%s}/unlock", request.PathParamLFSLockID), handlerlfs.HandleUnlock(lfsCtrl, urlProvider))
+	})
+}
+
 func stubGitHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write([]byte("Seems like an asteroid destroyed the ancient git protocol"))
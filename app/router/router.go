@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/metrics"
 	"github.com/harness/gitness/app/request"
 
 	"github.com/go-logr/logr"
@@ -28,14 +29,16 @@ import (
 )
 
 const (
-	APIMount = "/api"
-	GitMount = "/git"
+	APIMount     = "/api"
+	GitMount     = "/git"
+	MetricsMount = "/metrics"
 )
 
 type Router struct {
-	api APIHandler
-	git GitHandler
-	web WebHandler
+	api     APIHandler
+	git     GitHandler
+	web     WebHandler
+	metrics metrics.Handler
 
 	// gitHost describes the optional host via which git traffic is identified.
 	// Note: always stored as lowercase.
@@ -48,12 +51,14 @@ func NewRouter(
 	api APIHandler,
 	git GitHandler,
 	web WebHandler,
+	metrics metrics.Handler,
 	gitHost string,
 ) *Router {
 	return &Router{
-		api: api,
-		git: git,
-		web: web,
+		api:     api,
+		git:     git,
+		web:     web,
+		metrics: metrics,
 
 		gitHost: strings.ToLower(gitHost),
 	}
@@ -72,6 +77,20 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			Str("http.original_url", req.URL.String())
 	})
 
+	/*
+	 * 0. METRICS
+	 *
+	 * The metrics endpoint is only served if it's enabled.
+	 */
+	if r.metrics != nil && req.URL.Path == MetricsMount {
+		log.UpdateContext(func(c zerolog.Context) zerolog.Context {
+			return c.Str("http.handler", "metrics")
+		})
+
+		r.metrics.ServeHTTP(w, req)
+		return
+	}
+
 	/*
 	 * 1. GIT
 	 *
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
 	"github.com/harness/gitness/app/api/controller/pipeline"
@@ -40,9 +41,11 @@ import (
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/metrics"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
@@ -62,6 +65,7 @@ func ProvideRouter(
 	api APIHandler,
 	git GitHandler,
 	web WebHandler,
+	metrics metrics.Handler,
 	urlProvider url.Provider,
 ) *Router {
 	// use url provider as it has the latest data.
@@ -75,18 +79,20 @@ func ProvideRouter(
 		gitRoutingHost = gitHostname
 	}
 
-	return NewRouter(api, git, web, gitRoutingHost)
+	return NewRouter(api, git, web, metrics, gitRoutingHost)
 }
 
 func ProvideGitHandler(
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
 ) GitHandler {
 	return NewGitHandler(
 		urlProvider,
 		authenticator,
 		repoCtrl,
+		lfsCtrl,
 	)
 }
 
@@ -118,12 +124,13 @@ func ProvideAPIHandler(
 	searchCtrl *keywordsearch.Controller,
 	gitspaceCtrl *gitspace.Controller,
 	migrateCtrl *migrate.Controller,
+	userGroupCtrl *usergroup.Controller,
 ) APIHandler {
 	return NewAPIHandler(appCtx, config,
 		authenticator, repoCtrl, repoSettingsCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
 		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
 		githookCtrl, git, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl,
-		migrateCtrl, gitspaceCtrl)
+		migrateCtrl, gitspaceCtrl, userGroupCtrl)
 }
 
 func ProvideWebHandler(config *types.Config, openapi openapi.Service) WebHandler {
@@ -0,0 +1,326 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	controllerpullreq "github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxPullReqsPerCheck is the maximum number of pull requests evaluated for a single reported check.
+const maxPullReqsPerCheck = 100
+
+// maxPullReqsPerBranch is the maximum number of pull requests evaluated for a single target branch update.
+const maxPullReqsPerBranch = 100
+
+// errAutoMergeDisabled is returned if auto-merge of the pull request has already been disabled.
+var errAutoMergeDisabled = errors.New("auto-merge is disabled")
+
+func (s *Service) mergeOnAutoMergeEnabled(ctx context.Context,
+	event *events.Event[*pullreqevents.AutoMergeEnabledPayload],
+) error {
+	return s.mergePullReqByID(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) mergeOnReviewSubmitted(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	if event.Payload.Decision != enum.PullReqReviewDecisionApproved {
+		return nil
+	}
+
+	return s.mergePullReqByID(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) mergeOnReviewerAdded(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerAddedPayload],
+) error {
+	return s.mergePullReqByID(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) mergeOnReviewerRemoved(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerRemovedPayload],
+) error {
+	return s.mergePullReqByID(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) mergeOnCommentStatusUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.CommentStatusUpdatedPayload],
+) error {
+	if !event.Payload.Resolved {
+		return nil
+	}
+
+	return s.mergePullReqByID(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) mergeOnMergeCheckCompleted(ctx context.Context,
+	event *events.Event[*pullreqevents.MergeCheckCompletedPayload],
+) error {
+	return s.mergePullReqByID(ctx, event.Payload.PullReqID)
+}
+
+// mergeOnTargetBranchUpdated re-evaluates the pull requests targeting the updated branch,
+// because the update might have changed the code owners of the pull requests.
+func (s *Service) mergeOnTargetBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	branch := strings.TrimPrefix(event.Payload.Ref, api.BranchPrefix)
+
+	prs, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Size:         maxPullReqsPerBranch,
+		TargetRepoID: event.Payload.RepoID,
+		TargetBranch: branch,
+		AutoMerge:    true,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests with auto-merge enabled: %w", err)
+	}
+
+	for _, pr := range prs {
+		if err = s.mergePullReq(ctx, pr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) mergeOnCheckStatusReported(ctx context.Context,
+	event *events.Event[*checkevents.StatusReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	prs, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Size:         maxPullReqsPerCheck,
+		TargetRepoID: event.Payload.RepoID,
+		SourceSHA:    event.Payload.CommitSHA,
+		AutoMerge:    true,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests with auto-merge enabled: %w", err)
+	}
+
+	for _, pr := range prs {
+		if err = s.mergePullReq(ctx, pr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) mergePullReqByID(ctx context.Context, pullreqID int64) error {
+	pr, err := s.pullreqStore.Find(ctx, pullreqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	return s.mergePullReq(ctx, pr)
+}
+
+// mergePullReq merges the pull request on behalf of the principal who enabled auto-merge.
+// If the pull request doesn't yet satisfy all protection rules, auto-merge stays enabled and the pull request
+// will be re-evaluated on the next event. If the target branch requires the merge queue, the pull request is
+// added to the merge queue instead. If the merge fails for any other reason, auto-merge gets disabled.
+func (s *Service) mergePullReq(ctx context.Context, pr *types.PullReq) error {
+	if pr.AutoMergeMethod == nil || pr.AutoMergeBy == nil || pr.State != enum.PullReqStateOpen || pr.IsDraft {
+		return nil
+	}
+
+	method := *pr.AutoMergeMethod
+
+	principal, err := s.principalStore.Find(ctx, *pr.AutoMergeBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal who enabled auto-merge: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	session := &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}
+
+	_, violations, err := s.pullreqCtrl.Merge(ctx, session, repo.Path, pr.Number, &controllerpullreq.MergeInput{
+		Method:    method,
+		SourceSHA: pr.SourceSHA,
+	})
+	if err != nil {
+		return s.handleMergeError(ctx, pr, method, err)
+	}
+
+	if violations != nil {
+		if len(violations.ConflictFiles) > 0 {
+			return s.disable(ctx, pr, method, enum.PullReqAutoMergeActionFailed,
+				"The pull request has merge conflicts.")
+		}
+
+		if protection.IsMergeQueueRequired(violations.RuleViolations) {
+			return s.addToMergeQueue(ctx, session, repo, pr, method)
+		}
+
+		log.Ctx(ctx).Debug().Msgf("pull request %d isn't ready for auto-merge yet", pr.Number)
+
+		return nil
+	}
+
+	pr, err = s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		return fmt.Errorf("failed to update activity sequence of pull request: %w", err)
+	}
+
+	s.writeActivity(ctx, pr, principal.ID, &types.PullRequestActivityPayloadAutoMerge{
+		Action:      enum.PullReqAutoMergeActionMerged,
+		MergeMethod: method,
+		SourceSHA:   pr.SourceSHA,
+	})
+
+	return nil
+}
+
+// addToMergeQueue adds the pull request to the merge queue of the target branch on behalf of the principal
+// who enabled auto-merge. Once added, the merge queue merges the pull request and auto-merge is disabled.
+func (s *Service) addToMergeQueue(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+) error {
+	_, violations, err := s.pullreqCtrl.MergeQueueAdd(ctx, session, repo.Path, pr.Number,
+		&controllerpullreq.MergeQueueAddInput{
+			SourceSHA: pr.SourceSHA,
+		})
+	if err != nil {
+		return s.handleMergeError(ctx, pr, method, err)
+	}
+
+	if violations != nil {
+		log.Ctx(ctx).Debug().Msgf("pull request %d isn't ready for the merge queue yet", pr.Number)
+
+		return nil
+	}
+
+	return s.disable(ctx, pr, method, enum.PullReqAutoMergeActionQueued, "")
+}
+
+// handleMergeError disables auto-merge of the pull request if the merge has been rejected.
+// Server errors are returned, so the event gets retried.
+func (s *Service) handleMergeError(
+	ctx context.Context,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+	err error,
+) error {
+	uErr := usererror.Translate(ctx, err)
+	if uErr.Status >= http.StatusInternalServerError || uErr.Status == http.StatusLocked {
+		return fmt.Errorf("failed to auto-merge pull request: %w", err)
+	}
+
+	latest, errFind := s.pullreqStore.Find(ctx, pr.ID)
+	if errFind != nil {
+		return fmt.Errorf("failed to find pull request: %w", errFind)
+	}
+
+	if latest.SourceSHA != pr.SourceSHA {
+		return s.disable(ctx, latest, method, enum.PullReqAutoMergeActionCanceled,
+			"New commits were pushed to the source branch.")
+	}
+
+	return s.disable(ctx, latest, method, enum.PullReqAutoMergeActionFailed, uErr.Message)
+}
+
+// disable disables auto-merge of the pull request and records the action and the reason
+// as a pull request activity. Nothing is recorded if auto-merge has already been disabled.
+func (s *Service) disable(
+	ctx context.Context,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+	action enum.PullReqAutoMergeAction,
+	reason string,
+) error {
+	if pr.AutoMergeBy == nil {
+		return nil
+	}
+
+	principalID := *pr.AutoMergeBy
+
+	pr, err := s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.AutoMergeMethod == nil {
+			return errAutoMergeDisabled
+		}
+
+		pr.AutoMergeMethod = nil
+		pr.AutoMergeBy = nil
+		pr.ActivitySeq++
+		return nil
+	})
+	if errors.Is(err, errAutoMergeDisabled) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to disable auto-merge of pull request: %w", err)
+	}
+
+	s.writeActivity(ctx, pr, principalID, &types.PullRequestActivityPayloadAutoMerge{
+		Action:      action,
+		MergeMethod: method,
+		SourceSHA:   pr.SourceSHA,
+		Reason:      reason,
+	})
+
+	return nil
+}
+
+func (s *Service) writeActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadAutoMerge,
+) {
+	if _, err := s.activityStore.CreateWithPayload(ctx, pr, principalID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request auto-merge activity")
+	}
+}
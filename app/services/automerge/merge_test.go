@@ -0,0 +1,729 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	controllerpullreq "github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testRepoID      = 1
+	testPrincipalID = 2
+	testSourceSHA   = "1111111111111111111111111111111111111111"
+	testNewSHA      = "2222222222222222222222222222222222222222"
+)
+
+var queueRequiredViolations = []types.RuleViolations{{
+	Rule:       types.RuleInfo{State: enum.RuleStateActive},
+	Violations: []types.Violation{{Code: "pullreq.merge.queue_required"}},
+}}
+
+//nolint:gocognit // it's a unit test
+func TestMergePullReq(t *testing.T) {
+	tests := []struct {
+		name            string
+		prFn            func(pr *types.PullReq)
+		violations      *types.MergeViolations
+		err             error
+		queueViolations []types.RuleViolations
+		queueErr        error
+		onMerge         func(pr *types.PullReq)
+		wantErr         bool
+		wantMerges      int
+		wantQueueAdds   int
+		wantEnabled     bool
+		wantAction      enum.PullReqAutoMergeAction
+		wantReason      string
+	}{
+		{
+			name:        "merged",
+			wantMerges:  1,
+			wantEnabled: true, // the pull request is merged, so auto-merge doesn't have to be disabled.
+			wantAction:  enum.PullReqAutoMergeActionMerged,
+		},
+		{
+			name:        "draft isn't merged",
+			prFn:        func(pr *types.PullReq) { pr.IsDraft = true },
+			wantEnabled: true,
+		},
+		{
+			name: "auto-merge not enabled",
+			prFn: func(pr *types.PullReq) {
+				pr.AutoMergeMethod = nil
+				pr.AutoMergeBy = nil
+			},
+		},
+		{
+			name: "rules not satisfied yet",
+			violations: &types.MergeViolations{RuleViolations: []types.RuleViolations{{
+				Rule:       types.RuleInfo{State: enum.RuleStateActive},
+				Violations: []types.Violation{{Code: "pullreq.approvals.require_minimum_count"}},
+			}}},
+			wantMerges:  1,
+			wantEnabled: true,
+		},
+		{
+			name:       "merge conflicts",
+			violations: &types.MergeViolations{ConflictFiles: []string{"file.txt"}},
+			wantMerges: 1,
+			wantAction: enum.PullReqAutoMergeActionFailed,
+			wantReason: "merge conflicts",
+		},
+		{
+			name:       "merge conflicts after auto-merge got disabled concurrently",
+			violations: &types.MergeViolations{ConflictFiles: []string{"file.txt"}},
+			onMerge: func(pr *types.PullReq) {
+				pr.AutoMergeMethod = nil
+				pr.AutoMergeBy = nil
+			},
+			wantMerges: 1,
+		},
+		{
+			name:          "merge queue required",
+			violations:    &types.MergeViolations{RuleViolations: queueRequiredViolations},
+			wantMerges:    1,
+			wantQueueAdds: 1,
+			wantAction:    enum.PullReqAutoMergeActionQueued,
+		},
+		{
+			name:       "merge queue required but not ready yet",
+			violations: &types.MergeViolations{RuleViolations: queueRequiredViolations},
+			queueViolations: []types.RuleViolations{{
+				Rule:       types.RuleInfo{State: enum.RuleStateActive},
+				Violations: []types.Violation{{Code: "pullreq.approvals.require_minimum_count"}},
+			}},
+			wantMerges:    1,
+			wantQueueAdds: 1,
+			wantEnabled:   true,
+		},
+		{
+			name:          "merge queue rejects the pull request",
+			violations:    &types.MergeViolations{RuleViolations: queueRequiredViolations},
+			queueErr:      usererror.BadRequest("The pull request is already in the merge queue."),
+			wantMerges:    1,
+			wantQueueAdds: 1,
+			wantAction:    enum.PullReqAutoMergeActionFailed,
+			wantReason:    "already in the merge queue",
+		},
+		{
+			name:       "merge rejected",
+			err:        usererror.BadRequest("Merge method not allowed."),
+			wantMerges: 1,
+			wantAction: enum.PullReqAutoMergeActionFailed,
+			wantReason: "Merge method not allowed.",
+		},
+		{
+			name:       "new commits pushed to the source branch",
+			err:        usererror.BadRequest("The source SHA doesn't match."),
+			onMerge:    func(pr *types.PullReq) { pr.SourceSHA = testNewSHA },
+			wantMerges: 1,
+			wantAction: enum.PullReqAutoMergeActionCanceled,
+			wantReason: "New commits",
+		},
+		{
+			name: "merge rejected after auto-merge got disabled concurrently",
+			err:  usererror.BadRequest("Merge method not allowed."),
+			onMerge: func(pr *types.PullReq) {
+				pr.AutoMergeMethod = nil
+				pr.AutoMergeBy = nil
+			},
+			wantMerges: 1,
+		},
+		{
+			name:        "server error is retried",
+			err:         usererror.New(http.StatusInternalServerError, "Internal error."),
+			wantErr:     true,
+			wantMerges:  1,
+			wantEnabled: true,
+		},
+		{
+			name:        "locked pull request is retried",
+			err:         usererror.New(http.StatusLocked, "The pull request is being merged."),
+			wantErr:     true,
+			wantMerges:  1,
+			wantEnabled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			pr := newPullReq(1)
+			if test.prFn != nil {
+				test.prFn(pr)
+			}
+
+			env := newTestEnv(pr)
+			env.merger.violations = test.violations
+			env.merger.err = test.err
+			env.merger.queueViolations = test.queueViolations
+			env.merger.queueErr = test.queueErr
+			if test.onMerge != nil {
+				env.merger.onMerge = func() { env.pullreqs.modify(pr.ID, test.onMerge) }
+			}
+
+			err := env.svc.mergePullReq(ctx, pr)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("mergePullReq() error = %v, wantErr %t", err, test.wantErr)
+			}
+
+			if want, got := test.wantMerges, len(env.merger.merges); want != got {
+				t.Errorf("want %d merge attempts, got %d", want, got)
+			}
+			for _, in := range env.merger.merges {
+				if in.Method != enum.MergeMethodSquash || in.SourceSHA != testSourceSHA {
+					t.Errorf("want squash merge of %s, got %s merge of %s", testSourceSHA, in.Method, in.SourceSHA)
+				}
+			}
+			if want, got := test.wantQueueAdds, env.merger.queueAdds; want != got {
+				t.Errorf("want %d merge queue additions, got %d", want, got)
+			}
+
+			stored, _ := env.pullreqs.Find(ctx, pr.ID)
+			if want, got := test.wantEnabled, stored.AutoMergeMethod != nil; want != got {
+				t.Errorf("want auto-merge enabled=%t, got %t", want, got)
+			}
+
+			payloads := env.activities.payloads
+			if test.wantAction == "" {
+				if len(payloads) != 0 {
+					t.Fatalf("want no activity, got %+v", payloads[0])
+				}
+				return
+			}
+
+			if len(payloads) != 1 {
+				t.Fatalf("want one activity, got %d", len(payloads))
+			}
+			if want, got := test.wantAction, payloads[0].Action; want != got {
+				t.Errorf("want action %q, got %q", want, got)
+			}
+			if !strings.Contains(payloads[0].Reason, test.wantReason) {
+				t.Errorf("want reason containing %q, got %q", test.wantReason, payloads[0].Reason)
+			}
+		})
+	}
+}
+
+func TestMergeOnCheckStatusReported(t *testing.T) {
+	ctx := context.Background()
+
+	env := newTestEnv(newPullReq(1), newPullReq(2))
+
+	err := env.svc.mergeOnCheckStatusReported(ctx, &events.Event[*checkevents.StatusReportedPayload]{
+		Payload: &checkevents.StatusReportedPayload{
+			RepoID:    testRepoID,
+			CommitSHA: testSourceSHA,
+			Status:    enum.CheckStatusRunning,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to handle running check: %v", err)
+	}
+	if len(env.pullreqs.filters) != 0 || len(env.merger.merges) != 0 {
+		t.Fatalf("want running check to be ignored")
+	}
+
+	err = env.svc.mergeOnCheckStatusReported(ctx, &events.Event[*checkevents.StatusReportedPayload]{
+		Payload: &checkevents.StatusReportedPayload{
+			RepoID:    testRepoID,
+			CommitSHA: testSourceSHA,
+			Status:    enum.CheckStatusSuccess,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to handle completed check: %v", err)
+	}
+
+	if len(env.pullreqs.filters) != 1 {
+		t.Fatalf("want pull requests to be listed once, got %d", len(env.pullreqs.filters))
+	}
+	filter := env.pullreqs.filters[0]
+	if filter.TargetRepoID != testRepoID || filter.SourceSHA != testSourceSHA || !filter.AutoMerge {
+		t.Errorf("unexpected pull request filter: %+v", filter)
+	}
+	if want, got := 2, len(env.merger.merges); want != got {
+		t.Errorf("want %d merge attempts, got %d", want, got)
+	}
+}
+
+func TestMergeOnTargetBranchUpdated(t *testing.T) {
+	ctx := context.Background()
+
+	env := newTestEnv(newPullReq(1), newPullReq(2))
+
+	err := env.svc.mergeOnTargetBranchUpdated(ctx, &events.Event[*gitevents.BranchUpdatedPayload]{
+		Payload: &gitevents.BranchUpdatedPayload{
+			RepoID: testRepoID,
+			Ref:    "refs/heads/main",
+			NewSHA: testNewSHA,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to handle branch update: %v", err)
+	}
+
+	if len(env.pullreqs.filters) != 1 {
+		t.Fatalf("want pull requests to be listed once, got %d", len(env.pullreqs.filters))
+	}
+	filter := env.pullreqs.filters[0]
+	if filter.TargetRepoID != testRepoID || filter.TargetBranch != "main" || !filter.AutoMerge {
+		t.Errorf("unexpected pull request filter: %+v", filter)
+	}
+	if want, got := 2, len(env.merger.merges); want != got {
+		t.Errorf("want %d merge attempts, got %d", want, got)
+	}
+}
+
+func TestMergeOnPullReqEvents(t *testing.T) {
+	base := pullreqevents.Base{PullReqID: 1, TargetRepoID: testRepoID}
+
+	tests := []struct {
+		name      string
+		handle    func(ctx context.Context, s *Service) error
+		wantMerge bool
+	}{
+		{
+			name: "approved review",
+			handle: func(ctx context.Context, s *Service) error {
+				return s.mergeOnReviewSubmitted(ctx, &events.Event[*pullreqevents.ReviewSubmittedPayload]{
+					Payload: &pullreqevents.ReviewSubmittedPayload{
+						Base:     base,
+						Decision: enum.PullReqReviewDecisionApproved,
+					},
+				})
+			},
+			wantMerge: true,
+		},
+		{
+			name: "change request",
+			handle: func(ctx context.Context, s *Service) error {
+				return s.mergeOnReviewSubmitted(ctx, &events.Event[*pullreqevents.ReviewSubmittedPayload]{
+					Payload: &pullreqevents.ReviewSubmittedPayload{
+						Base:     base,
+						Decision: enum.PullReqReviewDecisionChangeReq,
+					},
+				})
+			},
+		},
+		{
+			name: "resolved comment",
+			handle: func(ctx context.Context, s *Service) error {
+				return s.mergeOnCommentStatusUpdated(ctx, &events.Event[*pullreqevents.CommentStatusUpdatedPayload]{
+					Payload: &pullreqevents.CommentStatusUpdatedPayload{Base: base, Resolved: true},
+				})
+			},
+			wantMerge: true,
+		},
+		{
+			name: "reopened comment",
+			handle: func(ctx context.Context, s *Service) error {
+				return s.mergeOnCommentStatusUpdated(ctx, &events.Event[*pullreqevents.CommentStatusUpdatedPayload]{
+					Payload: &pullreqevents.CommentStatusUpdatedPayload{Base: base, Resolved: false},
+				})
+			},
+		},
+		{
+			name: "reviewer removed",
+			handle: func(ctx context.Context, s *Service) error {
+				return s.mergeOnReviewerRemoved(ctx, &events.Event[*pullreqevents.ReviewerRemovedPayload]{
+					Payload: &pullreqevents.ReviewerRemovedPayload{Base: base},
+				})
+			},
+			wantMerge: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := newTestEnv(newPullReq(1))
+
+			if err := test.handle(context.Background(), env.svc); err != nil {
+				t.Fatalf("failed to handle event: %v", err)
+			}
+
+			if want, got := test.wantMerge, len(env.merger.merges) == 1; want != got {
+				t.Errorf("want merge attempted=%t, got %t", want, got)
+			}
+		})
+	}
+}
+
+func TestService_Events(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		Namespace:       "test",
+		MaxStreamLength: 100,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+
+	pullreqReaderFactory, err := pullreqevents.NewReaderFactory(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reader factory: %v", err)
+	}
+	checkReaderFactory, err := checkevents.NewReaderFactory(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create check event reader factory: %v", err)
+	}
+	gitReaderFactory, err := gitevents.NewReaderFactory(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create git event reader factory: %v", err)
+	}
+
+	pullreqReporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reporter: %v", err)
+	}
+	checkReporter, err := checkevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create check event reporter: %v", err)
+	}
+	gitReporter, err := gitevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create git event reporter: %v", err)
+	}
+
+	prCheck := newPullReq(2)
+	prCheck.SourceSHA = testNewSHA
+
+	prBranch := newPullReq(3)
+	prBranch.TargetBranch = "release"
+
+	env := newTestEnv(newPullReq(1), prCheck, prBranch)
+	// the pull requests aren't mergeable to keep auto-merge enabled for all events.
+	env.merger.violations = &types.MergeViolations{RuleViolations: []types.RuleViolations{{
+		Rule:       types.RuleInfo{State: enum.RuleStateActive},
+		Violations: []types.Violation{{Code: "pullreq.approvals.require_minimum_count"}},
+	}}}
+
+	svc, err := NewService(ctx, &types.Config{InstanceID: "test"},
+		pullreqReaderFactory, checkReaderFactory, gitReaderFactory,
+		nil, env.pullreqs, env.activities, env.repos, env.principals)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+	svc.pullreqCtrl = env.merger
+
+	waitForMerge(t, env.merger, 1, func() {
+		pullreqReporter.AutoMergeEnabled(ctx, &pullreqevents.AutoMergeEnabledPayload{
+			Base:        pullreqevents.Base{PullReqID: 1, TargetRepoID: testRepoID},
+			MergeMethod: enum.MergeMethodSquash,
+		})
+	})
+
+	waitForMerge(t, env.merger, prCheck.Number, func() {
+		checkReporter.StatusReported(ctx, &checkevents.StatusReportedPayload{
+			RepoID:    testRepoID,
+			CommitSHA: testNewSHA,
+			Status:    enum.CheckStatusSuccess,
+		})
+	})
+
+	waitForMerge(t, env.merger, prBranch.Number, func() {
+		gitReporter.BranchUpdated(ctx, &gitevents.BranchUpdatedPayload{
+			RepoID: testRepoID,
+			Ref:    "refs/heads/release",
+			NewSHA: testNewSHA,
+		})
+	})
+}
+
+// waitForMerge publishes the event until the pull request gets merged.
+// The event is published repeatedly because the readers might not be subscribed yet.
+func waitForMerge(t *testing.T, m *fakeMerger, pullreqNum int64, publish func()) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		publish()
+
+		for i := 0; i < 10; i++ {
+			if m.merged(pullreqNum) {
+				return
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	t.Fatalf("want pull request %d to be merged before timeout", pullreqNum)
+}
+
+type testEnv struct {
+	svc        *Service
+	merger     *fakeMerger
+	pullreqs   *fakePullReqStore
+	activities *fakeActivityStore
+	repos      *fakeRepoStore
+	principals *fakePrincipalStore
+}
+
+func newTestEnv(prs ...*types.PullReq) *testEnv {
+	env := &testEnv{
+		merger:     &fakeMerger{},
+		pullreqs:   &fakePullReqStore{prs: map[int64]*types.PullReq{}},
+		activities: &fakeActivityStore{},
+		repos: &fakeRepoStore{repo: &types.Repository{
+			ID:            testRepoID,
+			Path:          "space/repo",
+			DefaultBranch: "main",
+		}},
+		principals: &fakePrincipalStore{},
+	}
+
+	for _, pr := range prs {
+		stored := *pr
+		env.pullreqs.prs[pr.ID] = &stored
+	}
+
+	env.svc = &Service{
+		pullreqCtrl:    env.merger,
+		pullreqStore:   env.pullreqs,
+		activityStore:  env.activities,
+		repoStore:      env.repos,
+		principalStore: env.principals,
+	}
+
+	return env
+}
+
+func newPullReq(id int64) *types.PullReq {
+	method := enum.MergeMethodSquash
+	principalID := int64(testPrincipalID)
+
+	return &types.PullReq{
+		ID:              id,
+		Number:          id,
+		State:           enum.PullReqStateOpen,
+		TargetRepoID:    testRepoID,
+		SourceRepoID:    testRepoID,
+		SourceBranch:    "feature",
+		SourceSHA:       testSourceSHA,
+		TargetBranch:    "main",
+		AutoMergeMethod: &method,
+		AutoMergeBy:     &principalID,
+	}
+}
+
+type fakeMerger struct {
+	mu              sync.Mutex
+	merges          []controllerpullreq.MergeInput
+	pullreqNums     []int64
+	queueAdds       int
+	violations      *types.MergeViolations
+	err             error
+	queueViolations []types.RuleViolations
+	queueErr        error
+	onMerge         func()
+}
+
+func (m *fakeMerger) Merge(
+	_ context.Context,
+	_ *auth.Session,
+	_ string,
+	pullreqNum int64,
+	in *controllerpullreq.MergeInput,
+) (*types.MergeResponse, *types.MergeViolations, error) {
+	m.mu.Lock()
+	m.merges = append(m.merges, *in)
+	m.pullreqNums = append(m.pullreqNums, pullreqNum)
+	m.mu.Unlock()
+
+	if m.onMerge != nil {
+		m.onMerge()
+	}
+
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	if m.violations != nil {
+		return nil, m.violations, nil
+	}
+
+	return &types.MergeResponse{SHA: testNewSHA}, nil, nil
+}
+
+func (m *fakeMerger) merged(pullreqNum int64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, num := range m.pullreqNums {
+		if num == pullreqNum {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *fakeMerger) MergeQueueAdd(
+	context.Context,
+	*auth.Session,
+	string,
+	int64,
+	*controllerpullreq.MergeQueueAddInput,
+) (*types.MergeQueueEntry, []types.RuleViolations, error) {
+	m.mu.Lock()
+	m.queueAdds++
+	m.mu.Unlock()
+
+	if m.queueErr != nil {
+		return nil, nil, m.queueErr
+	}
+	if m.queueViolations != nil {
+		return nil, m.queueViolations, nil
+	}
+
+	return &types.MergeQueueEntry{}, nil, nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	mu      sync.Mutex
+	prs     map[int64]*types.PullReq
+	filters []types.PullReqFilter
+}
+
+func (s *fakePullReqStore) modify(id int64, fn func(pr *types.PullReq)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn(s.prs[id])
+}
+
+func (s *fakePullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	result := *pr
+	return &result, nil
+}
+
+func (s *fakePullReqStore) List(_ context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.filters = append(s.filters, *opts)
+
+	result := make([]*types.PullReq, 0, len(s.prs))
+	for id := int64(1); id <= int64(len(s.prs)); id++ {
+		pr := *s.prs[id]
+		if (opts.SourceSHA != "" && opts.SourceSHA != pr.SourceSHA) ||
+			(opts.TargetBranch != "" && opts.TargetBranch != pr.TargetBranch) {
+			continue
+		}
+
+		result = append(result, &pr)
+	}
+
+	return result, nil
+}
+
+func (s *fakePullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// like the real store, the mutation is applied to the latest version of the pull request.
+	latest := *s.prs[pr.ID]
+	if err := mutateFn(&latest); err != nil {
+		return nil, err
+	}
+
+	latest.Version++
+	s.prs[pr.ID] = &latest
+
+	result := latest
+	return &result, nil
+}
+
+func (s *fakePullReqStore) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	return s.UpdateOptLock(context.Background(), pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++
+		return nil
+	})
+}
+
+type fakeActivityStore struct {
+	store.PullReqActivityStore
+	mu       sync.Mutex
+	payloads []*types.PullRequestActivityPayloadAutoMerge
+}
+
+func (s *fakeActivityStore) CreateWithPayload(
+	_ context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+) (*types.PullReqActivity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.payloads = append(s.payloads, payload.(*types.PullRequestActivityPayloadAutoMerge))
+
+	return &types.PullReqActivity{PullReqID: pr.ID, CreatedBy: principalID}, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	if id != s.repo.ID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	return s.repo, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (s *fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	return &types.Principal{ID: id, UID: "user", Type: enum.PrincipalTypeUser}, nil
+}
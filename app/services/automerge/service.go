@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	controllerpullreq "github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/auth"
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	groupPullReq = "gitness:automerge:pullreq"
+	groupCheck   = "gitness:automerge:check"
+	groupGit     = "gitness:automerge:git"
+)
+
+// Service merges pull requests that have auto-merge enabled, as soon as they satisfy all protection rules.
+// Pull requests are re-evaluated whenever anything the protection rules depend on changes: auto-merge gets enabled,
+// a review is submitted, reviewers are added or removed, a comment is resolved or reopened, the mergeability check
+// completes, a check is reported or the target branch (and with it the code owners) is updated.
+// If the target branch requires the merge queue, the pull request is added to the merge queue instead.
+type Service struct {
+	pullreqCtrl    merger
+	pullreqStore   store.PullReqStore
+	activityStore  store.PullReqActivityStore
+	repoStore      store.RepoStore
+	principalStore store.PrincipalStore
+}
+
+// merger merges pull requests or adds them to the merge queue, it's implemented by the pull request controller.
+type merger interface {
+	Merge(
+		ctx context.Context,
+		session *auth.Session,
+		repoRef string,
+		pullreqNum int64,
+		in *controllerpullreq.MergeInput,
+	) (*types.MergeResponse, *types.MergeViolations, error)
+
+	MergeQueueAdd(
+		ctx context.Context,
+		session *auth.Session,
+		repoRef string,
+		pullreqNum int64,
+		in *controllerpullreq.MergeQueueAddInput,
+	) (*types.MergeQueueEntry, []types.RuleViolations, error)
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	gitEvReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqCtrl *controllerpullreq.Controller,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+) (*Service, error) {
+	service := &Service{
+		pullreqCtrl:    pullreqCtrl,
+		pullreqStore:   pullreqStore,
+		activityStore:  activityStore,
+		repoStore:      repoStore,
+		principalStore: principalStore,
+	}
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReq, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterAutoMergeEnabled(service.mergeOnAutoMergeEnabled)
+			_ = r.RegisterReviewSubmitted(service.mergeOnReviewSubmitted)
+			_ = r.RegisterReviewerAdded(service.mergeOnReviewerAdded)
+			_ = r.RegisterReviewerRemoved(service.mergeOnReviewerRemoved)
+			_ = r.RegisterCommentStatusUpdated(service.mergeOnCommentStatusUpdated)
+			_ = r.RegisterMergeCheckCompleted(service.mergeOnMergeCheckCompleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for auto-merge: %w", err)
+	}
+
+	_, err = checkEvReaderFactory.Launch(ctx, groupCheck, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterStatusReported(service.mergeOnCheckStatusReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for auto-merge: %w", err)
+	}
+
+	_, err = gitEvReaderFactory.Launch(ctx, groupGit, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchUpdated(service.mergeOnTargetBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for auto-merge: %w", err)
+	}
+
+	return service, nil
+}
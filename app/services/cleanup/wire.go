@@ -17,6 +17,7 @@ package cleanup
 import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
@@ -34,6 +35,7 @@ func ProvideService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	auditStore audit.Store,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -43,5 +45,6 @@ func ProvideService(
 		tokenStore,
 		repoStore,
 		repoCtrl,
+		auditStore,
 	)
 }
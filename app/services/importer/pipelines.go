@@ -116,6 +116,7 @@ func (r *Repository) processPipelines(ctx context.Context,
 			// We log an error on failure but don't fail the op.
 			trigger := &types.Trigger{
 				Description: "auto-created trigger on pipeline conversion",
+				Type:        enum.TriggerTypeHook,
 				Created:     nowMilli,
 				Updated:     nowMilli,
 				PipelineID:  pipeline.ID,
@@ -0,0 +1,226 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/harness/gitness/types"
+)
+
+// shardVersion is the version of the shard format. Shards of a different version are re-indexed.
+const shardVersion = 1
+
+// binaryDetectionLength is the number of leading bytes that are checked for NUL characters
+// to detect binary files (same heuristic as used by git).
+const binaryDetectionLength = 8000
+
+// trigram is a sequence of three (lower-cased) bytes packed into an integer.
+type trigram uint32
+
+func newTrigram(b []byte) trigram {
+	return trigram(b[0])<<16 | trigram(b[1])<<8 | trigram(b[2])
+}
+
+// shard is the trigram search index of the default branch of a single repository.
+type shard struct {
+	Version int
+	RepoID  int64
+	Branch  string
+	// CommitSHA is the commit of the branch the shard was built from.
+	CommitSHA string
+	Files     []indexedFile
+	// Trigrams maps every (lower-cased) trigram to the sorted indices of the files containing it.
+	Trigrams map[trigram][]uint32
+}
+
+// indexedFile is a single file stored in the search index.
+// The content isn't part of the index, it's read from git using the blob SHA.
+type indexedFile struct {
+	Path     string
+	Language string
+	BlobSHA  string
+	// LineOffsets contains the offset of the first byte of every line of the file.
+	LineOffsets []uint32
+}
+
+// fileContent is the raw content of a file that should be added to a shard.
+type fileContent struct {
+	Path    string
+	BlobSHA string
+	Content []byte
+}
+
+// newShard builds the trigram index for the provided files of the commit. Binary files are skipped.
+func newShard(repo *types.Repository, commitSHA string, files []fileContent) *shard {
+	s := &shard{
+		Version:   shardVersion,
+		RepoID:    repo.ID,
+		Branch:    repo.DefaultBranch,
+		CommitSHA: commitSHA,
+		Files:     make([]indexedFile, 0, len(files)),
+		Trigrams:  make(map[trigram][]uint32),
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	for _, f := range files {
+		if isBinary(f.Content) {
+			continue
+		}
+
+		idx := uint32(len(s.Files))
+		s.Files = append(s.Files, indexedFile{
+			Path:        f.Path,
+			Language:    detectLanguage(f.Path),
+			BlobSHA:     f.BlobSHA,
+			LineOffsets: lineOffsets(f.Content),
+		})
+
+		for t := range trigramsOf(f.Content) {
+			s.Trigrams[t] = append(s.Trigrams[t], idx)
+		}
+	}
+
+	return s
+}
+
+// candidates returns the indices of all files that contain all provided trigrams.
+// If no trigrams are provided, all files of the shard are candidates.
+func (s *shard) candidates(trigrams map[trigram]struct{}) []uint32 {
+	if len(trigrams) == 0 {
+		all := make([]uint32, len(s.Files))
+		for i := range all {
+			all[i] = uint32(i)
+		}
+		return all
+	}
+
+	postings := make([][]uint32, 0, len(trigrams))
+	for t := range trigrams {
+		list, ok := s.Trigrams[t]
+		if !ok {
+			return nil
+		}
+		postings = append(postings, list)
+	}
+
+	// intersect starting with the shortest posting list to keep the intermediate results small.
+	sort.Slice(postings, func(i, j int) bool {
+		return len(postings[i]) < len(postings[j])
+	})
+
+	result := postings[0]
+	for _, list := range postings[1:] {
+		result = intersect(result, list)
+		if len(result) == 0 {
+			return nil
+		}
+	}
+
+	return result
+}
+
+// encode writes the gzip compressed gob representation of the shard to the writer.
+func (s *shard) encode(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+
+	if err := gob.NewEncoder(zw).Encode(s); err != nil {
+		return fmt.Errorf("failed to encode shard: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to flush compressed shard: %w", err)
+	}
+
+	return nil
+}
+
+// decodeShard reads a shard previously written with shard.encode.
+func decodeShard(r io.Reader) (*shard, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open compressed shard: %w", err)
+	}
+	defer zr.Close()
+
+	s := &shard{}
+	if err := gob.NewDecoder(zr).Decode(s); err != nil {
+		return nil, fmt.Errorf("failed to decode shard: %w", err)
+	}
+
+	return s, nil
+}
+
+// trigramsOf returns the set of all lower-cased trigrams of the data.
+func trigramsOf(data []byte) map[trigram]struct{} {
+	data = bytes.ToLower(data)
+
+	result := make(map[trigram]struct{})
+	for i := 0; i+3 <= len(data); i++ {
+		result[newTrigram(data[i:i+3])] = struct{}{}
+	}
+
+	return result
+}
+
+// lineOffsets returns the offset of the first byte of every line of the data.
+func lineOffsets(data []byte) []uint32 {
+	offsets := []uint32{0}
+	for i, b := range data {
+		if b == '\n' {
+			offsets = append(offsets, uint32(i+1))
+		}
+	}
+
+	return offsets
+}
+
+func isBinary(data []byte) bool {
+	if len(data) > binaryDetectionLength {
+		data = data[:binaryDetectionLength]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// intersect returns the intersection of two sorted lists.
+func intersect(a, b []uint32) []uint32 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	result := make([]uint32, 0, n)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
@@ -22,6 +22,7 @@ import (
 
 type Indexer interface {
 	Index(ctx context.Context, repo *types.Repository) error
+	Delete(ctx context.Context, repoID int64) error
 }
 
 type Searcher interface {
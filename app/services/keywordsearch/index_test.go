@@ -0,0 +1,360 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	testCommitSHA = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	testNewSHA    = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+var testFiles = []fileContent{
+	{
+		Path:    "main.go",
+		BlobSHA: "1111111111111111111111111111111111111111",
+		Content: []byte("package main\n\nfunc main() {\n\tprintln(\"Hello World\")\n}\n"),
+	},
+	{
+		Path:    "README.md",
+		BlobSHA: "2222222222222222222222222222222222222222",
+		Content: []byte("# Hello\nA sample hello world project.\n"),
+	},
+	{
+		Path:    "logo.png",
+		BlobSHA: "3333333333333333333333333333333333333333",
+		Content: []byte("\x89PNG\x00\x00hello"),
+	},
+}
+
+func testShard() *shard {
+	repo := &types.Repository{ID: 1, DefaultBranch: "main"}
+	return newShard(repo, testCommitSHA, append([]fileContent(nil), testFiles...))
+}
+
+func testFileContent(blobSHA string) []byte {
+	for _, f := range testFiles {
+		if f.BlobSHA == blobSHA {
+			return f.Content
+		}
+	}
+	return nil
+}
+
+func searchShard(t *testing.T, sh *shard, q string, enableRegex bool) []string {
+	t.Helper()
+
+	query, err := newQuery(q, enableRegex)
+	if err != nil {
+		t.Fatalf("failed to compile query %q: %v", q, err)
+	}
+
+	var paths []string
+	for _, idx := range sh.candidates(query.trigrams) {
+		file := sh.Files[idx]
+		if len(query.matchFile(testFileContent(file.BlobSHA), file.LineOffsets)) > 0 {
+			paths = append(paths, sh.Files[idx].Path)
+		}
+	}
+
+	return paths
+}
+
+func TestShardSearch(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		enableRegex bool
+		want        []string
+	}{
+		{name: "case insensitive literal", query: "hello world", want: []string{"README.md", "main.go"}},
+		{name: "literal with special chars", query: "main()", want: []string{"main.go"}},
+		{name: "short literal", query: "# ", want: []string{"README.md"}},
+		{name: "no match", query: "goodbye", want: nil},
+		{name: "regex", query: `func \w+\(\)`, enableRegex: true, want: []string{"main.go"}},
+		{name: "case sensitive regex", query: `Hello World`, enableRegex: true, want: []string{"main.go"}},
+		{name: "regex alternation", query: `sample|println`, enableRegex: true, want: []string{"README.md", "main.go"}},
+	}
+
+	sh := testShard()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := searchShard(t, sh, test.query, test.enableRegex)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestShardEncodeDecode(t *testing.T) {
+	sh := testShard()
+
+	buf := &bytes.Buffer{}
+	if err := sh.encode(buf); err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	decoded, err := decodeShard(buf)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	if !reflect.DeepEqual(sh, decoded) {
+		t.Errorf("decoded shard doesn't match the original")
+	}
+
+	if len(decoded.Files) != 2 {
+		t.Errorf("expected binary file to be skipped, got %d files", len(decoded.Files))
+	}
+}
+
+func TestMatchFile(t *testing.T) {
+	query, err := newQuery("ab", false)
+	if err != nil {
+		t.Fatalf("failed to compile query: %v", err)
+	}
+
+	content := []byte("first\nxaby AB\nlast")
+	got := query.matchFile(content, lineOffsets(content))
+	want := []types.Match{
+		{
+			LineNum: 2,
+			Fragments: []types.Fragment{
+				{Pre: "x", Match: "ab"},
+				{Pre: "y ", Match: "AB", Post: ""},
+			},
+			Before: "first",
+			After:  "last",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+
+	// content that doesn't belong to the line offsets isn't matched.
+	if got = query.matchFile([]byte("ab"), lineOffsets(content)); got != nil {
+		t.Errorf("want no matches for mismatching content, got %+v", got)
+	}
+}
+
+func TestLineOffsets(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []uint32
+	}{
+		{content: "", want: []uint32{0}},
+		{content: "a", want: []uint32{0}},
+		{content: "a\n", want: []uint32{0, 2}},
+		{content: "ab\n\ncd", want: []uint32{0, 3, 4}},
+	}
+
+	for _, test := range tests {
+		if got := lineOffsets([]byte(test.content)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("content %q: want %v, got %v", test.content, test.want, got)
+		}
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := map[string]string{
+		"cmd/main.go":        "Go",
+		"web/src/App.TSX":    "TypeScript",
+		"build/Dockerfile":   "Dockerfile",
+		"docs/unknown.xyz12": "",
+	}
+
+	for path, want := range tests {
+		if got := detectLanguage(path); got != want {
+			t.Errorf("path %q: want %q, got %q", path, want, got)
+		}
+	}
+}
+
+func TestLocalIndexSearcherDelete(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewLocalIndexSearcher(Config{
+		EventReaderName: "test",
+		Concurrency:     1,
+		IndexPath:       t.TempDir(),
+		MaxFileSize:     1024,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create searcher: %v", err)
+	}
+
+	if err = s.writeShard(testShard()); err != nil {
+		t.Fatalf("failed to write shard: %v", err)
+	}
+
+	sh, err := s.getShard(ctx, 1)
+	if err != nil || sh == nil {
+		t.Fatalf("expected shard to be found, got %v (err: %v)", sh, err)
+	}
+
+	if err = s.Delete(ctx, 1); err != nil {
+		t.Fatalf("failed to delete shard: %v", err)
+	}
+
+	if _, err = os.Stat(shardPath(s.config.IndexPath, 1)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected shard file to be removed, got %v", err)
+	}
+
+	// deleting the index of a repo that isn't indexed is a no-op.
+	if err = s.Delete(ctx, 1); err != nil {
+		t.Errorf("expected no error for missing shard, got %v", err)
+	}
+}
+
+func TestLocalIndexSearcherSearch(t *testing.T) {
+	ctx := context.Background()
+
+	gitFake := &fakeGit{head: testCommitSHA}
+	repo := &types.Repository{ID: 1, GitUID: "repo", DefaultBranch: "main"}
+
+	s, err := NewLocalIndexSearcher(Config{
+		EventReaderName: "test",
+		Concurrency:     1,
+		IndexPath:       t.TempDir(),
+		MaxFileSize:     1024,
+	}, gitFake, &fakeRepoStore{repo: repo})
+	if err != nil {
+		t.Fatalf("failed to create searcher: %v", err)
+	}
+
+	if err = s.writeShard(testShard()); err != nil {
+		t.Fatalf("failed to write shard: %v", err)
+	}
+
+	result, err := s.Search(ctx, []int64{1}, "hello world", false, 0)
+	if err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+
+	var paths []string
+	for _, f := range result.FileMatches {
+		paths = append(paths, f.FileName)
+	}
+	if want := []string{"README.md", "main.go"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("want %v, got %v", want, paths)
+	}
+	if result.Stats.TotalMatches != 2 || result.FileMatches[1].Matches[0].LineNum != 4 {
+		t.Errorf("unexpected matches: %+v", result)
+	}
+	if gitFake.indexed() {
+		t.Errorf("expected up to date search index not to be re-indexed")
+	}
+
+	// the default branch got updated by an event consumed by another instance.
+	gitFake.setHead(testNewSHA)
+
+	result, err = s.Search(ctx, []int64{1}, "hello world", false, 0)
+	if err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+	if len(result.FileMatches) != 2 {
+		t.Errorf("expected the outdated search index to be used until re-indexed, got %+v", result)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		sh, err := s.getShard(ctx, 1)
+		if err != nil {
+			t.Fatalf("failed to get shard: %v", err)
+		}
+		if sh.CommitSHA == testNewSHA {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected outdated search index to be re-indexed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+type fakeGit struct {
+	git.Interface
+	mu      sync.Mutex
+	head    string
+	matched bool
+}
+
+func (g *fakeGit) setHead(head string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.head = head
+}
+
+func (g *fakeGit) indexed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.matched
+}
+
+func (g *fakeGit) GetRef(context.Context, git.GetRefParams) (git.GetRefResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return git.GetRefResponse{SHA: sha.Must(g.head)}, nil
+}
+
+func (g *fakeGit) MatchFiles(context.Context, *git.MatchFilesParams) (*git.MatchFilesOutput, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.matched = true
+
+	out := &git.MatchFilesOutput{}
+	for _, f := range testFiles {
+		out.Files = append(out.Files, api.FileContent{Path: f.Path, SHA: sha.Must(f.BlobSHA), Content: f.Content})
+	}
+	return out, nil
+}
+
+func (g *fakeGit) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	content := testFileContent(params.SHA)
+	return &git.GetBlobOutput{
+		SHA:         sha.Must(params.SHA),
+		Size:        int64(len(content)),
+		ContentSize: int64(len(content)),
+		Content:     io.NopCloser(bytes.NewReader(content)),
+	}, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *fakeRepoStore) Find(context.Context, int64) (*types.Repository, error) {
+	return s.repo, nil
+}
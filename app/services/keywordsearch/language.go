@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"path"
+	"strings"
+)
+
+var languageByFileName = map[string]string{
+	"dockerfile":     "Dockerfile",
+	"makefile":       "Makefile",
+	"gnumakefile":    "Makefile",
+	"jenkinsfile":    "Groovy",
+	"codeowners":     "CODEOWNERS",
+	"go.mod":         "Go Module",
+	"go.sum":         "Go Checksums",
+	"cmakelists.txt": "CMake",
+}
+
+var languageByExtension = map[string]string{
+	".bash":    "Shell",
+	".bat":     "Batchfile",
+	".c":       "C",
+	".cc":      "C++",
+	".clj":     "Clojure",
+	".cmake":   "CMake",
+	".cpp":     "C++",
+	".cs":      "C#",
+	".css":     "CSS",
+	".cxx":     "C++",
+	".dart":    "Dart",
+	".erl":     "Erlang",
+	".ex":      "Elixir",
+	".exs":     "Elixir",
+	".fs":      "F#",
+	".go":      "Go",
+	".gradle":  "Gradle",
+	".graphql": "GraphQL",
+	".groovy":  "Groovy",
+	".h":       "C",
+	".hcl":     "HCL",
+	".hpp":     "C++",
+	".hs":      "Haskell",
+	".htm":     "HTML",
+	".html":    "HTML",
+	".ini":     "INI",
+	".java":    "Java",
+	".js":      "JavaScript",
+	".json":    "JSON",
+	".jsx":     "JavaScript",
+	".kt":      "Kotlin",
+	".kts":     "Kotlin",
+	".less":    "Less",
+	".lua":     "Lua",
+	".m":       "Objective-C",
+	".md":      "Markdown",
+	".mjs":     "JavaScript",
+	".php":     "PHP",
+	".pl":      "Perl",
+	".proto":   "Protocol Buffer",
+	".ps1":     "PowerShell",
+	".py":      "Python",
+	".r":       "R",
+	".rb":      "Ruby",
+	".rs":      "Rust",
+	".sass":    "Sass",
+	".scala":   "Scala",
+	".scss":    "SCSS",
+	".sh":      "Shell",
+	".sql":     "SQL",
+	".swift":   "Swift",
+	".tf":      "HCL",
+	".toml":    "TOML",
+	".ts":      "TypeScript",
+	".tsx":     "TypeScript",
+	".txt":     "Text",
+	".vue":     "Vue",
+	".xml":     "XML",
+	".yaml":    "YAML",
+	".yml":     "YAML",
+	".zsh":     "Shell",
+}
+
+// detectLanguage returns the programming language of a file based on its name,
+// or an empty string in case the language is unknown.
+func detectLanguage(filePath string) string {
+	name := strings.ToLower(path.Base(filePath))
+
+	if lang, ok := languageByFileName[name]; ok {
+		return lang
+	}
+
+	return languageByExtension[path.Ext(name)]
+}
@@ -16,27 +16,435 @@ package keywordsearch
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
 
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/contextutil"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultMaxResultCount = 50
+	shardFileExtension    = ".idx"
+	shardCacheDuration    = 5 * time.Minute
+	indexDirPermissions   = 0o700
+	// backgroundIndexTimeout is the max time given to indexing a repo that was found missing during a search.
+	backgroundIndexTimeout = 15 * time.Minute
 )
 
+// LocalIndexSearcher is a trigram based code search index stored on the local disk.
+// Only the default branch of a repository is indexed. The index contains the trigrams and line offsets
+// of the files, the content of the candidate files of a query is read from git.
+//
+// NOTE: The index isn't shared between instances and the events that update it are consumed by a single
+// instance only. In a deployment with multiple instances every instance detects during a search that its index
+// of a repository is outdated and re-indexes the repository in the background, until then the search results
+// are based on the previously indexed commit.
 type LocalIndexSearcher struct {
+	config    Config
+	git       git.Interface
+	repoStore store.RepoStore
+	shards    *cache.TTLCache[shardKey, *shard]
+
+	repoLocksMx sync.Mutex
+	repoLocks   map[int64]*sync.Mutex
+	// indexing contains the repos that are indexed in the background.
+	indexing map[int64]struct{}
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+func NewLocalIndexSearcher(
+	config Config,
+	git git.Interface,
+	repoStore store.RepoStore,
+) (*LocalIndexSearcher, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided codesearch config is invalid: %w", err)
+	}
+
+	if err := os.MkdirAll(config.IndexPath, indexDirPermissions); err != nil {
+		return nil, fmt.Errorf("failed to create search index directory: %w", err)
+	}
+
+	return &LocalIndexSearcher{
+		config:    config,
+		git:       git,
+		repoStore: repoStore,
+		shards:    cache.New[shardKey, *shard](shardLoader{dir: config.IndexPath}, shardCacheDuration),
+		repoLocks: make(map[int64]*sync.Mutex),
+		indexing:  make(map[int64]struct{}),
+	}, nil
 }
 
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ bool,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	queryString string,
+	enableRegex bool,
+	maxResultCount int,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, nil
+	q, err := newQuery(queryString, enableRegex)
+	if err != nil {
+		return types.SearchResult{}, err
+	}
+
+	if maxResultCount <= 0 {
+		maxResultCount = defaultMaxResultCount
+	}
+
+	// search repos in a stable order to return consistent results in case of truncation
+	repoIDs = append([]int64(nil), repoIDs...)
+	sort.Slice(repoIDs, func(i, j int) bool {
+		return repoIDs[i] < repoIDs[j]
+	})
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+	}
+
+	for _, repoID := range repoIDs {
+		if err = ctx.Err(); err != nil {
+			return types.SearchResult{}, err
+		}
+
+		repo, err := s.repoStore.Find(ctx, repoID)
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to find repo %d: %w", repoID, err)
+		}
+
+		sh, err := s.getShard(ctx, repoID)
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to get search index of repo %d: %w", repoID, err)
+		}
+		if sh == nil {
+			// the repo isn't indexed yet, it's skipped until the background indexing completes.
+			continue
+		}
+
+		s.indexIfOutdated(ctx, repo, sh)
+
+		for _, idx := range sh.candidates(q.trigrams) {
+			file := sh.Files[idx]
+
+			content, err := s.readFile(ctx, repo, file.BlobSHA)
+			if errors.IsNotFound(err) {
+				// the blob got removed from the repo (e.g. after a force push), the repo is re-indexed already.
+				continue
+			}
+			if err != nil {
+				return types.SearchResult{}, fmt.Errorf("failed to read file %q of repo %d: %w", file.Path, repoID, err)
+			}
+
+			matches := q.matchFile(content, file.LineOffsets)
+			if len(matches) == 0 {
+				continue
+			}
+
+			result.FileMatches = append(result.FileMatches, types.FileMatch{
+				FileName:   file.Path,
+				RepoID:     repoID,
+				RepoBranch: sh.Branch,
+				Language:   file.Language,
+				Matches:    matches,
+			})
+			result.Stats.TotalFiles++
+			result.Stats.TotalMatches += len(matches)
+
+			if len(result.FileMatches) >= maxResultCount {
+				return result, nil
+			}
+		}
+	}
+
+	return result, nil
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
+// Index (re-)builds the search index of the default branch of the repository.
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	unlock := s.lockRepo(repo.ID)
+	defer unlock()
+
+	commitSHA, files, err := s.readFiles(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	sh := newShard(repo, commitSHA, files)
+
+	err = s.writeShard(sh)
+	if err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repo.ID).
+		Str("branch", repo.DefaultBranch).
+		Int("files", len(sh.Files)).
+		Msg("updated search index of repository")
+
+	return nil
+}
+
+// Delete removes the search index of the repository.
+func (s *LocalIndexSearcher) Delete(ctx context.Context, repoID int64) error {
+	unlock := s.lockRepo(repoID)
+	defer unlock()
+
+	err := os.Remove(shardPath(s.config.IndexPath, repoID))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove search index file: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repoID).
+		Msg("removed search index of repository")
+
 	return nil
 }
+
+// readFiles returns the head commit of the default branch and the content of all its files
+// that aren't exceeding the max file size.
+func (s *LocalIndexSearcher) readFiles(ctx context.Context, repo *types.Repository) (string, []fileContent, error) {
+	if repo.IsEmpty {
+		return "", nil, nil
+	}
+
+	commitSHA, err := s.headCommitSHA(ctx, repo)
+	if err != nil {
+		return "", nil, err
+	}
+	if commitSHA == "" {
+		return "", nil, nil
+	}
+
+	out, err := s.git.MatchFiles(ctx, &git.MatchFilesParams{
+		ReadParams: git.CreateReadParams(repo),
+		Ref:        commitSHA,
+		DirPath:    "",
+		Pattern:    "*",
+		MaxSize:    s.config.MaxFileSize,
+		Recursive:  true,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read files of repo %d: %w", repo.ID, err)
+	}
+
+	files := make([]fileContent, len(out.Files))
+	for i, f := range out.Files {
+		files[i] = fileContent{
+			Path:    f.Path,
+			BlobSHA: f.SHA.String(),
+			Content: f.Content,
+		}
+	}
+
+	return commitSHA, files, nil
+}
+
+// headCommitSHA returns the commit the default branch of the repo is pointing to,
+// or an empty string if the default branch doesn't exist (yet).
+func (s *LocalIndexSearcher) headCommitSHA(ctx context.Context, repo *types.Repository) (string, error) {
+	ref, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.CreateReadParams(repo),
+		Name:       repo.DefaultBranch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch of repo %d: %w", repo.ID, err)
+	}
+
+	return ref.SHA.String(), nil
+}
+
+// readFile returns the content of an indexed file.
+func (s *LocalIndexSearcher) readFile(ctx context.Context, repo *types.Repository, blobSHA string) ([]byte, error) {
+	out, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: git.CreateReadParams(repo),
+		SHA:        blobSHA,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Content.Close()
+
+	content, err := io.ReadAll(out.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", blobSHA, err)
+	}
+
+	return content, nil
+}
+
+// indexIfOutdated re-indexes the repo in the background if the search index isn't based on the current head
+// of the default branch. Usually the index is updated on branch events, but those are consumed by only one of
+// multiple instances, hence the indices of the other instances get outdated.
+func (s *LocalIndexSearcher) indexIfOutdated(ctx context.Context, repo *types.Repository, sh *shard) {
+	if repo.IsEmpty {
+		return
+	}
+
+	commitSHA, err := s.headCommitSHA(ctx, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).
+			Msg("failed to check whether search index is up to date")
+		return
+	}
+
+	if sh.Branch != repo.DefaultBranch || sh.CommitSHA != commitSHA {
+		s.indexInBackground(ctx, repo.ID)
+	}
+}
+
+// getShard returns the search index of the repo.
+// If the repo wasn't indexed yet, it gets indexed in the background and nil is returned.
+func (s *LocalIndexSearcher) getShard(ctx context.Context, repoID int64) (*shard, error) {
+	info, err := os.Stat(shardPath(s.config.IndexPath, repoID))
+	if errors.Is(err, fs.ErrNotExist) {
+		s.indexInBackground(ctx, repoID)
+		return nil, nil //nolint:nilnil // a missing shard isn't an error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat search index file: %w", err)
+	}
+
+	// the modification time is part of the key to ensure updated indices are picked up.
+	sh, err := s.shards.Get(ctx, shardKey{
+		repoID:  repoID,
+		modTime: info.ModTime().UnixNano(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if sh.Version != shardVersion {
+		// the index was written in an older format.
+		s.indexInBackground(ctx, repoID)
+		return nil, nil //nolint:nilnil // an outdated shard is treated as missing
+	}
+
+	return sh, nil
+}
+
+// indexInBackground indexes the repo without blocking the caller.
+// It's a no-op in case the repo is already being indexed in the background.
+func (s *LocalIndexSearcher) indexInBackground(ctx context.Context, repoID int64) {
+	s.repoLocksMx.Lock()
+	if _, ok := s.indexing[repoID]; ok {
+		s.repoLocksMx.Unlock()
+		return
+	}
+	s.indexing[repoID] = struct{}{}
+	s.repoLocksMx.Unlock()
+
+	// the indexing outlives the search request, hence it can't use the request context.
+	ctx, cancel := context.WithTimeout(contextutil.WithNewValues(context.Background(), ctx), backgroundIndexTimeout)
+
+	go func() {
+		defer cancel()
+		defer func() {
+			s.repoLocksMx.Lock()
+			delete(s.indexing, repoID)
+			s.repoLocksMx.Unlock()
+		}()
+
+		repo, err := s.repoStore.Find(ctx, repoID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).
+				Msg("failed to find repository for search indexing")
+			return
+		}
+
+		if err = s.Index(ctx, repo); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).
+				Msg("failed to index repository in background")
+		}
+	}()
+}
+
+// writeShard atomically replaces the search index file of the repo.
+func (s *LocalIndexSearcher) writeShard(sh *shard) error {
+	tmp, err := os.CreateTemp(s.config.IndexPath, strconv.FormatInt(sh.RepoID, 10)+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		// no-op in case of success as the file was renamed
+		_ = os.Remove(tmp.Name())
+	}()
+
+	err = sh.encode(tmp)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	// the modification time is part of the cache key, but file systems use a coarse clock for it.
+	now := time.Now()
+	if err = os.Chtimes(tmp.Name(), now, now); err != nil {
+		return fmt.Errorf("failed to set modification time of temporary file: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), shardPath(s.config.IndexPath, sh.RepoID)); err != nil {
+		return fmt.Errorf("failed to replace search index file: %w", err)
+	}
+
+	return nil
+}
+
+// lockRepo ensures that a repository is indexed only once at a time.
+func (s *LocalIndexSearcher) lockRepo(repoID int64) func() {
+	s.repoLocksMx.Lock()
+	mx, ok := s.repoLocks[repoID]
+	if !ok {
+		mx = &sync.Mutex{}
+		s.repoLocks[repoID] = mx
+	}
+	s.repoLocksMx.Unlock()
+
+	mx.Lock()
+	return mx.Unlock
+}
+
+type shardKey struct {
+	repoID  int64
+	modTime int64
+}
+
+// shardLoader loads search index files from disk, it's used as the getter of the shard cache.
+type shardLoader struct {
+	dir string
+}
+
+func (l shardLoader) Find(_ context.Context, key shardKey) (*shard, error) {
+	f, err := os.Open(shardPath(l.dir, key.repoID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open search index file: %w", err)
+	}
+	defer f.Close()
+
+	return decodeShard(f)
+}
+
+func shardPath(dir string, repoID int64) string {
+	return filepath.Join(dir, strconv.FormatInt(repoID, 10)+shardFileExtension)
+}
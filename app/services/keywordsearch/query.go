@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+)
+
+// query is a compiled search query.
+type query struct {
+	re *regexp.Regexp
+	// trigrams contains the trigrams every matching file is guaranteed to contain.
+	trigrams map[trigram]struct{}
+}
+
+// newQuery compiles the search query. Plain text queries are matched case-insensitive,
+// regular expressions are used as provided.
+func newQuery(q string, enableRegex bool) (*query, error) {
+	if !enableRegex {
+		return &query{
+			re:       regexp.MustCompile("(?i)" + regexp.QuoteMeta(q)),
+			trigrams: trigramsOf([]byte(q)),
+		}, nil
+	}
+
+	re, err := regexp.Compile(q)
+	if err != nil {
+		return nil, errors.InvalidArgument("Invalid regular expression: %s", err)
+	}
+
+	parsed, err := syntax.Parse(q, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse regular expression: %w", err)
+	}
+
+	trigrams := make(map[trigram]struct{})
+	for _, literal := range requiredLiterals(parsed.Simplify()) {
+		for t := range trigramsOf([]byte(literal)) {
+			trigrams[t] = struct{}{}
+		}
+	}
+
+	return &query{
+		re:       re,
+		trigrams: trigrams,
+	}, nil
+}
+
+// requiredLiterals returns literal strings that are part of every match of the regular expression.
+// The result is used for pre-filtering only, hence it's fine to return fewer literals than possible.
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min < 1 {
+			return nil
+		}
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpConcat:
+		var result []string
+		current := ""
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral {
+				// adjacent literals form a single, longer literal
+				current += string(sub.Rune)
+				continue
+			}
+
+			if current != "" {
+				result = append(result, current)
+				current = ""
+			}
+			result = append(result, requiredLiterals(sub)...)
+		}
+		if current != "" {
+			result = append(result, current)
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// matchFile returns the line matches of the query within the content of the file.
+// The line offsets are the ones stored in the search index for the content.
+func (q *query) matchFile(content []byte, lineOffsets []uint32) []types.Match {
+	if len(lineOffsets) == 0 || int(lineOffsets[len(lineOffsets)-1]) > len(content) {
+		// the content doesn't belong to the line offsets.
+		return nil
+	}
+
+	lineAt := func(i int) []byte {
+		end := len(content)
+		if i+1 < len(lineOffsets) {
+			end = int(lineOffsets[i+1]) - 1 // without the line feed
+		}
+		return content[lineOffsets[i]:end]
+	}
+
+	var matches []types.Match
+	for i := range lineOffsets {
+		line := lineAt(i)
+
+		locs := q.re.FindAllIndex(line, -1)
+		if len(locs) == 0 {
+			continue
+		}
+
+		match := types.Match{
+			LineNum:   i + 1,
+			Fragments: make([]types.Fragment, 0, len(locs)),
+		}
+
+		if i > 0 {
+			match.Before = string(lineAt(i - 1))
+		}
+		if i+1 < len(lineOffsets) {
+			match.After = string(lineAt(i + 1))
+		}
+
+		prevEnd := 0
+		for _, loc := range locs {
+			if loc[0] == loc[1] {
+				// skip empty matches (e.g. for "a*") as there's nothing to highlight
+				continue
+			}
+
+			match.Fragments = append(match.Fragments, types.Fragment{
+				Pre:   string(line[prevEnd:loc[0]]),
+				Match: string(line[loc[0]:loc[1]]),
+			})
+			prevEnd = loc[1]
+		}
+
+		if len(match.Fragments) == 0 {
+			continue
+		}
+
+		// the last fragment holds the remainder of the line
+		match.Fragments[len(match.Fragments)-1].Post = string(line[prevEnd:])
+
+		matches = append(matches, match)
+	}
+
+	return matches
+}
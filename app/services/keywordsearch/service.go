@@ -33,6 +33,11 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// IndexPath is the directory used by the local index to store its data.
+	IndexPath string
+	// MaxFileSize is the maximum size of a file that gets indexed, bigger files are skipped.
+	MaxFileSize int
 }
 
 func (c *Config) Prepare() error {
@@ -48,6 +53,12 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.IndexPath == "" {
+		return errors.New("config.IndexPath is required")
+	}
+	if c.MaxFileSize < 1 {
+		return errors.New("config.MaxFileSize has to be a positive number")
+	}
 	return nil
 }
 
@@ -106,6 +117,7 @@ func NewService(
 				))
 
 			_ = r.RegisterDefaultBranchUpdated((service.handleUpdateDefaultBranch))
+			_ = r.RegisterRepoDeleted(service.handleEventRepoDeleted)
 			return nil
 		})
 	if err != nil {
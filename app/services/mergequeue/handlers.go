@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+)
+
+func (s *Service) processOnMergeQueueUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.MergeQueueUpdatedPayload],
+) error {
+	return s.processQueue(ctx, event.Payload.TargetRepoID, event.Payload.TargetBranch)
+}
+
+func (s *Service) removeOnBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.removePullReq(ctx, event.Payload.PullReqID,
+		"New commits have been pushed to the source branch of the pull request.")
+}
+
+func (s *Service) removeOnClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.removePullReq(ctx, event.Payload.PullReqID, "The pull request has been closed.")
+}
+
+func (s *Service) removeOnMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.removePullReq(ctx, event.Payload.PullReqID, "The pull request has been merged.")
+}
+
+func (s *Service) processOnCheckStatusReported(ctx context.Context,
+	event *events.Event[*checkevents.StatusReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	entry, err := s.mergeQueueStore.FindByMergeSHA(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil // the check doesn't belong to a merge queue ref
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry by merge SHA: %w", err)
+	}
+
+	return s.processQueue(ctx, entry.RepoID, entry.TargetBranch)
+}
+
+func (s *Service) processOnBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	branch := strings.TrimPrefix(event.Payload.Ref, "refs/heads/")
+
+	return s.processQueue(ctx, event.Payload.RepoID, branch)
+}
+
+// removePullReq removes the pull request from the merge queue, if it's in it,
+// and rebuilds the merge refs of the pull requests queued after it.
+func (s *Service) removePullReq(ctx context.Context, pullreqID int64, reason string) error {
+	entry, err := s.mergeQueueStore.FindByPullReqID(ctx, pullreqID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, entry.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, pullreqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if err = s.removeEntry(ctx, repo, entry, pr, reason); err != nil {
+		return err
+	}
+
+	return s.processQueue(ctx, entry.RepoID, entry.TargetBranch)
+}
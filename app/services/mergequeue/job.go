@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+type processJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*processJob)(nil)
+
+// Handle processes all merge queues. The merge queues are processed on events, so this only catches up with
+// events that got lost and removes the heads of the queues whose required status checks timed out.
+func (j *processJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	s := j.service
+
+	heads, err := s.mergeQueueStore.ListHeads(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list merge queue heads: %w", err)
+	}
+
+	for _, head := range heads {
+		if err := s.processQueue(ctx, head.RepoID, head.TargetBranch); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo.id", head.RepoID).
+				Str("branch", head.TargetBranch).
+				Msg("failed to process merge queue")
+		}
+	}
+
+	return "", nil
+}
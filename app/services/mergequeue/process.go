@@ -0,0 +1,540 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// lockTimeout is the max time a merge queue is locked for processing.
+// The repository level pull request lock is used, so the queue doesn't interfere with regular merges.
+const lockTimeout = 5 * time.Minute
+
+// processQueue brings the merge queue of a branch up to date: It (re)builds outdated merge refs,
+// merges the head of the queue once its required status checks succeed,
+// and removes the pull requests that can't be merged.
+func (s *Service) processQueue(ctx context.Context, repoID int64, branch string) error {
+	entries, err := s.mergeQueueStore.List(ctx, repoID, branch)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	unlock, err := s.locker.LockPR(ctx, repoID, 0, lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	// Every iteration either stops processing or removes one entry from the queue.
+	for {
+		entries, err = s.mergeQueueStore.List(ctx, repoID, branch)
+		if err != nil {
+			return fmt.Errorf("failed to list merge queue entries: %w", err)
+		}
+
+		if len(entries) == 0 {
+			return nil
+		}
+
+		ref, err := s.git.GetRef(ctx, git.GetRefParams{
+			ReadParams: git.CreateReadParams(repo),
+			Name:       branch,
+			Type:       gitenum.RefTypeBranch,
+		})
+		if errors.IsNotFound(err) {
+			return s.removeEntries(ctx, repo, entries, "The target branch of the pull request has been deleted.")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get target branch: %w", err)
+		}
+
+		targetSHA := ref.SHA.String()
+
+		removed, err := s.buildMergeRefs(ctx, repo, entries, targetSHA)
+		if err != nil {
+			return err
+		}
+		if removed {
+			continue
+		}
+
+		processed, err := s.processHead(ctx, repo, entries[0], targetSHA)
+		if err != nil {
+			return err
+		}
+		if !processed {
+			return nil
+		}
+	}
+}
+
+// buildMergeRefs builds the merge refs of all entries that are outdated, because either the entry ahead of
+// them in the queue, the target branch or the source branch of the pull request has changed.
+// It returns true if an entry had to be removed from the queue, in which case the queue must be rebuilt.
+func (s *Service) buildMergeRefs(
+	ctx context.Context,
+	repo *types.Repository,
+	entries []*types.MergeQueueEntry,
+	targetSHA string,
+) (bool, error) {
+	baseSHA := targetSHA
+
+	for _, entry := range entries {
+		pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if pr.State != enum.PullReqStateOpen {
+			return true, s.removeEntry(ctx, repo, entry, pr, "The pull request isn't open anymore.")
+		}
+
+		if entry.State == enum.MergeQueueEntryStateChecking &&
+			entry.BaseSHA == baseSHA && entry.HeadSHA == pr.SourceSHA {
+			baseSHA = entry.MergeSHA
+			continue
+		}
+
+		mergeSHA, reason, err := s.buildMergeRef(ctx, repo, entry, pr, baseSHA)
+		if err != nil {
+			return false, err
+		}
+		if reason != "" {
+			return true, s.removeEntry(ctx, repo, entry, pr, reason)
+		}
+
+		entry.State = enum.MergeQueueEntryStateChecking
+		entry.BaseSHA = baseSHA
+		entry.HeadSHA = pr.SourceSHA
+		entry.MergeSHA = mergeSHA
+
+		if err = s.mergeQueueStore.Update(ctx, entry); err != nil {
+			return false, fmt.Errorf("failed to update merge queue entry: %w", err)
+		}
+
+		s.triggerPipelines(ctx, repo, pr, entry)
+
+		baseSHA = mergeSHA
+	}
+
+	return false, nil
+}
+
+// buildMergeRef merges the pull request into the provided base commit and stores the result in the queue ref
+// of the pull request. If the pull request can't be merged, the reason is returned.
+func (s *Service) buildMergeRef(
+	ctx context.Context,
+	repo *types.Repository,
+	entry *types.MergeQueueEntry,
+	pr *types.PullReq,
+	baseSHA string,
+) (string, string, error) {
+	sourceRepo := repo
+	if pr.SourceRepoID != repo.ID {
+		var err error
+		sourceRepo, err = s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to find source repository: %w", err)
+		}
+	}
+
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find principal who added the pull request to the merge queue: %w", err)
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, s.urlProvider, newSession(principal), repo)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	systemIdentity := identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+
+	var author, committer *git.Identity
+	var title string
+
+	switch entry.Method {
+	case enum.MergeMethodMerge:
+		author = identityFromPrincipalInfo(*principal.ToPrincipalInfo())
+		committer = systemIdentity
+		title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	case enum.MergeMethodSquash:
+		author = identityFromPrincipalInfo(pr.Author)
+		committer = systemIdentity
+		title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	case enum.MergeMethodRebase:
+		committer = identityFromPrincipalInfo(*principal.ToPrincipalInfo())
+	case enum.MergeMethodFastForward:
+		// Not used: no new commits are created.
+	}
+
+	now := time.Now()
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     writeParams,
+		BaseBranch:      baseSHA,
+		HeadRepoUID:     sourceRepo.GitUID,
+		HeadBranch:      pr.SourceBranch,
+		Title:           title,
+		Committer:       committer,
+		CommitterDate:   &now,
+		Author:          author,
+		AuthorDate:      &now,
+		RefType:         gitenum.RefTypePullReqQueue,
+		RefName:         strconv.FormatInt(pr.Number, 10),
+		HeadExpectedSHA: sha.Must(pr.SourceSHA),
+		Force:           true,
+		Method:          gitenum.MergeMethod(entry.Method),
+	})
+	if errors.IsPreconditionFailed(err) || errors.IsInvalidArgument(err) {
+		return "", errors.Message(err), nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build merge queue ref of pull request %d: %w", pr.Number, err)
+	}
+
+	if len(mergeOutput.ConflictFiles) > 0 {
+		return "", "The pull request has merge conflicts with the target branch " +
+			"or with the pull requests ahead of it in the merge queue.", nil
+	}
+
+	return mergeOutput.MergeSHA.String(), "", nil
+}
+
+// processHead merges the head of the queue if all its required status checks succeeded,
+// or removes it from the queue if any of them failed or they didn't complete within the check timeout.
+// It returns false if the required status checks of the head are still running.
+func (s *Service) processHead(
+	ctx context.Context,
+	repo *types.Repository,
+	head *types.MergeQueueEntry,
+	targetSHA string,
+) (bool, error) {
+	pr, err := s.pullreqStore.Find(ctx, head.PullReqID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, head.CreatedBy)
+	if err != nil {
+		return false, fmt.Errorf("failed to find principal who added the pull request to the merge queue: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	requiredChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		Actor:   principal,
+		Repo:    repo,
+		PullReq: pr,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get required status checks: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, repo.ID, head.MergeSHA)
+	if err != nil {
+		return false, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	statuses := make(map[string]enum.CheckStatus, len(checkResults))
+	for _, result := range checkResults {
+		statuses[result.Identifier] = result.Status
+	}
+
+	// the merge queue doesn't allow bypassing of required status checks
+	var pending bool
+	for _, identifiers := range []map[string]struct{}{
+		requiredChecks.RequiredIdentifiers,
+		requiredChecks.BypassableIdentifiers,
+	} {
+		for identifier := range identifiers {
+			status, ok := statuses[identifier]
+			switch {
+			case !ok || !status.IsCompleted():
+				pending = true
+			case status != enum.CheckStatusSuccess:
+				return true, s.removeEntry(ctx, repo, head, pr,
+					fmt.Sprintf("The required status check %q didn't succeed on the merge queue ref.", identifier))
+			}
+		}
+	}
+
+	if pending {
+		if s.checkTimeout > 0 && time.Since(time.UnixMilli(head.Updated)) > s.checkTimeout {
+			return true, s.removeEntry(ctx, repo, head, pr,
+				"The required status checks didn't complete in time on the merge queue ref.")
+		}
+
+		return false, nil
+	}
+
+	return true, s.mergeHead(ctx, repo, head, pr, principal, targetSHA)
+}
+
+// mergeHead fast-forwards the target branch to the merge ref of the head of the queue
+// and marks the pull request as merged.
+func (s *Service) mergeHead(
+	ctx context.Context,
+	repo *types.Repository,
+	head *types.MergeQueueEntry,
+	pr *types.PullReq,
+	principal *types.Principal,
+	targetSHA string,
+) error {
+	if pr.SourceSHA != head.HeadSHA {
+		return fmt.Errorf("source SHA of pull request %d has changed", pr.Number)
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, s.urlProvider, newSession(principal), repo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        head.TargetBranch,
+		Type:        gitenum.RefTypeBranch,
+		NewValue:    sha.Must(head.MergeSHA),
+		OldValue:    sha.Must(targetSHA),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update target branch to the merge queue ref: %w", err)
+	}
+
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.State = enum.PullReqStateMerged
+
+		nowMilli := time.Now().UnixMilli()
+		pr.Merged = &nowMilli
+		pr.MergedBy = &principal.ID
+		pr.MergeMethod = &head.Method
+
+		pr.MergeCheckStatus = enum.MergeCheckStatusMergeable
+		pr.MergeTargetSHA = ptr.String(targetSHA)
+		pr.MergeSHA = ptr.String(head.MergeSHA)
+		pr.MergeConflicts = nil
+
+		pr.ActivitySeq++
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	if err = s.mergeQueueStore.Delete(ctx, head.ID); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to remove merged pull request from the merge queue: %w", err)
+	}
+
+	s.deleteMergeRef(ctx, repo, pr)
+
+	activityPayload := &types.PullRequestActivityPayloadMerge{
+		MergeMethod: head.Method,
+		MergeSHA:    head.MergeSHA,
+		TargetSHA:   targetSHA,
+		SourceSHA:   head.HeadSHA,
+	}
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, principal.ID, activityPayload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
+	}
+
+	s.pullreqEvReporter.Merged(ctx, &pullreqevents.MergedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  principal.ID,
+			Number:       pr.Number,
+		},
+		MergeMethod: head.Method,
+		MergeSHA:    head.MergeSHA,
+		TargetSHA:   targetSHA,
+		SourceSHA:   head.HeadSHA,
+	})
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return nil
+}
+
+// removeEntries removes all provided entries from the merge queue.
+func (s *Service) removeEntries(
+	ctx context.Context,
+	repo *types.Repository,
+	entries []*types.MergeQueueEntry,
+	reason string,
+) error {
+	for _, entry := range entries {
+		pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if err = s.removeEntry(ctx, repo, entry, pr, reason); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeEntry removes the pull request from the merge queue and records the reason as a pull request activity.
+func (s *Service) removeEntry(
+	ctx context.Context,
+	repo *types.Repository,
+	entry *types.MergeQueueEntry,
+	pr *types.PullReq,
+	reason string,
+) error {
+	err := s.mergeQueueStore.Delete(ctx, entry.ID)
+	if errors.IsNotFound(err) {
+		return nil // already removed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove pull request from the merge queue: %w", err)
+	}
+
+	if entry.MergeSHA != "" {
+		s.deleteMergeRef(ctx, repo, pr)
+	}
+
+	pr, err = s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to update activity sequence of pull request")
+		return nil
+	}
+
+	payload := &types.PullRequestActivityPayloadMergeQueue{
+		Action:       enum.PullReqMergeQueueActionFailed,
+		TargetBranch: entry.TargetBranch,
+		SourceSHA:    entry.HeadSHA,
+		MergeSHA:     entry.MergeSHA,
+		Reason:       reason,
+	}
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, entry.CreatedBy, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request merge queue activity")
+	}
+
+	return nil
+}
+
+// deleteMergeRef deletes the merge ref the merge queue has built for the pull request.
+func (s *Service) deleteMergeRef(ctx context.Context, repo *types.Repository, pr *types.PullReq) {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, s.urlProvider,
+		bootstrap.NewSystemServiceSession(), repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create RPC write params")
+		return
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        strconv.FormatInt(pr.Number, 10),
+		Type:        gitenum.RefTypePullReqQueue,
+		NewValue:    sha.None, // when NewValue is empty will delete the ref.
+		OldValue:    sha.None, // we don't care about the old value
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue ref of pull request")
+	}
+}
+
+// triggerPipelines triggers the pipelines of the repository for the merge ref of the entry.
+func (s *Service) triggerPipelines(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) {
+	ref, err := git.GetRefPath(strconv.FormatInt(pr.Number, 10), gitenum.RefTypePullReqQueue)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get merge queue ref path")
+		return
+	}
+
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerHook,
+		Action:      enum.TriggerActionPullReqMergeQueued,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		Title:       pr.Title,
+		Timestamp:   pr.Created,
+		AuthorLogin: pr.Author.UID,
+		AuthorName:  pr.Author.DisplayName,
+		AuthorEmail: pr.Author.Email,
+		Message:     pr.Description,
+		Before:      entry.BaseSHA,
+		After:       entry.MergeSHA,
+		Ref:         ref,
+		Source:      pr.SourceBranch,
+		Target:      pr.TargetBranch,
+	}
+
+	err = s.triggerSvc.Trigger(ctx, repo.ID, enum.TriggerActionPullReqMergeQueued, hook)
+	if err != nil {
+		// non-critical error: the entry stays in the queue until its required checks are reported.
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to trigger pipelines for merge queue ref of pull request %d",
+			pr.Number)
+	}
+}
+
+func newSession(principal *types.Principal) *auth.Session {
+	return &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}
+}
+
+func identityFromPrincipalInfo(p types.PrincipalInfo) *git.Identity {
+	return &git.Identity{
+		Name:  p.DisplayName,
+		Email: p.Email,
+	}
+}
@@ -0,0 +1,746 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/bootstrap"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/lock"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testRepoID       = 1
+	testPrincipalID  = 2
+	testTargetBranch = "main"
+	testTargetSHA    = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	testCheck        = "ci"
+)
+
+func TestProcessQueue(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, testCheck)
+	env.addPullReq(1)
+	env.addPullReq(2)
+
+	if err := env.svc.processQueue(ctx, testRepoID, testTargetBranch); err != nil {
+		t.Fatalf("processQueue() error = %v", err)
+	}
+
+	entries := env.queue.list()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries in the queue, got %d", len(entries))
+	}
+	env.requireChecking(t, entries[0], testTargetSHA, 1)
+	env.requireChecking(t, entries[1], entries[0].MergeSHA, 2)
+
+	if env.triggers.calls != 2 {
+		t.Errorf("expected pipelines to be triggered for 2 merge refs, got %d", env.triggers.calls)
+	}
+
+	// the merge refs are up to date, processing the queue again must not rebuild them
+	if err := env.svc.processQueue(ctx, testRepoID, testTargetBranch); err != nil {
+		t.Fatalf("processQueue() error = %v", err)
+	}
+	if env.git.merges != 2 {
+		t.Errorf("expected the merge refs not to be rebuilt, got %d merges", env.git.merges)
+	}
+
+	env.checks.report(entries[0].MergeSHA, enum.CheckStatusSuccess)
+
+	if err := env.svc.processQueue(ctx, testRepoID, testTargetBranch); err != nil {
+		t.Fatalf("processQueue() error = %v", err)
+	}
+
+	if got := env.git.branches[testTargetBranch]; got != entries[0].MergeSHA {
+		t.Errorf("expected the target branch to be fast-forwarded to %s, got %s", entries[0].MergeSHA, got)
+	}
+
+	pr := env.pullreqs.prs[1]
+	if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || *pr.MergeSHA != entries[0].MergeSHA {
+		t.Errorf("expected pull request 1 to be merged with %s, got %+v", entries[0].MergeSHA, pr)
+	}
+
+	remaining := env.queue.list()
+	if len(remaining) != 1 || remaining[0].PullReqID != 2 {
+		t.Fatalf("expected only pull request 2 to remain in the queue, got %+v", remaining)
+	}
+	if remaining[0].MergeSHA != entries[1].MergeSHA || env.git.merges != 2 {
+		t.Errorf("expected the merge ref of pull request 2 to remain valid, got %+v", remaining[0])
+	}
+}
+
+func TestProcessQueue_TargetBranchDeleted(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, testCheck)
+	env.addPullReq(1)
+	env.addPullReq(2)
+
+	delete(env.git.branches, testTargetBranch)
+
+	if err := env.svc.processQueue(ctx, testRepoID, testTargetBranch); err != nil {
+		t.Fatalf("processQueue() error = %v", err)
+	}
+
+	if entries := env.queue.list(); len(entries) != 0 {
+		t.Errorf("expected the queue to be empty, got %+v", entries)
+	}
+	env.requireRemoved(t, 1, "target branch")
+	env.requireRemoved(t, 2, "target branch")
+}
+
+func TestBuildMergeRefs(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(env *testEnv)
+		wantRemoved bool
+		check       func(t *testing.T, env *testEnv, entries []*types.MergeQueueEntry)
+	}{
+		{
+			name:  "stacks merge refs",
+			setup: func(*testEnv) {},
+			check: func(t *testing.T, env *testEnv, entries []*types.MergeQueueEntry) {
+				env.requireChecking(t, entries[0], testTargetSHA, 1)
+				env.requireChecking(t, entries[1], entries[0].MergeSHA, 2)
+			},
+		},
+		{
+			name: "closed pull request",
+			setup: func(env *testEnv) {
+				env.pullreqs.prs[2].State = enum.PullReqStateClosed
+			},
+			wantRemoved: true,
+			check: func(t *testing.T, env *testEnv, entries []*types.MergeQueueEntry) {
+				if len(entries) != 1 || entries[0].PullReqID != 1 {
+					t.Fatalf("expected only pull request 1 to remain in the queue, got %+v", entries)
+				}
+				env.requireRemoved(t, 2, "isn't open")
+			},
+		},
+		{
+			name: "merge conflicts",
+			setup: func(env *testEnv) {
+				env.git.conflicts[env.pullreqs.prs[1].SourceBranch] = true
+			},
+			wantRemoved: true,
+			check: func(t *testing.T, env *testEnv, entries []*types.MergeQueueEntry) {
+				if len(entries) != 1 || entries[0].PullReqID != 2 {
+					t.Fatalf("expected only pull request 2 to remain in the queue, got %+v", entries)
+				}
+				env.requireRemoved(t, 1, "merge conflicts")
+			},
+		},
+		{
+			name: "rebuilds outdated merge refs",
+			setup: func(env *testEnv) {
+				entries := env.queue.list()
+				_, _ = env.svc.buildMergeRefs(context.Background(), env.repo, entries, testTargetSHA)
+				env.pullreqs.prs[1].SourceSHA = sourceSHA(11)
+			},
+			check: func(t *testing.T, env *testEnv, entries []*types.MergeQueueEntry) {
+				if env.git.merges != 4 {
+					t.Errorf("expected both merge refs to be rebuilt, got %d merges", env.git.merges)
+				}
+				env.requireChecking(t, entries[0], testTargetSHA, 1)
+				env.requireChecking(t, entries[1], entries[0].MergeSHA, 2)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := newTestEnv(t, testCheck)
+			env.addPullReq(1)
+			env.addPullReq(2)
+
+			test.setup(env)
+
+			removed, err := env.svc.buildMergeRefs(context.Background(), env.repo, env.queue.list(), testTargetSHA)
+			if err != nil {
+				t.Fatalf("buildMergeRefs() error = %v", err)
+			}
+			if removed != test.wantRemoved {
+				t.Errorf("buildMergeRefs() removed = %t, want %t", removed, test.wantRemoved)
+			}
+
+			test.check(t, env, env.queue.list())
+		})
+	}
+}
+
+func TestProcessHead(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        enum.CheckStatus
+		checkDuration time.Duration
+		wantProcessed bool
+		wantMerged    bool
+		wantReason    string
+	}{
+		{
+			name:          "not reported",
+			wantProcessed: false,
+		},
+		{
+			name:          "running",
+			status:        enum.CheckStatusRunning,
+			wantProcessed: false,
+		},
+		{
+			name:          "succeeded",
+			status:        enum.CheckStatusSuccess,
+			wantProcessed: true,
+			wantMerged:    true,
+		},
+		{
+			name:          "failed",
+			status:        enum.CheckStatusFailure,
+			wantProcessed: true,
+			wantReason:    `"ci" didn't succeed`,
+		},
+		{
+			name:          "timed out",
+			status:        enum.CheckStatusRunning,
+			checkDuration: 3 * time.Hour,
+			wantProcessed: true,
+			wantReason:    "didn't complete in time",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(t, testCheck)
+			env.addPullReq(1)
+
+			if _, err := env.svc.buildMergeRefs(ctx, env.repo, env.queue.list(), testTargetSHA); err != nil {
+				t.Fatalf("buildMergeRefs() error = %v", err)
+			}
+
+			head := env.queue.list()[0]
+			head.Updated = time.Now().Add(-test.checkDuration).UnixMilli()
+			if test.status != "" {
+				env.checks.report(head.MergeSHA, test.status)
+			}
+
+			processed, err := env.svc.processHead(ctx, env.repo, head, testTargetSHA)
+			if err != nil {
+				t.Fatalf("processHead() error = %v", err)
+			}
+			if processed != test.wantProcessed {
+				t.Errorf("processHead() processed = %t, want %t", processed, test.wantProcessed)
+			}
+
+			inQueue := len(env.queue.list()) == 1
+			if inQueue == test.wantProcessed {
+				t.Errorf("expected the head to be in the queue: %t, got %t", !test.wantProcessed, inQueue)
+			}
+
+			merged := env.pullreqs.prs[1].State == enum.PullReqStateMerged
+			if merged != test.wantMerged {
+				t.Errorf("expected the pull request to be merged: %t, got %t", test.wantMerged, merged)
+			}
+
+			if test.wantReason != "" {
+				env.requireRemoved(t, 1, test.wantReason)
+			}
+		})
+	}
+}
+
+func TestProcessJob(t *testing.T) {
+	env := newTestEnv(t)
+	env.git.branches["release"] = testTargetSHA
+	env.addPullReq(1)
+	env.addPullReq(2)
+	env.pullreqs.prs[2].TargetBranch = "release"
+	env.queue.entries[2].TargetBranch = "release"
+
+	job := &processJob{service: env.svc}
+	if _, err := job.Handle(context.Background(), "", nil); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	// without required status checks both pull requests get merged into their target branches
+	for _, number := range []int64{1, 2} {
+		if pr := env.pullreqs.prs[number]; pr.State != enum.PullReqStateMerged {
+			t.Errorf("expected pull request %d to be merged, got state %s", number, pr.State)
+		}
+	}
+	if entries := env.queue.list(); len(entries) != 0 {
+		t.Errorf("expected all queues to be empty, got %+v", entries)
+	}
+}
+
+type testEnv struct {
+	svc        *Service
+	repo       *types.Repository
+	git        *fakeGit
+	queue      *fakeMergeQueueStore
+	pullreqs   *fakePullReqStore
+	activities *fakeActivityStore
+	checks     *fakeCheckStore
+	triggers   *fakeTriggerStore
+}
+
+func newTestEnv(t *testing.T, requiredChecks ...string) *testEnv {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	principalStore := &fakePrincipalStore{}
+
+	config := &types.Config{}
+	config.Principal.System.UID = "gitness"
+	config.MergeQueue.CheckTimeout = 2 * time.Hour
+
+	if err := bootstrap.SystemService(ctx, config, service.NewController(nil, nil, principalStore)); err != nil {
+		t.Fatalf("failed to set up system service: %v", err)
+	}
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		Namespace:       "test",
+		MaxStreamLength: 100,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+
+	pullreqEvReporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reporter: %v", err)
+	}
+
+	gitReaderFactory, err := gitevents.NewReaderFactory(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create git event reader factory: %v", err)
+	}
+
+	pullreqEvReaderFactory, err := pullreqevents.NewReaderFactory(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reader factory: %v", err)
+	}
+
+	triggerStore := &fakeTriggerStore{}
+	triggerSvc, err := trigger.New(ctx, trigger.Config{EventReaderName: "test", Concurrency: 1},
+		triggerStore, nil, nil, nil, nil, nil, gitReaderFactory, pullreqEvReaderFactory)
+	if err != nil {
+		t.Fatalf("failed to create trigger service: %v", err)
+	}
+
+	ruleStore := &fakeRuleStore{}
+	if len(requiredChecks) > 0 {
+		definition, _ := json.Marshal(map[string]any{
+			"pullreq": map[string]any{
+				"status_checks": map[string]any{"require_identifiers": requiredChecks},
+			},
+		})
+		ruleStore.rules = []types.RuleInfoInternal{{
+			RuleInfo: types.RuleInfo{
+				ID:         1,
+				Identifier: "merge-queue",
+				Type:       protection.TypeBranch,
+				State:      enum.RuleStateActive,
+			},
+			Pattern:    []byte(`{"default":true}`),
+			Definition: definition,
+		}}
+	}
+
+	protectionManager, err := protection.ProvideManager(ruleStore, nil)
+	if err != nil {
+		t.Fatalf("failed to create protection manager: %v", err)
+	}
+
+	urlProvider, err := url.NewProvider("http://localhost:3000", "http://localhost:3000",
+		"http://localhost:3000/api", "http://localhost:3000/git", "localhost", "git", false,
+		"http://localhost:3000")
+	if err != nil {
+		t.Fatalf("failed to create url provider: %v", err)
+	}
+
+	repo := &types.Repository{
+		ID:            testRepoID,
+		ParentID:      1,
+		GitUID:        "repo",
+		Path:          "space/repo",
+		DefaultBranch: testTargetBranch,
+	}
+
+	env := &testEnv{
+		repo: repo,
+		git: &fakeGit{
+			branches:  map[string]string{testTargetBranch: testTargetSHA},
+			conflicts: map[string]bool{},
+		},
+		queue:      &fakeMergeQueueStore{entries: map[int64]*types.MergeQueueEntry{}},
+		pullreqs:   &fakePullReqStore{prs: map[int64]*types.PullReq{}},
+		activities: &fakeActivityStore{payloads: map[int64][]types.PullReqActivityPayload{}},
+		checks:     &fakeCheckStore{results: map[string][]types.CheckResult{}},
+		triggers:   triggerStore,
+	}
+
+	env.svc = &Service{
+		checkTimeout: config.MergeQueue.CheckTimeout,
+		git:          env.git,
+		urlProvider:  urlProvider,
+		locker: locker.NewLocker(lock.NewInMemory(lock.Config{
+			App:        "gitness",
+			Namespace:  "test",
+			Expiry:     time.Minute,
+			Tries:      1,
+			RetryDelay: time.Millisecond,
+		})),
+		mergeQueueStore:   env.queue,
+		pullreqStore:      env.pullreqs,
+		activityStore:     env.activities,
+		repoStore:         &fakeRepoStore{repo: repo},
+		principalStore:    principalStore,
+		checkStore:        env.checks,
+		protectionManager: protectionManager,
+		triggerSvc:        triggerSvc,
+		pullreqEvReporter: pullreqEvReporter,
+		sseStreamer:       fakeStreamer{},
+	}
+
+	return env
+}
+
+func sourceSHA(number int64) string {
+	return fmt.Sprintf("b%039d", number)
+}
+
+// addPullReq creates an open pull request with the provided number and adds it to the end of the merge queue.
+func (env *testEnv) addPullReq(number int64) {
+	pr := &types.PullReq{
+		ID:           number,
+		Number:       number,
+		State:        enum.PullReqStateOpen,
+		Title:        fmt.Sprintf("Pull request %d", number),
+		SourceRepoID: testRepoID,
+		SourceBranch: fmt.Sprintf("feature-%d", number),
+		SourceSHA:    sourceSHA(number),
+		TargetRepoID: testRepoID,
+		TargetBranch: testTargetBranch,
+	}
+	env.pullreqs.prs[number] = pr
+
+	env.queue.nextID++
+	env.queue.entries[env.queue.nextID] = &types.MergeQueueEntry{
+		ID:           env.queue.nextID,
+		RepoID:       testRepoID,
+		PullReqID:    pr.ID,
+		TargetBranch: pr.TargetBranch,
+		Method:       enum.MergeMethodMerge,
+		State:        enum.MergeQueueEntryStateQueued,
+		CreatedBy:    testPrincipalID,
+		Created:      time.Now().UnixMilli(),
+		Updated:      time.Now().UnixMilli(),
+	}
+}
+
+func (env *testEnv) requireChecking(t *testing.T, entry *types.MergeQueueEntry, baseSHA string, pullreqID int64) {
+	t.Helper()
+
+	pr := env.pullreqs.prs[pullreqID]
+	if entry.PullReqID != pullreqID || entry.State != enum.MergeQueueEntryStateChecking ||
+		entry.BaseSHA != baseSHA || entry.HeadSHA != pr.SourceSHA || entry.MergeSHA == "" {
+		t.Errorf("expected pull request %d to be checked on top of %s, got %+v", pullreqID, baseSHA, entry)
+	}
+	if got := env.git.bases[entry.MergeSHA]; got != baseSHA {
+		t.Errorf("expected the merge ref of pull request %d to be built on %s, got %s", pullreqID, baseSHA, got)
+	}
+}
+
+func (env *testEnv) requireRemoved(t *testing.T, pullreqID int64, reason string) {
+	t.Helper()
+
+	for _, payload := range env.activities.payloads[pullreqID] {
+		p, ok := payload.(*types.PullRequestActivityPayloadMergeQueue)
+		if ok && p.Action == enum.PullReqMergeQueueActionFailed && strings.Contains(p.Reason, reason) {
+			return
+		}
+	}
+
+	t.Errorf("expected pull request %d to be removed from the merge queue with reason %q, got %+v",
+		pullreqID, reason, env.activities.payloads[pullreqID])
+}
+
+type fakeGit struct {
+	git.Interface
+	branches  map[string]string
+	conflicts map[string]bool
+	bases     map[string]string
+	merges    int
+}
+
+func (g *fakeGit) GetRef(_ context.Context, params git.GetRefParams) (git.GetRefResponse, error) {
+	value, ok := g.branches[params.Name]
+	if params.Type != gitenum.RefTypeBranch || !ok {
+		return git.GetRefResponse{}, errors.NotFound("reference %q not found", params.Name)
+	}
+
+	return git.GetRefResponse{SHA: sha.Must(value)}, nil
+}
+
+func (g *fakeGit) Merge(_ context.Context, params *git.MergeParams) (git.MergeOutput, error) {
+	if g.conflicts[params.HeadBranch] {
+		return git.MergeOutput{ConflictFiles: []string{"file.txt"}}, nil
+	}
+
+	g.merges++
+	mergeSHA := fmt.Sprintf("%040x", g.merges)
+
+	if g.bases == nil {
+		g.bases = map[string]string{}
+	}
+	g.bases[mergeSHA] = params.BaseBranch
+
+	return git.MergeOutput{MergeSHA: sha.Must(mergeSHA)}, nil
+}
+
+func (g *fakeGit) UpdateRef(_ context.Context, params git.UpdateRefParams) error {
+	if params.Type == gitenum.RefTypeBranch {
+		g.branches[params.Name] = params.NewValue.String()
+	}
+
+	return nil
+}
+
+type fakeMergeQueueStore struct {
+	store.MergeQueueStore
+	entries map[int64]*types.MergeQueueEntry
+	nextID  int64
+}
+
+func (s *fakeMergeQueueStore) list() []*types.MergeQueueEntry {
+	entries := make([]*types.MergeQueueEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		e := *entry
+		entries = append(entries, &e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	return entries
+}
+
+func (s *fakeMergeQueueStore) List(
+	_ context.Context,
+	repoID int64,
+	targetBranch string,
+) ([]*types.MergeQueueEntry, error) {
+	var entries []*types.MergeQueueEntry
+	for _, entry := range s.list() {
+		if entry.RepoID == repoID && entry.TargetBranch == targetBranch {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func (s *fakeMergeQueueStore) ListHeads(context.Context) ([]*types.MergeQueueEntry, error) {
+	var heads []*types.MergeQueueEntry
+	seen := map[string]struct{}{}
+	for _, entry := range s.list() {
+		key := fmt.Sprintf("%d/%s", entry.RepoID, entry.TargetBranch)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		heads = append(heads, entry)
+	}
+
+	return heads, nil
+}
+
+func (s *fakeMergeQueueStore) Update(_ context.Context, entry *types.MergeQueueEntry) error {
+	stored, ok := s.entries[entry.ID]
+	if !ok || stored.Version != entry.Version {
+		return gitness_store.ErrVersionConflict
+	}
+
+	entry.Version++
+	entry.Updated = time.Now().UnixMilli()
+
+	e := *entry
+	s.entries[entry.ID] = &e
+
+	return nil
+}
+
+func (s *fakeMergeQueueStore) Delete(_ context.Context, id int64) error {
+	if _, ok := s.entries[id]; !ok {
+		return errors.NotFound("Merge queue entry not found")
+	}
+
+	delete(s.entries, id)
+
+	return nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	prs map[int64]*types.PullReq
+}
+
+func (s *fakePullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	p := *pr
+	return &p, nil
+}
+
+func (s *fakePullReqStore) UpdateOptLock(
+	ctx context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	p, err := s.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = mutateFn(p); err != nil {
+		return nil, err
+	}
+
+	p.Version++
+	s.prs[p.ID] = p
+
+	return s.Find(ctx, p.ID)
+}
+
+func (s *fakePullReqStore) UpdateActivitySeq(ctx context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	return s.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++
+		return nil
+	})
+}
+
+type fakeActivityStore struct {
+	store.PullReqActivityStore
+	payloads map[int64][]types.PullReqActivityPayload
+}
+
+func (s *fakeActivityStore) CreateWithPayload(
+	_ context.Context,
+	pr *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+) (*types.PullReqActivity, error) {
+	s.payloads[pr.ID] = append(s.payloads[pr.ID], payload)
+	return &types.PullReqActivity{}, nil
+}
+
+type fakeCheckStore struct {
+	store.CheckStore
+	results map[string][]types.CheckResult
+}
+
+func (s *fakeCheckStore) report(commitSHA string, status enum.CheckStatus) {
+	s.results[commitSHA] = []types.CheckResult{{Identifier: testCheck, Status: status}}
+}
+
+func (s *fakeCheckStore) ListResults(_ context.Context, _ int64, commitSHA string) ([]types.CheckResult, error) {
+	return s.results[commitSHA], nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	if id != s.repo.ID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	return s.repo, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (s *fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	return &types.Principal{
+		ID:          id,
+		UID:         fmt.Sprintf("user-%d", id),
+		Email:       fmt.Sprintf("user-%d@example.com", id),
+		DisplayName: fmt.Sprintf("User %d", id),
+		Type:        enum.PrincipalTypeUser,
+	}, nil
+}
+
+func (s *fakePrincipalStore) FindServiceByUID(_ context.Context, uid string) (*types.Service, error) {
+	return &types.Service{ID: 1, UID: uid, Admin: true}, nil
+}
+
+type fakeRuleStore struct {
+	store.RuleStore
+	rules []types.RuleInfoInternal
+}
+
+func (s *fakeRuleStore) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	return s.rules, nil
+}
+
+type fakeTriggerStore struct {
+	store.TriggerStore
+	calls int
+}
+
+func (s *fakeTriggerStore) ListAllEnabled(context.Context, int64) ([]*types.Trigger, error) {
+	s.calls++
+	return nil, nil
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+}
+
+func (fakeStreamer) Publish(context.Context, int64, enum.SSEType, any) error {
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	groupPullReq = "gitness:mergequeue:pullreq"
+	groupCheck   = "gitness:mergequeue:check"
+	groupGit     = "gitness:mergequeue:git"
+
+	jobTypeProcess        = "gitness:mergequeue:process"
+	jobCronProcess        = "*/5 * * * *"
+	jobMaxDurationProcess = 5 * time.Minute
+)
+
+// Service processes the merge queues of protected branches.
+//
+// For every pull request in a merge queue a merge ref is built on top of the merge ref of the previous pull request
+// in the queue (or the target branch for the head of the queue) and the pipelines of the repository are triggered
+// for it. Once all required status checks of the head of the queue succeed, the target branch is fast-forwarded
+// to its merge ref and the pull request is marked as merged. Pull requests that can't be merged or whose
+// required status checks fail are removed from the queue, and the merge refs of the remaining pull requests
+// are rebuilt.
+//
+// The queues are processed whenever a related event occurs. Additionally, all queues are processed periodically,
+// which removes the head of a queue whose required status checks didn't complete in time.
+type Service struct {
+	scheduler         *job.Scheduler
+	executor          *job.Executor
+	checkTimeout      time.Duration
+	git               git.Interface
+	urlProvider       url.Provider
+	locker            *locker.Locker
+	mergeQueueStore   store.MergeQueueStore
+	pullreqStore      store.PullReqStore
+	activityStore     store.PullReqActivityStore
+	repoStore         store.RepoStore
+	principalStore    store.PrincipalStore
+	checkStore        store.CheckStore
+	protectionManager *protection.Manager
+	triggerSvc        *trigger.Service
+	pullreqEvReporter *pullreqevents.Reporter
+	sseStreamer       sse.Streamer
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	git git.Interface,
+	urlProvider url.Provider,
+	locker *locker.Locker,
+	mergeQueueStore store.MergeQueueStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	protectionManager *protection.Manager,
+	triggerSvc *trigger.Service,
+	pullreqEvReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+) (*Service, error) {
+	service := &Service{
+		scheduler:         scheduler,
+		executor:          executor,
+		checkTimeout:      config.MergeQueue.CheckTimeout,
+		git:               git,
+		urlProvider:       urlProvider,
+		locker:            locker,
+		mergeQueueStore:   mergeQueueStore,
+		pullreqStore:      pullreqStore,
+		activityStore:     activityStore,
+		repoStore:         repoStore,
+		principalStore:    principalStore,
+		checkStore:        checkStore,
+		protectionManager: protectionManager,
+		triggerSvc:        triggerSvc,
+		pullreqEvReporter: pullreqEvReporter,
+		sseStreamer:       sseStreamer,
+	}
+
+	const idleTimeout = 10 * time.Minute
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReq, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterMergeQueueUpdated(service.processOnMergeQueueUpdated)
+			_ = r.RegisterBranchUpdated(service.removeOnBranchUpdated)
+			_ = r.RegisterClosed(service.removeOnClosed)
+			_ = r.RegisterMerged(service.removeOnMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for merge queue: %w", err)
+	}
+
+	_, err = checkEvReaderFactory.Launch(ctx, groupCheck, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterStatusReported(service.processOnCheckStatusReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for merge queue: %w", err)
+	}
+
+	_, err = gitReaderFactory.Launch(ctx, groupGit, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchUpdated(service.processOnBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for merge queue: %w", err)
+	}
+
+	return service, nil
+}
+
+// Register registers the job handler of the service and the recurring job that processes all merge queues.
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobTypeProcess, &processJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register job handler for merge queue processing: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeProcess, jobTypeProcess, jobCronProcess, jobMaxDurationProcess)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for merge queues: %w", err)
+	}
+
+	return nil
+}
@@ -32,6 +32,7 @@ const (
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplateWebhookDisabled      = "webhook_disabled.html"
 )
 
 type MailClient struct {
@@ -169,6 +170,29 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendWebhookDisabled(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *WebhookDisabledPayload,
+) error {
+	body, err := GetHTMLBody(TemplateWebhookDisabled, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail body for disabled webhook: %w", err)
+	}
+
+	var email mailer.Payload
+	email.Body = string(body)
+	if payload.Repo != nil {
+		email.Subject = fmt.Sprintf(subjectWebhookDisabled, payload.Repo.Identifier, payload.Webhook.DisplayName)
+		email.RepoRef = payload.Repo.Path
+	} else {
+		email.Subject = fmt.Sprintf(subjectWebhookDisabled, payload.Space.Identifier, payload.Webhook.DisplayName)
+	}
+	email.ToRecipients = RetrieveEmailsFromPrincipals(recipients)
+
+	return m.Mailer.Send(ctx, email)
+}
+
 func GetSubjectPullRequest(
 	repoIdentifier string,
 	prNum int64,
@@ -34,6 +34,8 @@ const (
 	eventReaderGroupName = "gitness:notification"
 	templatesDir         = "templates"
 	subjectPullReqEvent  = "[%s] %s (PR #%d)"
+
+	subjectWebhookDisabled = "[%s] Webhook %s has been disabled"
 )
 
 var (
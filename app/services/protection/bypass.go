@@ -23,14 +23,25 @@ import (
 )
 
 type DefBypass struct {
-	UserIDs    []int64 `json:"user_ids,omitempty"`
-	RepoOwners bool    `json:"repo_owners,omitempty"`
+	UserIDs      []int64 `json:"user_ids,omitempty"`
+	UserGroupIDs []int64 `json:"user_group_ids,omitempty"`
+	RepoOwners   bool    `json:"repo_owners,omitempty"`
 }
 
-func (v DefBypass) matches(actor *types.Principal, isRepoOwner bool) bool {
+func (v DefBypass) matches(actor *types.Principal, isRepoOwner bool, actorUserGroupIDs []int64) bool {
 	return actor != nil &&
 		(v.RepoOwners && isRepoOwner ||
-			slices.Contains(v.UserIDs, actor.ID))
+			slices.Contains(v.UserIDs, actor.ID) ||
+			intersects(v.UserGroupIDs, actorUserGroupIDs))
+}
+
+func intersects(a, b []int64) bool {
+	for _, id := range a {
+		if slices.Contains(b, id) {
+			return true
+		}
+	}
+	return false
 }
 
 func (v DefBypass) Sanitize() error {
@@ -38,5 +49,9 @@ func (v DefBypass) Sanitize() error {
 		return fmt.Errorf("user IDs error: %w", err)
 	}
 
+	if err := validateIDSlice(v.UserGroupIDs); err != nil {
+		return fmt.Errorf("user group IDs error: %w", err)
+	}
+
 	return nil
 }
@@ -29,6 +29,7 @@ func TestBranch_matches(t *testing.T) {
 		bypass DefBypass
 		actor  *types.Principal
 		owner  bool
+		groups []int64
 		exp    bool
 	}{
 		{
@@ -70,6 +71,20 @@ func TestBranch_matches(t *testing.T) {
 			actor:  user,
 			exp:    true,
 		},
+		{
+			name:   "user-group-false",
+			bypass: DefBypass{UserGroupIDs: []int64{3, 5}},
+			actor:  user,
+			groups: []int64{4},
+			exp:    false,
+		},
+		{
+			name:   "user-group-true",
+			bypass: DefBypass{UserGroupIDs: []int64{3, 5}},
+			actor:  user,
+			groups: []int64{4, 5},
+			exp:    true,
+		},
 	}
 
 	for _, test := range tests {
@@ -78,7 +93,7 @@ func TestBranch_matches(t *testing.T) {
 				t.Errorf("invalid: %s", err.Error())
 			}
 
-			if want, got := test.exp, test.bypass.matches(test.actor, test.owner); want != got {
+			if want, got := test.exp, test.bypass.matches(test.actor, test.owner, test.groups); want != got {
 				t.Errorf("want=%t got=%t", want, got)
 			}
 		})
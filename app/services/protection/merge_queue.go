@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type (
+	MergeQueueProvider interface {
+		MergeQueueConfig(ctx context.Context, in MergeQueueInput) (MergeQueueOutput, error)
+	}
+
+	MergeQueueInput struct {
+		Repo         *types.Repository
+		TargetBranch string
+	}
+
+	MergeQueueOutput struct {
+		Enabled bool
+		Method  enum.MergeMethod
+	}
+)
+
+// ensures that the DefMergeQueue type implements Sanitizer, MergeVerifier and MergeQueueProvider interfaces.
+var (
+	_ Sanitizer          = (*DefMergeQueue)(nil)
+	_ MergeVerifier      = (*DefMergeQueue)(nil)
+	_ MergeQueueProvider = (*DefMergeQueue)(nil)
+)
+
+const (
+	codePullReqMergeQueueRequired = "pullreq.merge.queue_required"
+)
+
+// DefMergeQueue configures the merge queue of protected branches.
+// When enabled, pull requests can't be merged directly, but are added to the merge queue instead.
+// The merge queue builds a merge ref for every pull request on top of the previous one in the queue
+// and advances the branch only when the required status checks of the head of the queue succeed.
+type DefMergeQueue struct {
+	Enabled bool             `json:"enabled,omitempty"`
+	Method  enum.MergeMethod `json:"method,omitempty"`
+}
+
+// IsMergeQueueRequired returns true if the violations require the pull request to be merged using the merge queue.
+func IsMergeQueueRequired(violations []types.RuleViolations) bool {
+	for i := range violations {
+		if !violations[i].IsCritical() {
+			continue
+		}
+
+		for _, violation := range violations[i].Violations {
+			if violation.Code == codePullReqMergeQueueRequired {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (v *DefMergeQueue) MergeVerify(
+	_ context.Context,
+	in MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	if !v.Enabled || in.MergeQueue {
+		return MergeVerifyOutput{}, nil, nil
+	}
+
+	var violations types.RuleViolations
+
+	violations.Add(codePullReqMergeQueueRequired,
+		"Pull requests targeting this branch must be merged using the merge queue.")
+
+	return MergeVerifyOutput{}, []types.RuleViolations{violations}, nil
+}
+
+func (v *DefMergeQueue) RequiredChecks(
+	context.Context,
+	RequiredChecksInput,
+) (RequiredChecksOutput, error) {
+	return RequiredChecksOutput{}, nil
+}
+
+func (v *DefMergeQueue) MergeQueueConfig(
+	context.Context,
+	MergeQueueInput,
+) (MergeQueueOutput, error) {
+	if !v.Enabled {
+		return MergeQueueOutput{}, nil
+	}
+
+	method := v.Method
+	if method == "" {
+		method = enum.MergeMethodMerge
+	}
+
+	return MergeQueueOutput{
+		Enabled: true,
+		Method:  method,
+	}, nil
+}
+
+func (v *DefMergeQueue) Sanitize() error {
+	if v.Method == "" {
+		return nil
+	}
+
+	method, ok := v.Method.Sanitize()
+	if !ok {
+		return fmt.Errorf("unrecognized merge method: %s", v.Method)
+	}
+
+	if method == enum.MergeMethodFastForward {
+		return errors.New("fast-forward merge method can't be used with the merge queue")
+	}
+
+	v.Method = method
+
+	return nil
+}
@@ -25,9 +25,11 @@ const TypeBranch types.RuleType = "branch"
 
 // Branch implements protection rules for the rule type TypeBranch.
 type Branch struct {
-	Bypass    DefBypass    `json:"bypass"`
-	PullReq   DefPullReq   `json:"pullreq"`
-	Lifecycle DefLifecycle `json:"lifecycle"`
+	Bypass     DefBypass     `json:"bypass"`
+	PullReq    DefPullReq    `json:"pullreq"`
+	Lifecycle  DefLifecycle  `json:"lifecycle"`
+	MergeQueue DefMergeQueue `json:"merge_queue"`
+	Signatures DefSignatures `json:"signatures"`
 }
 
 var (
@@ -44,7 +46,20 @@ func (v *Branch) MergeVerify(
 		return
 	}
 
-	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
+	_, queueViolations, err := v.MergeQueue.MergeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	_, signatureViolations, err := v.Signatures.MergeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	// report all violations of the rule together
+	violations = combineViolations(violations, queueViolations, signatureViolations)
+
+	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner, in.actorUserGroupIDs)
 	bypassed := in.AllowBypass && bypassable
 	for i := range violations {
 		violations[i].Bypassable = bypassable
@@ -73,7 +88,7 @@ func (v *Branch) RequiredChecks(
 		bypassableIDs map[string]struct{}
 	)
 
-	if bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner); bypassable {
+	if bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner, in.actorUserGroupIDs); bypassable {
 		bypassableIDs = ids
 	} else {
 		requiredIDs = ids
@@ -94,8 +109,19 @@ func (v *Branch) RefChangeVerify(
 	}
 
 	violations, err = v.Lifecycle.RefChangeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	signatureViolations, err := v.Signatures.RefChangeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	// report all violations of the rule together
+	violations = combineViolations(violations, signatureViolations)
 
-	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
+	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner, in.actorUserGroupIDs)
 	bypassed := in.AllowBypass && bypassable
 	for i := range violations {
 		violations[i].Bypassable = bypassable
@@ -105,10 +131,35 @@ func (v *Branch) RefChangeVerify(
 	return
 }
 
+func (v *Branch) MergeQueueConfig(
+	ctx context.Context,
+	in MergeQueueInput,
+) (MergeQueueOutput, error) {
+	return v.MergeQueue.MergeQueueConfig(ctx, in)
+}
+
 func (v *Branch) UserIDs() ([]int64, error) {
 	return v.Bypass.UserIDs, nil
 }
 
+func (v *Branch) UserGroupIDs() ([]int64, error) {
+	return v.Bypass.UserGroupIDs, nil
+}
+
+// combineViolations merges violations of all parts of the rule into a single types.RuleViolations.
+func combineViolations(violations []types.RuleViolations, others ...[]types.RuleViolations) []types.RuleViolations {
+	for _, other := range others {
+		for i := range other {
+			if len(violations) == 0 {
+				violations = []types.RuleViolations{{}}
+			}
+			violations[0].Violations = append(violations[0].Violations, other[i].Violations...)
+		}
+	}
+
+	return violations
+}
+
 func (v *Branch) Sanitize() error {
 	if err := v.Bypass.Sanitize(); err != nil {
 		return fmt.Errorf("bypass: %w", err)
@@ -122,5 +173,13 @@ func (v *Branch) Sanitize() error {
 		return fmt.Errorf("lifecycle: %w", err)
 	}
 
+	if err := v.MergeQueue.Sanitize(); err != nil {
+		return fmt.Errorf("merge queue: %w", err)
+	}
+
+	if err := v.Signatures.Sanitize(); err != nil {
+		return fmt.Errorf("signatures: %w", err)
+	}
+
 	return nil
 }
@@ -139,6 +139,72 @@ func TestBranch_MergeVerify(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "merge-queue-required",
+			branch: Branch{
+				PullReq: DefPullReq{
+					StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"abc"}},
+				},
+				MergeQueue: DefMergeQueue{Enabled: true},
+			},
+			in: MergeVerifyInput{
+				Actor:   user,
+				PullReq: &types.PullReq{},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+			expVs: []types.RuleViolations{
+				{
+					Violations: []types.Violation{
+						{Code: codePullReqStatusChecksReqIdentifiers},
+						{Code: codePullReqMergeQueueRequired},
+					},
+				},
+			},
+		},
+		{
+			name: "merge-queue-verify",
+			branch: Branch{
+				PullReq: DefPullReq{
+					StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"abc"}},
+				},
+				MergeQueue: DefMergeQueue{Enabled: true},
+			},
+			in: MergeVerifyInput{
+				Actor:      user,
+				PullReq:    &types.PullReq{},
+				MergeQueue: true,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "signatures-unverified",
+			branch: Branch{
+				Signatures: DefSignatures{RequireVerified: true},
+			},
+			in: MergeVerifyInput{
+				Actor:   user,
+				PullReq: &types.PullReq{TargetBranch: "main"},
+				VerifyCommitSignatures: func(context.Context, string) (VerifyCommitSignaturesOutput, error) {
+					return VerifyCommitSignaturesOutput{Unverified: []string{"abc", "def"}}, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+			expVs: []types.RuleViolations{
+				{
+					Violations: []types.Violation{
+						{Code: codeSignaturesUnverified},
+						{Code: codeSignaturesUnverified},
+					},
+				},
+			},
+		},
 		{
 			name: "merge-methods",
 			branch: Branch{
@@ -227,6 +293,7 @@ func TestBranch_MergeVerify(t *testing.T) {
 			},
 			expOut: MergeVerifyOutput{
 				AllowedMethods: []enum.MergeMethod{
+					enum.MergeMethodFastForward,
 					enum.MergeMethodMerge,
 					enum.MergeMethodRebase,
 					enum.MergeMethodSquash,
@@ -480,6 +547,50 @@ func TestBranch_RefChangeVerify(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "signatures-unverified",
+			branch: Branch{
+				Bypass:     DefBypass{RepoOwners: true},
+				Signatures: DefSignatures{RequireVerified: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				IsRepoOwner: true,
+				RefAction:   RefActionUpdate,
+				RefType:     RefTypeBranch,
+				RefNames:    []string{"abc"},
+				VerifyCommitSignatures: func(context.Context, string) (VerifyCommitSignaturesOutput, error) {
+					return VerifyCommitSignaturesOutput{Unverified: []string{"def"}, TooManyCommits: true}, nil
+				},
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{
+						{Code: codeSignaturesTooManyCommits},
+						{Code: codeSignaturesUnverified},
+					},
+				},
+			},
+		},
+		{
+			name: "signatures-delete",
+			branch: Branch{
+				Signatures: DefSignatures{RequireVerified: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:     user,
+				RefAction: RefActionDelete,
+				RefType:   RefTypeBranch,
+				RefNames:  []string{"abc"},
+				VerifyCommitSignatures: func(context.Context, string) (VerifyCommitSignaturesOutput, error) {
+					return VerifyCommitSignaturesOutput{Unverified: []string{"def"}}, nil
+				},
+			},
+			expVs: []types.RuleViolations{},
+		},
 	}
 
 	ctx := context.Background()
@@ -34,8 +34,10 @@ type (
 	Protection interface {
 		MergeVerifier
 		RefChangeVerifier
+		MergeQueueProvider
 
 		UserIDs() ([]int64, error)
+		UserGroupIDs() ([]int64, error)
 	}
 
 	Definition interface {
@@ -48,8 +50,9 @@ type (
 
 	// Manager is used to enforce protection rules.
 	Manager struct {
-		defGenMap map[types.RuleType]DefinitionGenerator
-		ruleStore store.RuleStore
+		defGenMap      map[types.RuleType]DefinitionGenerator
+		ruleStore      store.RuleStore
+		userGroupStore store.UserGroupStore
 	}
 )
 
@@ -79,10 +82,11 @@ func IsBypassed(violations []types.RuleViolations) bool {
 }
 
 // NewManager creates new protection Manager.
-func NewManager(ruleStore store.RuleStore) *Manager {
+func NewManager(ruleStore store.RuleStore, userGroupStore store.UserGroupStore) *Manager {
 	return &Manager{
-		defGenMap: make(map[types.RuleType]DefinitionGenerator),
-		ruleStore: ruleStore,
+		defGenMap:      make(map[types.RuleType]DefinitionGenerator),
+		ruleStore:      ruleStore,
+		userGroupStore: userGroupStore,
 	}
 }
 
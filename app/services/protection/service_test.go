@@ -76,6 +76,62 @@ func TestIsCritical(t *testing.T) {
 	}
 }
 
+func TestIsMergeQueueRequired(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []types.RuleViolations
+		exp   bool
+	}{
+		{
+			name:  "empty",
+			input: []types.RuleViolations{},
+			exp:   false,
+		},
+		{
+			name: "other-violations",
+			input: []types.RuleViolations{
+				{
+					Rule:       types.RuleInfo{State: enum.RuleStateActive},
+					Violations: []types.Violation{{Code: codePullReqApprovalReqMinCount}},
+				},
+			},
+			exp: false,
+		},
+		{
+			name: "bypassed",
+			input: []types.RuleViolations{
+				{
+					Rule:       types.RuleInfo{State: enum.RuleStateActive},
+					Bypassed:   true,
+					Violations: []types.Violation{{Code: codePullReqMergeQueueRequired}},
+				},
+			},
+			exp: false,
+		},
+		{
+			name: "required",
+			input: []types.RuleViolations{
+				{
+					Rule: types.RuleInfo{State: enum.RuleStateActive},
+					Violations: []types.Violation{
+						{Code: codePullReqApprovalReqMinCount},
+						{Code: codePullReqMergeQueueRequired},
+					},
+				},
+			},
+			exp: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if want, got := test.exp, IsMergeQueueRequired(test.input); want != got {
+				t.Errorf("want=%t got=%t", want, got)
+			}
+		})
+	}
+}
+
 func TestManager_SanitizeJSON(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -105,7 +161,7 @@ func TestManager_SanitizeJSON(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			m := NewManager(nil)
+			m := NewManager(nil, nil)
 
 			err := func() error {
 				for _, ruleType := range test.ruleTypes {
@@ -43,7 +43,13 @@ func (s ruleSet) MergeVerify(
 		out.AllowedMethods = slices.Clone(enum.MergeMethods)
 	}
 
-	err := s.forEachRuleMatchBranch(in.TargetRepo.DefaultBranch, in.PullReq.TargetBranch,
+	var err error
+	in.actorUserGroupIDs, err = s.actorUserGroupIDs(ctx, in.Actor)
+	if err != nil {
+		return out, nil, err
+	}
+
+	err = s.forEachRuleMatchBranch(in.TargetRepo.DefaultBranch, in.PullReq.TargetBranch,
 		func(r *types.RuleInfoInternal, p Protection) error {
 			rOut, rVs, err := p.MergeVerify(ctx, in)
 			if err != nil {
@@ -74,9 +80,15 @@ func (s ruleSet) RequiredChecks(
 	ctx context.Context,
 	in RequiredChecksInput,
 ) (RequiredChecksOutput, error) {
+	var err error
+	in.actorUserGroupIDs, err = s.actorUserGroupIDs(ctx, in.Actor)
+	if err != nil {
+		return RequiredChecksOutput{}, err
+	}
+
 	requiredIDMap := map[string]struct{}{}
 	bypassableIDMap := map[string]struct{}{}
-	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.PullReq.TargetBranch,
+	err = s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.PullReq.TargetBranch,
 		func(_ *types.RuleInfoInternal, p Protection) error {
 			out, err := p.RequiredChecks(ctx, in)
 			if err != nil {
@@ -106,10 +118,49 @@ func (s ruleSet) RequiredChecks(
 	}, nil
 }
 
+func (s ruleSet) MergeQueueConfig(
+	ctx context.Context,
+	in MergeQueueInput,
+) (MergeQueueOutput, error) {
+	var out MergeQueueOutput
+
+	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.TargetBranch,
+		func(_ *types.RuleInfoInternal, p Protection) error {
+			rOut, err := p.MergeQueueConfig(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			// the first rule that enables the merge queue determines the merge method
+			if rOut.Enabled && !out.Enabled {
+				out = rOut
+			}
+
+			return nil
+		})
+	if err != nil {
+		return MergeQueueOutput{}, fmt.Errorf("failed to get merge queue configuration: %w", err)
+	}
+
+	return out, nil
+}
+
 func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations []types.RuleViolations
 
-	err := s.forEachRuleMatchRefs(in.Repo.DefaultBranch, in.RefNames,
+	// the default branch pattern applies only to branches.
+	defaultBranch := in.Repo.DefaultBranch
+	if in.RefType != RefTypeBranch {
+		defaultBranch = ""
+	}
+
+	var err error
+	in.actorUserGroupIDs, err = s.actorUserGroupIDs(ctx, in.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.forEachRuleMatchRefs(defaultBranch, in.RefNames,
 		func(r *types.RuleInfoInternal, p Protection, matched []string) error {
 			ruleIn := in
 			ruleIn.RefNames = matched
@@ -156,6 +207,56 @@ func (s ruleSet) UserIDs() ([]int64, error) {
 	return result, nil
 }
 
+// actorUserGroupIDs returns IDs of all user groups the actor is a member of.
+// The user group membership is relevant only for rules that can be bypassed by user groups.
+func (s ruleSet) actorUserGroupIDs(ctx context.Context, actor *types.Principal) ([]int64, error) {
+	if actor == nil || s.manager.userGroupStore == nil {
+		return nil, nil
+	}
+
+	bypassGroupIDs, err := s.UserGroupIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bypassGroupIDs) == 0 {
+		return nil, nil
+	}
+
+	groupIDs, err := s.manager.userGroupStore.ListIDsForPrincipal(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user groups of the actor: %w", err)
+	}
+
+	return groupIDs, nil
+}
+
+func (s ruleSet) UserGroupIDs() ([]int64, error) {
+	mapIDs := make(map[int64]struct{})
+	err := s.forEachRule(func(_ *types.RuleInfoInternal, p Protection) error {
+		groupIDs, err := p.UserGroupIDs()
+		if err != nil {
+			return err
+		}
+
+		for _, groupID := range groupIDs {
+			mapIDs[groupID] = struct{}{}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]int64, 0, len(mapIDs))
+	for groupID := range mapIDs {
+		result = append(result, groupID)
+	}
+
+	return result, nil
+}
+
 func (s ruleSet) forEachRule(
 	fn func(r *types.RuleInfoInternal, p Protection) error,
 ) error {
@@ -186,6 +287,11 @@ func (s ruleSet) forEachRuleMatchBranch(
 	for i := range s.rules {
 		r := s.rules[i]
 
+		// only branch rules apply to pull requests, other rule types would match any branch name.
+		if r.Type != TypeBranch {
+			continue
+		}
+
 		matches, err := matchesName(r.Pattern, defaultBranch, branchName)
 		if err != nil {
 			return err
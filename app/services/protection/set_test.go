@@ -171,6 +171,45 @@ func TestRuleSet_MergeVerify(t *testing.T) {
 			},
 			expViol: []types.RuleViolations{},
 		},
+		{
+			name: "branch-and-tag-rules",
+			rules: []types.RuleInfoInternal{
+				{
+					RuleInfo: types.RuleInfo{
+						SpacePath:  "",
+						RepoPath:   "space/repo",
+						ID:         1,
+						Identifier: "rule1",
+						Type:       TypeBranch,
+						State:      enum.RuleStateActive,
+					},
+					Pattern:    []byte(`{"default":true}`),
+					Definition: []byte(`{"pullreq":{"merge":{"strategies_allowed":["merge","rebase"]}}}`),
+				},
+				{
+					RuleInfo: types.RuleInfo{
+						SpacePath:  "",
+						RepoPath:   "space/repo",
+						ID:         2,
+						Identifier: "rule2",
+						Type:       TypeTag,
+						State:      enum.RuleStateActive,
+					},
+					Pattern:    []byte(`{}`),
+					Definition: []byte(`{"lifecycle":{"delete_forbidden":true}}`),
+				},
+			},
+			input: MergeVerifyInput{
+				Actor:      &types.Principal{ID: 1},
+				TargetRepo: &types.Repository{ID: 1, DefaultBranch: "main"},
+				PullReq:    &types.PullReq{ID: 1, SourceBranch: "pr", TargetBranch: "main"},
+			},
+			expOut: MergeVerifyOutput{
+				DeleteSourceBranch: false,
+				AllowedMethods:     []enum.MergeMethod{enum.MergeMethodMerge, enum.MergeMethodRebase},
+			},
+			expViol: []types.RuleViolations{},
+		},
 		{
 			name: "combine-definition-values",
 			rules: []types.RuleInfoInternal{
@@ -325,10 +364,13 @@ func TestRuleSet_MergeVerify(t *testing.T) {
 
 	ctx := context.Background()
 
-	m := NewManager(nil)
+	m := NewManager(nil, nil)
 	_ = m.Register(TypeBranch, func() Definition {
 		return &Branch{}
 	})
+	_ = m.Register(TypeTag, func() Definition {
+		return &Tag{}
+	})
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -438,7 +480,7 @@ func TestRuleSet_RequiredChecks(t *testing.T) {
 
 	ctx := context.Background()
 
-	m := NewManager(nil)
+	m := NewManager(nil, nil)
 	_ = m.Register(TypeBranch, func() Definition {
 		return &Branch{}
 	})
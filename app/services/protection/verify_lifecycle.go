@@ -33,6 +33,11 @@ type (
 		RefAction   RefAction
 		RefType     RefType
 		RefNames    []string
+		// VerifyCommitSignatures verifies the signatures of the new commits of created and updated branches.
+		VerifyCommitSignatures VerifyCommitSignaturesFunc
+
+		// actorUserGroupIDs is populated by the rule set with the IDs of the user groups the actor is a member of.
+		actorUserGroupIDs []int64
 	}
 
 	RefType int
@@ -73,19 +78,29 @@ const (
 func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations types.RuleViolations
 
+	refTypeName := "branch"
+	if in.RefType == RefTypeTag {
+		refTypeName = "tag"
+	}
+
 	switch in.RefAction {
 	case RefActionCreate:
 		if v.CreateForbidden {
 			violations.Addf(codeLifecycleCreate,
-				"Creation of branch %q is not allowed.", in.RefNames[0])
+				"Creation of "+refTypeName+" %q is not allowed.", in.RefNames[0])
 		}
 	case RefActionDelete:
 		if v.DeleteForbidden {
 			violations.Addf(codeLifecycleDelete,
-				"Delete of branch %q is not allowed.", in.RefNames[0])
+				"Delete of "+refTypeName+" %q is not allowed.", in.RefNames[0])
 		}
 	case RefActionUpdate:
-		if v.UpdateForbidden {
+		switch {
+		case !v.UpdateForbidden:
+		case in.RefType == RefTypeTag:
+			violations.Addf(codeLifecycleUpdate,
+				"Update of tag %q is not allowed.", in.RefNames[0])
+		default:
 			violations.Addf(codeLifecycleUpdate,
 				"Push to branch %q is not allowed. Please use pull requests.", in.RefNames[0])
 		}
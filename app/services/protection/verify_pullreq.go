@@ -45,6 +45,14 @@ type (
 		Method       enum.MergeMethod
 		CheckResults []types.CheckResult
 		CodeOwners   *codeowners.Evaluation
+		// MergeQueue is set when the pull request is verified for being added to the merge queue.
+		// The status checks are then verified on the merge ref built by the merge queue instead.
+		MergeQueue bool
+		// VerifyCommitSignatures verifies the signatures of the pull request commits.
+		VerifyCommitSignatures VerifyCommitSignaturesFunc
+
+		// actorUserGroupIDs is populated by the rule set with the IDs of the user groups the actor is a member of.
+		actorUserGroupIDs []int64
 	}
 
 	MergeVerifyOutput struct {
@@ -63,6 +71,9 @@ type (
 		IsRepoOwner bool
 		Repo        *types.Repository
 		PullReq     *types.PullReq
+
+		// actorUserGroupIDs is populated by the rule set with the IDs of the user groups the actor is a member of.
+		actorUserGroupIDs []int64
 	}
 
 	RequiredChecksOutput struct {
@@ -202,6 +213,10 @@ func (v *DefPullReq) MergeVerify(
 
 	var violatingStatusCheckIdentifiers []string
 	for _, requiredIdentifier := range v.StatusChecks.RequireIdentifiers {
+		if in.MergeQueue {
+			break
+		}
+
 		var succeeded bool
 		for i := range in.CheckResults {
 			if in.CheckResults[i].Identifier == requiredIdentifier {
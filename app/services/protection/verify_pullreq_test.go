@@ -317,6 +317,33 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			},
 			expOut: MergeVerifyOutput{},
 		},
+		{
+			name: codePullReqMergeStrategiesAllowed + "-fast-forward-fail",
+			def: DefPullReq{Merge: DefMerge{StrategiesAllowed: []enum.MergeMethod{
+				enum.MergeMethodFastForward,
+			}}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodRebase,
+			},
+			expCodes: []string{codePullReqMergeStrategiesAllowed},
+			expParams: [][]any{{
+				enum.MergeMethodRebase,
+				[]enum.MergeMethod{
+					enum.MergeMethodFastForward,
+				}},
+			},
+			expOut: MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqMergeStrategiesAllowed + "-fast-forward-success",
+			def: DefPullReq{Merge: DefMerge{StrategiesAllowed: []enum.MergeMethod{
+				enum.MergeMethodFastForward,
+			}}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodFastForward,
+			},
+			expOut: MergeVerifyOutput{},
+		},
 		{
 			name: codePullReqMergeDeleteBranch,
 			def:  DefPullReq{Merge: DefMerge{DeleteBranch: true}},
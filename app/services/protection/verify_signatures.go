@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+type (
+	// VerifyCommitSignaturesFunc verifies the signatures of the commits that a change introduces to a branch.
+	// For pushes these are the new commits of the branch, for pull requests the commits of the pull request.
+	// It's called only by rules that require signed commits, because the verification is expensive.
+	VerifyCommitSignaturesFunc func(ctx context.Context, branch string) (VerifyCommitSignaturesOutput, error)
+
+	VerifyCommitSignaturesOutput struct {
+		// Unverified contains the SHAs of the commits that aren't signed or whose signature isn't verified.
+		Unverified []string
+		// TooManyCommits is set if there were too many commits to verify all of them.
+		TooManyCommits bool
+	}
+
+	// DefSignatures defines the signature requirements for the commits of protected branches.
+	DefSignatures struct {
+		RequireVerified bool `json:"require_verified,omitempty"`
+	}
+)
+
+// ensures that the DefSignatures type implements Sanitizer, MergeVerifier and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefSignatures)(nil)
+	_ MergeVerifier     = (*DefSignatures)(nil)
+	_ RefChangeVerifier = (*DefSignatures)(nil)
+)
+
+const (
+	codeSignaturesUnverified     = "signatures.unverified"
+	codeSignaturesTooManyCommits = "signatures.too_many_commits"
+)
+
+// maxReportedUnverifiedCommits is the max number of unverified commits that are reported individually.
+const maxReportedUnverifiedCommits = 10
+
+func (v *DefSignatures) MergeVerify(
+	ctx context.Context,
+	in MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	if !v.RequireVerified || in.VerifyCommitSignatures == nil {
+		return MergeVerifyOutput{}, nil, nil
+	}
+
+	violations, err := v.verify(ctx, in.VerifyCommitSignatures, in.PullReq.TargetBranch)
+	if err != nil {
+		return MergeVerifyOutput{}, nil, err
+	}
+
+	return MergeVerifyOutput{}, violations, nil
+}
+
+func (v *DefSignatures) RequiredChecks(
+	context.Context,
+	RequiredChecksInput,
+) (RequiredChecksOutput, error) {
+	return RequiredChecksOutput{}, nil
+}
+
+func (v *DefSignatures) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	if !v.RequireVerified || in.VerifyCommitSignatures == nil || in.RefType != RefTypeBranch {
+		return nil, nil
+	}
+
+	if in.RefAction != RefActionCreate && in.RefAction != RefActionUpdate {
+		return nil, nil
+	}
+
+	var violations []types.RuleViolations
+
+	for _, branch := range in.RefNames {
+		branchViolations, err := v.verify(ctx, in.VerifyCommitSignatures, branch)
+		if err != nil {
+			return nil, err
+		}
+
+		violations = append(violations, branchViolations...)
+	}
+
+	return violations, nil
+}
+
+func (v *DefSignatures) verify(
+	ctx context.Context,
+	verifyFn VerifyCommitSignaturesFunc,
+	branch string,
+) ([]types.RuleViolations, error) {
+	out, err := verifyFn(ctx, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
+	var violations types.RuleViolations
+
+	if out.TooManyCommits {
+		violations.Addf(codeSignaturesTooManyCommits,
+			"Too many new commits for branch %q to verify their signatures.", branch)
+	}
+
+	for i, sha := range out.Unverified {
+		if i == maxReportedUnverifiedCommits {
+			violations.Addf(codeSignaturesUnverified,
+				"%d more commits for branch %q don't have a verified signature.",
+				len(out.Unverified)-maxReportedUnverifiedCommits, branch)
+			break
+		}
+
+		violations.Addf(codeSignaturesUnverified,
+			"Commit %s doesn't have a verified signature, which is required for branch %q.", sha, branch)
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (*DefSignatures) Sanitize() error {
+	return nil
+}
@@ -24,12 +24,16 @@ var WireSet = wire.NewSet(
 	ProvideManager,
 )
 
-func ProvideManager(ruleStore store.RuleStore) (*Manager, error) {
-	m := NewManager(ruleStore)
+func ProvideManager(ruleStore store.RuleStore, userGroupStore store.UserGroupStore) (*Manager, error) {
+	m := NewManager(ruleStore, userGroupStore)
 
 	if err := m.Register(TypeBranch, func() Definition { return &Branch{} }); err != nil {
 		return nil, err
 	}
 
+	if err := m.Register(TypeTag, func() Definition { return &Tag{} }); err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
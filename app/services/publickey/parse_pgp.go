@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck // deprecated, but the only OpenPGP implementation available
+)
+
+// KeyTypePGP is the type of armored OpenPGP (GPG) public keys.
+const KeyTypePGP = "pgp"
+
+const pgpPublicKeyArmorHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+// Key is a public key that can be registered by a user.
+type Key interface {
+	Matches(s string) bool
+	Fingerprint() string
+	Type() string
+}
+
+var (
+	_ Key = KeyInfo{}
+	_ Key = PGPKeyInfo{}
+)
+
+// IsPGP returns true if the key data contains an armored OpenPGP public key.
+func IsPGP(keyData string) bool {
+	return strings.HasPrefix(strings.TrimSpace(keyData), pgpPublicKeyArmorHeader)
+}
+
+// ParsePGP parses an armored OpenPGP public key. It returns the key and the first user ID of the key as comment.
+// Only RSA, DSA and ECDSA keys are supported.
+func ParsePGP(keyData string) (PGPKeyInfo, string, error) {
+	keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keyData))
+	if err != nil {
+		return PGPKeyInfo{}, "", errors.InvalidArgument("failed to read PGP public key: %s", err)
+	}
+
+	if len(keyRing) != 1 {
+		return PGPKeyInfo{}, "", errors.InvalidArgument("exactly one PGP public key must be provided")
+	}
+
+	entity := keyRing[0]
+
+	var comment string
+	for name := range entity.Identities {
+		if comment == "" || name < comment {
+			comment = name
+		}
+	}
+
+	return PGPKeyInfo{
+		Entity: entity,
+	}, comment, nil
+}
+
+type PGPKeyInfo struct {
+	Entity *openpgp.Entity
+}
+
+func (key PGPKeyInfo) Matches(s string) bool {
+	if !IsPGP(s) {
+		return false
+	}
+
+	otherKey, _, err := ParsePGP(s)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(key.Entity.PrimaryKey.Fingerprint[:], otherKey.Entity.PrimaryKey.Fingerprint[:])
+}
+
+// Fingerprint returns the fingerprint of the primary key as upper case hex string, as it's displayed by gpg.
+func (key PGPKeyInfo) Fingerprint() string {
+	return strings.ToUpper(hex.EncodeToString(key.Entity.PrimaryKey.Fingerprint[:]))
+}
+
+func (key PGPKeyInfo) Type() string {
+	return KeyTypePGP
+}
+
+// KeyRing returns the key as a key ring to verify signatures with.
+func (key PGPKeyInfo) KeyRing() openpgp.EntityList {
+	return openpgp.EntityList{key.Entity}
+}
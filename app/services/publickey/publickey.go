@@ -17,10 +17,12 @@ package publickey
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -29,21 +31,26 @@ import (
 
 type Service interface {
 	ValidateKey(ctx context.Context, publicKey ssh.PublicKey, usage enum.PublicKeyUsage) (*types.PrincipalInfo, error)
+	VerifyCommitSignature(ctx context.Context, commit *git.Commit) (*types.CommitVerification, error)
+	VerifyCommitSignatures(ctx context.Context, commits []git.Commit) ([]*types.CommitVerification, error)
 }
 
 func NewService(
 	publicKeyStore store.PublicKeyStore,
 	pCache store.PrincipalInfoCache,
+	principalStore store.PrincipalStore,
 ) LocalService {
 	return LocalService{
 		publicKeyStore: publicKeyStore,
 		pCache:         pCache,
+		principalStore: principalStore,
 	}
 }
 
 type LocalService struct {
 	publicKeyStore store.PublicKeyStore
 	pCache         store.PrincipalInfoCache
+	principalStore store.PrincipalStore
 }
 
 // ValidateKey tries to match the provided key to one of the keys in the database.
@@ -89,3 +96,127 @@ func (s LocalService) ValidateKey(
 
 	return pInfo, nil
 }
+
+// VerifyCommitSignature verifies the signature of the commit with the sign keys of the committer.
+// The committer is identified by the email address. It returns nil for unsigned commits.
+func (s LocalService) VerifyCommitSignature(
+	ctx context.Context,
+	commit *git.Commit,
+) (*types.CommitVerification, error) {
+	return s.verifyCommitSignature(ctx, commit, map[string]*signer{})
+}
+
+// VerifyCommitSignatures verifies the signatures of the commits, see VerifyCommitSignature.
+// The committers and their sign keys are read only once per committer email address.
+func (s LocalService) VerifyCommitSignatures(
+	ctx context.Context,
+	commits []git.Commit,
+) ([]*types.CommitVerification, error) {
+	signers := map[string]*signer{}
+	verifications := make([]*types.CommitVerification, len(commits))
+
+	for i := range commits {
+		verification, err := s.verifyCommitSignature(ctx, &commits[i], signers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of commit %s: %w", commits[i].SHA, err)
+		}
+
+		verifications[i] = verification
+	}
+
+	return verifications, nil
+}
+
+// signer is a committer with its sign keys. The committer is nil if no principal has the email address.
+type signer struct {
+	committer *types.Principal
+	keys      []types.PublicKey
+}
+
+func (s LocalService) verifyCommitSignature(
+	ctx context.Context,
+	commit *git.Commit,
+	signers map[string]*signer,
+) (*types.CommitVerification, error) {
+	if commit.Signature == nil {
+		return nil, nil //nolint:nilnil // unsigned commits don't have a verification
+	}
+
+	signature, err := ParseSignature(commit.Signature.Signature)
+	if err != nil {
+		return &types.CommitVerification{
+			Status: enum.CommitSignatureStatusUnverified,
+		}, nil
+	}
+
+	verification := &types.CommitVerification{
+		Status:         enum.CommitSignatureStatusUnknownKey,
+		Type:           signature.Type(),
+		KeyFingerprint: signature.Fingerprint(),
+	}
+
+	committer, err := s.findSigner(ctx, commit.Committer.Identity.Email, signers)
+	if err != nil {
+		return nil, err
+	}
+
+	if committer.committer == nil {
+		return verification, nil
+	}
+
+	for _, key := range committer.keys {
+		signedWithKey, err := signature.Verify(key, []byte(commit.Signature.Payload))
+		if !signedWithKey {
+			continue
+		}
+
+		verification.KeyFingerprint = key.Fingerprint
+
+		if err != nil {
+			verification.Status = enum.CommitSignatureStatusUnverified
+			return verification, nil
+		}
+
+		verification.Status = enum.CommitSignatureStatusVerified
+		verification.Signer = committer.committer.ToPrincipalInfo()
+
+		return verification, nil
+	}
+
+	return verification, nil
+}
+
+// findSigner returns the principal with the email address and its sign keys.
+// The result is stored in the signers map, which is consulted before reading from the stores.
+func (s LocalService) findSigner(
+	ctx context.Context,
+	email string,
+	signers map[string]*signer,
+) (*signer, error) {
+	key := strings.ToLower(email)
+	if result, ok := signers[key]; ok {
+		return result, nil
+	}
+
+	result := &signer{}
+
+	committer, err := s.principalStore.FindByEmail(ctx, email)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to find committer by email: %w", err)
+	}
+
+	if err == nil {
+		result.committer = committer
+
+		result.keys, err = s.publicKeyStore.List(ctx, committer.ID, &types.PublicKeyFilter{
+			Usage: enum.PublicKeyUsageSign,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sign keys of committer: %w", err)
+		}
+	}
+
+	signers[key] = result
+
+	return result, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestVerifyCommitSignatures(t *testing.T) {
+	principalStore := &countingPrincipalStore{
+		principals: map[string]*types.Principal{"jane@example.com": {ID: 1, UID: "jane"}},
+	}
+	publicKeyStore := &countingPublicKeyStore{
+		keys: map[int64][]types.PublicKey{1: {{Type: "ssh-ed25519", Content: testSSHKey}}},
+	}
+	svc := NewService(publicKeyStore, nil, principalStore)
+
+	signed := func(email string) git.Commit {
+		return git.Commit{
+			Committer: git.Signature{Identity: git.Identity{Email: email}},
+			Signature: &git.CommitSignature{Signature: testSSHSignature, Payload: testSSHPayload},
+		}
+	}
+
+	commits := []git.Commit{
+		signed("jane@example.com"),
+		{Committer: git.Signature{Identity: git.Identity{Email: "jane@example.com"}}},
+		signed("Jane@Example.com"),
+		signed("john@example.com"),
+		signed("john@example.com"),
+	}
+
+	verifications, err := svc.VerifyCommitSignatures(context.Background(), commits)
+	if err != nil {
+		t.Fatalf("failed to verify commit signatures: %s", err.Error())
+	}
+
+	expStatuses := []enum.CommitSignatureStatus{
+		enum.CommitSignatureStatusVerified,
+		"",
+		enum.CommitSignatureStatusVerified,
+		enum.CommitSignatureStatusUnknownKey,
+		enum.CommitSignatureStatusUnknownKey,
+	}
+
+	if want, got := len(expStatuses), len(verifications); want != got {
+		t.Fatalf("verification count mismatch: want=%d got=%d", want, got)
+	}
+
+	for i, verification := range verifications {
+		var status enum.CommitSignatureStatus
+		if verification != nil {
+			status = verification.Status
+		}
+
+		if want, got := expStatuses[i], status; want != got {
+			t.Errorf("commit %d status mismatch: want=%q got=%q", i, want, got)
+		}
+	}
+
+	if want, got := 2, principalStore.calls; want != got {
+		t.Errorf("committer lookups mismatch: want=%d got=%d", want, got)
+	}
+
+	if want, got := 1, publicKeyStore.calls; want != got {
+		t.Errorf("key lookups mismatch: want=%d got=%d", want, got)
+	}
+}
+
+type countingPrincipalStore struct {
+	store.PrincipalStore
+	principals map[string]*types.Principal
+	calls      int
+}
+
+func (s *countingPrincipalStore) FindByEmail(_ context.Context, email string) (*types.Principal, error) {
+	s.calls++
+
+	for principalEmail, principal := range s.principals {
+		if strings.EqualFold(principalEmail, email) {
+			return principal, nil
+		}
+	}
+
+	return nil, errors.NotFound("principal not found")
+}
+
+type countingPublicKeyStore struct {
+	store.PublicKeyStore
+	keys  map[int64][]types.PublicKey
+	calls int
+}
+
+func (s *countingPublicKeyStore) List(
+	_ context.Context,
+	principalID int64,
+	_ *types.PublicKeyFilter,
+) ([]types.PublicKey, error) {
+	s.calls++
+	return s.keys[principalID], nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/openpgp"                  //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/armor"            //nolint:staticcheck
+	pgperrors "golang.org/x/crypto/openpgp/errors" //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/packet"           //nolint:staticcheck
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	pgpSignatureArmorHeader = "-----BEGIN PGP SIGNATURE-----"
+	sshSignatureArmorHeader = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureArmorFooter = "-----END SSH SIGNATURE-----"
+
+	sshSignatureMagicPreamble = "SSHSIG"
+	sshSignatureVersion       = 1
+	sshSignatureNamespaceGit  = "git"
+)
+
+var (
+	ErrUnsupportedSignature = errors.New("unsupported signature format")
+	ErrInvalidSignature     = errors.New("invalid signature")
+)
+
+// Signature is a parsed signature of a git commit (content of the gpgsig header).
+type Signature interface {
+	Type() enum.CommitSignatureType
+
+	// Verify verifies the signature of the payload with the provided sign key.
+	// It returns false if the signature wasn't created with the key,
+	// and ErrInvalidSignature if it was, but the signature doesn't match the payload.
+	Verify(key types.PublicKey, payload []byte) (bool, error)
+
+	// Fingerprint returns the fingerprint (or ID) of the key used to create the signature, if available.
+	Fingerprint() string
+}
+
+// ParseSignature parses an armored GPG or SSH signature.
+func ParseSignature(signature string) (Signature, error) {
+	signature = strings.TrimSpace(signature)
+
+	switch {
+	case strings.HasPrefix(signature, pgpSignatureArmorHeader):
+		return parsePGPSignature(signature)
+	case strings.HasPrefix(signature, sshSignatureArmorHeader):
+		return parseSSHSignature(signature)
+	default:
+		return nil, ErrUnsupportedSignature
+	}
+}
+
+type pgpSignature struct {
+	armored string
+	keyID   uint64
+}
+
+func parsePGPSignature(signature string) (pgpSignature, error) {
+	block, err := armor.Decode(strings.NewReader(signature))
+	if err != nil {
+		return pgpSignature{}, fmt.Errorf("%w: failed to decode PGP signature: %w", ErrInvalidSignature, err)
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return pgpSignature{}, fmt.Errorf("%w: failed to read PGP signature: %w", ErrInvalidSignature, err)
+	}
+
+	var keyID uint64
+	switch sig := p.(type) {
+	case *packet.Signature:
+		if sig.IssuerKeyId != nil {
+			keyID = *sig.IssuerKeyId
+		}
+	case *packet.SignatureV3:
+		keyID = sig.IssuerKeyId
+	default:
+		return pgpSignature{}, fmt.Errorf("%w: not a PGP signature", ErrInvalidSignature)
+	}
+
+	return pgpSignature{
+		armored: signature,
+		keyID:   keyID,
+	}, nil
+}
+
+func (s pgpSignature) Type() enum.CommitSignatureType {
+	return enum.CommitSignatureTypeGPG
+}
+
+func (s pgpSignature) Fingerprint() string {
+	if s.keyID == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%016X", s.keyID)
+}
+
+func (s pgpSignature) Verify(key types.PublicKey, payload []byte) (bool, error) {
+	if key.Type != KeyTypePGP {
+		return false, nil
+	}
+
+	keyInfo, _, err := ParsePGP(key.Content)
+	if err != nil {
+		return false, nil //nolint:nilerr // a key that can't be parsed can't be the signing key
+	}
+
+	_, err = openpgp.CheckArmoredDetachedSignature(keyInfo.KeyRing(), bytes.NewReader(payload),
+		strings.NewReader(s.armored))
+	if errors.Is(err, pgperrors.ErrUnknownIssuer) {
+		return false, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return true, nil
+}
+
+// sshSignatureBlob is the format of SSH signatures created by ssh-keygen -Y sign,
+// see https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig.
+type sshSignatureBlob struct {
+	MagicPreamble [6]byte
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the data that is signed for an SSH signature.
+type sshSignedData struct {
+	MagicPreamble [6]byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+type sshSignature struct {
+	publicKey gossh.PublicKey
+	blob      sshSignatureBlob
+	signature *gossh.Signature
+}
+
+func parseSSHSignature(signature string) (sshSignature, error) {
+	armored := strings.TrimSuffix(strings.TrimPrefix(signature, sshSignatureArmorHeader), sshSignatureArmorFooter)
+
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(armored), ""))
+	if err != nil {
+		return sshSignature{}, fmt.Errorf("%w: failed to decode SSH signature: %w", ErrInvalidSignature, err)
+	}
+
+	var blob sshSignatureBlob
+	if err = gossh.Unmarshal(data, &blob); err != nil {
+		return sshSignature{}, fmt.Errorf("%w: failed to unmarshal SSH signature: %w", ErrInvalidSignature, err)
+	}
+
+	if string(blob.MagicPreamble[:]) != sshSignatureMagicPreamble || blob.Version != sshSignatureVersion {
+		return sshSignature{}, fmt.Errorf("%w: unsupported SSH signature version", ErrInvalidSignature)
+	}
+
+	if blob.Namespace != sshSignatureNamespaceGit {
+		return sshSignature{}, fmt.Errorf("%w: SSH signature namespace must be %q",
+			ErrInvalidSignature, sshSignatureNamespaceGit)
+	}
+
+	publicKey, err := gossh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return sshSignature{}, fmt.Errorf("%w: failed to parse public key of SSH signature: %w",
+			ErrInvalidSignature, err)
+	}
+
+	sig := &gossh.Signature{}
+	if err = gossh.Unmarshal(blob.Signature, sig); err != nil {
+		return sshSignature{}, fmt.Errorf("%w: failed to unmarshal SSH signature: %w", ErrInvalidSignature, err)
+	}
+
+	return sshSignature{
+		publicKey: publicKey,
+		blob:      blob,
+		signature: sig,
+	}, nil
+}
+
+func (s sshSignature) Type() enum.CommitSignatureType {
+	return enum.CommitSignatureTypeSSH
+}
+
+func (s sshSignature) Fingerprint() string {
+	return From(s.publicKey).Fingerprint()
+}
+
+func (s sshSignature) Verify(key types.PublicKey, payload []byte) (bool, error) {
+	if key.Type == KeyTypePGP || !From(s.publicKey).Matches(key.Content) {
+		return false, nil
+	}
+
+	var h hash.Hash
+	switch s.blob.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return true, fmt.Errorf("%w: unsupported hash algorithm %q", ErrInvalidSignature, s.blob.HashAlgorithm)
+	}
+
+	_, _ = h.Write(payload)
+
+	signedData := sshSignedData{
+		Namespace:     s.blob.Namespace,
+		Reserved:      s.blob.Reserved,
+		HashAlgorithm: s.blob.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	}
+	copy(signedData.MagicPreamble[:], sshSignatureMagicPreamble)
+
+	if err := s.publicKey.Verify(gossh.Marshal(signedData), s.signature); err != nil {
+		return true, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return true, nil
+}
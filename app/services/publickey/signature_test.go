@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testSSHKey = `ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGYq36HkF4kJjNipMQlZNTlubBO1SqVX0S2odeM2Ej0u jane@example.com`
+
+	testSSHSignature = `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgZirfoeQXiQmM2KkxCVk1OW5sE7
+VKpVfRLah14zYSPS4AAAADZ2l0AAAAAAAAAAZzaGE1MTIAAABTAAAAC3NzaC1lZDI1NTE5
+AAAAQBeibnyfd6p5QKG6EHUdmd7PZtgUBgJyOPB8r6UOLqBWGVRI2OPzC51ts1i2vBKlAr
+zHz1QjBSXqI6UdqCY/gAc=
+-----END SSH SIGNATURE-----
+`
+
+	testSSHPayload = `tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904
+author Jane Doe <jane@example.com> 1704067200 +0000
+committer Jane Doe <jane@example.com> 1704067200 +0000
+
+ssh signed
+`
+
+	testPGPKey = `-----BEGIN PGP PUBLIC KEY BLOCK-----
+
+mQENBGrUO3YBCAC/fQVEISPgIQmob61ulsxll8HZa6eQW4yiOgYQQ5kPTZb/Cotl
+ybeQMZ/WCxTSmBfM6nE2LswzeS9t4vwTTAu6d2T8vDe+zL7a1RgXgdOWoGVr7EvC
+mLVu358KzjEth/mTlzX8/SpSurBre/b02dHab9NdBb1o2/RCqTNKef4TwJpcx2fg
+zJDPLgMTU7TPQ7nr/PYQPwQ1xQb3D2bjtad22E/kAkA1jr7cUSv9uDKvRV+hsSaA
+c5IYRqUSqFezoSxV+2UA/Nyk37j7PSjn01p7aztwS/RFQr6pncN3U3IPwCGlNDXj
+7IQtSIbJTbAEaUtcp3siMcSLFT+fq5hlxNHjABEBAAG0G0pvaG4gRG9lIDxqb2hu
+QGV4YW1wbGUuY29tPokBTQQTAQoAOBYhBDQqW95wSUe0cDKHK1qS8sr/xgM4BQJq
+1Dt2AhsDBQsJCAcCBhUKCQgLAgQWAgMBAh4BAheAAAoJEFqS8sr/xgM4Ia0H9Rl4
+8mcpDM3yK6zhKrVHMgHLKwF32cO6K6JLRfhYg3+ctRgsMiPbvm1pw1n611EEgeRp
+VH89sVWvdidfB7DV/oNQkdZO762/azeFwWdlSFfEm7AoPGnh3CeK/xeH8ACSe4s3
+Yqjdes/pxpNBekm9fo/pU7i5ywgMlXwvs3v4UuLDyDF+4RbndivVijBHc6LMyRAd
+jnO61QLeWklNJnvP38oAt7p8CZzwP0tQTM/0xcsYpslntxnAw1UQ+fJknIwxRzXJ
+fs4CwGdBxmdGRXvmHXhgE/UaMum8BHh0RBW5Pq3oIEF1oSUQihRoHvMP1Xlda++M
+lHwCa4CEX0/HZTMEEQ==
+=mCxc
+-----END PGP PUBLIC KEY BLOCK-----`
+
+	testPGPSignature = `-----BEGIN PGP SIGNATURE-----
+
+iQFFBAABCgAvFiEENCpb3nBJR7RwMocrWpLyyv/GAzgFAmrUO3YRHGpvaG5AZXhh
+bXBsZS5jb20ACgkQWpLyyv/GAzhY7Qf/YWf5f2GedxAbtRrQCTnSNXLgsihpiKf9
+lx+5H1h2BLfvprP5ou52NU9Y0+ChJ/TRBHmeeAdErsBjbuR5lf0IW9DT1KkN9iEj
+hhLPm4A2rZ0cgSyReUZvPS1KH316wd7lUAkLUSs9kfomiS8g1pH6QyFi41HBQky1
+pQ/j61hRdEy5pjeFzcU6DPsA60Nt8/RUBOwUYEwA4ORSEJGjNr1AN1BGCHXktVlz
+rEx416I7SakmOMt6RB2KCHgHAFnxnzUtJVh/BHUoIqQpyy+8M4Z7I9Er5dz9/wp/
+ST8Rd01ul8MLPFxKj+bwDrMadT/i4FmQwmlfTyXo4z1KTP+/pAeKtA==
+=tM3J
+-----END PGP SIGNATURE-----
+`
+
+	testPGPPayload = `tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904
+parent 6b65b87f9d6567a5c04205885e78ac7875432050
+author John Doe <john@example.com> 1704240000 +0000
+committer John Doe <john@example.com> 1704240000 +0000
+
+gpg signed
+`
+)
+
+func TestSignatureVerify(t *testing.T) {
+	sshKey := types.PublicKey{Type: "ssh-ed25519", Content: testSSHKey}
+	pgpKey := types.PublicKey{Type: KeyTypePGP, Content: testPGPKey}
+
+	tests := []struct {
+		name       string
+		signature  string
+		payload    string
+		key        types.PublicKey
+		expType    enum.CommitSignatureType
+		expMatches bool
+		expInvalid bool
+	}{
+		{
+			name:       "ssh-verified",
+			signature:  testSSHSignature,
+			payload:    testSSHPayload,
+			key:        sshKey,
+			expType:    enum.CommitSignatureTypeSSH,
+			expMatches: true,
+		},
+		{
+			name:       "ssh-tampered",
+			signature:  testSSHSignature,
+			payload:    testSSHPayload + "tampered",
+			key:        sshKey,
+			expType:    enum.CommitSignatureTypeSSH,
+			expMatches: true,
+			expInvalid: true,
+		},
+		{
+			name:      "ssh-other-key",
+			signature: testSSHSignature,
+			payload:   testSSHPayload,
+			key:       pgpKey,
+			expType:   enum.CommitSignatureTypeSSH,
+		},
+		{
+			name:       "pgp-verified",
+			signature:  testPGPSignature,
+			payload:    testPGPPayload,
+			key:        pgpKey,
+			expType:    enum.CommitSignatureTypeGPG,
+			expMatches: true,
+		},
+		{
+			name:       "pgp-tampered",
+			signature:  testPGPSignature,
+			payload:    testPGPPayload + "tampered",
+			key:        pgpKey,
+			expType:    enum.CommitSignatureTypeGPG,
+			expMatches: true,
+			expInvalid: true,
+		},
+		{
+			name:      "pgp-other-key",
+			signature: testPGPSignature,
+			payload:   testPGPPayload,
+			key:       sshKey,
+			expType:   enum.CommitSignatureTypeGPG,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signature, err := ParseSignature(test.signature)
+			if err != nil {
+				t.Fatalf("failed to parse signature: %s", err.Error())
+			}
+
+			if want, got := test.expType, signature.Type(); want != got {
+				t.Errorf("type mismatch: want=%s got=%s", want, got)
+			}
+
+			matches, err := signature.Verify(test.key, []byte(test.payload))
+			if want, got := test.expMatches, matches; want != got {
+				t.Errorf("key match mismatch: want=%t got=%t", want, got)
+			}
+
+			if want, got := test.expInvalid, err != nil; want != got {
+				t.Errorf("invalid signature mismatch: want=%t got=%v", want, err)
+			}
+		})
+	}
+}
+
+func TestParseSignature_Unsupported(t *testing.T) {
+	_, err := ParseSignature("-----BEGIN SIGNED MESSAGE-----\nabc\n-----END SIGNED MESSAGE-----\n")
+	if err != ErrUnsupportedSignature { //nolint:errorlint // the error isn't wrapped
+		t.Errorf("expected unsupported signature error, got: %v", err)
+	}
+}
@@ -27,6 +27,7 @@ var WireSet = wire.NewSet(
 func ProvidePublicKey(
 	publicKeyStore store.PublicKeyStore,
 	pCache store.PrincipalInfoCache,
+	principalStore store.PrincipalStore,
 ) Service {
-	return NewService(publicKeyStore, pCache)
+	return NewService(publicKeyStore, pCache, principalStore)
 }
@@ -57,17 +57,21 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		}
 	}
 
-	// TODO: This function is currently executed directly on branch update event.
-	// TODO: But it should be executed after the PR's head ref has been updated.
-	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
-		// First check if the merge base has changed
-
 		targetRepo, err := s.repoGitInfoCache.Get(ctx, pr.TargetRepoID)
 		if err != nil {
 			return fmt.Errorf("failed to get repo git info: %w", err)
 		}
 
+		// For pull requests from forks, make sure the new commit exists in the target repository.
+		if pr.SourceRepoID != pr.TargetRepoID {
+			if err = s.fetchSourceCommit(ctx, pr.SourceRepoID, targetRepo, event.Payload.NewSHA); err != nil {
+				return err
+			}
+		}
+
+		// First check if the merge base has changed
+
 		mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
 			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
 			Ref1:       event.Payload.NewSHA,
@@ -81,6 +85,9 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		oldMergeBase := pr.MergeBaseSHA
 		newMergeBase := mergeBaseInfo.MergeBaseSHA
 
+		var activitySeqBranchUpdated, activitySeqAutoMergeCanceled int64
+		var autoMergeMethod *enum.MergeMethod
+
 		// Update the database with the latest source commit SHA and the merge base SHA.
 		pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 			// to avoid racing conditions with merge
@@ -89,6 +96,17 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 			}
 
 			pr.ActivitySeq++
+			activitySeqBranchUpdated = pr.ActivitySeq
+
+			// new commits cancel the auto-merge
+			autoMergeMethod = pr.AutoMergeMethod
+			if autoMergeMethod != nil {
+				pr.ActivitySeq++
+				activitySeqAutoMergeCanceled = pr.ActivitySeq
+				pr.AutoMergeMethod = nil
+				pr.AutoMergeBy = nil
+			}
+
 			if pr.SourceSHA != event.Payload.OldSHA {
 				return fmt.Errorf(
 					"failed to set SourceSHA for PR %d to value '%s', expected SHA '%s' but current pr has '%s'",
@@ -120,12 +138,32 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 			New: event.Payload.NewSHA,
 		}
 
+		activitySeq := pr.ActivitySeq
+
+		pr.ActivitySeq = activitySeqBranchUpdated
 		_, err = s.activityStore.CreateWithPayload(ctx, pr, event.Payload.PrincipalID, payload)
 		if err != nil {
 			// non-critical error
 			log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after branch update")
 		}
 
+		if autoMergeMethod != nil {
+			pr.ActivitySeq = activitySeqAutoMergeCanceled
+			_, err = s.activityStore.CreateWithPayload(ctx, pr, event.Payload.PrincipalID,
+				&types.PullRequestActivityPayloadAutoMerge{
+					Action:      enum.PullReqAutoMergeActionCanceled,
+					MergeMethod: *autoMergeMethod,
+					SourceSHA:   event.Payload.NewSHA,
+					Reason:      "New commits were pushed to the source branch.",
+				})
+			if err != nil {
+				// non-critical error
+				log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after auto-merge cancellation")
+			}
+		}
+
+		pr.ActivitySeq = activitySeq
+
 		s.pullreqEvReporter.BranchUpdated(ctx, &pullreqevents.BranchUpdatedPayload{
 			Base: pullreqevents.Base{
 				PullReqID:    pr.ID,
@@ -177,6 +215,8 @@ func (s *Service) closePullReqOnBranchDelete(ctx context.Context,
 			pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
 			pr.MergeSHA = nil
 			pr.MergeConflicts = nil
+			pr.AutoMergeMethod = nil
+			pr.AutoMergeBy = nil
 
 			return nil
 		})
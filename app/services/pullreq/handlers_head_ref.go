@@ -41,8 +41,7 @@ func (s *Service) createHeadRefOnCreated(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo).
-	// This is because commits from the source repository must be first pulled into the target repository.
+	// NOTE: For pull requests from forks the source commit has already been fetched into the target repository.
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -72,8 +71,7 @@ func (s *Service) updateHeadRefOnBranchUpdate(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	// NOTE: For pull requests from forks the source commit has already been fetched into the target repository.
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -103,8 +101,7 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	// NOTE: For pull requests from forks the source commit has already been fetched into the target repository.
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
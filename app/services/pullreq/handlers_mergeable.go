@@ -20,6 +20,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/harness/gitness/app/bootstrap"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/events"
@@ -84,14 +85,14 @@ func (s *Service) mergeCheckOnReopen(ctx context.Context,
 func (s *Service) mergeCheckOnClosed(ctx context.Context,
 	event *events.Event[*pullreqevents.ClosedPayload],
 ) error {
-	return s.deleteMergeRef(ctx, event.Payload.SourceRepoID, event.Payload.Number)
+	return s.deleteMergeRef(ctx, event.Payload.TargetRepoID, event.Payload.Number)
 }
 
 // mergeCheckOnMerged deletes the merge ref.
 func (s *Service) mergeCheckOnMerged(ctx context.Context,
 	event *events.Event[*pullreqevents.MergedPayload],
 ) error {
-	return s.deleteMergeRef(ctx, event.Payload.SourceRepoID, event.Payload.Number)
+	return s.deleteMergeRef(ctx, event.Payload.TargetRepoID, event.Payload.Number)
 }
 
 func (s *Service) deleteMergeRef(ctx context.Context, repoID int64, prNum int64) error {
@@ -105,7 +106,6 @@ func (s *Service) deleteMergeRef(ctx context.Context, repoID int64, prNum int64)
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(prNum)),
@@ -211,7 +211,7 @@ func (s *Service) updateMergeData(
 	}
 
 	// Update DB in both cases (failure or success)
-	_, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		// to avoid racing conditions with merge
 		if pr.State != enum.PullReqStateOpen {
 			return errPRNotOpen
@@ -246,5 +246,17 @@ func (s *Service) updateMergeData(
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
 
+	s.pullreqEvReporter.MergeCheckCompleted(ctx, &pullreqevents.MergeCheckCompletedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  bootstrap.NewSystemServiceSession().Principal.ID,
+			Number:       pr.Number,
+		},
+		SourceSHA:        pr.SourceSHA,
+		MergeCheckStatus: pr.MergeCheckStatus,
+	})
+
 	return nil
 }
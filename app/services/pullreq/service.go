@@ -30,6 +30,7 @@ import (
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
@@ -278,3 +279,32 @@ func createSystemRPCWriteParams(
 		EnvVars: envVars,
 	}, nil
 }
+
+// fetchSourceCommit fetches the commit from the source repository of a pull request into the target repository.
+func (s *Service) fetchSourceCommit(
+	ctx context.Context,
+	sourceRepoID int64,
+	targetRepo *types.RepositoryGitInfo,
+	commitSHA string,
+) error {
+	sourceRepo, err := s.repoGitInfoCache.Get(ctx, sourceRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to generate rpc write params: %w", err)
+	}
+
+	err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHAs:    []sha.SHA{sha.Must(commitSHA)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch commit %s from source repo: %w", commitSHA, err)
+	}
+
+	return nil
+}
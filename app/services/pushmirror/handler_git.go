@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushmirror
+
+import (
+	"context"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+)
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventBranchDeleted(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventTagCreated(ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventTagUpdated(ctx context.Context,
+	event *events.Event[*gitevents.TagUpdatedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventTagDeleted(ctx context.Context,
+	event *events.Event[*gitevents.TagDeletedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushmirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/gitspace/scm"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultCredentialsUsername is used for basic authentication if no username is provided.
+const defaultCredentialsUsername = "git"
+
+// pushInput is the input of the push mirror job.
+type pushInput struct {
+	PushMirrorID int64 `json:"push_mirror_id"`
+}
+
+// pushJob is a job handler that pushes the filtered references of a repository to a push mirror.
+type pushJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*pushJob)(nil)
+
+// Handle pushes the repository to the push mirror and records the outcome on the push mirror.
+// A failed push is returned as an error, hence it's retried by the job scheduler.
+func (j *pushJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input pushInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal push mirror job input: %w", err)
+	}
+
+	s := j.service
+
+	mirror, err := s.pushMirrorStore.Find(ctx, input.PushMirrorID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the push mirror has been deleted in the meantime
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	if !mirror.Enabled {
+		return "", nil
+	}
+
+	pushErr := j.push(ctx, mirror)
+
+	_, err = s.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+		now := time.Now().UnixMilli()
+		mirror.Updated = now
+		if pushErr != nil {
+			mirror.LastError = pushErr.Error()
+			return nil
+		}
+
+		mirror.LastSuccess = now
+		mirror.LastError = ""
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("push_mirror.id", mirror.ID).Msg("failed to update push mirror status")
+	}
+
+	if pushErr != nil {
+		return "", pushErr
+	}
+
+	return "", nil
+}
+
+// push pushes the filtered references of the repository to the push mirror.
+// The returned error is stored on the push mirror, hence it must not contain any credentials.
+func (j *pushJob) push(ctx context.Context, mirror *types.PushMirror) error {
+	s := j.service
+
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	remoteURL, secret, err := j.remoteURLWithAuth(ctx, repo, mirror)
+	if err != nil {
+		return err
+	}
+
+	err = s.git.PushRemote(ctx, &git.PushRemoteParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		RemoteURL:  remoteURL,
+		RefSpecs:   refSpecs(mirror.RefFilter),
+		Force:      mirror.Force,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to push to %q: %s", mirror.URL, redactSecret(err.Error(), secret))
+	}
+
+	return nil
+}
+
+// redactSecret removes the password from the message, both as is and in the percent-encoded form
+// it has in the remote URL.
+func redactSecret(msg, secret string) string {
+	msg = api.SanitizeCredentialURLs(msg)
+	if secret == "" {
+		return msg
+	}
+
+	encodedSecret := strings.TrimPrefix(url.UserPassword("", secret).String(), ":")
+
+	msg = strings.ReplaceAll(msg, secret, "xxxxx")
+	msg = strings.ReplaceAll(msg, encodedSecret, "xxxxx")
+
+	return msg
+}
+
+// remoteURLWithAuth returns the URL of the push mirror with the credentials of its connector or secret, if any,
+// along with the secret that has to be redacted from any error message.
+func (j *pushJob) remoteURLWithAuth(
+	ctx context.Context,
+	repo *types.Repository,
+	mirror *types.PushMirror,
+) (string, string, error) {
+	var username, password string
+
+	switch {
+	case mirror.Connector != "":
+		connector, err := j.service.connectorStore.FindByIdentifier(ctx, repo.ParentID, mirror.Connector)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to find connector %s: %w", mirror.Connector, err)
+		}
+
+		creds := &scm.ConnectorCredentials{}
+		if err = json.Unmarshal([]byte(connector.Data), creds); err != nil {
+			return "", "", fmt.Errorf("failed to parse credentials of connector %s: %w", connector.Identifier, err)
+		}
+
+		username, password = creds.Username, creds.Token
+
+	case mirror.Secret != "":
+		secret, err := j.service.secretStore.FindByIdentifier(ctx, repo.ParentID, mirror.Secret)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to find secret %s: %w", mirror.Secret, err)
+		}
+
+		password, err = j.service.encrypter.Decrypt([]byte(secret.Data))
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decrypt secret %s: %w", mirror.Secret, err)
+		}
+
+		username = mirror.Username
+
+	default:
+		return mirror.URL, "", nil
+	}
+
+	if password == "" {
+		return "", "", errors.New("credentials of the push mirror don't contain a password or token")
+	}
+
+	remoteURL, err := url.Parse(mirror.URL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse push mirror URL: %w", err)
+	}
+
+	if username == "" {
+		username = defaultCredentialsUsername
+	}
+
+	remoteURL.User = url.UserPassword(username, password)
+
+	return remoteURL.String(), password, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushmirror
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultRefFilter is used for push mirrors that don't define a ref filter: all branches and tags are pushed.
+var DefaultRefFilter = []string{"refs/heads/*", "refs/tags/*"}
+
+// ValidateRefPattern checks that the pattern can be used as a ref filter of a push mirror.
+func ValidateRefPattern(pattern string) error {
+	if !strings.HasPrefix(pattern, "refs/") {
+		return errors.New("ref pattern must start with 'refs/'")
+	}
+
+	if strings.Count(pattern, "*") > 1 {
+		return errors.New("ref pattern can contain at most one '*'")
+	}
+
+	if strings.ContainsAny(pattern, " :+^~?[\\") || strings.Contains(pattern, "..") {
+		return errors.New("ref pattern contains invalid characters")
+	}
+
+	return nil
+}
+
+// MatchRef returns true if the reference matches any of the patterns of the ref filter.
+func MatchRef(refFilter []string, ref string) bool {
+	if len(refFilter) == 0 {
+		refFilter = DefaultRefFilter
+	}
+
+	for _, pattern := range refFilter {
+		prefix, suffix, wildcard := strings.Cut(pattern, "*")
+		if !wildcard {
+			if ref == pattern {
+				return true
+			}
+			continue
+		}
+
+		if len(ref) >= len(prefix)+len(suffix) && strings.HasPrefix(ref, prefix) && strings.HasSuffix(ref, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// refSpecs returns the refspecs that push the references of the ref filter to the same references of the remote.
+func refSpecs(refFilter []string) []string {
+	if len(refFilter) == 0 {
+		refFilter = DefaultRefFilter
+	}
+
+	specs := make([]string, len(refFilter))
+	for i, pattern := range refFilter {
+		specs[i] = pattern + ":" + pattern
+	}
+
+	return specs
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushmirror
+
+import "testing"
+
+func TestMatchRef(t *testing.T) {
+	tests := []struct {
+		name      string
+		refFilter []string
+		ref       string
+		want      bool
+	}{
+		{name: "default filter branch", ref: "refs/heads/main", want: true},
+		{name: "default filter tag", ref: "refs/tags/v1.0", want: true},
+		{name: "default filter pull request", ref: "refs/pullreq/1/head", want: false},
+		{name: "exact", refFilter: []string{"refs/heads/main"}, ref: "refs/heads/main", want: true},
+		{name: "exact mismatch", refFilter: []string{"refs/heads/main"}, ref: "refs/heads/mainline", want: false},
+		{name: "prefix", refFilter: []string{"refs/heads/release/*"}, ref: "refs/heads/release/1.0", want: true},
+		{name: "prefix and suffix", refFilter: []string{"refs/tags/v*-lts"}, ref: "refs/tags/v2-lts", want: true},
+		{name: "prefix and suffix mismatch", refFilter: []string{"refs/tags/v*-lts"}, ref: "refs/tags/v2", want: false},
+		{name: "overlapping prefix and suffix", refFilter: []string{"refs/tags/ab*ba"}, ref: "refs/tags/aba", want: false},
+		{name: "any pattern", refFilter: []string{"refs/tags/*", "refs/heads/main"}, ref: "refs/heads/main", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := MatchRef(test.refFilter, test.ref); got != test.want {
+				t.Errorf("MatchRef(%v, %q) = %t, want %t", test.refFilter, test.ref, got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateRefPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   bool
+	}{
+		{pattern: "refs/heads/*", valid: true},
+		{pattern: "refs/tags/v*", valid: true},
+		{pattern: "refs/heads/main", valid: true},
+		{pattern: "heads/*", valid: false},
+		{pattern: "refs/*/*", valid: false},
+		{pattern: "+refs/heads/*", valid: false},
+		{pattern: "refs/heads/*:refs/heads/*", valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			if err := ValidateRefPattern(test.pattern); (err == nil) != test.valid {
+				t.Errorf("ValidateRefPattern(%q) error = %v, want valid = %t", test.pattern, err, test.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushmirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:pushmirror"
+
+	jobTypePush        = "gitness:push-mirror:push"
+	jobMaxDurationPush = 15 * time.Minute
+
+	// pushDebounce is the time window in which changes of a repository are pushed to a mirror together.
+	pushDebounce = 5 * time.Second
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+
+	return nil
+}
+
+// Service pushes the branches and tags of repositories to their push mirrors after every change.
+type Service struct {
+	config          Config
+	scheduler       *job.Scheduler
+	git             git.Interface
+	encrypter       encrypt.Encrypter
+	repoStore       store.RepoStore
+	pushMirrorStore store.PushMirrorStore
+	connectorStore  store.ConnectorStore
+	secretStore     store.SecretStore
+}
+
+func New(
+	ctx context.Context,
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	git git.Interface,
+	encrypter encrypt.Encrypter,
+	repoStore store.RepoStore,
+	pushMirrorStore store.PushMirrorStore,
+	connectorStore store.ConnectorStore,
+	secretStore store.SecretStore,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided push mirror service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:          config,
+		scheduler:       scheduler,
+		git:             git,
+		encrypter:       encrypter,
+		repoStore:       repoStore,
+		pushMirrorStore: pushMirrorStore,
+		connectorStore:  connectorStore,
+		secretStore:     secretStore,
+	}
+
+	if err := executor.Register(jobTypePush, &pushJob{service: service}); err != nil {
+		return nil, fmt.Errorf("failed to register job handler for push mirrors: %w", err)
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterBranchDeleted(service.handleEventBranchDeleted)
+
+			_ = r.RegisterTagCreated(service.handleEventTagCreated)
+			_ = r.RegisterTagUpdated(service.handleEventTagUpdated)
+			_ = r.RegisterTagDeleted(service.handleEventTagDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// Push schedules a push of the repository to the push mirror.
+// All changes made within a short time window are pushed by a single job.
+func (s *Service) Push(ctx context.Context, mirror *types.PushMirror) error {
+	data, err := json.Marshal(pushInput{PushMirrorID: mirror.ID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal push mirror job input: %w", err)
+	}
+
+	now := time.Now()
+	window := now.Truncate(pushDebounce)
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        fmt.Sprintf("push-mirror-%d-%d", mirror.ID, window.UnixMilli()),
+		Type:       jobTypePush,
+		MaxRetries: s.config.MaxRetries,
+		Timeout:    jobMaxDurationPush,
+		Data:       string(data),
+		Delay:      window.Add(pushDebounce).Sub(now),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the push job for the time window is already scheduled
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to schedule push mirror job: %w", err)
+	}
+
+	return nil
+}
+
+// pushRef schedules a push to all enabled push mirrors of the repository whose ref filter matches the reference.
+func (s *Service) pushRef(ctx context.Context, repoID int64, ref string) error {
+	mirrors, err := s.pushMirrorStore.List(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to list push mirrors: %w", err)
+	}
+
+	for _, mirror := range mirrors {
+		if !mirror.Enabled || !MatchRef(mirror.RefFilter, ref) {
+			continue
+		}
+
+		if err = s.Push(ctx, mirror); err != nil {
+			return err
+		}
+
+		log.Ctx(ctx).Debug().
+			Int64("push_mirror.id", mirror.ID).
+			Str("ref", ref).
+			Msg("scheduled push to push mirror")
+	}
+
+	return nil
+}
@@ -38,6 +38,7 @@ type SizeCalculator struct {
 	git        git.Interface
 	repoStore  store.RepoStore
 	scheduler  *job.Scheduler
+	lfsStore   store.LFSObjectStore
 }
 
 func (s *SizeCalculator) Register(ctx context.Context) error {
@@ -103,16 +104,25 @@ func worker(ctx context.Context, s *SizeCalculator, wg *sync.WaitGroup, taskCh <
 			log.Error().Msgf("failed to get repo size: %s", err.Error())
 			continue
 		}
-		if sizeOut.Size == sizeInfo.Size {
+
+		// LFS objects are stored outside of git but are part of the repository.
+		lfsSize, err := s.lfsStore.GetSizeInKBByRepoID(ctx, sizeInfo.ID)
+		if err != nil {
+			log.Error().Msgf("failed to get repo lfs objects size: %s", err.Error())
+			continue
+		}
+
+		size := sizeOut.Size + lfsSize
+		if size == sizeInfo.Size {
 			log.Debug().Msg("repo size not changed")
 			continue
 		}
 
-		if err := s.repoStore.UpdateSize(ctx, sizeInfo.ID, sizeOut.Size); err != nil {
+		if err := s.repoStore.UpdateSize(ctx, sizeInfo.ID, size); err != nil {
 			log.Error().Msgf("failed to update repo size: %s", err.Error())
 			continue
 		}
 
-		log.Debug().Msgf("new repo size: %d KiB", sizeOut.Size)
+		log.Debug().Msgf("new repo size: %d KiB", size)
 	}
 }
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repomirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// scheduleJob is a recurring job that schedules the synchronization of all mirrors that are due.
+type scheduleJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*scheduleJob)(nil)
+
+// Handle schedules a sync job for every mirror that is due.
+// Before scheduling, the next sync time of a mirror is advanced using optimistic locking,
+// which guarantees that a mirror is synchronized only once even with multiple instances.
+func (j *scheduleJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	s := j.service
+	now := time.Now()
+
+	mirrors, err := s.repoMirrorStore.ListDue(ctx, now.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list due repo mirrors: %w", err)
+	}
+
+	for _, mirror := range mirrors {
+		log := log.Ctx(ctx).With().Int64("repo.id", mirror.RepoID).Logger()
+
+		claimed, err := j.claim(ctx, mirror, now)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to schedule next sync of repo mirror")
+			continue
+		}
+		if !claimed {
+			log.Debug().Msg("repo mirror sync already scheduled")
+			continue
+		}
+
+		if err = s.Sync(ctx, mirror); err != nil {
+			log.Warn().Err(err).Msg("failed to schedule repo mirror sync")
+			continue
+		}
+
+		log.Debug().Msg("repo mirror sync scheduled")
+	}
+
+	return "", nil
+}
+
+// claim advances the next sync of the mirror and marks it as pending. It returns false if the mirror
+// has been modified in the meantime (e.g. its sync was already scheduled by another instance).
+func (j *scheduleJob) claim(ctx context.Context, mirror *types.RepoMirror, now time.Time) (bool, error) {
+	mirror.NextSync = now.Add(time.Duration(mirror.Interval) * time.Minute).UnixMilli()
+	mirror.SyncStatus = enum.RepoMirrorSyncStatusPending
+	mirror.Updated = now.UnixMilli()
+
+	err := j.service.repoMirrorStore.Update(ctx, mirror)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update repo mirror: %w", err)
+	}
+
+	return true, nil
+}
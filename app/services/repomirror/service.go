@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repomirror
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	jobTypeSchedule        = "gitness:repo-mirror:schedule"
+	jobCronSchedule        = "* * * * *"
+	jobMaxDurationSchedule = time.Minute
+
+	jobTypeSync        = "gitness:repo-mirror:sync"
+	jobMaxDurationSync = 30 * time.Minute
+)
+
+// Service synchronizes repositories that are pull mirrors of external git repositories.
+// Mirrors are synchronized periodically, according to their interval, or on request.
+type Service struct {
+	scheduler       *job.Scheduler
+	executor        *job.Executor
+	urlProvider     url.Provider
+	git             git.Interface
+	repoStore       store.RepoStore
+	repoMirrorStore store.RepoMirrorStore
+	connectorStore  store.ConnectorStore
+	indexer         keywordsearch.Indexer
+}
+
+func NewService(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	urlProvider url.Provider,
+	git git.Interface,
+	repoStore store.RepoStore,
+	repoMirrorStore store.RepoMirrorStore,
+	connectorStore store.ConnectorStore,
+	indexer keywordsearch.Indexer,
+) *Service {
+	return &Service{
+		scheduler:       scheduler,
+		executor:        executor,
+		urlProvider:     urlProvider,
+		git:             git,
+		repoStore:       repoStore,
+		repoMirrorStore: repoMirrorStore,
+		connectorStore:  connectorStore,
+		indexer:         indexer,
+	}
+}
+
+// Register registers the job handlers of the service and the recurring job
+// that schedules the synchronization of the mirrors that are due.
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobTypeSchedule, &scheduleJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register job handler for repo mirror scheduling: %w", err)
+	}
+
+	if err := s.executor.Register(jobTypeSync, &syncJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register job handler for repo mirror sync: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeSchedule, jobTypeSchedule, jobCronSchedule, jobMaxDurationSchedule)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for repo mirrors: %w", err)
+	}
+
+	return nil
+}
+
+// IsSyncInProgress returns true if a synchronization of the mirror is scheduled or running.
+// A state that hasn't been updated for longer than a sync job may run (e.g. it was left behind
+// by an instance that was shut down) is not considered in progress.
+func IsSyncInProgress(mirror *types.RepoMirror, now time.Time) bool {
+	return mirror.SyncStatus.IsInProgress() &&
+		now.Sub(time.UnixMilli(mirror.Updated)) < jobMaxDurationSync
+}
+
+// Sync schedules an immediate synchronization of the provided repository mirror.
+// The caller is expected to have already marked the mirror as pending.
+func (s *Service) Sync(ctx context.Context, mirror *types.RepoMirror) error {
+	data, err := json.Marshal(syncInput{RepoID: mirror.RepoID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal repo mirror sync job input: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        fmt.Sprintf("repo-mirror-sync-%d-%d", mirror.RepoID, time.Now().UnixMilli()),
+		Type:       jobTypeSync,
+		MaxRetries: 0,
+		Timeout:    jobMaxDurationSync,
+		Data:       string(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule repo mirror sync job: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repomirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/gitspace/scm"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultCredentialsUsername is used for basic authentication if the connector doesn't define a username.
+const defaultCredentialsUsername = "git"
+
+// syncInput is the input of the repo mirror sync job.
+type syncInput struct {
+	RepoID int64 `json:"repo_id"`
+}
+
+// syncJob is a job handler that fetches all branches and tags of a mirror from its source repository.
+type syncJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*syncJob)(nil)
+
+// Handle synchronizes the repository mirror and records the outcome of the synchronization on the mirror.
+func (j *syncJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input syncInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal repo mirror sync job input: %w", err)
+	}
+
+	s := j.service
+
+	mirror, err := s.repoMirrorStore.Find(ctx, input.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the repository is no longer a mirror
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find repo mirror: %w", err)
+	}
+
+	mirror, err = s.repoMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.RepoMirror) error {
+		mirror.SyncStatus = enum.RepoMirrorSyncStatusRunning
+		mirror.Updated = time.Now().UnixMilli()
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to mark repo mirror sync as running: %w", err)
+	}
+
+	syncErr := j.sync(ctx, mirror)
+	if syncErr != nil {
+		log.Ctx(ctx).Warn().Err(syncErr).Int64("repo.id", mirror.RepoID).Msg("failed to sync repo mirror")
+	}
+
+	_, err = s.repoMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.RepoMirror) error {
+		now := time.Now().UnixMilli()
+		mirror.Updated = now
+		if syncErr != nil {
+			mirror.SyncStatus = enum.RepoMirrorSyncStatusFailed
+			mirror.LastError = syncErr.Error()
+			return nil
+		}
+
+		mirror.SyncStatus = enum.RepoMirrorSyncStatusSuccess
+		mirror.LastSync = now
+		mirror.LastError = ""
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update repo mirror sync status: %w", err)
+	}
+
+	return "", nil
+}
+
+// sync fetches the branches and tags of the source repository and updates the repository accordingly.
+// The returned error is stored on the mirror, hence it must not contain any credentials.
+func (j *syncJob) sync(ctx context.Context, mirror *types.RepoMirror) error {
+	s := j.service
+
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	sourceURL, secret, err := j.sourceURLWithAuth(ctx, repo, mirror)
+	if err != nil {
+		return err
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		systemPrincipal.ID,
+		false,
+		true,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	syncOut, err := s.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams: git.WriteParams{
+			Actor: git.Identity{
+				Name:  systemPrincipal.DisplayName,
+				Email: systemPrincipal.Email,
+			},
+			RepoUID: repo.GitUID,
+			EnvVars: envVars,
+		},
+		Source:            sourceURL,
+		CreateIfNotExists: false,
+		RefSpecs:          []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync repository from %q: %s", mirror.SourceURL, redactSecret(err.Error(), secret))
+	}
+
+	if syncOut.DefaultBranch != "" &&
+		(repo.DefaultBranch != syncOut.DefaultBranch || repo.IsEmpty) {
+		repo, err = s.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.DefaultBranch = syncOut.DefaultBranch
+			repo.IsEmpty = false
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update repository: %w", err)
+		}
+	}
+
+	if err = s.indexer.Index(ctx, repo); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo.id", repo.ID).Msg("failed to index repo mirror")
+	}
+
+	return nil
+}
+
+// redactSecret removes the credentials from the message. Git might print the source URL, in which case the secret
+// is percent-encoded, hence both the plain and the encoded secret are redacted.
+func redactSecret(msg, secret string) string {
+	msg = api.SanitizeCredentialURLs(msg)
+	if secret == "" {
+		return msg
+	}
+
+	encodedSecret := strings.TrimPrefix(url.UserPassword("", secret).String(), ":")
+
+	msg = strings.ReplaceAll(msg, secret, "xxxxx")
+	msg = strings.ReplaceAll(msg, encodedSecret, "xxxxx")
+
+	return msg
+}
+
+// sourceURLWithAuth returns the source URL of the mirror with the credentials of the mirror's connector, if any,
+// along with the secret that has to be redacted from any error message.
+func (j *syncJob) sourceURLWithAuth(
+	ctx context.Context,
+	repo *types.Repository,
+	mirror *types.RepoMirror,
+) (string, string, error) {
+	if mirror.Connector == "" {
+		return mirror.SourceURL, "", nil
+	}
+
+	connector, err := j.service.connectorStore.FindByIdentifier(ctx, repo.ParentID, mirror.Connector)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find connector %s: %w", mirror.Connector, err)
+	}
+
+	creds := &scm.ConnectorCredentials{}
+	if err = json.Unmarshal([]byte(connector.Data), creds); err != nil {
+		return "", "", fmt.Errorf("failed to parse credentials of connector %s: %w", connector.Identifier, err)
+	}
+	if creds.Token == "" {
+		return "", "", fmt.Errorf("connector %s doesn't contain a token", connector.Identifier)
+	}
+
+	sourceURL, err := url.Parse(mirror.SourceURL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse source URL: %w", err)
+	}
+
+	username := creds.Username
+	if username == "" {
+		username = defaultCredentialsUsername
+	}
+
+	sourceURL.User = url.UserPassword(username, creds.Token)
+
+	return sourceURL.String(), creds.Token, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/gorhill/cronexpr"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	cronJobType = "pipeline-cron-triggers"
+	// cronJobDef defines how often the due cron triggers are checked.
+	// It's the smallest interval supported by cron triggers.
+	cronJobDef    = "* * * * *"
+	cronJobMaxDur = time.Minute
+)
+
+// NextCronRun returns the next time (unix millis) after the provided time
+// at which the cron expression matches in the provided timezone.
+func NextCronRun(expression string, timezone string, after time.Time) (int64, error) {
+	exp, err := cronexpr.Parse(expression)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cron expression: %w", err)
+	}
+
+	loc := time.UTC
+	if timezone != "" {
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+
+	next := exp.Next(after.In(loc))
+	if next.IsZero() {
+		return 0, errors.New("cron expression never matches")
+	}
+
+	return next.UnixMilli(), nil
+}
+
+// CronScheduler is a recurring job that fires executions for all due cron triggers.
+type CronScheduler struct {
+	triggerStore  store.TriggerStore
+	repoStore     store.RepoStore
+	pipelineStore store.PipelineStore
+	triggerSvc    triggerer.Triggerer
+	commitSvc     commit.Service
+	scheduler     *job.Scheduler
+}
+
+func (s *CronScheduler) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, cronJobType, cronJobType, cronJobDef, cronJobMaxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for cron triggers: %w", err)
+	}
+
+	return nil
+}
+
+// Handle fires an execution for every cron trigger that is due.
+// Before firing, the next run time of a trigger is advanced using optimistic locking,
+// which guarantees that a scheduled run is fired only once even with multiple instances.
+func (s *CronScheduler) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+
+	triggers, err := s.triggerStore.ListDueCron(ctx, now.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list due cron triggers: %w", err)
+	}
+
+	for _, t := range triggers {
+		log := log.Ctx(ctx).With().
+			Int64("trigger.id", t.ID).
+			Str("trigger.identifier", t.Identifier).
+			Int64("pipeline.id", t.PipelineID).
+			Logger()
+
+		claimed, err := s.claim(ctx, t, now)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to schedule next run of cron trigger")
+			continue
+		}
+		if !claimed {
+			log.Debug().Msg("cron trigger already fired")
+			continue
+		}
+
+		if err = s.fire(ctx, t); err != nil {
+			log.Warn().Err(err).Msg("failed to fire cron trigger")
+			continue
+		}
+
+		log.Debug().Msg("cron trigger fired")
+	}
+
+	return "", nil
+}
+
+// claim advances the next run of the trigger. It returns false if the trigger
+// has been modified in the meantime (e.g. it was already fired by another instance).
+func (s *CronScheduler) claim(ctx context.Context, t *types.Trigger, now time.Time) (bool, error) {
+	next, errNext := NextCronRun(t.CronExpression, t.CronTimezone, now)
+
+	dup := *t
+	dup.CronNext = next // if the expression is broken, stop scheduling the trigger.
+
+	err := s.triggerStore.Update(ctx, &dup)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update cron trigger: %w", err)
+	}
+
+	if errNext != nil {
+		return false, errNext
+	}
+
+	return true, nil
+}
+
+func (s *CronScheduler) fire(ctx context.Context, t *types.Trigger) error {
+	pipeline, err := s.pipelineStore.Find(ctx, t.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	// Don't fire triggers for disabled pipelines
+	if pipeline.Disabled {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, pipeline.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	// If the branch is empty, use the default branch specified in the pipeline.
+	// It that is also empty, use the repo default branch.
+	branch := t.CronBranch
+	if branch == "" {
+		branch = pipeline.DefaultBranch
+		if branch == "" {
+			branch = repo.DefaultBranch
+		}
+	}
+	ref := scm.ExpandRef(branch, "refs/heads")
+
+	commit, err := s.commitSvc.FindRef(ctx, repo, ref)
+	if err != nil {
+		return fmt.Errorf("failed to fetch commit: %w", err)
+	}
+
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerCron,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		Cron:        t.Identifier,
+		AuthorLogin: commit.Author.Identity.Name,
+		AuthorName:  commit.Author.Identity.Name,
+		AuthorEmail: commit.Author.Identity.Email,
+		Ref:         ref,
+		Message:     commit.Message,
+		Title:       commit.Title,
+		Before:      commit.SHA,
+		After:       commit.SHA,
+		Source:      branch,
+		Target:      branch,
+		Params:      map[string]string{},
+		Timestamp:   commit.Author.When.UnixMilli(),
+	}
+
+	_, err = s.triggerSvc.Trigger(ctx, pipeline, hook)
+	if err != nil {
+		return fmt.Errorf("failed to trigger execution: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,290 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestNextCronRun(t *testing.T) {
+	after := time.Date(2024, 3, 10, 10, 30, 0, 0, time.UTC)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone database not available: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		timezone   string
+		want       time.Time
+		wantErr    bool
+	}{
+		{
+			name:       "hourly",
+			expression: "0 * * * *",
+			want:       time.Date(2024, 3, 10, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "daily in utc",
+			expression: "0 9 * * *",
+			want:       time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "daily in timezone",
+			expression: "0 12 * * *",
+			timezone:   "Europe/Berlin",
+			want:       time.Date(2024, 3, 10, 12, 0, 0, 0, berlin),
+		},
+		{
+			name:       "invalid expression",
+			expression: "not a cron",
+			wantErr:    true,
+		},
+		{
+			name:       "invalid timezone",
+			expression: "0 * * * *",
+			timezone:   "Mars/Olympus",
+			wantErr:    true,
+		},
+		{
+			name:       "never matches",
+			expression: "0 0 1 1 * 2000",
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NextCronRun(test.expression, test.timezone, after)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("NextCronRun() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if want := test.want.UnixMilli(); got != want {
+				t.Errorf("NextCronRun() = %s, want %s", time.UnixMilli(got).UTC(), test.want.UTC())
+			}
+		})
+	}
+}
+
+func TestCronScheduler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	config := &types.Config{}
+	config.Principal.System.UID = "gitness"
+	if err := bootstrap.SystemService(ctx, config, service.NewController(nil, nil, &fakePrincipalStore{})); err != nil {
+		t.Fatalf("failed to set up system service: %v", err)
+	}
+
+	repo := &types.Repository{ID: 1, DefaultBranch: "main"}
+
+	tests := []struct {
+		name        string
+		trigger     types.Trigger
+		pipeline    types.Pipeline
+		conflict    bool
+		wantUpdated bool
+		wantNoNext  bool
+		wantRef     string
+	}{
+		{
+			name: "due trigger fires on the repo default branch",
+			trigger: types.Trigger{
+				ID: 1, PipelineID: 1, Identifier: "nightly",
+				Type: enum.TriggerTypeCron, CronExpression: "0 * * * *",
+			},
+			pipeline:    types.Pipeline{ID: 1, RepoID: 1},
+			wantUpdated: true,
+			wantRef:     "refs/heads/main",
+		},
+		{
+			name: "due trigger fires on the configured branch",
+			trigger: types.Trigger{
+				ID: 1, PipelineID: 1, Identifier: "nightly",
+				Type: enum.TriggerTypeCron, CronExpression: "0 * * * *", CronBranch: "release",
+			},
+			pipeline:    types.Pipeline{ID: 1, RepoID: 1, DefaultBranch: "develop"},
+			wantUpdated: true,
+			wantRef:     "refs/heads/release",
+		},
+		{
+			name: "trigger claimed by another instance doesn't fire",
+			trigger: types.Trigger{
+				ID: 1, PipelineID: 1, Identifier: "nightly",
+				Type: enum.TriggerTypeCron, CronExpression: "0 * * * *",
+			},
+			pipeline: types.Pipeline{ID: 1, RepoID: 1},
+			conflict: true,
+		},
+		{
+			name: "broken expression stops the schedule without firing",
+			trigger: types.Trigger{
+				ID: 1, PipelineID: 1, Identifier: "nightly",
+				Type: enum.TriggerTypeCron, CronExpression: "broken",
+			},
+			pipeline:    types.Pipeline{ID: 1, RepoID: 1},
+			wantUpdated: true,
+			wantNoNext:  true,
+		},
+		{
+			name: "trigger of disabled pipeline doesn't fire",
+			trigger: types.Trigger{
+				ID: 1, PipelineID: 1, Identifier: "nightly",
+				Type: enum.TriggerTypeCron, CronExpression: "0 * * * *",
+			},
+			pipeline:    types.Pipeline{ID: 1, RepoID: 1, Disabled: true},
+			wantUpdated: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trigger := test.trigger
+			pipeline := test.pipeline
+			triggerStore := &fakeTriggerStore{due: []*types.Trigger{&trigger}, conflict: test.conflict}
+			triggerSvc := &fakeTriggerer{}
+
+			s := &CronScheduler{
+				triggerStore:  triggerStore,
+				repoStore:     &fakeRepoStore{repo: repo},
+				pipelineStore: &fakePipelineStore{pipeline: &pipeline},
+				triggerSvc:    triggerSvc,
+				commitSvc:     &fakeCommitService{},
+			}
+
+			before := time.Now()
+
+			if _, err := s.Handle(ctx, "", nil); err != nil {
+				t.Fatalf("failed to handle cron job: %v", err)
+			}
+
+			if want, got := test.wantUpdated, triggerStore.updated != nil; want != got {
+				t.Fatalf("want trigger updated=%t, got %t", want, got)
+			}
+			if triggerStore.updated != nil {
+				next := triggerStore.updated.CronNext
+				if test.wantNoNext && next != 0 {
+					t.Errorf("want next run to be cleared, got %d", next)
+				}
+				if !test.wantNoNext && next <= before.UnixMilli() {
+					t.Errorf("want next run in the future, got %s", time.UnixMilli(next))
+				}
+			}
+
+			if test.wantRef == "" {
+				if len(triggerSvc.hooks) != 0 {
+					t.Fatalf("want no execution, got %d", len(triggerSvc.hooks))
+				}
+				return
+			}
+
+			if len(triggerSvc.hooks) != 1 {
+				t.Fatalf("want one execution, got %d", len(triggerSvc.hooks))
+			}
+			hook := triggerSvc.hooks[0]
+			if hook.Trigger != enum.TriggerCron || hook.Cron != trigger.Identifier {
+				t.Errorf("want cron hook of trigger %q, got %s %q", trigger.Identifier, hook.Trigger, hook.Cron)
+			}
+			if hook.Ref != test.wantRef {
+				t.Errorf("want ref %q, got %q", test.wantRef, hook.Ref)
+			}
+			if hook.After != "sha" {
+				t.Errorf("want commit sha, got %q", hook.After)
+			}
+		})
+	}
+}
+
+type fakeTriggerStore struct {
+	store.TriggerStore
+	due      []*types.Trigger
+	conflict bool
+	updated  *types.Trigger
+}
+
+func (s *fakeTriggerStore) ListDueCron(context.Context, int64) ([]*types.Trigger, error) {
+	return s.due, nil
+}
+
+func (s *fakeTriggerStore) Update(_ context.Context, trigger *types.Trigger) error {
+	if s.conflict {
+		return gitness_store.ErrVersionConflict
+	}
+	s.updated = trigger
+	return nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *fakeRepoStore) Find(context.Context, int64) (*types.Repository, error) {
+	return s.repo, nil
+}
+
+type fakePipelineStore struct {
+	store.PipelineStore
+	pipeline *types.Pipeline
+}
+
+func (s *fakePipelineStore) Find(context.Context, int64) (*types.Pipeline, error) {
+	return s.pipeline, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (s *fakePrincipalStore) FindServiceByUID(_ context.Context, uid string) (*types.Service, error) {
+	return &types.Service{ID: 1, UID: uid, Admin: true}, nil
+}
+
+type fakeCommitService struct{}
+
+func (s *fakeCommitService) FindRef(context.Context, *types.Repository, string) (*types.Commit, error) {
+	return &types.Commit{SHA: "sha", Title: "title", Message: "message"}, nil
+}
+
+func (s *fakeCommitService) FindCommit(context.Context, *types.Repository, string) (*types.Commit, error) {
+	return &types.Commit{SHA: "sha", Title: "title", Message: "message"}, nil
+}
+
+type fakeTriggerer struct {
+	hooks []*triggerer.Hook
+}
+
+func (s *fakeTriggerer) Trigger(
+	_ context.Context,
+	pipeline *types.Pipeline,
+	hook *triggerer.Hook,
+) (*types.Execution, error) {
+	s.hooks = append(s.hooks, hook)
+	return &types.Execution{PipelineID: pipeline.ID}, nil
+}
@@ -46,7 +46,7 @@ func (s *Service) handleEventBranchCreated(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("could not augment commit info: %w", err)
 	}
-	return s.trigger(ctx, event.Payload.RepoID, enum.TriggerActionBranchCreated, hook)
+	return s.Trigger(ctx, event.Payload.RepoID, enum.TriggerActionBranchCreated, hook)
 }
 
 func (s *Service) handleEventBranchUpdated(ctx context.Context,
@@ -65,7 +65,7 @@ func (s *Service) handleEventBranchUpdated(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("could not augment commit info: %w", err)
 	}
-	return s.trigger(ctx, event.Payload.RepoID, enum.TriggerActionBranchUpdated, hook)
+	return s.Trigger(ctx, event.Payload.RepoID, enum.TriggerActionBranchUpdated, hook)
 }
 
 // augmentCommitInfo adds information about the commit to the hook by interacting with
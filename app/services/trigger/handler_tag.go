@@ -41,7 +41,7 @@ func (s *Service) handleEventTagCreated(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("could not augment commit info: %w", err)
 	}
-	return s.trigger(ctx, event.Payload.RepoID, enum.TriggerActionTagCreated, hook)
+	return s.Trigger(ctx, event.Payload.RepoID, enum.TriggerActionTagCreated, hook)
 }
 
 func (s *Service) handleEventTagUpdated(ctx context.Context,
@@ -60,5 +60,5 @@ func (s *Service) handleEventTagUpdated(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("could not augment commit info: %w", err)
 	}
-	return s.trigger(ctx, event.Payload.RepoID, enum.TriggerActionTagUpdated, hook)
+	return s.Trigger(ctx, event.Payload.RepoID, enum.TriggerActionTagUpdated, hook)
 }
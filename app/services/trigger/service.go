@@ -142,11 +142,11 @@ func New(
 	return service, nil
 }
 
-// trigger a build given an action on a repo and a hook.
+// Trigger triggers a build given an action on a repo and a hook.
 // It tries to find all enabled triggers, see if the action is the same
 // as the trigger action - and if so, find the pipeline for the trigger
 // and fire an execution.
-func (s *Service) trigger(ctx context.Context, repoID int64,
+func (s *Service) Trigger(ctx context.Context, repoID int64,
 	action enum.TriggerAction, hook *triggerer.Hook) error {
 	// Get all enabled triggers for a repo.
 	ret, err := s.triggerStore.ListAllEnabled(ctx, repoID)
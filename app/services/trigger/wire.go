@@ -23,12 +23,14 @@ import (
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	ProvideService,
+	ProvideCronScheduler,
 )
 
 func ProvideService(
@@ -46,3 +48,29 @@ func ProvideService(
 	return New(ctx, config, triggerStore, pullReqStore, repoStore, pipelineStore, triggerSvc,
 		commitSvc, gitReaderFactory, pullReqEvFactory)
 }
+
+func ProvideCronScheduler(
+	triggerStore store.TriggerStore,
+	repoStore store.RepoStore,
+	pipelineStore store.PipelineStore,
+	triggerSvc triggerer.Triggerer,
+	commitSvc commit.Service,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*CronScheduler, error) {
+	job := &CronScheduler{
+		triggerStore:  triggerStore,
+		repoStore:     repoStore,
+		pipelineStore: pipelineStore,
+		triggerSvc:    triggerSvc,
+		commitSvc:     commitSvc,
+		scheduler:     scheduler,
+	}
+
+	err := executor.Register(cronJobType, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// disableWebhookIfFailing disables the webhook in case its latest executions all ended with a fatal error,
+// and notifies the creator of the webhook about it.
+func (s *Service) disableWebhookIfFailing(ctx context.Context, webhook *types.Webhook, lastError string) {
+	threshold := s.config.AutoDisableThreshold
+	if threshold == 0 || webhook.Internal || !webhook.Enabled {
+		return
+	}
+
+	executions, err := s.webhookExecutionStore.ListForWebhook(ctx, webhook.ID, &types.WebhookExecutionFilter{
+		Page: 1,
+		Size: threshold,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to list latest executions of webhook %d", webhook.ID)
+		return
+	}
+
+	if len(executions) < threshold {
+		return
+	}
+
+	for _, execution := range executions {
+		if execution.Result != enum.WebhookExecutionResultFatalError {
+			return
+		}
+	}
+
+	disabled := false
+	webhook, err = s.webhookStore.UpdateOptLock(ctx, webhook, func(hook *types.Webhook) error {
+		disabled = hook.Enabled
+		hook.Enabled = false
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to disable webhook %d", webhook.ID)
+		return
+	}
+
+	// the webhook could have been disabled concurrently - notify only once.
+	if !disabled {
+		return
+	}
+
+	log.Ctx(ctx).Info().Msgf("disabled webhook %d after %d consecutive failed executions", webhook.ID, threshold)
+
+	s.notifyWebhookDisabled(ctx, webhook, threshold, lastError)
+}
+
+// notifyWebhookDisabled notifies the creator of the webhook that it got disabled (best effort).
+func (s *Service) notifyWebhookDisabled(ctx context.Context, webhook *types.Webhook, failures int, lastError string) {
+	payload := &notification.WebhookDisabledPayload{
+		Webhook:   webhook,
+		Failures:  failures,
+		LastError: lastError,
+	}
+
+	switch webhook.ParentType {
+	case enum.WebhookParentRepo:
+		repo, err := s.repoStore.Find(ctx, webhook.ParentID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to find repo %d of disabled webhook %d",
+				webhook.ParentID, webhook.ID)
+			return
+		}
+
+		payload.Repo = repo
+		payload.WebhookURL = s.urlProvider.GenerateUIWebhookURL(repo.Path, webhook.ID)
+
+	case enum.WebhookParentSpace:
+		space, err := s.spaceStore.Find(ctx, webhook.ParentID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to find space %d of disabled webhook %d",
+				webhook.ParentID, webhook.ID)
+			return
+		}
+
+		payload.Space = space
+		payload.WebhookURL = s.urlProvider.GenerateUISpaceURL(space.Path)
+	}
+
+	creator, err := s.principalStore.Find(ctx, webhook.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to find creator %d of disabled webhook %d",
+			webhook.CreatedBy, webhook.ID)
+		return
+	}
+
+	err = s.notificationClient.SendWebhookDisabled(ctx, []*types.PrincipalInfo{creator.ToPrincipalInfo()}, payload)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to notify creator of disabled webhook %d", webhook.ID)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestNotifyWebhookDisabled(t *testing.T) {
+	urlProvider, err := url.NewProvider("http://localhost:3000", "http://localhost:3000",
+		"http://localhost:3000/api", "http://localhost:3000/git", "localhost", "git", false,
+		"http://localhost:3000")
+	if err != nil {
+		t.Fatalf("failed to create url provider: %s", err.Error())
+	}
+
+	tests := []struct {
+		name       string
+		parentType enum.WebhookParent
+		expPath    string
+		expURL     string
+	}{
+		{
+			name:       "repo",
+			parentType: enum.WebhookParentRepo,
+			expPath:    "acme/app",
+			expURL:     "http://localhost:3000/acme/app/webhook/7",
+		},
+		{
+			name:       "space",
+			parentType: enum.WebhookParentSpace,
+			expPath:    "acme",
+			expURL:     "http://localhost:3000/spaces/acme",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &recordingNotificationClient{}
+			s := &Service{
+				urlProvider:        urlProvider,
+				repoStore:          fakeRepoStore{repo: &types.Repository{ID: 3, Identifier: "app", Path: "acme/app"}},
+				spaceStore:         fakeSpaceStore{space: &types.Space{ID: 3, Identifier: "acme", Path: "acme"}},
+				principalStore:     fakePrincipalStore{},
+				notificationClient: client,
+			}
+
+			webhook := &types.Webhook{ID: 7, ParentType: test.parentType, ParentID: 3, CreatedBy: 1}
+
+			s.notifyWebhookDisabled(context.Background(), webhook, 10, "connection refused")
+
+			if client.payload == nil {
+				t.Fatal("expected a notification to be sent")
+			}
+
+			var path string
+			if client.payload.Repo != nil {
+				path = client.payload.Repo.Path
+			} else if client.payload.Space != nil {
+				path = client.payload.Space.Path
+			}
+
+			if want, got := test.expPath, path; want != got {
+				t.Errorf("parent path: want=%q got=%q", want, got)
+			}
+
+			if want, got := test.expURL, client.payload.WebhookURL; want != got {
+				t.Errorf("webhook url: want=%q got=%q", want, got)
+			}
+
+			if _, err := notification.GetHTMLBody(notification.TemplateWebhookDisabled, client.payload); err != nil {
+				t.Errorf("failed to render notification: %s", err.Error())
+			}
+		})
+	}
+}
+
+type recordingNotificationClient struct {
+	notification.Client
+	payload *notification.WebhookDisabledPayload
+}
+
+func (c *recordingNotificationClient) SendWebhookDisabled(
+	_ context.Context,
+	_ []*types.PrincipalInfo,
+	payload *notification.WebhookDisabledPayload,
+) error {
+	c.payload = payload
+	return nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s fakeRepoStore) Find(context.Context, int64) (*types.Repository, error) {
+	return s.repo, nil
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	space *types.Space
+}
+
+func (s fakeSpaceStore) Find(context.Context, int64) (*types.Space, error) {
+	return s.space, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	return &types.Principal{ID: id, UID: "jane", Email: "jane@example.com"}, nil
+}
@@ -176,7 +176,8 @@ func (s *Service) triggerForEvent(ctx context.Context, eventID string,
 				result.Execution.ID, result.Webhook.ID, result.Execution.Result, result.Err))
 		}
 
-		if result.Execution.Result == enum.WebhookExecutionResultRetriableError {
+		// executions scheduled for redelivery are retried by the redelivery job
+		if result.Execution.Result == enum.WebhookExecutionResultRetriableError && !result.RedeliveryScheduled {
 			retryRequired = true
 		}
 	}
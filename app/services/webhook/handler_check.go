@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CheckStatusChangedPayload describes the body of the check status changed trigger.
+type CheckStatusChangedPayload struct {
+	BaseSegment
+	CheckSegment
+}
+
+// handleEventCheckStatusReported handles status reported events for status checks
+// and triggers check status changed webhooks for the repo.
+func (s *Service) handleEventCheckStatusReported(ctx context.Context,
+	event *events.Event[*checkevents.StatusReportedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerCheckStatusChanged,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			check, err := s.checkStore.FindByIdentifier(ctx, repo.ID, event.Payload.CommitSHA, event.Payload.Identifier)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return nil, events.NewDiscardEventErrorf("check '%s' for commit '%s' doesn't exist anymore",
+					event.Payload.Identifier, event.Payload.CommitSHA)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to find check '%s' for commit '%s': %w",
+					event.Payload.Identifier, event.Payload.CommitSHA, err)
+			}
+
+			// the status from the event is used as the check might have been reported again in the meantime.
+			checkInfo := checkInfoFrom(&check)
+			checkInfo.Status = event.Payload.Status
+
+			return &CheckStatusChangedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerCheckStatusChanged,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				CheckSegment: CheckSegment{
+					Check: checkInfo,
+				},
+			}, nil
+		})
+}
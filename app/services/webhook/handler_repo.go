@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepositoryCreatedPayload describes the body of the repo created trigger.
+type RepositoryCreatedPayload struct {
+	BaseSegment
+}
+
+// handleEventRepoCreated handles created events for repositories
+// and triggers repo created webhooks for the repo and its parent space.
+func (s *Service) handleEventRepoCreated(ctx context.Context,
+	event *events.Event[*repoevents.CreatedPayload]) error {
+	repo, err := s.findRepositoryForEvent(ctx, event.Payload.RepoID)
+	if err != nil {
+		return err
+	}
+
+	return s.triggerForRepoLifecycleEvent(ctx, enum.WebhookTriggerRepoCreated,
+		event.ID, event.Payload.PrincipalID, repo,
+		func(principal *types.Principal) any {
+			return &RepositoryCreatedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoCreated,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+			}
+		})
+}
+
+// RepositoryDeletedPayload describes the body of the repo deleted trigger.
+type RepositoryDeletedPayload struct {
+	BaseSegment
+	RepositoryDeletedSegment
+}
+
+// handleEventRepoSoftDeleted handles soft deleted events for repositories
+// and triggers repo deleted webhooks for the repo and its parent space.
+func (s *Service) handleEventRepoSoftDeleted(ctx context.Context,
+	event *events.Event[*repoevents.SoftDeletedPayload]) error {
+	repo, err := s.repoStore.FindByRefAndDeletedAt(ctx,
+		strconv.FormatInt(event.Payload.RepoID, 10), event.Payload.Deleted)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// most likely the repo got restored or purged by now
+		return events.NewDiscardEventErrorf("deleted repo with id '%d' doesn't exist anymore", event.Payload.RepoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get deleted repo for id '%d': %w", event.Payload.RepoID, err)
+	}
+
+	return s.triggerForRepoLifecycleEvent(ctx, enum.WebhookTriggerRepoDeleted,
+		event.ID, event.Payload.PrincipalID, repo,
+		func(principal *types.Principal) any {
+			return &RepositoryDeletedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoDeleted,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				RepositoryDeletedSegment: RepositoryDeletedSegment{
+					Deleted: event.Payload.Deleted,
+				},
+			}
+		})
+}
+
+// RepositoryRenamedPayload describes the body of the repo renamed trigger.
+type RepositoryRenamedPayload struct {
+	BaseSegment
+	RepositoryRenameSegment
+}
+
+// handleEventRepoRenamed handles renamed events for repositories
+// and triggers repo renamed webhooks for the repo and its parent space.
+func (s *Service) handleEventRepoRenamed(ctx context.Context,
+	event *events.Event[*repoevents.RenamedPayload]) error {
+	repo, err := s.findRepositoryForEvent(ctx, event.Payload.RepoID)
+	if err != nil {
+		return err
+	}
+
+	return s.triggerForRepoLifecycleEvent(ctx, enum.WebhookTriggerRepoRenamed,
+		event.ID, event.Payload.PrincipalID, repo,
+		func(principal *types.Principal) any {
+			return &RepositoryRenamedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoRenamed,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				RepositoryRenameSegment: RepositoryRenameSegment{
+					OldIdentifier: event.Payload.OldIdentifier,
+					OldPath:       paths.Concatenate(paths.Parent(repo.Path), event.Payload.OldIdentifier),
+				},
+			}
+		})
+}
+
+// RepositoryDefaultBranchChangedPayload describes the body of the repo default branch changed trigger.
+type RepositoryDefaultBranchChangedPayload struct {
+	BaseSegment
+	DefaultBranchUpdateSegment
+}
+
+// handleEventRepoDefaultBranchUpdated handles default branch updated events for repositories
+// and triggers repo default branch changed webhooks for the repo.
+func (s *Service) handleEventRepoDefaultBranchUpdated(ctx context.Context,
+	event *events.Event[*repoevents.DefaultBranchUpdatedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoDefaultBranchChanged,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			repoInfo := repositoryInfoFrom(repo, s.urlProvider)
+			// the default branch might have been changed again in the meantime.
+			repoInfo.DefaultBranch = event.Payload.NewName
+
+			return &RepositoryDefaultBranchChangedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoDefaultBranchChanged,
+					Repo:      repoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				DefaultBranchUpdateSegment: DefaultBranchUpdateSegment{
+					OldDefaultBranch: event.Payload.OldName,
+				},
+			}, nil
+		})
+}
+
+// triggerForRepoLifecycleEvent triggers all webhooks of the provided repo and of its parent space.
+// NOTE: webhooks of the parent space are included as a repo doesn't have any webhooks yet when it gets created.
+func (s *Service) triggerForRepoLifecycleEvent(ctx context.Context,
+	triggerType enum.WebhookTrigger, eventID string, principalID int64, repo *types.Repository,
+	createBodyFn func(*types.Principal) any) error {
+	principal, err := s.findPrincipalForEvent(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	body := createBodyFn(principal)
+
+	// executions are deduplicated by trigger id, so reprocessing the event won't execute successful webhooks again.
+	err = s.triggerForEvent(ctx, eventID, enum.WebhookParentRepo, repo.ID, triggerType, body)
+	if err != nil {
+		return err
+	}
+
+	return s.triggerForEvent(ctx, eventID, enum.WebhookParentSpace, repo.ParentID, triggerType, body)
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	webhookExecutions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "webhook",
+		Name:      "executions_total",
+		Help:      "Number of webhook deliveries, partitioned by trigger type and result.",
+	}, []string{"trigger", "result"})
+
+	webhookExecutionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "webhook",
+		Name:      "execution_duration_seconds",
+		Help:      "Duration of webhook deliveries, partitioned by result.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 20},
+	}, []string{"result"})
+)
+
+// observeExecution records the metrics of a finished webhook execution.
+func observeExecution(execution *types.WebhookExecution) {
+	webhookExecutions.WithLabelValues(string(execution.TriggerType), string(execution.Result)).Inc()
+	webhookExecutionDuration.WithLabelValues(string(execution.Result)).
+		Observe(time.Duration(execution.Duration).Seconds())
+}
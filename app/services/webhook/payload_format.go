@@ -0,0 +1,380 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// MaxPayloadTemplateLength defines the max allowed length of a webhook payload template.
+	MaxPayloadTemplateLength = 64 * 1024
+
+	// payloadTemplateOutputBytesLimit defines the maximum size of the request body generated from a template.
+	payloadTemplateOutputBytesLimit = 1024 * 1024
+)
+
+var payloadTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"shortSHA": shortSHA,
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// ParsePayloadTemplate parses a user provided Go text template used to generate the webhook request body.
+// The template is executed with the webhook payload of the trigger as data.
+func ParsePayloadTemplate(text string) (*template.Template, error) {
+	return template.New("payload").
+		Option("missingkey=error").
+		Funcs(payloadTemplateFuncs).
+		Parse(text)
+}
+
+// formatPayload generates the request body of the webhook in the payload format of the webhook.
+func formatPayload(webhook *types.Webhook, triggerType enum.WebhookTrigger, body any) ([]byte, error) {
+	format, _ := webhook.PayloadFormat.Sanitize()
+
+	switch format {
+	case enum.WebhookPayloadFormatSlack:
+		return json.Marshal(slackMessageFrom(chatMessageFrom(triggerType, body)))
+
+	case enum.WebhookPayloadFormatTeams:
+		return json.Marshal(teamsMessageFrom(chatMessageFrom(triggerType, body)))
+
+	case enum.WebhookPayloadFormatTemplate:
+		tmpl, err := ParsePayloadTemplate(webhook.PayloadTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse payload template: %w", err)
+		}
+
+		buffer := &limitedBuffer{limit: payloadTemplateOutputBytesLimit}
+		if err = tmpl.Execute(buffer, body); err != nil {
+			return nil, fmt.Errorf("failed to execute payload template: %w", err)
+		}
+
+		return buffer.Bytes(), nil
+
+	case enum.WebhookPayloadFormatJSON:
+	}
+
+	bBuff := &bytes.Buffer{}
+	if err := json.NewEncoder(bBuff).Encode(body); err != nil {
+		return nil, fmt.Errorf("failed to serialize body to json: %w", err)
+	}
+
+	return bBuff.Bytes(), nil
+}
+
+// payloadContentType returns the content type of the request body generated in the payload format of the webhook.
+func payloadContentType(webhook *types.Webhook, body []byte) string {
+	if webhook.PayloadFormat == enum.WebhookPayloadFormatTemplate && !json.Valid(body) {
+		return "text/plain; charset=utf-8"
+	}
+
+	return "application/json"
+}
+
+// limitedBuffer is a bytes.Buffer that fails writes once the limit is exceeded.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf("payload exceeds the limit of %d bytes", b.limit)
+	}
+
+	return b.Buffer.Write(p)
+}
+
+// chatMessage is a short human-readable description of a webhook trigger used for chat payload formats.
+type chatMessage struct {
+	Title string
+	Text  string
+	URL   string
+}
+
+// The methods below are promoted to all payload types embedding the segments
+// and allow describing the payloads without knowing their concrete type.
+
+func (s *BaseSegment) baseSegment() *BaseSegment                { return s }
+func (s *ReferenceSegment) referenceSegment() *ReferenceSegment { return s }
+func (s *PullReqSegment) pullReqSegment() *PullReqSegment       { return s }
+
+func (s *ReferenceDetailsSegment) referenceDetailsSegment() *ReferenceDetailsSegment { return s }
+
+func (s *ReferenceUpdateSegment) referenceUpdateSegment() *ReferenceUpdateSegment { return s }
+
+func (s *PullReqCommentSegment) pullReqCommentSegment() *PullReqCommentSegment { return s }
+
+func (s *PullReqReviewSegment) pullReqReviewSegment() *PullReqReviewSegment { return s }
+
+func (s *PullReqReviewerSegment) pullReqReviewerSegment() *PullReqReviewerSegment { return s }
+
+func (s *CheckSegment) checkSegment() *CheckSegment { return s }
+
+func (s *RepositoryRenameSegment) repositoryRenameSegment() *RepositoryRenameSegment { return s }
+
+func (s *DefaultBranchUpdateSegment) defaultBranchUpdateSegment() *DefaultBranchUpdateSegment {
+	return s
+}
+
+//nolint:gocognit,cyclop // it's just a long list of triggers
+func chatMessageFrom(triggerType enum.WebhookTrigger, body any) chatMessage {
+	msg := chatMessage{
+		Title: string(triggerType),
+	}
+
+	base, ok := body.(interface{ baseSegment() *BaseSegment })
+	if !ok {
+		msg.Text = fmt.Sprintf("Webhook triggered for %s", triggerType)
+		return msg
+	}
+
+	repo := base.baseSegment().Repo.Path
+	principal := base.baseSegment().Principal.DisplayName
+
+	var ref string
+	if p, ok := body.(interface{ referenceSegment() *ReferenceSegment }); ok {
+		ref = shortRefName(p.referenceSegment().Ref.Name)
+	}
+
+	var commits int
+	if p, ok := body.(interface {
+		referenceDetailsSegment() *ReferenceDetailsSegment
+	}); ok {
+		commits = p.referenceDetailsSegment().TotalCommitsCount
+	}
+
+	var forced bool
+	if p, ok := body.(interface {
+		referenceUpdateSegment() *ReferenceUpdateSegment
+	}); ok {
+		forced = p.referenceUpdateSegment().Forced
+	}
+
+	var pr *PullReqInfo
+	if p, ok := body.(interface{ pullReqSegment() *PullReqSegment }); ok {
+		pr = &p.pullReqSegment().PullReq
+		msg.URL = pr.PrURL
+	}
+
+	var pushed string
+	if forced {
+		pushed = "force-pushed"
+	} else {
+		pushed = "pushed"
+	}
+
+	//nolint:exhaustive // triggers not listed here get a generic message
+	switch {
+	case triggerType == enum.WebhookTriggerBranchCreated:
+		msg.Title = fmt.Sprintf("[%s] Branch created", repo)
+		msg.Text = fmt.Sprintf("%s created branch %s", principal, ref)
+	case triggerType == enum.WebhookTriggerBranchUpdated:
+		msg.Title = fmt.Sprintf("[%s] Branch updated", repo)
+		msg.Text = fmt.Sprintf("%s %s %d commit(s) to branch %s", principal, pushed, commits, ref)
+	case triggerType == enum.WebhookTriggerBranchDeleted:
+		msg.Title = fmt.Sprintf("[%s] Branch deleted", repo)
+		msg.Text = fmt.Sprintf("%s deleted branch %s", principal, ref)
+	case triggerType == enum.WebhookTriggerTagCreated:
+		msg.Title = fmt.Sprintf("[%s] Tag created", repo)
+		msg.Text = fmt.Sprintf("%s created tag %s", principal, ref)
+	case triggerType == enum.WebhookTriggerTagUpdated:
+		msg.Title = fmt.Sprintf("[%s] Tag updated", repo)
+		msg.Text = fmt.Sprintf("%s updated tag %s", principal, ref)
+	case triggerType == enum.WebhookTriggerTagDeleted:
+		msg.Title = fmt.Sprintf("[%s] Tag deleted", repo)
+		msg.Text = fmt.Sprintf("%s deleted tag %s", principal, ref)
+	case triggerType == enum.WebhookTriggerRepoCreated:
+		msg.Title = fmt.Sprintf("[%s] Repository created", repo)
+		msg.Text = fmt.Sprintf("%s created repository %s", principal, repo)
+	case triggerType == enum.WebhookTriggerRepoDeleted:
+		msg.Title = fmt.Sprintf("[%s] Repository deleted", repo)
+		msg.Text = fmt.Sprintf("%s deleted repository %s", principal, repo)
+	case triggerType == enum.WebhookTriggerRepoRenamed:
+		msg.Title = fmt.Sprintf("[%s] Repository renamed", repo)
+		msg.Text = fmt.Sprintf("%s renamed repository to %s", principal, repo)
+		if p, ok := body.(interface {
+			repositoryRenameSegment() *RepositoryRenameSegment
+		}); ok {
+			msg.Text = fmt.Sprintf("%s renamed repository %s to %s",
+				principal, p.repositoryRenameSegment().OldPath, repo)
+		}
+	case triggerType == enum.WebhookTriggerRepoDefaultBranchChanged:
+		msg.Title = fmt.Sprintf("[%s] Default branch changed", repo)
+		msg.Text = fmt.Sprintf("%s changed the default branch to %s",
+			principal, base.baseSegment().Repo.DefaultBranch)
+		if p, ok := body.(interface {
+			defaultBranchUpdateSegment() *DefaultBranchUpdateSegment
+		}); ok {
+			msg.Text = fmt.Sprintf("%s changed the default branch from %s to %s",
+				principal, p.defaultBranchUpdateSegment().OldDefaultBranch, base.baseSegment().Repo.DefaultBranch)
+		}
+	case triggerType == enum.WebhookTriggerCheckStatusChanged:
+		msg.Title = fmt.Sprintf("[%s] Check status changed", repo)
+		msg.Text = fmt.Sprintf("Check status changed by %s", principal)
+		if p, ok := body.(interface{ checkSegment() *CheckSegment }); ok {
+			check := p.checkSegment().Check
+			msg.Title = fmt.Sprintf("[%s] Check %s %s", repo, check.Identifier, check.Status)
+			msg.Text = fmt.Sprintf("Check %s reported %s for commit %s", check.Identifier, check.Status,
+				shortSHA(check.CommitSHA))
+			if check.Summary != "" {
+				msg.Text += "\n\n" + check.Summary
+			}
+			msg.URL = check.Link
+		}
+	case pr == nil:
+		msg.Title = fmt.Sprintf("[%s] %s", repo, triggerType)
+		msg.Text = fmt.Sprintf("Webhook triggered by %s", principal)
+	case triggerType == enum.WebhookTriggerPullReqCreated:
+		msg.Title = fmt.Sprintf("[%s] Pull request #%d opened", repo, pr.Number)
+		msg.Text = fmt.Sprintf("%s opened pull request #%d: %s", principal, pr.Number, pr.Title)
+	case triggerType == enum.WebhookTriggerPullReqReopened:
+		msg.Title = fmt.Sprintf("[%s] Pull request #%d reopened", repo, pr.Number)
+		msg.Text = fmt.Sprintf("%s reopened pull request #%d: %s", principal, pr.Number, pr.Title)
+	case triggerType == enum.WebhookTriggerPullReqBranchUpdated:
+		msg.Title = fmt.Sprintf("[%s] Pull request #%d updated", repo, pr.Number)
+		msg.Text = fmt.Sprintf("%s %s %d commit(s) to pull request #%d: %s",
+			principal, pushed, commits, pr.Number, pr.Title)
+	case triggerType == enum.WebhookTriggerPullReqClosed:
+		msg.Title = fmt.Sprintf("[%s] Pull request #%d closed", repo, pr.Number)
+		msg.Text = fmt.Sprintf("%s closed pull request #%d: %s", principal, pr.Number, pr.Title)
+	case triggerType == enum.WebhookTriggerPullReqMerged:
+		msg.Title = fmt.Sprintf("[%s] Pull request #%d merged", repo, pr.Number)
+		msg.Text = fmt.Sprintf("%s merged pull request #%d: %s", principal, pr.Number, pr.Title)
+	case triggerType == enum.WebhookTriggerPullReqCommentCreated:
+		msg.Title = fmt.Sprintf("[%s] New comment on pull request #%d", repo, pr.Number)
+		msg.Text = fmt.Sprintf("%s commented on pull request #%d: %s", principal, pr.Number, pr.Title)
+		if p, ok := body.(interface{ pullReqCommentSegment() *PullReqCommentSegment }); ok {
+			msg.Text += "\n\n" + p.pullReqCommentSegment().CommentInfo.Text
+		}
+	case triggerType == enum.WebhookTriggerPullReqUpdated:
+		msg.Title = fmt.Sprintf("[%s] Pull request #%d edited", repo, pr.Number)
+		msg.Text = fmt.Sprintf("%s edited pull request #%d: %s", principal, pr.Number, pr.Title)
+	case triggerType == enum.WebhookTriggerPullReqReviewSubmitted:
+		msg.Title = fmt.Sprintf("[%s] Pull request #%d reviewed", repo, pr.Number)
+		msg.Text = fmt.Sprintf("%s reviewed pull request #%d: %s", principal, pr.Number, pr.Title)
+		if p, ok := body.(interface{ pullReqReviewSegment() *PullReqReviewSegment }); ok {
+			msg.Text = fmt.Sprintf("%s submitted a review (%s) for pull request #%d: %s",
+				principal, p.pullReqReviewSegment().Review.Decision, pr.Number, pr.Title)
+		}
+	case triggerType == enum.WebhookTriggerPullReqReviewerAdded ||
+		triggerType == enum.WebhookTriggerPullReqReviewerRemoved:
+		var reviewer string
+		if p, ok := body.(interface {
+			pullReqReviewerSegment() *PullReqReviewerSegment
+		}); ok {
+			reviewer = p.pullReqReviewerSegment().Reviewer.DisplayName
+		}
+		if triggerType == enum.WebhookTriggerPullReqReviewerAdded {
+			msg.Title = fmt.Sprintf("[%s] Reviewer added to pull request #%d", repo, pr.Number)
+			msg.Text = fmt.Sprintf("%s requested a review from %s for pull request #%d: %s",
+				principal, reviewer, pr.Number, pr.Title)
+		} else {
+			msg.Title = fmt.Sprintf("[%s] Reviewer removed from pull request #%d", repo, pr.Number)
+			msg.Text = fmt.Sprintf("%s removed reviewer %s from pull request #%d: %s",
+				principal, reviewer, pr.Number, pr.Title)
+		}
+	default:
+		msg.Title = fmt.Sprintf("[%s] Pull request #%d", repo, pr.Number)
+		msg.Text = fmt.Sprintf("%s triggered %s for pull request #%d: %s",
+			principal, triggerType, pr.Number, pr.Title)
+	}
+
+	return msg
+}
+
+func shortRefName(ref string) string {
+	ref = strings.TrimPrefix(ref, "refs/heads/")
+	ref = strings.TrimPrefix(ref, "refs/tags/")
+	return ref
+}
+
+// slackMessage is the request body accepted by Slack incoming webhooks.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// slackEscaper escapes the control characters of the Slack message formatting.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func slackMessageFrom(msg chatMessage) slackMessage {
+	title := "*" + slackEscaper.Replace(msg.Title) + "*"
+	if msg.URL != "" {
+		title = fmt.Sprintf("<%s|%s>", msg.URL, slackEscaper.Replace(msg.Title))
+	}
+
+	return slackMessage{
+		Text: title + "\n" + slackEscaper.Replace(msg.Text),
+	}
+}
+
+// teamsMessage is the message card accepted by Microsoft Teams incoming webhooks.
+type teamsMessage struct {
+	Type            string        `json:"@type"`
+	Context         string        `json:"@context"`
+	Summary         string        `json:"summary"`
+	Title           string        `json:"title"`
+	Text            string        `json:"text"`
+	PotentialAction []teamsAction `json:"potentialAction,omitempty"`
+}
+
+type teamsAction struct {
+	Type    string        `json:"@type"`
+	Name    string        `json:"name"`
+	Targets []teamsTarget `json:"targets"`
+}
+
+type teamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+func teamsMessageFrom(msg chatMessage) teamsMessage {
+	card := teamsMessage{
+		Type:    "MessageCard",
+		Context: "https://schema.org/extensions",
+		Summary: msg.Title,
+		Title:   msg.Title,
+		Text:    msg.Text,
+	}
+
+	if msg.URL != "" {
+		card.PotentialAction = []teamsAction{{
+			Type:    "OpenUri",
+			Name:    "View",
+			Targets: []teamsTarget{{OS: "default", URI: msg.URL}},
+		}}
+	}
+
+	return card
+}
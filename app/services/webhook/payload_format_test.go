@@ -0,0 +1,227 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func testBranchUpdatedPayload() *ReferencePayload {
+	return &ReferencePayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerBranchUpdated,
+			Repo:      RepositoryInfo{Path: "space/repo"},
+			Principal: PrincipalInfo{DisplayName: "Jane"},
+		},
+		ReferenceSegment: ReferenceSegment{
+			Ref: ReferenceInfo{Name: "refs/heads/main"},
+		},
+		ReferenceDetailsSegment: ReferenceDetailsSegment{
+			SHA:               "abcdef0123456789",
+			TotalCommitsCount: 2,
+		},
+	}
+}
+
+func testPullReqCreatedPayload() *PullReqCreatedPayload {
+	return &PullReqCreatedPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerPullReqCreated,
+			Repo:      RepositoryInfo{Path: "space/repo"},
+			Principal: PrincipalInfo{DisplayName: "Jane"},
+		},
+		PullReqSegment: PullReqSegment{
+			PullReq: PullReqInfo{Number: 7, Title: "Fix <bug>", PrURL: "https://example.com/pr/7"},
+		},
+	}
+}
+
+func TestFormatPayload_JSON(t *testing.T) {
+	body, err := formatPayload(&types.Webhook{}, enum.WebhookTriggerBranchUpdated, testBranchUpdatedPayload())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var out ReferencePayload
+	if err = json.Unmarshal(body, &out); err != nil {
+		t.Fatalf("expected valid json: %s", err)
+	}
+	if out.Ref.Name != "refs/heads/main" {
+		t.Errorf("expected ref %q, got %q", "refs/heads/main", out.Ref.Name)
+	}
+}
+
+func TestFormatPayload_Slack(t *testing.T) {
+	hook := &types.Webhook{PayloadFormat: enum.WebhookPayloadFormatSlack}
+
+	tests := []struct {
+		name    string
+		trigger enum.WebhookTrigger
+		body    any
+		expText string
+	}{
+		{
+			name:    "branch updated",
+			trigger: enum.WebhookTriggerBranchUpdated,
+			body:    testBranchUpdatedPayload(),
+			expText: "*[space/repo] Branch updated*\nJane pushed 2 commit(s) to branch main",
+		},
+		{
+			name:    "pull request created",
+			trigger: enum.WebhookTriggerPullReqCreated,
+			body:    testPullReqCreatedPayload(),
+			expText: "<https://example.com/pr/7|[space/repo] Pull request #7 opened>\n" +
+				"Jane opened pull request #7: Fix &lt;bug&gt;",
+		},
+		{
+			name:    "pull request reviewer added",
+			trigger: enum.WebhookTriggerPullReqReviewerAdded,
+			body: &PullReqReviewerPayload{
+				BaseSegment:    testPullReqCreatedPayload().BaseSegment,
+				PullReqSegment: testPullReqCreatedPayload().PullReqSegment,
+				PullReqReviewerSegment: PullReqReviewerSegment{
+					Reviewer: PrincipalInfo{DisplayName: "John"},
+				},
+			},
+			expText: "<https://example.com/pr/7|[space/repo] Reviewer added to pull request #7>\n" +
+				"Jane requested a review from John for pull request #7: Fix &lt;bug&gt;",
+		},
+		{
+			name:    "repository renamed",
+			trigger: enum.WebhookTriggerRepoRenamed,
+			body: &RepositoryRenamedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoRenamed,
+					Repo:      RepositoryInfo{Path: "space/repo"},
+					Principal: PrincipalInfo{DisplayName: "Jane"},
+				},
+				RepositoryRenameSegment: RepositoryRenameSegment{
+					OldIdentifier: "old",
+					OldPath:       "space/old",
+				},
+			},
+			expText: "*[space/repo] Repository renamed*\nJane renamed repository space/old to space/repo",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, err := formatPayload(hook, test.trigger, test.body)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var out slackMessage
+			if err = json.Unmarshal(body, &out); err != nil {
+				t.Fatalf("expected valid json: %s", err)
+			}
+			if out.Text != test.expText {
+				t.Errorf("expected text %q, got %q", test.expText, out.Text)
+			}
+		})
+	}
+}
+
+func TestFormatPayload_Teams(t *testing.T) {
+	hook := &types.Webhook{PayloadFormat: enum.WebhookPayloadFormatTeams}
+
+	body, err := formatPayload(hook, enum.WebhookTriggerPullReqCreated, testPullReqCreatedPayload())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var out teamsMessage
+	if err = json.Unmarshal(body, &out); err != nil {
+		t.Fatalf("expected valid json: %s", err)
+	}
+	if out.Type != "MessageCard" {
+		t.Errorf("expected card type %q, got %q", "MessageCard", out.Type)
+	}
+	if out.Title != "[space/repo] Pull request #7 opened" {
+		t.Errorf("unexpected title %q", out.Title)
+	}
+	if len(out.PotentialAction) != 1 || out.PotentialAction[0].Targets[0].URI != "https://example.com/pr/7" {
+		t.Errorf("expected open uri action to the pull request, got %+v", out.PotentialAction)
+	}
+}
+
+func TestFormatPayload_Template(t *testing.T) {
+	tests := []struct {
+		name           string
+		template       string
+		expBody        string
+		expContentType string
+		expErr         bool
+	}{
+		{
+			name:           "json output",
+			template:       `{"ref":{{ json .Ref.Name }},"sha":"{{ shortSHA .SHA }}"}`,
+			expBody:        `{"ref":"refs/heads/main","sha":"abcdef01"}`,
+			expContentType: "application/json",
+		},
+		{
+			name:           "text output",
+			template:       `{{ .Principal.DisplayName }} pushed to {{ .Repo.Path }}`,
+			expBody:        "Jane pushed to space/repo",
+			expContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:     "unknown field",
+			template: `{{ .Unknown }}`,
+			expErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hook := &types.Webhook{
+				PayloadFormat:   enum.WebhookPayloadFormatTemplate,
+				PayloadTemplate: test.template,
+			}
+
+			body, err := formatPayload(hook, enum.WebhookTriggerBranchUpdated, testBranchUpdatedPayload())
+			if test.expErr {
+				if err == nil {
+					t.Fatalf("expected error, got body %q", body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(body) != test.expBody {
+				t.Errorf("expected body %q, got %q", test.expBody, body)
+			}
+			if ct := payloadContentType(hook, body); ct != test.expContentType {
+				t.Errorf("expected content type %q, got %q", test.expContentType, ct)
+			}
+		})
+	}
+}
+
+func TestParsePayloadTemplate(t *testing.T) {
+	if _, err := ParsePayloadTemplate(`{{ .Repo.Path`); err == nil {
+		t.Error("expected error for malformed template")
+	}
+	if _, err := ParsePayloadTemplate(strings.Repeat("a", 10)); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
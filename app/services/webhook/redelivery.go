@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeRedelivery        = "gitness:webhook:redelivery"
+	jobMaxDurationRedelivery = 1 * time.Minute
+)
+
+// redeliveryInput is the input of the webhook redelivery job.
+type redeliveryInput struct {
+	ExecutionID int64 `json:"execution_id"`
+	Attempt     int   `json:"attempt"`
+}
+
+// redeliveryJob is a job handler that redelivers a webhook execution that ended with a retriable error.
+type redeliveryJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*redeliveryJob)(nil)
+
+// Handle redelivers the webhook execution and schedules the next redelivery in case it failed again.
+func (j *redeliveryJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input redeliveryInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal webhook redelivery job input: %w", err)
+	}
+
+	s := j.service
+
+	execution, err := s.webhookExecutionStore.Find(ctx, input.ExecutionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find webhook execution with id %d: %w", input.ExecutionID, err)
+	}
+
+	webhook, err := s.webhookStore.Find(ctx, execution.WebhookID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find webhook with id %d: %w", execution.WebhookID, err)
+	}
+
+	// the webhook could have been disabled in the meantime (manually or automatically)
+	if !webhook.Enabled {
+		return "webhook is disabled", nil
+	}
+
+	// the execution could have been retriggered manually in the meantime
+	completed, err := s.isTriggerCompleted(ctx, webhook.ID, execution.TriggerID)
+	if err != nil {
+		return "", err
+	}
+	if completed {
+		return "webhook execution already completed", nil
+	}
+
+	result, err := s.retriggerWebhookExecution(ctx, webhook, execution)
+	if err != nil {
+		return "", err
+	}
+
+	if result.Execution.Result == enum.WebhookExecutionResultRetriableError {
+		s.scheduleRedelivery(ctx, result.Execution, input.Attempt+1)
+	}
+
+	return string(result.Execution.Result), nil
+}
+
+// isTriggerCompleted checks whether an execution of the webhook for the trigger ended with success or fatal error.
+func (s *Service) isTriggerCompleted(ctx context.Context, webhookID int64, triggerID string) (bool, error) {
+	executions, err := s.webhookExecutionStore.ListForTrigger(ctx, triggerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get executions for trigger '%s': %w", triggerID, err)
+	}
+
+	for _, execution := range executions {
+		if execution.WebhookID != webhookID {
+			continue
+		}
+
+		if execution.Result == enum.WebhookExecutionResultSuccess ||
+			execution.Result == enum.WebhookExecutionResultFatalError {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// scheduleRedelivery schedules a job that redelivers the provided execution after the backoff of the attempt.
+// It returns false in case no redelivery got scheduled.
+func (s *Service) scheduleRedelivery(ctx context.Context, execution *types.WebhookExecution, attempt int) bool {
+	if attempt > s.config.RedeliveryMaxAttempts {
+		log.Ctx(ctx).Info().Msgf("webhook execution %d of webhook %d exhausted all %d redelivery attempts",
+			execution.ID, execution.WebhookID, s.config.RedeliveryMaxAttempts)
+		return false
+	}
+
+	// executions that failed to be stored or have no request body can't be redelivered.
+	if execution.ID == 0 || !execution.Retriggerable {
+		return false
+	}
+
+	data, err := json.Marshal(redeliveryInput{
+		ExecutionID: execution.ID,
+		Attempt:     attempt,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to marshal redelivery input for webhook execution %d",
+			execution.ID)
+		return false
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        fmt.Sprintf("webhook-redelivery-%d", execution.ID),
+		Type:       jobTypeRedelivery,
+		MaxRetries: 0,
+		Timeout:    jobMaxDurationRedelivery,
+		Data:       string(data),
+		Delay:      s.redeliveryBackoff(attempt),
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to schedule redelivery of webhook execution %d",
+			execution.ID)
+		return false
+	}
+
+	return true
+}
+
+// redeliveryBackoff returns the exponential backoff for the provided attempt (starting with 1).
+func (s *Service) redeliveryBackoff(attempt int) time.Duration {
+	backoff := s.config.RedeliveryBackoff
+	for i := 1; i < attempt && backoff < s.config.RedeliveryBackoffMax; i++ {
+		backoff *= 2
+	}
+
+	if backoff > s.config.RedeliveryBackoffMax {
+		backoff = s.config.RedeliveryBackoffMax
+	}
+
+	return backoff
+}
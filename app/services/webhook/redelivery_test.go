@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRedeliveryBackoff(t *testing.T) {
+	s := &Service{
+		config: Config{
+			RedeliveryBackoff:    30 * time.Second,
+			RedeliveryBackoffMax: 5 * time.Minute,
+		},
+	}
+
+	tests := []struct {
+		attempt int
+		exp     time.Duration
+	}{
+		{attempt: 1, exp: 30 * time.Second},
+		{attempt: 2, exp: time.Minute},
+		{attempt: 3, exp: 2 * time.Minute},
+		{attempt: 4, exp: 4 * time.Minute},
+		{attempt: 5, exp: 5 * time.Minute},
+		{attempt: 100, exp: 5 * time.Minute},
+	}
+
+	for _, test := range tests {
+		if got := s.redeliveryBackoff(test.attempt); got != test.exp {
+			t.Errorf("attempt %d: expected backoff %s, got %s", test.attempt, test.exp, got)
+		}
+	}
+}
@@ -21,13 +21,17 @@ import (
 	"net/http"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/stream"
 )
 
@@ -47,6 +51,15 @@ type Config struct {
 	MaxRetries          int
 	AllowPrivateNetwork bool
 	AllowLoopback       bool
+
+	// RedeliveryMaxAttempts is the maximum number of automatic redeliveries of a retriable execution.
+	RedeliveryMaxAttempts int
+	// RedeliveryBackoff is the delay before the first redelivery, it's doubled with every further attempt.
+	RedeliveryBackoff time.Duration
+	// RedeliveryBackoffMax is the maximum delay between two redeliveries.
+	RedeliveryBackoffMax time.Duration
+	// AutoDisableThreshold is the number of consecutive fatal executions after which a webhook gets disabled.
+	AutoDisableThreshold int
 }
 
 func (c *Config) Prepare() error {
@@ -65,11 +78,23 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.RedeliveryMaxAttempts < 0 {
+		return errors.New("config.RedeliveryMaxAttempts can't be negative")
+	}
+	if c.RedeliveryMaxAttempts > 0 && c.RedeliveryBackoff <= 0 {
+		return errors.New("config.RedeliveryBackoff has to be a positive duration")
+	}
+	if c.AutoDisableThreshold < 0 {
+		return errors.New("config.AutoDisableThreshold can't be negative")
+	}
 
 	// Backfill data
 	if c.HeaderIdentity == "" {
 		c.HeaderIdentity = c.UserAgentIdentity
 	}
+	if c.RedeliveryBackoffMax < c.RedeliveryBackoff {
+		c.RedeliveryBackoffMax = c.RedeliveryBackoff
+	}
 
 	return nil
 }
@@ -80,11 +105,15 @@ type Service struct {
 	webhookExecutionStore store.WebhookExecutionStore
 	urlProvider           url.Provider
 	repoStore             store.RepoStore
+	spaceStore            store.SpaceStore
 	pullreqStore          store.PullReqStore
 	principalStore        store.PrincipalStore
 	git                   git.Interface
 	activityStore         store.PullReqActivityStore
 	encrypter             encrypt.Encrypter
+	scheduler             *job.Scheduler
+	notificationClient    notification.Client
+	checkStore            store.CheckStore
 
 	secureHTTPClient   *http.Client
 	insecureHTTPClient *http.Client
@@ -103,12 +132,19 @@ func NewService(
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
+	executor *job.Executor,
+	scheduler *job.Scheduler,
+	notificationClient notification.Client,
+	checkStore store.CheckStore,
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service config is invalid: %w", err)
@@ -117,12 +153,16 @@ func NewService(
 		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
 		repoStore:             repoStore,
+		spaceStore:            spaceStore,
 		pullreqStore:          pullreqStore,
 		activityStore:         activityStore,
 		urlProvider:           urlProvider,
 		principalStore:        principalStore,
 		git:                   git,
 		encrypter:             encrypter,
+		scheduler:             scheduler,
+		notificationClient:    notificationClient,
+		checkStore:            checkStore,
 
 		secureHTTPClient:   newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
 		insecureHTTPClient: newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
@@ -133,7 +173,12 @@ func NewService(
 		config: config,
 	}
 
-	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+	err := executor.Register(jobTypeRedelivery, &redeliveryJob{service: service})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register job handler for webhook redelivery: %w", err)
+	}
+
+	_, err = gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
 		func(r *gitevents.Reader) error {
 			const idleTimeout = 1 * time.Minute
 			r.Configure(
@@ -175,6 +220,10 @@ func NewService(
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterCommentCreated(service.handleEventPullReqComment)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterUpdated(service.handleEventPullReqUpdated)
+			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
+			_ = r.RegisterReviewerAdded(service.handleEventPullReqReviewerAdded)
+			_ = r.RegisterReviewerRemoved(service.handleEventPullReqReviewerRemoved)
 
 			return nil
 		})
@@ -182,5 +231,46 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = repoReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventRepoCreated)
+			_ = r.RegisterSoftDeleted(service.handleEventRepoSoftDeleted)
+			_ = r.RegisterRenamed(service.handleEventRepoRenamed)
+			_ = r.RegisterDefaultBranchUpdated(service.handleEventRepoDefaultBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for webhooks: %w", err)
+	}
+
+	_, err = checkReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterStatusReported(service.handleEventCheckStatusReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
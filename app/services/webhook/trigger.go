@@ -20,7 +20,6 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -60,6 +59,10 @@ type TriggerResult struct {
 	Webhook     *types.Webhook
 	Execution   *types.WebhookExecution
 	Err         error
+
+	// RedeliveryScheduled is true in case the execution failed with a retriable error
+	// and got scheduled for automatic redelivery.
+	RedeliveryScheduled bool
 }
 
 func (r *TriggerResult) Skipped() bool {
@@ -135,6 +138,11 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 
 		// execute trigger and store output in result
 		results[i].Execution, results[i].Err = s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+
+		// schedule automatic redelivery in case of a retriable error
+		if results[i].Execution.Result == enum.WebhookExecutionResultRetriableError {
+			results[i].RedeliveryScheduled = s.scheduleRedelivery(ctx, results[i].Execution, 1)
+		}
 	}
 
 	return results, nil
@@ -147,17 +155,24 @@ func (s *Service) RetriggerWebhookExecution(ctx context.Context, webhookExecutio
 		return nil, fmt.Errorf("failed to find webhook execution with id %d: %w", webhookExecutionID, err)
 	}
 
-	// ensure webhook can be retriggered
-	if !webhookExecution.Retriggerable {
-		return nil, ErrWebhookNotRetriggerable
-	}
-
 	// find webhook
 	webhook, err := s.webhookStore.Find(ctx, webhookExecution.WebhookID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find webhook with id %d: %w", webhookExecution.WebhookID, err)
 	}
 
+	return s.retriggerWebhookExecution(ctx, webhook, webhookExecution)
+}
+
+// retriggerWebhookExecution executes the webhook again with the request body of the provided execution.
+// The new execution is linked to the provided execution via RetriggerOf.
+func (s *Service) retriggerWebhookExecution(ctx context.Context, webhook *types.Webhook,
+	webhookExecution *types.WebhookExecution) (*TriggerResult, error) {
+	// ensure webhook can be retriggered
+	if !webhookExecution.Retriggerable {
+		return nil, ErrWebhookNotRetriggerable
+	}
+
 	// reuse same trigger id as original execution
 	triggerID := webhookExecution.TriggerID
 	triggerType := webhookExecution.TriggerType
@@ -195,6 +210,8 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		execution.Duration = int64(time.Since(start))
 		execution.Created = time.Now().UnixMilli()
 
+		observeExecution(&execution)
+
 		// TODO: what if saving execution failed? For now we will rerun it in case of error or not show it in history
 		err := s.webhookExecutionStore.Create(oCtx, &execution)
 		if err != nil {
@@ -215,6 +232,11 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 					execution.Result, webhook.ID)
 			}
 		}
+
+		// disable the webhook in case it keeps failing (best effort)
+		if execution.Result == enum.WebhookExecutionResultFatalError {
+			s.disableWebhookIfFailing(oCtx, webhook, execution.Error)
+		}
 	}(ctx, time.Now())
 
 	// derive context with time limit
@@ -309,18 +331,28 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 		bBuff.Write(bBytes)
 
 	default:
-		// all other types we json serialize
-		err := json.NewEncoder(bBuff).Encode(body)
+		// all other types we serialize in the payload format configured for the webhook
+		bBytes, err := formatPayload(webhook, triggerType, body)
+		if err != nil && webhook.PayloadFormat == enum.WebhookPayloadFormatTemplate {
+			// ASSUMPTION: there was an issue with the user provided template, not retriable
+			tErr := fmt.Errorf("failed to generate request body: %w", err)
+			execution.Error = tErr.Error()
+			execution.Result = enum.WebhookExecutionResultFatalError
+			return nil, tErr
+		}
 		if err != nil {
 			// this is an internal issue, nothing the user can do - don't expose error details
 			execution.Error = "an error occurred preparing the request body"
 			execution.Result = enum.WebhookExecutionResultFatalError
-			return nil, fmt.Errorf("failed to serialize body to json: %w", err)
+			return nil, fmt.Errorf("failed to serialize body: %w", err)
 		}
+
+		bBuff.Write(bBytes)
 	}
 	// set executioon body and mark it as retriggerable
 	execution.Request.Body = bBuff.String()
 	execution.Retriggerable = true
+	contentType := payloadContentType(webhook, bBuff.Bytes())
 
 	// create request (url + body)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bBuff)
@@ -334,7 +366,7 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 
 	// setup headers
 	req.Header.Add("User-Agent", fmt.Sprintf("%s/%s", s.config.UserAgentIdentity, version.Version))
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Content-Type", contentType)
 	req.Header.Add(s.toXHeader("Trigger"), string(triggerType))
 	req.Header.Add(s.toXHeader("Webhook-Parent-Type"), string(webhook.ParentType))
 	req.Header.Add(s.toXHeader("Webhook-Parent-Id"), fmt.Sprint(webhook.ParentID))
@@ -79,6 +79,42 @@ type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
 }
 
+// PullReqUpdateSegment contains the previous values of the edited fields of a pull req for webhooks.
+type PullReqUpdateSegment struct {
+	Changes PullReqChangesInfo `json:"changes"`
+}
+
+// PullReqReviewerSegment contains details for all pull req reviewer related payloads for webhooks.
+type PullReqReviewerSegment struct {
+	Reviewer PrincipalInfo `json:"reviewer"`
+}
+
+// PullReqReviewSegment contains details for all pull req review related payloads for webhooks.
+type PullReqReviewSegment struct {
+	Review ReviewInfo `json:"review"`
+}
+
+// CheckSegment contains details for all status check related payloads for webhooks.
+type CheckSegment struct {
+	Check CheckInfo `json:"check"`
+}
+
+// RepositoryDeletedSegment contains extra details for the repo deleted payload for webhooks.
+type RepositoryDeletedSegment struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// RepositoryRenameSegment contains extra details for the repo renamed payload for webhooks.
+type RepositoryRenameSegment struct {
+	OldIdentifier string `json:"old_identifier"`
+	OldPath       string `json:"old_path"`
+}
+
+// DefaultBranchUpdateSegment contains extra details for the default branch changed payload for webhooks.
+type DefaultBranchUpdateSegment struct {
+	OldDefaultBranch string `json:"old_default_branch"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -268,6 +304,50 @@ type ReferenceInfo struct {
 	Repo RepositoryInfo `json:"repo"`
 }
 
+// ChangeInfo describes the old and the new value of a changed field.
+type ChangeInfo struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// PullReqChangesInfo describes which fields of a pull request got edited.
+// Fields that didn't change are omitted.
+type PullReqChangesInfo struct {
+	Title       *ChangeInfo `json:"title,omitempty"`
+	Description *ChangeInfo `json:"description,omitempty"`
+}
+
+// ReviewInfo describes a submitted pull request review for a webhook payload.
+type ReviewInfo struct {
+	Reviewer PrincipalInfo              `json:"reviewer"`
+	Decision enum.PullReqReviewDecision `json:"decision"`
+}
+
+// CheckInfo describes the status check related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type CheckInfo struct {
+	Identifier string           `json:"identifier"`
+	CommitSHA  string           `json:"commit_sha"`
+	Status     enum.CheckStatus `json:"status"`
+	Summary    string           `json:"summary,omitempty"`
+	Link       string           `json:"link,omitempty"`
+	Started    int64            `json:"started,omitempty"`
+	Ended      int64            `json:"ended,omitempty"`
+}
+
+// checkInfoFrom gets the CheckInfo from a types.Check.
+func checkInfoFrom(check *types.Check) CheckInfo {
+	return CheckInfo{
+		Identifier: check.Identifier,
+		CommitSHA:  check.CommitSHA,
+		Status:     check.Status,
+		Summary:    check.Summary,
+		Link:       check.Link,
+		Started:    check.Started,
+		Ended:      check.Ended,
+	}
+}
+
 type CommentInfo struct {
 	ID       int64  `json:"id"`
 	ParentID *int64 `json:"parent_id,omitempty"`
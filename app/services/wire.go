@@ -15,12 +15,16 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pushmirror"
 	"github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repomirror"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
@@ -43,6 +47,11 @@ type Services struct {
 	Cleanup            *cleanup.Service
 	Notification       *notification.Service
 	Keywordsearch      *keywordsearch.Service
+	CronTriggers       *trigger.CronScheduler
+	AutoMerge          *automerge.Service
+	MergeQueue         *mergequeue.Service
+	RepoMirror         *repomirror.Service
+	PushMirror         *pushmirror.Service
 }
 
 func ProvideServices(
@@ -56,6 +65,11 @@ func ProvideServices(
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	cronTriggers *trigger.CronScheduler,
+	autoMergeSvc *automerge.Service,
+	mergeQueueSvc *mergequeue.Service,
+	repoMirrorSvc *repomirror.Service,
+	pushMirrorSvc *pushmirror.Service,
 ) Services {
 	return Services{
 		Webhook:            webhooksSvc,
@@ -68,5 +82,10 @@ func ProvideServices(
 		Cleanup:            cleanupSvc,
 		Notification:       notificationSvc,
 		Keywordsearch:      keywordsearchSvc,
+		CronTriggers:       cronTriggers,
+		AutoMerge:          autoMergeSvc,
+		MergeQueue:         mergeQueueSvc,
+		RepoMirror:         repoMirrorSvc,
+		PushMirror:         pushMirrorSvc,
 	}
 }
@@ -241,6 +241,61 @@ type (
 
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// CountForks returns the number of forks of a repo. With "DeletedBeforeOrAt" filter, counts deleted forks.
+		CountForks(ctx context.Context, repoID int64, opts *types.RepoFilter) (int64, error)
+
+		// ListForks returns a list of forks of a repo. With "DeletedBeforeOrAt" filter, lists deleted forks.
+		ListForks(ctx context.Context, repoID int64, opts *types.RepoFilter) ([]*types.Repository, error)
+	}
+
+	// RepoMirrorStore defines the repository pull mirror data storage.
+	RepoMirrorStore interface {
+		// Find finds the mirror configuration of a repository.
+		Find(ctx context.Context, repoID int64) (*types.RepoMirror, error)
+
+		// Create creates a new mirror configuration of a repository.
+		Create(ctx context.Context, mirror *types.RepoMirror) error
+
+		// Update updates the mirror configuration of a repository.
+		// It returns store.ErrVersionConflict if the mirror has been modified in the meantime.
+		Update(ctx context.Context, mirror *types.RepoMirror) error
+
+		// UpdateOptLock updates the mirror configuration of a repository using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, mirror *types.RepoMirror,
+			mutateFn func(mirror *types.RepoMirror) error) (*types.RepoMirror, error)
+
+		// Delete deletes the mirror configuration of a repository.
+		Delete(ctx context.Context, repoID int64) error
+
+		// ListDue returns the mirrors of active repositories that are due for a scheduled synchronization.
+		ListDue(ctx context.Context, now int64) ([]*types.RepoMirror, error)
+	}
+
+	// PushMirrorStore defines the push mirror data storage.
+	PushMirrorStore interface {
+		// Find finds the push mirror by id.
+		Find(ctx context.Context, id int64) (*types.PushMirror, error)
+
+		// FindByIdentifier finds the push mirror of a repository by its identifier.
+		FindByIdentifier(ctx context.Context, repoID int64, identifier string) (*types.PushMirror, error)
+
+		// Create creates a new push mirror.
+		Create(ctx context.Context, mirror *types.PushMirror) error
+
+		// Update updates the push mirror.
+		// It returns store.ErrVersionConflict if the push mirror has been modified in the meantime.
+		Update(ctx context.Context, mirror *types.PushMirror) error
+
+		// UpdateOptLock updates the push mirror using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, mirror *types.PushMirror,
+			mutateFn func(mirror *types.PushMirror) error) (*types.PushMirror, error)
+
+		// Delete deletes the push mirror by id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all push mirrors of a repository.
+		List(ctx context.Context, repoID int64) ([]*types.PushMirror, error)
 	}
 
 	// SettingsStore defines the settings storage.
@@ -308,6 +363,9 @@ type (
 		// Create saves the token details.
 		Create(ctx context.Context, token *types.Token) error
 
+		// UpdateLastUsed updates the time and the client IP address of the last use of the token.
+		UpdateLastUsed(ctx context.Context, id int64, lastUsed int64, lastUsedIP string) error
+
 		// Delete deletes the token with the given id.
 		Delete(ctx context.Context, id int64) error
 
@@ -561,6 +619,17 @@ type (
 		ListResults(ctx context.Context, repoID int64, commitSHA string) ([]types.CheckResult, error)
 	}
 
+	CheckAnnotationStore interface {
+		// ReplaceForCheck replaces all annotations of a status check with the provided ones.
+		ReplaceForCheck(ctx context.Context, checkID int64, annotations []*types.CheckAnnotation) error
+
+		// ListForCheck returns all annotations of a status check.
+		ListForCheck(ctx context.Context, checkID int64) ([]types.CheckAnnotation, error)
+
+		// ListForCommit returns annotations of all status checks reported for a specific commit in a repo.
+		ListForCommit(ctx context.Context, repoID int64, commitSHA string) ([]types.CheckAnnotation, error)
+	}
+
 	GitspaceConfigStore interface {
 		// Find returns a gitspace config given a ID from the datastore.
 		Find(ctx context.Context, id int64) (*types.GitspaceConfig, error)
@@ -866,6 +935,10 @@ type (
 		// ListAllEnabled lists all enabled triggers for a given repo without pagination.
 		// It's used only internally to trigger builds.
 		ListAllEnabled(ctx context.Context, repoID int64) ([]*types.Trigger, error)
+
+		// ListDueCron lists all enabled cron triggers across all repos which are due at the provided time.
+		// It's used only internally to fire scheduled builds.
+		ListDueCron(ctx context.Context, now int64) ([]*types.Trigger, error)
 	}
 
 	PluginStore interface {
@@ -890,8 +963,71 @@ type (
 	}
 
 	UserGroupStore interface {
+		// Find returns a types.UserGroup given its ID.
+		Find(ctx context.Context, id int64) (*types.UserGroup, error)
+
 		// FindByIdentifier returns a types.UserGroup given a space ID and identifier.
 		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.UserGroup, error)
+
+		// Create creates a new user group.
+		Create(ctx context.Context, group *types.UserGroup) error
+
+		// Update updates the name and the description of a user group.
+		Update(ctx context.Context, group *types.UserGroup) error
+
+		// Delete deletes the user group with the given ID, including its members and space memberships.
+		Delete(ctx context.Context, id int64) error
+
+		// Count returns the number of user groups of a space matching the provided filter.
+		Count(ctx context.Context, spaceID int64, filter *types.ListQueryFilter) (int64, error)
+
+		// List returns a list of user groups of a space matching the provided filter.
+		List(ctx context.Context, spaceID int64, filter *types.ListQueryFilter) ([]*types.UserGroup, error)
+
+		// AddMember adds a user to a user group.
+		AddMember(ctx context.Context, member *types.UserGroupMember) error
+
+		// RemoveMember removes a user from a user group.
+		RemoveMember(ctx context.Context, userGroupID, principalID int64) error
+
+		// CountMembers returns the number of members of a user group matching the provided filter.
+		CountMembers(ctx context.Context, userGroupID int64, filter *types.ListQueryFilter) (int64, error)
+
+		// ListMembers returns a list of members of a user group matching the provided filter.
+		ListMembers(ctx context.Context, userGroupID int64,
+			filter *types.ListQueryFilter) ([]types.UserGroupMember, error)
+
+		// ListMemberPrincipals returns the principal infos of all members of a user group.
+		ListMemberPrincipals(ctx context.Context, userGroupID int64) ([]*types.PrincipalInfo, error)
+
+		// ListIDsForPrincipal returns the IDs of all user groups the principal is a member of.
+		ListIDsForPrincipal(ctx context.Context, principalID int64) ([]int64, error)
+	}
+
+	// UserGroupMembershipStore defines the user group space membership data storage.
+	UserGroupMembershipStore interface {
+		// Find finds the space membership of a user group.
+		Find(ctx context.Context, key types.UserGroupMembershipKey) (*types.UserGroupMembership, error)
+
+		// Create creates a new space membership of a user group.
+		Create(ctx context.Context, membership *types.UserGroupMembership) error
+
+		// Update updates the role of a space membership of a user group.
+		Update(ctx context.Context, membership *types.UserGroupMembership) error
+
+		// Delete deletes the space membership of a user group.
+		Delete(ctx context.Context, key types.UserGroupMembershipKey) error
+
+		// Count returns the number of user group memberships of a space.
+		Count(ctx context.Context, spaceID int64, filter *types.ListQueryFilter) (int64, error)
+
+		// List returns a list of user group memberships of a space.
+		List(ctx context.Context, spaceID int64,
+			filter *types.ListQueryFilter) ([]types.UserGroupMembershipInfo, error)
+
+		// ListRolesForPrincipal returns the roles the principal got assigned in the space
+		// through memberships of the user groups it is a member of.
+		ListRolesForPrincipal(ctx context.Context, spaceID, principalID int64) ([]enum.MembershipRole, error)
 	}
 
 	PublicKeyStore interface {
@@ -935,4 +1071,58 @@ type (
 			gitspaceConfigID int64,
 		) (*types.GitspaceEvent, error)
 	}
+
+	LFSObjectStore interface {
+		// Find finds an LFS object with a specified oid and repo-id.
+		Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error)
+
+		// FindMany finds LFS objects with the specified oids for a repo.
+		FindMany(ctx context.Context, repoID int64, oids []string) ([]*types.LFSObject, error)
+
+		// Create creates an LFS object.
+		Create(ctx context.Context, lfsObject *types.LFSObject) error
+
+		// GetSizeInKBByRepoID returns the total size of all LFS objects of a repo in KiB.
+		GetSizeInKBByRepoID(ctx context.Context, repoID int64) (int64, error)
+	}
+
+	LFSLockStore interface {
+		// Find finds an LFS lock by id.
+		Find(ctx context.Context, id int64) (*types.LFSLock, error)
+
+		// Create creates an LFS lock.
+		Create(ctx context.Context, lock *types.LFSLock) error
+
+		// Delete deletes an LFS lock by id.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the LFS locks of a repo that match the provided filter.
+		List(ctx context.Context, repoID int64, filter *types.LFSLockFilter) ([]*types.LFSLock, error)
+	}
+
+	MergeQueueStore interface {
+		// Find finds a merge queue entry by id.
+		Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error)
+
+		// FindByPullReqID finds the merge queue entry of a pull request.
+		FindByPullReqID(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error)
+
+		// FindByMergeSHA finds the merge queue entry whose merge ref points at the provided commit.
+		FindByMergeSHA(ctx context.Context, repoID int64, mergeSHA string) (*types.MergeQueueEntry, error)
+
+		// Create adds a pull request to the end of the merge queue of its target branch.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates the state and the merge ref details of a merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes an entry from the merge queue.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the merge queue of a branch, ordered from the head of the queue to its end.
+		List(ctx context.Context, repoID int64, targetBranch string) ([]*types.MergeQueueEntry, error)
+
+		// ListHeads returns the entry at the head of every merge queue.
+		ListHeads(ctx context.Context) ([]*types.MergeQueueEntry, error)
+	}
 )
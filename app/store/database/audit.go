@@ -0,0 +1,403 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ audit.Store = (*AuditEventStore)(nil)
+
+// NewAuditEventStore returns a new AuditEventStore.
+func NewAuditEventStore(db *sqlx.DB, spacePathCache store.SpacePathCache) *AuditEventStore {
+	return &AuditEventStore{
+		db:             db,
+		spacePathCache: spacePathCache,
+	}
+}
+
+// AuditEventStore implements an audit.Store backed by a relational database.
+type AuditEventStore struct {
+	db             *sqlx.DB
+	spacePathCache store.SpacePathCache
+}
+
+type auditEvent struct {
+	ID        string `db:"audit_event_id"`
+	Timestamp int64  `db:"audit_event_timestamp"`
+	Action    string `db:"audit_event_action"`
+
+	ResourceType       string   `db:"audit_event_resource_type"`
+	ResourceIdentifier string   `db:"audit_event_resource_identifier"`
+	SpacePath          string   `db:"audit_event_space_path"`
+	SpaceID            null.Int `db:"audit_event_space_id"`
+
+	PrincipalID          int64  `db:"audit_event_principal_id"`
+	PrincipalUID         string `db:"audit_event_principal_uid"`
+	PrincipalType        string `db:"audit_event_principal_type"`
+	PrincipalEmail       string `db:"audit_event_principal_email"`
+	PrincipalDisplayName string `db:"audit_event_principal_display_name"`
+
+	OldObject null.String `db:"audit_event_old_object"`
+	NewObject null.String `db:"audit_event_new_object"`
+
+	ClientIP      string `db:"audit_event_client_ip"`
+	RequestMethod string `db:"audit_event_request_method"`
+	Data          string `db:"audit_event_data"`
+}
+
+const (
+	auditEventColumns = `
+		 audit_event_id
+		,audit_event_timestamp
+		,audit_event_action
+		,audit_event_resource_type
+		,audit_event_resource_identifier
+		,audit_event_space_path
+		,audit_event_space_id
+		,audit_event_principal_id
+		,audit_event_principal_uid
+		,audit_event_principal_type
+		,audit_event_principal_email
+		,audit_event_principal_display_name
+		,audit_event_old_object
+		,audit_event_new_object
+		,audit_event_client_ip
+		,audit_event_request_method
+		,audit_event_data`
+)
+
+// Create persists a new audit event.
+func (s *AuditEventStore) Create(ctx context.Context, event *audit.Event) error {
+	const sqlQuery = `
+		INSERT INTO audit_events (` + auditEventColumns + `
+		) values (
+			 :audit_event_id
+			,:audit_event_timestamp
+			,:audit_event_action
+			,:audit_event_resource_type
+			,:audit_event_resource_identifier
+			,:audit_event_space_path
+			,:audit_event_space_id
+			,:audit_event_principal_id
+			,:audit_event_principal_uid
+			,:audit_event_principal_type
+			,:audit_event_principal_email
+			,:audit_event_principal_display_name
+			,:audit_event_old_object
+			,:audit_event_new_object
+			,:audit_event_client_ip
+			,:audit_event_request_method
+			,:audit_event_data
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbEvent, err := mapToInternalAuditEvent(event)
+	if err != nil {
+		return fmt.Errorf("failed to map audit event: %w", err)
+	}
+
+	// the space is stored by ID as well, so its history isn't lost if the space gets renamed or moved.
+	dbEvent.SpaceID, err = s.findSpaceID(ctx, event.SpacePath)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbEvent)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit event object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert audit event query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of audit events matching the filter.
+func (s *AuditEventStore) Count(ctx context.Context, filter *audit.ListFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("audit_events")
+
+	stmt = s.applyFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to execute count audit events query")
+	}
+
+	return count, nil
+}
+
+// List returns the audit events matching the filter, the most recent events come first.
+func (s *AuditEventStore) List(ctx context.Context, filter *audit.ListFilter) ([]audit.Event, error) {
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events")
+
+	stmt = s.applyFilter(stmt, filter)
+	stmt = stmt.
+		OrderBy("audit_event_timestamp DESC", "audit_event_id DESC").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*auditEvent, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list audit events query")
+	}
+
+	return mapToAuditEvents(dst)
+}
+
+// DeleteOld removes all audit events that were created before the provided time.
+func (s *AuditEventStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("audit_events").
+		Where("audit_event_timestamp < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete audit events query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete audit events query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted audit events")
+	}
+
+	return n, nil
+}
+
+// findSpaceID returns the ID of the space with the provided path.
+// Events of principal scoped resources and of spaces that don't exist anymore don't have a space ID.
+func (s *AuditEventStore) findSpaceID(ctx context.Context, spacePath string) (null.Int, error) {
+	if spacePath == "" {
+		return null.Int{}, nil
+	}
+
+	path, err := s.spacePathCache.Get(ctx, spacePath)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return null.Int{}, nil
+	}
+	if err != nil {
+		return null.Int{}, fmt.Errorf("failed to find space of audit event: %w", err)
+	}
+
+	return null.IntFrom(path.SpaceID), nil
+}
+
+func (*AuditEventStore) applyFilter(
+	stmt squirrel.SelectBuilder,
+	filter *audit.ListFilter,
+) squirrel.SelectBuilder {
+	// events without a space ID (e.g. events stored before the space ID was recorded) are matched by path.
+	var spaceIDCond, spacePathCond squirrel.Sqlizer
+	spacePath := strings.ToLower(filter.SpacePath)
+	if filter.Recursive {
+		spaceIDCond = squirrel.Expr(`audit_event_space_id IN (
+			WITH RECURSIVE SpaceHierarchy AS (
+				SELECT space_id FROM spaces WHERE space_id = ?
+				UNION
+				SELECT s.space_id FROM spaces s JOIN SpaceHierarchy h ON s.space_parent_id = h.space_id
+			)
+			SELECT space_id FROM SpaceHierarchy)`, filter.SpaceID)
+		spacePathCond = squirrel.Or{
+			squirrel.Eq{"LOWER(audit_event_space_path)": spacePath},
+			squirrel.Expr(`LOWER(audit_event_space_path) LIKE ? ESCAPE '\'`, escapeLike(spacePath)+"/%"),
+		}
+	} else {
+		spaceIDCond = squirrel.Eq{"audit_event_space_id": filter.SpaceID}
+		spacePathCond = squirrel.Eq{"LOWER(audit_event_space_path)": spacePath}
+	}
+
+	switch {
+	case filter.SpaceID > 0 && spacePath != "":
+		stmt = stmt.Where(squirrel.Or{
+			spaceIDCond,
+			squirrel.And{squirrel.Eq{"audit_event_space_id": nil}, spacePathCond},
+		})
+	case filter.SpaceID > 0:
+		stmt = stmt.Where(spaceIDCond)
+	default:
+		stmt = stmt.Where(spacePathCond)
+	}
+
+	if len(filter.ResourceTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_resource_type": filter.ResourceTypes})
+	}
+
+	if len(filter.Actions) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_action": filter.Actions})
+	}
+
+	if filter.PrincipalID > 0 {
+		stmt = stmt.Where("audit_event_principal_id = ?", filter.PrincipalID)
+	}
+
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("audit_event_timestamp > ?", filter.CreatedGt)
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("audit_event_timestamp < ?", filter.CreatedLt)
+	}
+
+	return stmt
+}
+
+// escapeLike escapes all wildcard characters of the LIKE operator using the backslash as escape character.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func mapToInternalAuditEvent(in *audit.Event) (*auditEvent, error) {
+	oldObject, err := marshalAuditObject(in.DiffObject.OldObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal old object: %w", err)
+	}
+
+	newObject, err := marshalAuditObject(in.DiffObject.NewObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal new object: %w", err)
+	}
+
+	data := []byte("{}")
+	if len(in.Data) > 0 {
+		data, err = json.Marshal(in.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal data: %w", err)
+		}
+	}
+
+	return &auditEvent{
+		ID:                   in.ID,
+		Timestamp:            in.Timestamp,
+		Action:               string(in.Action),
+		ResourceType:         string(in.Resource.Type),
+		ResourceIdentifier:   in.Resource.Identifier,
+		SpacePath:            in.SpacePath,
+		PrincipalID:          in.User.ID,
+		PrincipalUID:         in.User.UID,
+		PrincipalType:        string(in.User.Type),
+		PrincipalEmail:       in.User.Email,
+		PrincipalDisplayName: in.User.DisplayName,
+		OldObject:            oldObject,
+		NewObject:            newObject,
+		ClientIP:             in.ClientIP,
+		RequestMethod:        in.RequestMethod,
+		Data:                 string(data),
+	}, nil
+}
+
+func marshalAuditObject(v any) (null.String, error) {
+	if v == nil {
+		return null.String{}, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return null.String{}, err
+	}
+
+	return null.StringFrom(string(raw)), nil
+}
+
+func mapToAuditEvent(in *auditEvent) (audit.Event, error) {
+	event := audit.Event{
+		ID:        in.ID,
+		Timestamp: in.Timestamp,
+		Action:    audit.Action(in.Action),
+		User: types.Principal{
+			ID:          in.PrincipalID,
+			UID:         in.PrincipalUID,
+			Email:       in.PrincipalEmail,
+			Type:        enum.PrincipalType(in.PrincipalType),
+			DisplayName: in.PrincipalDisplayName,
+		},
+		SpacePath: in.SpacePath,
+		Resource: audit.Resource{
+			Type:       audit.ResourceType(in.ResourceType),
+			Identifier: in.ResourceIdentifier,
+		},
+		ClientIP:      in.ClientIP,
+		RequestMethod: in.RequestMethod,
+	}
+
+	if in.OldObject.Valid {
+		event.DiffObject.OldObject = json.RawMessage(in.OldObject.String)
+	}
+	if in.NewObject.Valid {
+		event.DiffObject.NewObject = json.RawMessage(in.NewObject.String)
+	}
+
+	if err := json.Unmarshal([]byte(in.Data), &event.Data); err != nil {
+		return audit.Event{}, fmt.Errorf("failed to unmarshal data of audit event %s: %w", in.ID, err)
+	}
+
+	return event, nil
+}
+
+func mapToAuditEvents(in []*auditEvent) ([]audit.Event, error) {
+	events := make([]audit.Event, len(in))
+	for i := range in {
+		event, err := mapToAuditEvent(in[i])
+		if err != nil {
+			return nil, err
+		}
+		events[i] = event
+	}
+	return events, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_AuditEvents(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	spacePathCache := cache.New(spacePathStore, store.ToLowerSpacePathTransformation)
+	auditStore := database.NewAuditEventStore(db, spacePathCache)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createAuditSpace(ctx, t, spaceStore, spacePathStore, 1, 0, "space")
+	createAuditSpace(ctx, t, spaceStore, spacePathStore, 2, 1, "sub")
+	createAuditSpace(ctx, t, spaceStore, spacePathStore, 3, 0, "space_other")
+
+	events := []audit.Event{
+		{ID: "1", Timestamp: 1000, SpacePath: "Space", Action: audit.ActionCreated,
+			Resource: audit.NewResource(audit.ResourceTypeRepository, "repo1"), User: types.Principal{ID: 1}},
+		{ID: "2", Timestamp: 2000, SpacePath: "space/sub", Action: audit.ActionUpdated,
+			Resource: audit.NewResource(audit.ResourceTypeRepository, "repo2"), User: types.Principal{ID: 2},
+			DiffObject: audit.DiffObject{OldObject: map[string]string{"a": "b"}}},
+		{ID: "3", Timestamp: 3000, SpacePath: "space_other", Action: audit.ActionDeleted,
+			Resource: audit.NewResource(audit.ResourceTypeBranchRule, "rule"), User: types.Principal{ID: 1}},
+		// the space doesn't exist, so the event is stored without space ID and is only matched by path.
+		{ID: "4", Timestamp: 4000, SpacePath: "space/gone", Action: audit.ActionDeleted,
+			Resource: audit.NewResource(audit.ResourceTypeRepository, "repo3"), User: types.Principal{ID: 1}},
+		// principal scoped events don't belong to any space.
+		{ID: "5", Timestamp: 5000, Action: audit.ActionCreated,
+			Resource: audit.NewResource(audit.ResourceTypeToken, "token"), User: types.Principal{ID: 2}},
+	}
+	for i := range events {
+		if err := auditStore.Create(ctx, &events[i]); err != nil {
+			t.Fatalf("failed to create audit event: %v", err)
+		}
+	}
+
+	// rename the space, its events have to stay accessible via the space ID.
+	if err := spacePathStore.DeletePrimarySegment(ctx, 1); err != nil {
+		t.Fatalf("failed to delete primary segment: %v", err)
+	}
+	if err := spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: "renamed", IsPrimary: true, SpaceID: 1, CreatedBy: userID,
+	}); err != nil {
+		t.Fatalf("failed to insert segment: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter audit.ListFilter
+		want   []string
+	}{
+		{name: "space only", filter: audit.ListFilter{SpaceID: 1, SpacePath: "renamed"}, want: []string{"1"}},
+		{name: "recursive", filter: audit.ListFilter{SpaceID: 1, SpacePath: "renamed", Recursive: true},
+			want: []string{"2", "1"}},
+		{name: "legacy path", filter: audit.ListFilter{SpaceID: 1, SpacePath: "space", Recursive: true},
+			want: []string{"4", "2", "1"}},
+		{name: "subspace", filter: audit.ListFilter{SpaceID: 2, SpacePath: "renamed/sub"}, want: []string{"2"}},
+		{name: "action", filter: audit.ListFilter{SpaceID: 1, Recursive: true,
+			Actions: []audit.Action{audit.ActionUpdated}}, want: []string{"2"}},
+		{name: "principal", filter: audit.ListFilter{SpaceID: 3, SpacePath: "space_other", PrincipalID: 1},
+			want: []string{"3"}},
+		{name: "created", filter: audit.ListFilter{SpaceID: 1, Recursive: true,
+			CreatedFilter: types.CreatedFilter{CreatedLt: 2000}}, want: []string{"1"}},
+		{name: "principal scoped", filter: audit.ListFilter{}, want: []string{"5"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, err := auditStore.List(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to list audit events: %v", err)
+			}
+
+			count, err := auditStore.Count(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to count audit events: %v", err)
+			}
+
+			if int(count) != len(test.want) || len(list) != len(test.want) {
+				t.Fatalf("want %d events, got list=%d count=%d", len(test.want), len(list), count)
+			}
+
+			for i := range list {
+				if list[i].ID != test.want[i] {
+					t.Errorf("event %d: want ID %s, got %s", i, test.want[i], list[i].ID)
+				}
+			}
+		})
+	}
+
+	n, err := auditStore.DeleteOld(ctx, time.UnixMilli(2500))
+	if err != nil {
+		t.Fatalf("failed to delete old audit events: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("want 2 deleted audit events, got %d", n)
+	}
+}
+
+func createAuditSpace(
+	ctx context.Context,
+	t *testing.T,
+	spaceStore *database.SpaceStore,
+	spacePathStore store.SpacePathStore,
+	spaceID int64,
+	parentID int64,
+	identifier string,
+) {
+	t.Helper()
+
+	space := types.Space{ID: spaceID, Identifier: identifier, CreatedBy: userID, ParentID: parentID}
+	if err := spaceStore.Create(ctx, &space); err != nil {
+		t.Fatalf("failed to create space %v", err)
+	}
+
+	if err := spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: identifier, CreatedBy: userID, SpaceID: spaceID, ParentID: parentID, IsPrimary: true,
+	}); err != nil {
+		t.Fatalf("failed to insert segment %v", err)
+	}
+}
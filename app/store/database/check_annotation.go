@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CheckAnnotationStore = (*CheckAnnotationStore)(nil)
+
+// checkAnnotationInsertBatchSize is the number of annotations inserted with a single statement.
+const checkAnnotationInsertBatchSize = 100
+
+// NewCheckAnnotationStore returns a new CheckAnnotationStore.
+func NewCheckAnnotationStore(db *sqlx.DB) *CheckAnnotationStore {
+	return &CheckAnnotationStore{
+		db: db,
+	}
+}
+
+// CheckAnnotationStore implements store.CheckAnnotationStore backed by a relational database.
+type CheckAnnotationStore struct {
+	db *sqlx.DB
+}
+
+const (
+	checkAnnotationColumns = `
+		 check_annotation_id
+		,check_annotation_check_id
+		,check_uid
+		,check_annotation_path
+		,check_annotation_start_line
+		,check_annotation_end_line
+		,check_annotation_severity
+		,check_annotation_title
+		,check_annotation_message
+		,check_annotation_created`
+)
+
+type checkAnnotation struct {
+	ID              int64                        `db:"check_annotation_id"`
+	CheckID         int64                        `db:"check_annotation_check_id"`
+	CheckIdentifier string                       `db:"check_uid"`
+	Path            string                       `db:"check_annotation_path"`
+	StartLine       int                          `db:"check_annotation_start_line"`
+	EndLine         int                          `db:"check_annotation_end_line"`
+	Severity        enum.CheckAnnotationSeverity `db:"check_annotation_severity"`
+	Title           string                       `db:"check_annotation_title"`
+	Message         string                       `db:"check_annotation_message"`
+	Created         int64                        `db:"check_annotation_created"`
+}
+
+// ReplaceForCheck replaces all annotations of a status check with the provided ones.
+func (s *CheckAnnotationStore) ReplaceForCheck(
+	ctx context.Context,
+	checkID int64,
+	annotations []*types.CheckAnnotation,
+) error {
+	const sqlDelete = `
+	DELETE FROM check_annotations
+	WHERE check_annotation_check_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlDelete, checkID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete status check annotations")
+	}
+
+	for from := 0; from < len(annotations); from += checkAnnotationInsertBatchSize {
+		to := from + checkAnnotationInsertBatchSize
+		if to > len(annotations) {
+			to = len(annotations)
+		}
+
+		stmt := database.Builder.
+			Insert("check_annotations").
+			Columns(
+				"check_annotation_check_id",
+				"check_annotation_path",
+				"check_annotation_start_line",
+				"check_annotation_end_line",
+				"check_annotation_severity",
+				"check_annotation_title",
+				"check_annotation_message",
+				"check_annotation_created",
+			)
+
+		for _, a := range annotations[from:to] {
+			a.CheckID = checkID
+			stmt = stmt.Values(a.CheckID, a.Path, a.StartLine, a.EndLine, a.Severity, a.Title, a.Message, a.Created)
+		}
+
+		sql, args, err := stmt.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to convert insert status check annotations query to sql: %w", err)
+		}
+
+		if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to insert status check annotations")
+		}
+	}
+
+	return nil
+}
+
+// ListForCheck returns all annotations of a status check.
+func (s *CheckAnnotationStore) ListForCheck(ctx context.Context, checkID int64) ([]types.CheckAnnotation, error) {
+	stmt := database.Builder.
+		Select(checkAnnotationColumns).
+		From("check_annotations").
+		InnerJoin("checks ON check_id = check_annotation_check_id").
+		Where("check_annotation_check_id = ?", checkID).
+		OrderBy("check_annotation_path", "check_annotation_start_line", "check_annotation_id")
+
+	return s.list(ctx, stmt)
+}
+
+// ListForCommit returns annotations of all status checks reported for a specific commit in a repo.
+func (s *CheckAnnotationStore) ListForCommit(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+) ([]types.CheckAnnotation, error) {
+	stmt := database.Builder.
+		Select(checkAnnotationColumns).
+		From("check_annotations").
+		InnerJoin("checks ON check_id = check_annotation_check_id").
+		Where("check_repo_id = ?", repoID).
+		Where("check_commit_sha = ?", commitSHA).
+		OrderBy("check_annotation_path", "check_annotation_start_line", "check_annotation_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *CheckAnnotationStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]types.CheckAnnotation, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list status check annotations query to sql: %w", err)
+	}
+
+	dst := make([]*checkAnnotation, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list status check annotations query")
+	}
+
+	result := make([]types.CheckAnnotation, len(dst))
+	for i, a := range dst {
+		result[i] = types.CheckAnnotation{
+			ID:              a.ID,
+			CheckID:         a.CheckID,
+			CheckIdentifier: a.CheckIdentifier,
+			Path:            a.Path,
+			StartLine:       a.StartLine,
+			EndLine:         a.EndLine,
+			Severity:        a.Severity,
+			Title:           a.Title,
+			Message:         a.Message,
+			Created:         a.Created,
+		}
+	}
+
+	return result, nil
+}
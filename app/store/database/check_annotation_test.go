@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_CheckAnnotations(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	checkStore := database.NewCheckStore(db, nil)
+	annotationStore := database.NewCheckAnnotationStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	const commitSHA = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+	checks := make([]*types.Check, 2)
+	for i, identifier := range []string{"lint", "scan"} {
+		checks[i] = &types.Check{
+			CreatedBy:  userID,
+			RepoID:     1,
+			CommitSHA:  commitSHA,
+			Identifier: identifier,
+			Status:     enum.CheckStatusFailure,
+			Metadata:   []byte("{}"),
+			Payload:    types.CheckPayload{Data: []byte("{}")},
+		}
+		if err := checkStore.Upsert(ctx, checks[i]); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+	}
+
+	lintAnnotations := make([]*types.CheckAnnotation, 150)
+	for i := range lintAnnotations {
+		lintAnnotations[i] = &types.CheckAnnotation{
+			Path:      "main.go",
+			StartLine: i + 1,
+			EndLine:   i + 1,
+			Severity:  enum.CheckAnnotationSeverityWarning,
+			Message:   "unused variable",
+		}
+	}
+
+	if err := annotationStore.ReplaceForCheck(ctx, checks[0].ID, lintAnnotations); err != nil {
+		t.Fatalf("ReplaceForCheck() error = %v", err)
+	}
+
+	err := annotationStore.ReplaceForCheck(ctx, checks[1].ID, []*types.CheckAnnotation{{
+		Path:      "db.go",
+		StartLine: 10,
+		EndLine:   12,
+		Severity:  enum.CheckAnnotationSeverityFailure,
+		Message:   "sql injection",
+	}})
+	if err != nil {
+		t.Fatalf("ReplaceForCheck() error = %v", err)
+	}
+
+	list, err := annotationStore.ListForCheck(ctx, checks[0].ID)
+	if err != nil {
+		t.Fatalf("ListForCheck() error = %v", err)
+	}
+	if len(list) != len(lintAnnotations) {
+		t.Fatalf("ListForCheck() returned %d annotations, want %d", len(list), len(lintAnnotations))
+	}
+	if list[0].CheckIdentifier != "lint" || list[0].StartLine != 1 {
+		t.Errorf("ListForCheck()[0] = %+v, want first lint annotation", list[0])
+	}
+
+	list, err = annotationStore.ListForCommit(ctx, 1, commitSHA)
+	if err != nil {
+		t.Fatalf("ListForCommit() error = %v", err)
+	}
+	if len(list) != len(lintAnnotations)+1 {
+		t.Fatalf("ListForCommit() returned %d annotations, want %d", len(list), len(lintAnnotations)+1)
+	}
+	if list[0].Path != "db.go" || list[0].CheckIdentifier != "scan" {
+		t.Errorf("ListForCommit()[0] = %+v, want scan annotation", list[0])
+	}
+
+	if err = annotationStore.ReplaceForCheck(ctx, checks[0].ID, nil); err != nil {
+		t.Fatalf("ReplaceForCheck() error = %v", err)
+	}
+
+	list, err = annotationStore.ListForCheck(ctx, checks[0].ID)
+	if err != nil {
+		t.Fatalf("ListForCheck() error = %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("ListForCheck() returned %d annotations after replace, want none", len(list))
+	}
+}
@@ -373,6 +373,31 @@ func (s *JobStore) CountRunning(ctx context.Context) (int, error) {
 	return int(count), nil
 }
 
+// CountReady returns number of jobs that are ready for execution:
+// The jobs with state="scheduled" and scheduled time in the past.
+func (s *JobStore) CountReady(ctx context.Context, now time.Time) (int, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("jobs").
+		Where("job_state = ?", enum.JobStateScheduled).
+		Where("job_scheduled <= ?", now.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count ready jobs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed executing count ready jobs query")
+	}
+
+	return int(count), nil
+}
+
 // ListReady returns a list of jobs that are ready for execution:
 // The jobs with state="scheduled" and scheduled time in the past.
 func (s *JobStore) ListReady(ctx context.Context, now time.Time, limit int) ([]*job.Job, error) {
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_LFSObjects(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	lfsStore := database.NewLFSObjectStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 1, 0)
+
+	oidA := strings.Repeat("a", 64)
+	oidB := strings.Repeat("b", 64)
+
+	objects := []types.LFSObject{
+		{OID: oidA, Size: 1024, RepoID: 1, CreatedBy: userID},
+		{OID: oidB, Size: 1, RepoID: 1, CreatedBy: userID},
+		{OID: oidA, Size: 1024, RepoID: 2, CreatedBy: userID},
+	}
+	for i := range objects {
+		if err := lfsStore.Create(ctx, &objects[i]); err != nil {
+			t.Fatalf("failed to create lfs object: %v", err)
+		}
+	}
+
+	err := lfsStore.Create(ctx, &types.LFSObject{OID: oidA, Size: 1024, RepoID: 1, CreatedBy: userID})
+	if !errors.Is(err, store.ErrDuplicate) {
+		t.Errorf("Create() duplicate error = %v, want %v", err, store.ErrDuplicate)
+	}
+
+	obj, err := lfsStore.Find(ctx, 1, oidB)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if obj.Size != 1 || obj.RepoID != 1 {
+		t.Errorf("Find() = %+v, want size 1 in repo 1", obj)
+	}
+
+	if _, err = lfsStore.Find(ctx, 2, oidB); !errors.Is(err, store.ErrResourceNotFound) {
+		t.Errorf("Find() error = %v, want %v", err, store.ErrResourceNotFound)
+	}
+
+	objs, err := lfsStore.FindMany(ctx, 2, []string{oidA, oidB})
+	if err != nil {
+		t.Fatalf("FindMany() error = %v", err)
+	}
+	if len(objs) != 1 || objs[0].OID != oidA {
+		t.Errorf("FindMany() = %+v, want only object %s", objs, oidA)
+	}
+
+	// 1024 bytes + 1 byte are rounded up to 2 KiB.
+	size, err := lfsStore.GetSizeInKBByRepoID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetSizeInKBByRepoID() error = %v", err)
+	}
+	if size != 2 {
+		t.Errorf("GetSizeInKBByRepoID() = %d, want %d", size, 2)
+	}
+
+	size, err = lfsStore.GetSizeInKBByRepoID(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetSizeInKBByRepoID() error = %v", err)
+	}
+	if size != 0 {
+		t.Errorf("GetSizeInKBByRepoID() = %d, want %d", size, 0)
+	}
+}
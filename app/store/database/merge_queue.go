@@ -0,0 +1,331 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeQueueStore = (*MergeQueueStore)(nil)
+
+func NewMergeQueueStore(db *sqlx.DB) *MergeQueueStore {
+	return &MergeQueueStore{
+		db: db,
+	}
+}
+
+// MergeQueueStore implements a store.MergeQueueStore backed by a relational database.
+type MergeQueueStore struct {
+	db *sqlx.DB
+}
+
+type mergeQueueEntry struct {
+	ID           int64                     `db:"merge_queue_entry_id"`
+	Version      int64                     `db:"merge_queue_entry_version"`
+	RepoID       int64                     `db:"merge_queue_entry_repo_id"`
+	PullReqID    int64                     `db:"merge_queue_entry_pullreq_id"`
+	TargetBranch string                    `db:"merge_queue_entry_target_branch"`
+	Method       enum.MergeMethod          `db:"merge_queue_entry_method"`
+	State        enum.MergeQueueEntryState `db:"merge_queue_entry_state"`
+	BaseSHA      string                    `db:"merge_queue_entry_base_sha"`
+	HeadSHA      string                    `db:"merge_queue_entry_head_sha"`
+	MergeSHA     string                    `db:"merge_queue_entry_merge_sha"`
+	CreatedBy    int64                     `db:"merge_queue_entry_created_by"`
+	Created      int64                     `db:"merge_queue_entry_created"`
+	Updated      int64                     `db:"merge_queue_entry_updated"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 merge_queue_entry_id
+		,merge_queue_entry_version
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_pullreq_id
+		,merge_queue_entry_target_branch
+		,merge_queue_entry_method
+		,merge_queue_entry_state
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_head_sha
+		,merge_queue_entry_merge_sha
+		,merge_queue_entry_created_by
+		,merge_queue_entry_created
+		,merge_queue_entry_updated`
+)
+
+// Find finds the merge queue entry by id.
+func (s *MergeQueueStore) Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error) {
+	return s.find(ctx, "merge_queue_entry_id", id)
+}
+
+// FindByPullReqID finds the merge queue entry of a pull request.
+func (s *MergeQueueStore) FindByPullReqID(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error) {
+	return s.find(ctx, "merge_queue_entry_pullreq_id", pullreqID)
+}
+
+// FindByMergeSHA finds the merge queue entry whose merge ref points at the provided commit.
+func (s *MergeQueueStore) FindByMergeSHA(
+	ctx context.Context,
+	repoID int64,
+	mergeSHA string,
+) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_repo_id = ?", repoID).
+		Where("merge_queue_entry_merge_sha = ?", mergeSHA)
+
+	return s.get(ctx, stmt)
+}
+
+func (s *MergeQueueStore) find(ctx context.Context, column string, value int64) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where(column+" = ?", value)
+
+	return s.get(ctx, stmt)
+}
+
+func (s *MergeQueueStore) get(ctx context.Context, stmt squirrel.SelectBuilder) (*types.MergeQueueEntry, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := &mergeQueueEntry{}
+	db := dbtx.GetAccessor(ctx, s.db)
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry")
+	}
+
+	return mapMergeQueueEntry(dst), nil
+}
+
+// Create adds a pull request to the end of the merge queue of its target branch.
+func (s *MergeQueueStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		INSERT INTO merge_queue_entries (
+			 merge_queue_entry_version
+			,merge_queue_entry_repo_id
+			,merge_queue_entry_pullreq_id
+			,merge_queue_entry_target_branch
+			,merge_queue_entry_method
+			,merge_queue_entry_state
+			,merge_queue_entry_base_sha
+			,merge_queue_entry_head_sha
+			,merge_queue_entry_merge_sha
+			,merge_queue_entry_created_by
+			,merge_queue_entry_created
+			,merge_queue_entry_updated
+		) values (
+			 :merge_queue_entry_version
+			,:merge_queue_entry_repo_id
+			,:merge_queue_entry_pullreq_id
+			,:merge_queue_entry_target_branch
+			,:merge_queue_entry_method
+			,:merge_queue_entry_state
+			,:merge_queue_entry_base_sha
+			,:merge_queue_entry_head_sha
+			,:merge_queue_entry_merge_sha
+			,:merge_queue_entry_created_by
+			,:merge_queue_entry_created
+			,:merge_queue_entry_updated
+		) RETURNING merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert merge queue entry query failed")
+	}
+
+	return nil
+}
+
+// Update updates the merge queue entry. It returns store.ErrVersionConflict if the entry has been modified meanwhile.
+func (s *MergeQueueStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		UPDATE merge_queue_entries
+		SET
+			 merge_queue_entry_version = :merge_queue_entry_version
+			,merge_queue_entry_updated = :merge_queue_entry_updated
+			,merge_queue_entry_state = :merge_queue_entry_state
+			,merge_queue_entry_base_sha = :merge_queue_entry_base_sha
+			,merge_queue_entry_head_sha = :merge_queue_entry_head_sha
+			,merge_queue_entry_merge_sha = :merge_queue_entry_merge_sha
+		WHERE merge_queue_entry_id = :merge_queue_entry_id AND merge_queue_entry_version = :merge_queue_entry_version - 1`
+
+	dbEntry := mapInternalMergeQueueEntry(entry)
+	dbEntry.Version++
+	dbEntry.Updated = time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, dbEntry)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update merge queue entry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated merge queue entry rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	entry.Version = dbEntry.Version
+	entry.Updated = dbEntry.Updated
+
+	return nil
+}
+
+// Delete removes the merge queue entry.
+func (s *MergeQueueStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `DELETE FROM merge_queue_entries WHERE merge_queue_entry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete merge queue entry query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "RowsAffected after delete of merge queue entry failed")
+	}
+
+	if count == 0 {
+		return errors.NotFound("Merge queue entry not found")
+	}
+
+	return nil
+}
+
+// List returns the merge queue of a branch, ordered from the head of the queue to its end.
+func (s *MergeQueueStore) List(
+	ctx context.Context,
+	repoID int64,
+	targetBranch string,
+) ([]*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_repo_id = ?", repoID).
+		Where("merge_queue_entry_target_branch = ?", targetBranch).
+		OrderBy("merge_queue_entry_id ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	var dst []*mergeQueueEntry
+	db := dbtx.GetAccessor(ctx, s.db)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue entries")
+	}
+
+	return mapMergeQueueEntries(dst), nil
+}
+
+// ListHeads returns the entry at the head of every merge queue.
+func (s *MergeQueueStore) ListHeads(ctx context.Context) ([]*types.MergeQueueEntry, error) {
+	const sqlQuery = `
+		SELECT` + mergeQueueEntryColumns + `
+		FROM merge_queue_entries
+		WHERE merge_queue_entry_id IN (
+			SELECT MIN(merge_queue_entry_id)
+			FROM merge_queue_entries
+			GROUP BY merge_queue_entry_repo_id, merge_queue_entry_target_branch
+		)
+		ORDER BY merge_queue_entry_id ASC`
+
+	var dst []*mergeQueueEntry
+	db := dbtx.GetAccessor(ctx, s.db)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue heads")
+	}
+
+	return mapMergeQueueEntries(dst), nil
+}
+
+func mapInternalMergeQueueEntry(entry *types.MergeQueueEntry) *mergeQueueEntry {
+	return &mergeQueueEntry{
+		ID:           entry.ID,
+		Version:      entry.Version,
+		RepoID:       entry.RepoID,
+		PullReqID:    entry.PullReqID,
+		TargetBranch: entry.TargetBranch,
+		Method:       entry.Method,
+		State:        entry.State,
+		BaseSHA:      entry.BaseSHA,
+		HeadSHA:      entry.HeadSHA,
+		MergeSHA:     entry.MergeSHA,
+		CreatedBy:    entry.CreatedBy,
+		Created:      entry.Created,
+		Updated:      entry.Updated,
+	}
+}
+
+func mapMergeQueueEntry(entry *mergeQueueEntry) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		ID:           entry.ID,
+		Version:      entry.Version,
+		RepoID:       entry.RepoID,
+		PullReqID:    entry.PullReqID,
+		TargetBranch: entry.TargetBranch,
+		Method:       entry.Method,
+		State:        entry.State,
+		BaseSHA:      entry.BaseSHA,
+		HeadSHA:      entry.HeadSHA,
+		MergeSHA:     entry.MergeSHA,
+		CreatedBy:    entry.CreatedBy,
+		Created:      entry.Created,
+		Updated:      entry.Updated,
+	}
+}
+
+func mapMergeQueueEntries(entries []*mergeQueueEntry) []*types.MergeQueueEntry {
+	res := make([]*types.MergeQueueEntry, len(entries))
+	for i := range entries {
+		res[i] = mapMergeQueueEntry(entries[i])
+	}
+	return res
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_MergeQueue(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pullreqStore := database.NewPullReqStore(db, nil)
+	queueStore := database.NewMergeQueueStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	entries := make([]*types.MergeQueueEntry, 3)
+	for i := range entries {
+		pr := &types.PullReq{
+			Number:           int64(i + 1),
+			CreatedBy:        userID,
+			State:            enum.PullReqStateOpen,
+			SourceRepoID:     1,
+			SourceBranch:     "feature-" + strconv.Itoa(i),
+			TargetRepoID:     1,
+			TargetBranch:     "main",
+			MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+		}
+		if i == 2 {
+			pr.TargetBranch = "release"
+		}
+		if err := pullreqStore.Create(ctx, pr); err != nil {
+			t.Fatalf("failed to create pull request: %v", err)
+		}
+
+		entries[i] = &types.MergeQueueEntry{
+			RepoID:       1,
+			PullReqID:    pr.ID,
+			TargetBranch: pr.TargetBranch,
+			Method:       enum.MergeMethodMerge,
+			State:        enum.MergeQueueEntryStateQueued,
+			CreatedBy:    userID,
+		}
+		if err := queueStore.Create(ctx, entries[i]); err != nil {
+			t.Fatalf("failed to create merge queue entry: %v", err)
+		}
+	}
+
+	err := queueStore.Create(ctx, &types.MergeQueueEntry{
+		RepoID:       1,
+		PullReqID:    entries[0].PullReqID,
+		TargetBranch: "main",
+		Method:       enum.MergeMethodMerge,
+		State:        enum.MergeQueueEntryStateQueued,
+		CreatedBy:    userID,
+	})
+	if !errors.Is(err, store.ErrDuplicate) {
+		t.Errorf("Create() duplicate error = %v, want %v", err, store.ErrDuplicate)
+	}
+
+	queue, err := queueStore.List(ctx, 1, "main")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(queue) != 2 || queue[0].ID != entries[0].ID || queue[1].ID != entries[1].ID {
+		t.Fatalf("List() = %+v, want entries %d and %d", queue, entries[0].ID, entries[1].ID)
+	}
+
+	heads, err := queueStore.ListHeads(ctx)
+	if err != nil {
+		t.Fatalf("ListHeads() error = %v", err)
+	}
+	if len(heads) != 2 || heads[0].ID != entries[0].ID || heads[1].ID != entries[2].ID {
+		t.Fatalf("ListHeads() = %+v, want entries %d and %d", heads, entries[0].ID, entries[2].ID)
+	}
+
+	entry := queue[0]
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.MergeSHA = "abc"
+	if err = queueStore.Update(ctx, entry); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	stale := *queue[0]
+	stale.Version--
+	if err = queueStore.Update(ctx, &stale); !errors.Is(err, store.ErrVersionConflict) {
+		t.Errorf("Update() stale error = %v, want %v", err, store.ErrVersionConflict)
+	}
+
+	entry, err = queueStore.FindByPullReqID(ctx, entries[0].PullReqID)
+	if err != nil {
+		t.Fatalf("FindByPullReqID() error = %v", err)
+	}
+	if entry.State != enum.MergeQueueEntryStateChecking || entry.MergeSHA != "abc" {
+		t.Errorf("FindByPullReqID() = %+v, want updated entry", entry)
+	}
+
+	entry, err = queueStore.FindByMergeSHA(ctx, 1, "abc")
+	if err != nil {
+		t.Fatalf("FindByMergeSHA() error = %v", err)
+	}
+	if entry.ID != entries[0].ID {
+		t.Errorf("FindByMergeSHA() = %+v, want entry %d", entry, entries[0].ID)
+	}
+
+	if err = queueStore.Delete(ctx, entry.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err = queueStore.Find(ctx, entry.ID); !errors.Is(err, store.ErrResourceNotFound) {
+		t.Errorf("Find() error = %v, want %v", err, store.ErrResourceNotFound)
+	}
+}
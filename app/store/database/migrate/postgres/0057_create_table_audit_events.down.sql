@@ -0,0 +1,3 @@
+DROP INDEX audit_events_timestamp;
+DROP INDEX audit_events_space_path_timestamp;
+DROP TABLE audit_events;
@@ -0,0 +1,2 @@
+ALTER TABLE pullreqs ADD COLUMN pullreq_auto_merge_method TEXT;
+ALTER TABLE pullreqs ADD COLUMN pullreq_auto_merge_by INTEGER;
@@ -0,0 +1,18 @@
+CREATE TABLE check_annotations (
+ check_annotation_id SERIAL PRIMARY KEY
+,check_annotation_check_id INTEGER NOT NULL
+,check_annotation_path TEXT NOT NULL
+,check_annotation_start_line INTEGER NOT NULL
+,check_annotation_end_line INTEGER NOT NULL
+,check_annotation_severity TEXT NOT NULL
+,check_annotation_title TEXT NOT NULL
+,check_annotation_message TEXT NOT NULL
+,check_annotation_created BIGINT NOT NULL
+,CONSTRAINT fk_check_annotation_check_id FOREIGN KEY (check_annotation_check_id)
+    REFERENCES checks (check_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX check_annotations_check_id_path
+    ON check_annotations(check_annotation_check_id, check_annotation_path);
@@ -0,0 +1,30 @@
+CREATE TABLE stream_messages (
+ stream_message_id SERIAL PRIMARY KEY
+,stream_message_stream_id TEXT NOT NULL
+,stream_message_payload BYTEA NOT NULL
+,stream_message_created BIGINT NOT NULL
+);
+
+CREATE INDEX stream_messages_stream_id_id
+    ON stream_messages(stream_message_stream_id, stream_message_id);
+
+CREATE TABLE stream_groups (
+ stream_group_stream_id TEXT NOT NULL
+,stream_group_name TEXT NOT NULL
+,stream_group_last_id BIGINT NOT NULL
+,stream_group_created BIGINT NOT NULL
+,PRIMARY KEY (stream_group_stream_id, stream_group_name)
+);
+
+CREATE TABLE stream_pending (
+ stream_pending_stream_id TEXT NOT NULL
+,stream_pending_group_name TEXT NOT NULL
+,stream_pending_message_id BIGINT NOT NULL
+,stream_pending_consumer TEXT NOT NULL
+,stream_pending_delivered BIGINT NOT NULL
+,stream_pending_delivery_count INTEGER NOT NULL
+,PRIMARY KEY (stream_pending_stream_id, stream_pending_group_name, stream_pending_message_id)
+);
+
+CREATE INDEX stream_pending_stream_id_group_name_consumer
+    ON stream_pending(stream_pending_stream_id, stream_pending_group_name, stream_pending_consumer);
@@ -0,0 +1,61 @@
+CREATE TABLE usergroups (
+ usergroup_id SERIAL PRIMARY KEY
+,usergroup_space_id INTEGER NOT NULL
+,usergroup_identifier TEXT NOT NULL
+,usergroup_name TEXT NOT NULL
+,usergroup_description TEXT NOT NULL
+,usergroup_created_by INTEGER NOT NULL
+,usergroup_created BIGINT NOT NULL
+,usergroup_updated BIGINT NOT NULL
+,CONSTRAINT fk_usergroup_space_id FOREIGN KEY (usergroup_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_usergroup_created_by FOREIGN KEY (usergroup_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX usergroups_space_id_identifier
+    ON usergroups(usergroup_space_id, LOWER(usergroup_identifier));
+
+CREATE TABLE usergroup_members (
+ usergroup_member_usergroup_id INTEGER NOT NULL
+,usergroup_member_principal_id INTEGER NOT NULL
+,usergroup_member_created_by INTEGER NOT NULL
+,usergroup_member_created BIGINT NOT NULL
+,CONSTRAINT pk_usergroup_members PRIMARY KEY (usergroup_member_usergroup_id, usergroup_member_principal_id)
+,CONSTRAINT fk_usergroup_member_usergroup_id FOREIGN KEY (usergroup_member_usergroup_id)
+    REFERENCES usergroups (usergroup_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_usergroup_member_principal_id FOREIGN KEY (usergroup_member_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX usergroup_members_principal_id
+    ON usergroup_members(usergroup_member_principal_id);
+
+CREATE TABLE usergroup_memberships (
+ usergroup_membership_space_id INTEGER NOT NULL
+,usergroup_membership_usergroup_id INTEGER NOT NULL
+,usergroup_membership_created_by INTEGER NOT NULL
+,usergroup_membership_created BIGINT NOT NULL
+,usergroup_membership_updated BIGINT NOT NULL
+,usergroup_membership_role TEXT NOT NULL
+,CONSTRAINT pk_usergroup_memberships PRIMARY KEY (usergroup_membership_space_id, usergroup_membership_usergroup_id)
+,CONSTRAINT fk_usergroup_membership_space_id FOREIGN KEY (usergroup_membership_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_usergroup_membership_usergroup_id FOREIGN KEY (usergroup_membership_usergroup_id)
+    REFERENCES usergroups (usergroup_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX usergroup_memberships_usergroup_id
+    ON usergroup_memberships(usergroup_membership_usergroup_id);
@@ -0,0 +1,4 @@
+ALTER TABLE audit_events ADD COLUMN audit_event_space_id INTEGER;
+
+CREATE INDEX audit_events_space_id_timestamp
+    ON audit_events(audit_event_space_id, audit_event_timestamp);
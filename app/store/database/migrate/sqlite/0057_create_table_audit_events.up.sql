@@ -0,0 +1,24 @@
+CREATE TABLE audit_events (
+ audit_event_id TEXT PRIMARY KEY
+,audit_event_timestamp BIGINT NOT NULL
+,audit_event_action TEXT NOT NULL
+,audit_event_resource_type TEXT NOT NULL
+,audit_event_resource_identifier TEXT NOT NULL
+,audit_event_space_path TEXT NOT NULL
+,audit_event_principal_id INTEGER NOT NULL
+,audit_event_principal_uid TEXT NOT NULL
+,audit_event_principal_type TEXT NOT NULL
+,audit_event_principal_email TEXT NOT NULL
+,audit_event_principal_display_name TEXT NOT NULL
+,audit_event_old_object TEXT
+,audit_event_new_object TEXT
+,audit_event_client_ip TEXT NOT NULL
+,audit_event_request_method TEXT NOT NULL
+,audit_event_data TEXT NOT NULL
+);
+
+CREATE INDEX audit_events_space_path_timestamp
+    ON audit_events(LOWER(audit_event_space_path), audit_event_timestamp);
+
+CREATE INDEX audit_events_timestamp
+    ON audit_events(audit_event_timestamp);
@@ -0,0 +1,6 @@
+DROP INDEX triggers_cron_next;
+
+ALTER TABLE triggers DROP COLUMN trigger_cron_expression;
+ALTER TABLE triggers DROP COLUMN trigger_cron_timezone;
+ALTER TABLE triggers DROP COLUMN trigger_cron_branch;
+ALTER TABLE triggers DROP COLUMN trigger_cron_next;
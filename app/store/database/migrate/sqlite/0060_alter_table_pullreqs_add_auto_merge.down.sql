@@ -0,0 +1,2 @@
+ALTER TABLE pullreqs DROP COLUMN pullreq_auto_merge_method;
+ALTER TABLE pullreqs DROP COLUMN pullreq_auto_merge_by;
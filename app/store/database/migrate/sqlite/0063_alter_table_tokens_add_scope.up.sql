@@ -0,0 +1,5 @@
+ALTER TABLE tokens ADD COLUMN token_permissions TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE tokens ADD COLUMN token_space_ids TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE tokens ADD COLUMN token_repo_ids TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE tokens ADD COLUMN token_last_used BIGINT;
+ALTER TABLE tokens ADD COLUMN token_last_used_ip TEXT NOT NULL DEFAULT '';
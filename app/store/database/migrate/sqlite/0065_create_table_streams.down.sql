@@ -0,0 +1,3 @@
+DROP TABLE stream_pending;
+DROP TABLE stream_groups;
+DROP TABLE stream_messages;
@@ -290,6 +290,10 @@ func (PublicKeyStore) applyQueryFilter(
 			fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
 	}
 
+	if filter.Usage != "" {
+		stmt = stmt.Where("public_key_usage = ?", filter.Usage)
+	}
+
 	return stmt
 }
 
@@ -85,6 +85,9 @@ type pullReq struct {
 	Merged      null.Int    `db:"pullreq_merged"`
 	MergeMethod null.String `db:"pullreq_merge_method"`
 
+	AutoMergeMethod null.String `db:"pullreq_auto_merge_method"`
+	AutoMergeBy     null.Int    `db:"pullreq_auto_merge_by"`
+
 	MergeCheckStatus enum.MergeCheckStatus `db:"pullreq_merge_check_status"`
 	MergeTargetSHA   null.String           `db:"pullreq_merge_target_sha"`
 	MergeBaseSHA     string                `db:"pullreq_merge_base_sha"`
@@ -120,6 +123,8 @@ const (
 		,pullreq_merged_by
 		,pullreq_merged
 		,pullreq_merge_method
+		,pullreq_auto_merge_method
+		,pullreq_auto_merge_by
 		,pullreq_merge_check_status
 		,pullreq_merge_target_sha
 		,pullreq_merge_base_sha
@@ -212,6 +217,8 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merged_by
 		,pullreq_merged
 		,pullreq_merge_method
+		,pullreq_auto_merge_method
+		,pullreq_auto_merge_by
 		,pullreq_merge_check_status
 		,pullreq_merge_target_sha
 		,pullreq_merge_base_sha
@@ -242,6 +249,8 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_merged_by
 		,:pullreq_merged
 		,:pullreq_merge_method
+		,:pullreq_auto_merge_method
+		,:pullreq_auto_merge_by
 		,:pullreq_merge_check_status
 		,:pullreq_merge_target_sha
 		,:pullreq_merge_base_sha
@@ -285,6 +294,8 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merged_by = :pullreq_merged_by
 		,pullreq_merged = :pullreq_merged
 		,pullreq_merge_method = :pullreq_merge_method
+		,pullreq_auto_merge_method = :pullreq_auto_merge_method
+		,pullreq_auto_merge_by = :pullreq_auto_merge_by
 		,pullreq_merge_check_status = :pullreq_merge_check_status
 		,pullreq_merge_target_sha = :pullreq_merge_target_sha
 		,pullreq_merge_base_sha = :pullreq_merge_base_sha
@@ -438,6 +449,14 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 		stmt = stmt.Where("pullreq_target_branch = ?", opts.TargetBranch)
 	}
 
+	if opts.SourceSHA != "" {
+		stmt = stmt.Where("pullreq_source_sha = ?", opts.SourceSHA)
+	}
+
+	if opts.AutoMerge {
+		stmt = stmt.Where("pullreq_auto_merge_method IS NOT NULL")
+	}
+
 	if opts.Query != "" {
 		stmt = stmt.Where("LOWER(pullreq_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
 	}
@@ -490,6 +509,14 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 		stmt = stmt.Where("pullreq_target_branch = ?", opts.TargetBranch)
 	}
 
+	if opts.SourceSHA != "" {
+		stmt = stmt.Where("pullreq_source_sha = ?", opts.SourceSHA)
+	}
+
+	if opts.AutoMerge {
+		stmt = stmt.Where("pullreq_auto_merge_method IS NOT NULL")
+	}
+
 	if opts.Query != "" {
 		stmt = stmt.Where("LOWER(pullreq_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
 	}
@@ -566,6 +593,8 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 		MergedBy:         pr.MergedBy.Ptr(),
 		Merged:           pr.Merged.Ptr(),
 		MergeMethod:      (*enum.MergeMethod)(pr.MergeMethod.Ptr()),
+		AutoMergeMethod:  (*enum.MergeMethod)(pr.AutoMergeMethod.Ptr()),
+		AutoMergeBy:      pr.AutoMergeBy.Ptr(),
 		MergeCheckStatus: pr.MergeCheckStatus,
 		MergeTargetSHA:   pr.MergeTargetSHA.Ptr(),
 		MergeBaseSHA:     pr.MergeBaseSHA,
@@ -610,6 +639,8 @@ func mapInternalPullReq(pr *types.PullReq) *pullReq {
 		MergedBy:         null.IntFromPtr(pr.MergedBy),
 		Merged:           null.IntFromPtr(pr.Merged),
 		MergeMethod:      null.StringFromPtr((*string)(pr.MergeMethod)),
+		AutoMergeMethod:  null.StringFromPtr((*string)(pr.AutoMergeMethod)),
+		AutoMergeBy:      null.IntFromPtr(pr.AutoMergeBy),
 		MergeCheckStatus: pr.MergeCheckStatus,
 		MergeTargetSHA:   null.StringFromPtr(pr.MergeTargetSHA),
 		MergeBaseSHA:     pr.MergeBaseSHA,
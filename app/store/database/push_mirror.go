@@ -0,0 +1,335 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PushMirrorStore = (*PushMirrorStore)(nil)
+
+// NewPushMirrorStore returns a new PushMirrorStore.
+func NewPushMirrorStore(db *sqlx.DB) *PushMirrorStore {
+	return &PushMirrorStore{
+		db: db,
+	}
+}
+
+// PushMirrorStore implements store.PushMirrorStore backed by a relational database.
+type PushMirrorStore struct {
+	db *sqlx.DB
+}
+
+// refFilterSeparator separates the ref patterns of a push mirror in the DB.
+// Space can't be part of a git reference name.
+const refFilterSeparator = " "
+
+type pushMirror struct {
+	ID          int64  `db:"push_mirror_id"`
+	Version     int64  `db:"push_mirror_version"`
+	RepoID      int64  `db:"push_mirror_repo_id"`
+	Identifier  string `db:"push_mirror_identifier"`
+	URL         string `db:"push_mirror_url"`
+	Connector   string `db:"push_mirror_connector"`
+	Username    string `db:"push_mirror_username"`
+	Secret      string `db:"push_mirror_secret"`
+	RefFilter   string `db:"push_mirror_ref_filter"`
+	Force       bool   `db:"push_mirror_force"`
+	Enabled     bool   `db:"push_mirror_enabled"`
+	LastSuccess int64  `db:"push_mirror_last_success"`
+	LastError   string `db:"push_mirror_last_error"`
+	CreatedBy   int64  `db:"push_mirror_created_by"`
+	Created     int64  `db:"push_mirror_created"`
+	Updated     int64  `db:"push_mirror_updated"`
+}
+
+const (
+	pushMirrorColumns = `
+		 push_mirror_id
+		,push_mirror_version
+		,push_mirror_repo_id
+		,push_mirror_identifier
+		,push_mirror_url
+		,push_mirror_connector
+		,push_mirror_username
+		,push_mirror_secret
+		,push_mirror_ref_filter
+		,push_mirror_force
+		,push_mirror_enabled
+		,push_mirror_last_success
+		,push_mirror_last_error
+		,push_mirror_created_by
+		,push_mirror_created
+		,push_mirror_updated`
+
+	pushMirrorSelectBase = `
+	SELECT` + pushMirrorColumns + `
+	FROM push_mirrors`
+)
+
+// Find finds the push mirror by id.
+func (s *PushMirrorStore) Find(ctx context.Context, id int64) (*types.PushMirror, error) {
+	const sqlQuery = pushMirrorSelectBase + `
+	WHERE push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pushMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find push mirror")
+	}
+
+	return mapToPushMirror(dst), nil
+}
+
+// FindByIdentifier finds the push mirror of a repository by its identifier.
+func (s *PushMirrorStore) FindByIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.PushMirror, error) {
+	const sqlQuery = pushMirrorSelectBase + `
+	WHERE push_mirror_repo_id = $1 AND LOWER(push_mirror_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pushMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find push mirror by identifier")
+	}
+
+	return mapToPushMirror(dst), nil
+}
+
+// Create creates a new push mirror.
+func (s *PushMirrorStore) Create(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+	INSERT INTO push_mirrors (
+		 push_mirror_version
+		,push_mirror_repo_id
+		,push_mirror_identifier
+		,push_mirror_url
+		,push_mirror_connector
+		,push_mirror_username
+		,push_mirror_secret
+		,push_mirror_ref_filter
+		,push_mirror_force
+		,push_mirror_enabled
+		,push_mirror_last_success
+		,push_mirror_last_error
+		,push_mirror_created_by
+		,push_mirror_created
+		,push_mirror_updated
+	) values (
+		 :push_mirror_version
+		,:push_mirror_repo_id
+		,:push_mirror_identifier
+		,:push_mirror_url
+		,:push_mirror_connector
+		,:push_mirror_username
+		,:push_mirror_secret
+		,:push_mirror_ref_filter
+		,:push_mirror_force
+		,:push_mirror_enabled
+		,:push_mirror_last_success
+		,:push_mirror_last_error
+		,:push_mirror_created_by
+		,:push_mirror_created
+		,:push_mirror_updated
+	) RETURNING push_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPushMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind push mirror object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&mirror.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert push mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the push mirror.
+func (s *PushMirrorStore) Update(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+	UPDATE push_mirrors
+	SET
+		 push_mirror_version = :push_mirror_version
+		,push_mirror_identifier = :push_mirror_identifier
+		,push_mirror_url = :push_mirror_url
+		,push_mirror_connector = :push_mirror_connector
+		,push_mirror_username = :push_mirror_username
+		,push_mirror_secret = :push_mirror_secret
+		,push_mirror_ref_filter = :push_mirror_ref_filter
+		,push_mirror_force = :push_mirror_force
+		,push_mirror_enabled = :push_mirror_enabled
+		,push_mirror_last_success = :push_mirror_last_success
+		,push_mirror_last_error = :push_mirror_last_error
+		,push_mirror_updated = :push_mirror_updated
+	WHERE push_mirror_id = :push_mirror_id AND push_mirror_version = :push_mirror_version - 1`
+
+	dbMirror := mapToInternalPushMirror(mirror)
+	dbMirror.Version++
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind push mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update push mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	mirror.Version = dbMirror.Version
+
+	return nil
+}
+
+// UpdateOptLock updates the push mirror using the optimistic locking mechanism.
+func (s *PushMirrorStore) UpdateOptLock(
+	ctx context.Context,
+	mirror *types.PushMirror,
+	mutateFn func(mirror *types.PushMirror) error,
+) (*types.PushMirror, error) {
+	for {
+		dup := *mirror
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		mirror, err = s.Find(ctx, mirror.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes the push mirror by id.
+func (s *PushMirrorStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM push_mirrors
+	WHERE push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete push mirror")
+	}
+
+	return nil
+}
+
+// List returns all push mirrors of a repository.
+func (s *PushMirrorStore) List(ctx context.Context, repoID int64) ([]*types.PushMirror, error) {
+	const sqlQuery = pushMirrorSelectBase + `
+	WHERE push_mirror_repo_id = $1
+	ORDER BY push_mirror_identifier ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pushMirror{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list push mirrors query")
+	}
+
+	result := make([]*types.PushMirror, len(dst))
+	for i, m := range dst {
+		result[i] = mapToPushMirror(m)
+	}
+
+	return result, nil
+}
+
+func mapToPushMirror(m *pushMirror) *types.PushMirror {
+	var refFilter []string
+	if m.RefFilter != "" {
+		refFilter = strings.Split(m.RefFilter, refFilterSeparator)
+	}
+
+	return &types.PushMirror{
+		ID:          m.ID,
+		Version:     m.Version,
+		RepoID:      m.RepoID,
+		Identifier:  m.Identifier,
+		URL:         m.URL,
+		Connector:   m.Connector,
+		Username:    m.Username,
+		Secret:      m.Secret,
+		RefFilter:   refFilter,
+		Force:       m.Force,
+		Enabled:     m.Enabled,
+		LastSuccess: m.LastSuccess,
+		LastError:   m.LastError,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+	}
+}
+
+func mapToInternalPushMirror(m *types.PushMirror) *pushMirror {
+	return &pushMirror{
+		ID:          m.ID,
+		Version:     m.Version,
+		RepoID:      m.RepoID,
+		Identifier:  m.Identifier,
+		URL:         m.URL,
+		Connector:   m.Connector,
+		Username:    m.Username,
+		Secret:      m.Secret,
+		RefFilter:   strings.Join(m.RefFilter, refFilterSeparator),
+		Force:       m.Force,
+		Enabled:     m.Enabled,
+		LastSuccess: m.LastSuccess,
+		LastError:   m.LastError,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestDatabase_PushMirrors(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	mirrorStore := database.NewPushMirrorStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	mirror := &types.PushMirror{
+		RepoID:     1,
+		Identifier: "Backup",
+		URL:        "https://example.com/backup.git",
+		RefFilter:  []string{"refs/heads/*", "refs/tags/v*"},
+		Force:      true,
+		Enabled:    true,
+		CreatedBy:  userID,
+	}
+	if err := mirrorStore.Create(ctx, mirror); err != nil {
+		t.Fatalf("failed to create push mirror: %v", err)
+	}
+
+	err := mirrorStore.Create(ctx, &types.PushMirror{RepoID: 1, Identifier: "backup", CreatedBy: userID})
+	if !errors.Is(err, store.ErrDuplicate) {
+		t.Errorf("Create() with duplicate identifier error = %v, want %v", err, store.ErrDuplicate)
+	}
+
+	found, err := mirrorStore.FindByIdentifier(ctx, 1, "backup")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+	if !slices.Equal(found.RefFilter, mirror.RefFilter) || !found.Force {
+		t.Errorf("FindByIdentifier() = %+v, want ref filter %v with force", found, mirror.RefFilter)
+	}
+
+	// recording a push result with a stale copy must not lose the changes made in the meantime.
+	stale := *found
+	if _, err = mirrorStore.UpdateOptLock(ctx, found, func(mirror *types.PushMirror) error {
+		mirror.Enabled = false
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateOptLock() error = %v", err)
+	}
+
+	updated, err := mirrorStore.UpdateOptLock(ctx, &stale, func(mirror *types.PushMirror) error {
+		mirror.LastError = "failed"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateOptLock() error = %v", err)
+	}
+	if updated.Enabled || updated.LastError != "failed" {
+		t.Errorf("UpdateOptLock() = %+v, want disabled push mirror with last error", updated)
+	}
+
+	list, err := mirrorStore.List(ctx, 1)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 1 {
+		t.Errorf("List() returned %d push mirrors, want 1", len(list))
+	}
+
+	if err = mirrorStore.Delete(ctx, mirror.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err = mirrorStore.Find(ctx, mirror.ID); !errors.Is(err, store.ErrResourceNotFound) {
+		t.Errorf("Find() error = %v, want %v", err, store.ErrResourceNotFound)
+	}
+}
@@ -690,6 +690,63 @@ FROM SpaceHierarchy h1;`
 	return s.mapToRepos(ctx, repos)
 }
 
+// CountForks returns the number of forks of a repo.
+func (s *RepoStore) CountForks(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("repositories").
+		Where("repo_fork_id = ?", repoID)
+
+	stmt = applyQueryFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count forks query")
+	}
+	return count, nil
+}
+
+// ListForks returns a list of forks of a repo.
+func (s *RepoStore) ListForks(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoFilter,
+) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where("repo_fork_id = ?", repoID)
+
+	stmt = applyQueryFilter(stmt, filter)
+	stmt = applySortFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list forks query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
 type repoSize struct {
 	ID          int64  `db:"repo_id"`
 	GitUID      string `db:"repo_git_uid"`
@@ -0,0 +1,289 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoMirrorStore = (*RepoMirrorStore)(nil)
+
+// NewRepoMirrorStore returns a new RepoMirrorStore.
+func NewRepoMirrorStore(db *sqlx.DB) *RepoMirrorStore {
+	return &RepoMirrorStore{
+		db: db,
+	}
+}
+
+// RepoMirrorStore implements store.RepoMirrorStore backed by a relational database.
+type RepoMirrorStore struct {
+	db *sqlx.DB
+}
+
+type repoMirror struct {
+	RepoID     int64                     `db:"repo_mirror_repo_id"`
+	Version    int64                     `db:"repo_mirror_version"`
+	SourceURL  string                    `db:"repo_mirror_source_url"`
+	Connector  string                    `db:"repo_mirror_connector"`
+	Interval   int64                     `db:"repo_mirror_interval"`
+	NextSync   int64                     `db:"repo_mirror_next_sync"`
+	LastSync   int64                     `db:"repo_mirror_last_sync"`
+	SyncStatus enum.RepoMirrorSyncStatus `db:"repo_mirror_sync_status"`
+	LastError  string                    `db:"repo_mirror_last_error"`
+	CreatedBy  int64                     `db:"repo_mirror_created_by"`
+	Created    int64                     `db:"repo_mirror_created"`
+	Updated    int64                     `db:"repo_mirror_updated"`
+}
+
+const (
+	repoMirrorColumns = `
+		 repo_mirror_repo_id
+		,repo_mirror_version
+		,repo_mirror_source_url
+		,repo_mirror_connector
+		,repo_mirror_interval
+		,repo_mirror_next_sync
+		,repo_mirror_last_sync
+		,repo_mirror_sync_status
+		,repo_mirror_last_error
+		,repo_mirror_created_by
+		,repo_mirror_created
+		,repo_mirror_updated`
+)
+
+// Find finds the mirror configuration of a repository.
+func (s *RepoMirrorStore) Find(ctx context.Context, repoID int64) (*types.RepoMirror, error) {
+	const sqlQuery = `
+	SELECT` + repoMirrorColumns + `
+	FROM repo_mirrors
+	WHERE repo_mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repo mirror")
+	}
+
+	return mapToRepoMirror(dst), nil
+}
+
+// Create creates a new mirror configuration of a repository.
+func (s *RepoMirrorStore) Create(ctx context.Context, mirror *types.RepoMirror) error {
+	const sqlQuery = `
+	INSERT INTO repo_mirrors (` + repoMirrorColumns + `
+	) values (
+		 :repo_mirror_repo_id
+		,:repo_mirror_version
+		,:repo_mirror_source_url
+		,:repo_mirror_connector
+		,:repo_mirror_interval
+		,:repo_mirror_next_sync
+		,:repo_mirror_last_sync
+		,:repo_mirror_sync_status
+		,:repo_mirror_last_error
+		,:repo_mirror_created_by
+		,:repo_mirror_created
+		,:repo_mirror_updated
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo mirror object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert repo mirror")
+	}
+
+	return nil
+}
+
+// Update updates the mirror configuration of a repository.
+func (s *RepoMirrorStore) Update(ctx context.Context, mirror *types.RepoMirror) error {
+	const sqlQuery = `
+	UPDATE repo_mirrors
+	SET
+		 repo_mirror_version = :repo_mirror_version
+		,repo_mirror_source_url = :repo_mirror_source_url
+		,repo_mirror_connector = :repo_mirror_connector
+		,repo_mirror_interval = :repo_mirror_interval
+		,repo_mirror_next_sync = :repo_mirror_next_sync
+		,repo_mirror_last_sync = :repo_mirror_last_sync
+		,repo_mirror_sync_status = :repo_mirror_sync_status
+		,repo_mirror_last_error = :repo_mirror_last_error
+		,repo_mirror_updated = :repo_mirror_updated
+	WHERE repo_mirror_repo_id = :repo_mirror_repo_id AND repo_mirror_version = :repo_mirror_version - 1`
+
+	dbMirror := mapToInternalRepoMirror(mirror)
+	dbMirror.Version++
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update repo mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	mirror.Version = dbMirror.Version
+
+	return nil
+}
+
+// UpdateOptLock updates the mirror configuration of a repository using the optimistic locking mechanism.
+func (s *RepoMirrorStore) UpdateOptLock(
+	ctx context.Context,
+	mirror *types.RepoMirror,
+	mutateFn func(mirror *types.RepoMirror) error,
+) (*types.RepoMirror, error) {
+	for {
+		dup := *mirror
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		mirror, err = s.Find(ctx, mirror.RepoID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes the mirror configuration of a repository.
+func (s *RepoMirrorStore) Delete(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+	DELETE FROM repo_mirrors
+	WHERE repo_mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, repoID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete repo mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListDue returns the mirrors of active repositories that are due for a scheduled synchronization.
+func (s *RepoMirrorStore) ListDue(ctx context.Context, now int64) ([]*types.RepoMirror, error) {
+	stmt := database.Builder.
+		Select(repoMirrorColumns).
+		From("repo_mirrors").
+		InnerJoin("repositories ON repo_id = repo_mirror_repo_id").
+		Where("repo_deleted IS NULL").
+		Where("repo_mirror_interval > 0").
+		Where("repo_mirror_next_sync <= ?", now).
+		OrderBy("repo_mirror_next_sync ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repoMirror{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list due repo mirrors query")
+	}
+
+	result := make([]*types.RepoMirror, len(dst))
+	for i, m := range dst {
+		result[i] = mapToRepoMirror(m)
+	}
+
+	return result, nil
+}
+
+func mapToRepoMirror(m *repoMirror) *types.RepoMirror {
+	return &types.RepoMirror{
+		RepoID:     m.RepoID,
+		Version:    m.Version,
+		SourceURL:  m.SourceURL,
+		Connector:  m.Connector,
+		Interval:   m.Interval,
+		NextSync:   m.NextSync,
+		LastSync:   m.LastSync,
+		SyncStatus: m.SyncStatus,
+		LastError:  m.LastError,
+		CreatedBy:  m.CreatedBy,
+		Created:    m.Created,
+		Updated:    m.Updated,
+	}
+}
+
+func mapToInternalRepoMirror(m *types.RepoMirror) *repoMirror {
+	return &repoMirror{
+		RepoID:     m.RepoID,
+		Version:    m.Version,
+		SourceURL:  m.SourceURL,
+		Connector:  m.Connector,
+		Interval:   m.Interval,
+		NextSync:   m.NextSync,
+		LastSync:   m.LastSync,
+		SyncStatus: m.SyncStatus,
+		LastError:  m.LastError,
+		CreatedBy:  m.CreatedBy,
+		Created:    m.Created,
+		Updated:    m.Updated,
+	}
+}
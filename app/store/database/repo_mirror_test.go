@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_RepoMirrors(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	mirrorStore := database.NewRepoMirrorStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepos(ctx, t, repoStore, 1, 4, 1)
+
+	mirrors := []*types.RepoMirror{
+		{RepoID: 1, SourceURL: "https://example.com/due.git", Interval: 60, NextSync: 100},
+		{RepoID: 2, SourceURL: "https://example.com/later.git", Interval: 60, NextSync: 300},
+		{RepoID: 3, SourceURL: "https://example.com/manual.git", NextSync: 100},
+		{RepoID: 4, SourceURL: "https://example.com/deleted.git", Interval: 60, NextSync: 100},
+	}
+	for _, mirror := range mirrors {
+		mirror.SyncStatus = enum.RepoMirrorSyncStatusPending
+		mirror.CreatedBy = userID
+		if err := mirrorStore.Create(ctx, mirror); err != nil {
+			t.Fatalf("failed to create repo mirror %q: %v", mirror.SourceURL, err)
+		}
+	}
+
+	deletedRepo, err := repoStore.Find(ctx, 4)
+	if err != nil {
+		t.Fatalf("failed to find repo: %v", err)
+	}
+	if err = repoStore.SoftDelete(ctx, deletedRepo, 1); err != nil {
+		t.Fatalf("failed to soft delete repo: %v", err)
+	}
+
+	due, err := mirrorStore.ListDue(ctx, 200)
+	if err != nil {
+		t.Fatalf("ListDue() error = %v", err)
+	}
+	if len(due) != 1 || due[0].RepoID != 1 {
+		t.Fatalf("ListDue() = %+v, want only the mirror of repo 1", due)
+	}
+
+	// claiming a mirror with a stale copy must fail so that a sync is scheduled only once.
+	first := *due[0]
+	first.NextSync = 400
+	if err = mirrorStore.Update(ctx, &first); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	second := *due[0]
+	second.NextSync = 400
+	if err = mirrorStore.Update(ctx, &second); !errors.Is(err, store.ErrVersionConflict) {
+		t.Errorf("Update() error = %v, want %v", err, store.ErrVersionConflict)
+	}
+
+	updated, err := mirrorStore.UpdateOptLock(ctx, due[0], func(mirror *types.RepoMirror) error {
+		mirror.SyncStatus = enum.RepoMirrorSyncStatusFailed
+		mirror.LastError = "failed"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateOptLock() error = %v", err)
+	}
+	if updated.NextSync != 400 || updated.SyncStatus != enum.RepoMirrorSyncStatusFailed {
+		t.Errorf("UpdateOptLock() = %+v, want next sync 400 and failed status", updated)
+	}
+
+	if err = mirrorStore.Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err = mirrorStore.Find(ctx, 1); !errors.Is(err, store.ErrResourceNotFound) {
+		t.Errorf("Find() error = %v, want %v", err, store.ErrResourceNotFound)
+	}
+}
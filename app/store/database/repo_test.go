@@ -177,6 +177,60 @@ func TestDatabase_ListAll(t *testing.T) {
 	}
 }
 
+func TestDatabase_ListForks(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	upstreamID := int64(1)
+	createRepo(ctx, t, repoStore, upstreamID, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 1, 0)
+
+	const numForks = 3
+	for i := int64(0); i < numForks; i++ {
+		identifier := "fork_" + strconv.FormatInt(i, 10)
+		fork := types.Repository{Identifier: identifier, ParentID: 1, GitUID: identifier, ForkID: upstreamID}
+		if err := repoStore.Create(ctx, &fork); err != nil {
+			t.Fatalf("failed to create fork %v", err)
+		}
+	}
+
+	count, err := repoStore.CountForks(ctx, upstreamID, &types.RepoFilter{})
+	if err != nil {
+		t.Fatalf("failed to count forks %v", err)
+	}
+	if count != numForks {
+		t.Errorf("count = %v, want %v", count, numForks)
+	}
+
+	forks, err := repoStore.ListForks(ctx, upstreamID, &types.RepoFilter{})
+	if err != nil {
+		t.Fatalf("failed to list forks %v", err)
+	}
+	if len(forks) != numForks {
+		t.Errorf("len(forks) = %v, want %v", len(forks), numForks)
+	}
+	for _, fork := range forks {
+		if fork.ForkID != upstreamID {
+			t.Errorf("fork.ForkID = %v, want %v", fork.ForkID, upstreamID)
+		}
+	}
+
+	count, err = repoStore.CountForks(ctx, 2, &types.RepoFilter{})
+	if err != nil {
+		t.Fatalf("failed to count forks %v", err)
+	}
+	if count != 0 {
+		t.Errorf("count = %v, want %v", count, 0)
+	}
+}
+
 func createRepo(
 	ctx context.Context,
 	t *testing.T,
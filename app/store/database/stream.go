@@ -0,0 +1,480 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/stream"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ stream.Store = (*StreamStore)(nil)
+
+// NewStreamStore returns a new StreamStore.
+func NewStreamStore(db *sqlx.DB) *StreamStore {
+	return &StreamStore{
+		db: db,
+		tx: dbtx.New(db),
+	}
+}
+
+// StreamStore implements the persistence of the SQL event streams backed by a postgres or sqlite database.
+type StreamStore struct {
+	db *sqlx.DB
+	tx dbtx.Transactor
+}
+
+const (
+	streamMessageColumns = `
+		 stream_message_id
+		,stream_message_payload
+		,stream_message_created`
+
+	streamPendingColumns = `
+		 stream_pending_message_id
+		,stream_pending_consumer
+		,stream_pending_delivered
+		,stream_pending_delivery_count`
+)
+
+type streamMessage struct {
+	ID      int64  `db:"stream_message_id"`
+	Payload []byte `db:"stream_message_payload"`
+	Created int64  `db:"stream_message_created"`
+}
+
+type streamPending struct {
+	MessageID     int64  `db:"stream_pending_message_id"`
+	Consumer      string `db:"stream_pending_consumer"`
+	Delivered     int64  `db:"stream_pending_delivered"`
+	DeliveryCount int    `db:"stream_pending_delivery_count"`
+}
+
+// streamAdvisoryLockClass namespaces the postgres advisory locks taken by the stream store.
+const streamAdvisoryLockClass = 0x5354524d
+
+// Add appends a new message with the provided payload to the stream and returns the message id.
+//
+// Message ids of a stream are committed in increasing order: consumer groups only remember
+// the last delivered id, so a message committed after a message with a bigger id would never be read.
+// On postgres the producers of a stream are serialized with a transaction-scoped advisory lock,
+// sqlite only allows a single writer anyway.
+func (s *StreamStore) Add(ctx context.Context, streamID string, payload []byte) (int64, error) {
+	const sqlLock = `SELECT pg_advisory_xact_lock($1, hashtext($2))`
+
+	const sqlQuery = `
+		INSERT INTO stream_messages (
+			 stream_message_stream_id
+			,stream_message_payload
+			,stream_message_created
+		) VALUES ($1, $2, $3)
+		RETURNING stream_message_id`
+
+	var id int64
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		db := dbtx.GetAccessor(ctx, s.db)
+
+		if !strings.HasPrefix(s.db.DriverName(), "sqlite") {
+			if _, err := db.ExecContext(ctx, sqlLock, streamAdvisoryLockClass, streamID); err != nil {
+				return database.ProcessSQLErrorf(ctx, err, "Failed to lock stream for insert")
+			}
+		}
+
+		err := db.QueryRowContext(ctx, sqlQuery, streamID, payload, time.Now().UnixMilli()).Scan(&id)
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to insert stream message")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Trim removes the oldest messages of the stream (and their pending entries),
+// keeping at most maxLength messages in the stream.
+func (s *StreamStore) Trim(ctx context.Context, streamID string, maxLength int64) (int64, error) {
+	const sqlQueryCutoff = `
+		SELECT stream_message_id
+		FROM stream_messages
+		WHERE stream_message_stream_id = $1
+		ORDER BY stream_message_id DESC
+		LIMIT 1 OFFSET $2`
+
+	const sqlDeletePending = `
+		DELETE FROM stream_pending
+		WHERE stream_pending_stream_id = $1 AND stream_pending_message_id < $2`
+
+	const sqlDeleteMessages = `
+		DELETE FROM stream_messages
+		WHERE stream_message_stream_id = $1 AND stream_message_id < $2`
+
+	var n int64
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		db := dbtx.GetAccessor(ctx, s.db)
+
+		// the oldest message that is kept in the stream
+		var cutoffID int64
+		err := db.QueryRowContext(ctx, sqlQueryCutoff, streamID, maxLength-1).Scan(&cutoffID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to find oldest stream message to keep")
+		}
+
+		if _, err = db.ExecContext(ctx, sqlDeletePending, streamID, cutoffID); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to delete pending entries of trimmed messages")
+		}
+
+		result, err := db.ExecContext(ctx, sqlDeleteMessages, streamID, cutoffID)
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to trim stream messages")
+		}
+
+		n, err = result.RowsAffected()
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to get number of trimmed stream messages")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// CreateGroup creates a consumer group for the stream in case it doesn't exist yet.
+// A new group only receives messages that are added to the stream after its creation.
+func (s *StreamStore) CreateGroup(ctx context.Context, streamID string, groupName string) error {
+	const sqlQuery = `
+		INSERT INTO stream_groups (
+			 stream_group_stream_id
+			,stream_group_name
+			,stream_group_last_id
+			,stream_group_created
+		)
+		SELECT $1, $2, COALESCE(MAX(stream_message_id), 0), $3
+		FROM stream_messages
+		WHERE stream_message_stream_id = $1
+		ON CONFLICT (stream_group_stream_id, stream_group_name) DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, streamID, groupName, time.Now().UnixMilli()); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to create stream consumer group")
+	}
+
+	return nil
+}
+
+// ReadGroup reads up to count messages of the stream that haven't been delivered to the group yet.
+// The returned messages are marked as pending for the consumer until they are acknowledged.
+func (s *StreamStore) ReadGroup(
+	ctx context.Context,
+	streamID string,
+	groupName string,
+	consumerName string,
+	count int,
+) ([]*stream.StoreMessage, error) {
+	const sqlQueryLastID = `
+		SELECT stream_group_last_id
+		FROM stream_groups
+		WHERE stream_group_stream_id = $1 AND stream_group_name = $2`
+
+	const sqlQueryMessages = `
+		SELECT` + streamMessageColumns + `
+		FROM stream_messages
+		WHERE stream_message_stream_id = $1 AND stream_message_id > $2
+		ORDER BY stream_message_id ASC
+		LIMIT $3`
+
+	// NOTE: the update is conditional to detect concurrent reads of the same group.
+	const sqlUpdateLastID = `
+		UPDATE stream_groups
+		SET stream_group_last_id = $1
+		WHERE stream_group_stream_id = $2 AND stream_group_name = $3 AND stream_group_last_id = $4`
+
+	var messages []*streamMessage
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		db := dbtx.GetAccessor(ctx, s.db)
+
+		var lastID int64
+		err := db.QueryRowContext(ctx, sqlQueryLastID, streamID, groupName).Scan(&lastID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return stream.ErrGroupNotFound
+		}
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to find stream consumer group")
+		}
+
+		dst := []*streamMessage{}
+		if err = db.SelectContext(ctx, &dst, sqlQueryMessages, streamID, lastID, count); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to read stream messages")
+		}
+
+		if len(dst) == 0 {
+			return nil
+		}
+
+		result, err := db.ExecContext(ctx, sqlUpdateLastID, dst[len(dst)-1].ID, streamID, groupName, lastID)
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to update last id of stream consumer group")
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated stream consumer groups")
+		}
+
+		// another consumer of the group read the messages concurrently - nothing to deliver.
+		if n == 0 {
+			return nil
+		}
+
+		if err = s.insertPending(ctx, streamID, groupName, consumerName, dst); err != nil {
+			return err
+		}
+
+		messages = dst
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapStreamMessages(messages), nil
+}
+
+func (s *StreamStore) insertPending(
+	ctx context.Context,
+	streamID string,
+	groupName string,
+	consumerName string,
+	messages []*streamMessage,
+) error {
+	now := time.Now().UnixMilli()
+
+	stmt := database.Builder.
+		Insert("stream_pending").
+		Columns(
+			"stream_pending_stream_id",
+			"stream_pending_group_name",
+			"stream_pending_message_id",
+			"stream_pending_consumer",
+			"stream_pending_delivered",
+			"stream_pending_delivery_count",
+		)
+
+	for _, m := range messages {
+		stmt = stmt.Values(streamID, groupName, m.ID, consumerName, now, 1)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert insert pending stream messages query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert pending stream messages")
+	}
+
+	return nil
+}
+
+// ListPending lists up to count pending messages of the consumer with an id bigger than afterID.
+func (s *StreamStore) ListPending(
+	ctx context.Context,
+	streamID string,
+	groupName string,
+	consumerName string,
+	afterID int64,
+	count int,
+) ([]*stream.StoreMessage, error) {
+	const sqlQuery = `
+		SELECT` + streamMessageColumns + `
+		FROM stream_pending
+		INNER JOIN stream_messages ON stream_message_id = stream_pending_message_id
+		WHERE stream_pending_stream_id = $1
+			AND stream_pending_group_name = $2
+			AND stream_pending_consumer = $3
+			AND stream_pending_message_id > $4
+		ORDER BY stream_pending_message_id ASC
+		LIMIT $5`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*streamMessage{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, streamID, groupName, consumerName, afterID, count); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pending stream messages")
+	}
+
+	return mapStreamMessages(dst), nil
+}
+
+// ListIdle lists up to count pending messages of the group that weren't delivered for at least minIdle.
+func (s *StreamStore) ListIdle(
+	ctx context.Context,
+	streamID string,
+	groupName string,
+	minIdle time.Duration,
+	count int,
+) ([]*stream.StorePendingMessage, error) {
+	const sqlQuery = `
+		SELECT` + streamPendingColumns + `
+		FROM stream_pending
+		WHERE stream_pending_stream_id = $1
+			AND stream_pending_group_name = $2
+			AND stream_pending_delivered <= $3
+		ORDER BY stream_pending_message_id ASC
+		LIMIT $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	idleBefore := time.Now().Add(-minIdle).UnixMilli()
+
+	dst := []*streamPending{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, streamID, groupName, idleBefore, count); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list idle stream messages")
+	}
+
+	result := make([]*stream.StorePendingMessage, len(dst))
+	for i, p := range dst {
+		result[i] = &stream.StorePendingMessage{
+			ID:            p.MessageID,
+			Consumer:      p.Consumer,
+			Delivered:     p.Delivered,
+			DeliveryCount: p.DeliveryCount,
+		}
+	}
+
+	return result, nil
+}
+
+// Claim transfers a pending message that wasn't delivered for at least minIdle to the consumer.
+func (s *StreamStore) Claim(
+	ctx context.Context,
+	streamID string,
+	groupName string,
+	consumerName string,
+	messageID int64,
+	minIdle time.Duration,
+) (*stream.StoreMessage, error) {
+	const sqlUpdate = `
+		UPDATE stream_pending
+		SET
+			 stream_pending_consumer = $1
+			,stream_pending_delivered = $2
+			,stream_pending_delivery_count = stream_pending_delivery_count + 1
+		WHERE stream_pending_stream_id = $3
+			AND stream_pending_group_name = $4
+			AND stream_pending_message_id = $5
+			AND stream_pending_delivered <= $6`
+
+	const sqlQuery = `
+		SELECT` + streamMessageColumns + `
+		FROM stream_messages
+		WHERE stream_message_id = $1`
+
+	now := time.Now()
+	idleBefore := now.Add(-minIdle).UnixMilli()
+
+	var message *streamMessage
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		db := dbtx.GetAccessor(ctx, s.db)
+
+		result, err := db.ExecContext(ctx, sqlUpdate,
+			consumerName, now.UnixMilli(), streamID, groupName, messageID, idleBefore)
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to claim pending stream message")
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to get number of claimed stream messages")
+		}
+
+		if n == 0 {
+			return stream.ErrMessageNotFound
+		}
+
+		message = &streamMessage{}
+		err = db.GetContext(ctx, message, sqlQuery, messageID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return stream.ErrMessageNotFound
+		}
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to find claimed stream message")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapStreamMessage(message), nil
+}
+
+// Ack acknowledges the message for the group, removing it from the pending messages.
+func (s *StreamStore) Ack(ctx context.Context, streamID string, groupName string, messageID int64) error {
+	const sqlQuery = `
+		DELETE FROM stream_pending
+		WHERE stream_pending_stream_id = $1
+			AND stream_pending_group_name = $2
+			AND stream_pending_message_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, streamID, groupName, messageID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to acknowledge stream message")
+	}
+
+	return nil
+}
+
+func mapStreamMessage(m *streamMessage) *stream.StoreMessage {
+	return &stream.StoreMessage{
+		ID:      m.ID,
+		Payload: m.Payload,
+		Created: m.Created,
+	}
+}
+
+func mapStreamMessages(messages []*streamMessage) []*stream.StoreMessage {
+	result := make([]*stream.StoreMessage, len(messages))
+	for i, m := range messages {
+		result[i] = mapStreamMessage(m)
+	}
+
+	return result
+}
@@ -0,0 +1,287 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/stream"
+)
+
+func TestDatabase_StreamStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	streamStore := database.NewStreamStore(db)
+
+	ctx := context.Background()
+
+	const (
+		streamID = "test:stream"
+		group    = "group"
+	)
+
+	if _, err := streamStore.ReadGroup(ctx, streamID, group, "c1", 10); !errors.Is(err, stream.ErrGroupNotFound) {
+		t.Fatalf("expected group not found error, got: %v", err)
+	}
+
+	// messages sent before the group was created are not delivered to the group
+	if _, err := streamStore.Add(ctx, streamID, []byte("old")); err != nil {
+		t.Fatalf("failed to add message: %v", err)
+	}
+
+	if err := streamStore.CreateGroup(ctx, streamID, group); err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	// creating the group again is a no-op
+	if err := streamStore.CreateGroup(ctx, streamID, group); err != nil {
+		t.Fatalf("failed to create group again: %v", err)
+	}
+
+	ids := make([]int64, 3)
+	for i := range ids {
+		id, err := streamStore.Add(ctx, streamID, []byte{byte(i)})
+		if err != nil {
+			t.Fatalf("failed to add message: %v", err)
+		}
+		ids[i] = id
+	}
+
+	messages, err := streamStore.ReadGroup(ctx, streamID, group, "c1", 2)
+	if err != nil {
+		t.Fatalf("failed to read group: %v", err)
+	}
+	if len(messages) != 2 || messages[0].ID != ids[0] || messages[1].ID != ids[1] {
+		t.Fatalf("expected first two messages, got %+v", messages)
+	}
+	if messages[0].Created == 0 {
+		t.Errorf("expected message creation time to be set")
+	}
+
+	messages, err = streamStore.ReadGroup(ctx, streamID, group, "c2", 10)
+	if err != nil {
+		t.Fatalf("failed to read group: %v", err)
+	}
+	if len(messages) != 1 || messages[0].ID != ids[2] || messages[0].Payload[0] != 2 {
+		t.Fatalf("expected last message, got %+v", messages)
+	}
+
+	pending, err := streamStore.ListPending(ctx, streamID, group, "c1", 0, 10)
+	if err != nil {
+		t.Fatalf("failed to list pending messages: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending messages for c1, got %d", len(pending))
+	}
+
+	if err = streamStore.Ack(ctx, streamID, group, ids[0]); err != nil {
+		t.Fatalf("failed to ack message: %v", err)
+	}
+
+	idle, err := streamStore.ListIdle(ctx, streamID, group, time.Hour, 10)
+	if err != nil {
+		t.Fatalf("failed to list idle messages: %v", err)
+	}
+	if len(idle) != 0 {
+		t.Fatalf("expected no idle messages, got %d", len(idle))
+	}
+
+	idle, err = streamStore.ListIdle(ctx, streamID, group, 0, 10)
+	if err != nil {
+		t.Fatalf("failed to list idle messages: %v", err)
+	}
+	if len(idle) != 2 || idle[0].ID != ids[1] || idle[0].Consumer != "c1" || idle[0].DeliveryCount != 1 {
+		t.Fatalf("unexpected idle messages %+v", idle)
+	}
+
+	if _, err = streamStore.Claim(ctx, streamID, group, "c2", ids[1], time.Hour); !errors.Is(err, stream.ErrMessageNotFound) {
+		t.Fatalf("expected message not found error for claim of recent message, got: %v", err)
+	}
+
+	claimed, err := streamStore.Claim(ctx, streamID, group, "c2", ids[1], 0)
+	if err != nil {
+		t.Fatalf("failed to claim message: %v", err)
+	}
+	if claimed.ID != ids[1] {
+		t.Fatalf("expected claimed message %d, got %d", ids[1], claimed.ID)
+	}
+
+	idle, err = streamStore.ListIdle(ctx, streamID, group, 0, 10)
+	if err != nil {
+		t.Fatalf("failed to list idle messages: %v", err)
+	}
+	if len(idle) != 2 || idle[0].Consumer != "c2" || idle[0].DeliveryCount != 2 {
+		t.Fatalf("unexpected idle messages after claim %+v", idle)
+	}
+
+	// trimming removes the oldest messages together with their pending entries
+	n, err := streamStore.Trim(ctx, streamID, 1)
+	if err != nil {
+		t.Fatalf("failed to trim stream: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 trimmed messages, got %d", n)
+	}
+
+	idle, err = streamStore.ListIdle(ctx, streamID, group, 0, 10)
+	if err != nil {
+		t.Fatalf("failed to list idle messages: %v", err)
+	}
+	if len(idle) != 1 || idle[0].ID != ids[2] {
+		t.Fatalf("expected only last message to be pending after trim, got %+v", idle)
+	}
+}
+
+func TestDatabase_StreamStoreConcurrentProducers(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	streamStore := database.NewStreamStore(db)
+
+	ctx := context.Background()
+
+	const (
+		streamID  = "test:stream"
+		group     = "group"
+		producers = 8
+		perWorker = 25
+	)
+
+	if err := streamStore.CreateGroup(ctx, streamID, group); err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, producers)
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				if _, err := streamStore.Add(ctx, streamID, []byte("payload")); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// read while the producers are still adding messages
+	received := map[int64]int{}
+	var lastID int64
+	read := func() {
+		messages, err := streamStore.ReadGroup(ctx, streamID, group, "consumer", 10)
+		if err != nil {
+			t.Fatalf("failed to read group: %v", err)
+		}
+		for _, m := range messages {
+			if m.ID <= lastID {
+				t.Fatalf("message %d delivered after message %d", m.ID, lastID)
+			}
+			lastID = m.ID
+			received[m.ID]++
+		}
+	}
+
+	for producing := true; producing; {
+		select {
+		case <-done:
+			producing = false
+		default:
+			read()
+		}
+	}
+	close(errs)
+	for err := range errs {
+		t.Fatalf("failed to add message: %v", err)
+	}
+
+	for len(received) < producers*perWorker {
+		n := len(received)
+		read()
+		if len(received) == n {
+			break
+		}
+	}
+
+	if len(received) != producers*perWorker {
+		t.Fatalf("expected %d messages to be delivered, got %d", producers*perWorker, len(received))
+	}
+	for id, n := range received {
+		if n != 1 {
+			t.Errorf("message %d delivered %d times", id, n)
+		}
+	}
+}
+
+func TestDatabase_StreamConsumer(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	streamStore := database.NewStreamStore(db)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	producer := stream.NewSQLProducer(streamStore, "ns", 100, false)
+
+	consumer, err := stream.NewSQLConsumer(streamStore, "ns", "group", "consumer")
+	if err != nil {
+		t.Fatalf("failed to create consumer: %v", err)
+	}
+
+	received := make(chan map[string]interface{}, 1)
+	err = consumer.Register("stream", func(_ context.Context, _ string, payload map[string]interface{}) error {
+		received <- payload
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to register handler: %v", err)
+	}
+
+	if err = consumer.Start(ctx); err != nil {
+		t.Fatalf("failed to start consumer: %v", err)
+	}
+
+	if _, err = producer.Send(ctx, "stream", map[string]interface{}{
+		"bytes":  []byte{0xff, 0x00, 0x01},
+		"string": "value",
+	}); err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+
+	select {
+	case payload := <-received:
+		if payload["bytes"] != string([]byte{0xff, 0x00, 0x01}) {
+			t.Errorf("unexpected bytes value %q", payload["bytes"])
+		}
+		if payload["string"] != "value" {
+			t.Errorf("unexpected string value %q", payload["string"])
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("message wasn't consumed")
+	}
+}
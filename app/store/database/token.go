@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
 )
 
 var _ store.TokenStore = (*TokenStore)(nil)
@@ -42,23 +44,31 @@ type TokenStore struct {
 	db *sqlx.DB
 }
 
+// token is used to store the token scope in JSON encoded columns.
+type token struct {
+	types.Token
+	PermissionsJSON sqlxtypes.JSONText `db:"token_permissions"`
+	SpaceIDsJSON    sqlxtypes.JSONText `db:"token_space_ids"`
+	RepoIDsJSON     sqlxtypes.JSONText `db:"token_repo_ids"`
+}
+
 // Find finds the token by id.
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token")
 	}
 
-	return dst, nil
+	return mapToken(dst)
 }
 
 // FindByIdentifier finds the token by principalId and token identifier.
 func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(
 		ctx,
 		dst,
@@ -69,14 +79,14 @@ func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, id
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token by identifier")
 	}
 
-	return dst, nil
+	return mapToken(dst)
 }
 
 // Create saves the token details.
 func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(tokenInsert, token)
+	query, arg, err := db.BindNamed(tokenInsert, mapInternalToken(token))
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind token object")
 	}
@@ -88,6 +98,17 @@ func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 	return nil
 }
 
+// UpdateLastUsed updates the time and the client IP address of the last use of the token.
+func (s *TokenStore) UpdateLastUsed(ctx context.Context, id int64, lastUsed int64, lastUsedIP string) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, tokenUpdateLastUsed, lastUsed, lastUsedIP, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update token last used")
+	}
+
+	return nil
+}
+
 // Delete deletes the token with the given id.
 func (s *TokenStore) Delete(ctx context.Context, id int64) error {
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -153,7 +174,7 @@ func (s *TokenStore) List(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) ([]*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Token{}
+	dst := []*token{}
 
 	// TODO: custom filters / sorting for tokens.
 
@@ -161,7 +182,59 @@ func (s *TokenStore) List(ctx context.Context,
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing token list query")
 	}
-	return dst, nil
+
+	result := make([]*types.Token, len(dst))
+	for i := range dst {
+		if result[i], err = mapToken(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func mapInternalToken(t *types.Token) *token {
+	return &token{
+		Token:           *t,
+		PermissionsJSON: EncodeToSQLXJSON(nonNilSlice(t.Permissions)),
+		SpaceIDsJSON:    EncodeToSQLXJSON(nonNilSlice(t.SpaceIDs)),
+		RepoIDsJSON:     EncodeToSQLXJSON(nonNilSlice(t.RepoIDs)),
+	}
+}
+
+func mapToken(t *token) (*types.Token, error) {
+	result := t.Token
+
+	if err := json.Unmarshal(t.PermissionsJSON, &result.Permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token permissions: %w", err)
+	}
+
+	if err := json.Unmarshal(t.SpaceIDsJSON, &result.SpaceIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token space IDs: %w", err)
+	}
+
+	if err := json.Unmarshal(t.RepoIDsJSON, &result.RepoIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token repository IDs: %w", err)
+	}
+
+	if len(result.Permissions) == 0 {
+		result.Permissions = nil
+	}
+	if len(result.SpaceIDs) == 0 {
+		result.SpaceIDs = nil
+	}
+	if len(result.RepoIDs) == 0 {
+		result.RepoIDs = nil
+	}
+
+	return &result, nil
+}
+
+func nonNilSlice[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
 }
 
 const tokenSelectBase = `
@@ -173,6 +246,11 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_permissions
+,token_space_ids
+,token_repo_ids
+,token_last_used
+,token_last_used_ip
 FROM tokens
 ` //#nosec G101
 
@@ -195,6 +273,14 @@ const TokenSelectByPrincipalIDAndIdentifier = tokenSelectBase + `
 WHERE token_principal_id = $1 AND LOWER(token_uid) = $2
 `
 
+const tokenUpdateLastUsed = `
+UPDATE tokens
+SET
+	token_last_used = $1
+	,token_last_used_ip = $2
+WHERE token_id = $3
+`
+
 const tokenDelete = `
 DELETE FROM tokens
 WHERE token_id = $1
@@ -208,6 +294,9 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_permissions
+	,token_space_ids
+	,token_repo_ids
 ) values (
 	:token_type
 	,:token_uid
@@ -215,5 +304,8 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_permissions
+	,:token_space_ids
+	,:token_repo_ids
 ) RETURNING token_id
 `
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_TokenScope(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+	tokenStore := database.NewTokenStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	scoped := &types.Token{
+		PrincipalID: userID,
+		Type:        enum.TokenTypePAT,
+		Identifier:  "scoped",
+		IssuedAt:    1,
+		CreatedBy:   userID,
+		TokenScope: types.TokenScope{
+			Permissions: []enum.Permission{enum.PermissionRepoView},
+			RepoIDs:     []int64{3, 4},
+		},
+	}
+	if err := tokenStore.Create(ctx, scoped); err != nil {
+		t.Fatalf("failed to create scoped token: %v", err)
+	}
+
+	unscoped := &types.Token{
+		PrincipalID: userID,
+		Type:        enum.TokenTypePAT,
+		Identifier:  "unscoped",
+		IssuedAt:    2,
+		CreatedBy:   userID,
+	}
+	if err := tokenStore.Create(ctx, unscoped); err != nil {
+		t.Fatalf("failed to create unscoped token: %v", err)
+	}
+
+	found, err := tokenStore.Find(ctx, scoped.ID)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if !reflect.DeepEqual(found.TokenScope, scoped.TokenScope) {
+		t.Errorf("Find() scope = %+v, want %+v", found.TokenScope, scoped.TokenScope)
+	}
+	if found.LastUsed != nil || found.LastUsedIP != "" {
+		t.Errorf("Find() last used = %v %q, want empty", found.LastUsed, found.LastUsedIP)
+	}
+
+	if err = tokenStore.UpdateLastUsed(ctx, scoped.ID, 1234, "10.0.0.1"); err != nil {
+		t.Fatalf("UpdateLastUsed() error = %v", err)
+	}
+
+	list, err := tokenStore.List(ctx, userID, enum.TokenTypePAT)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("List() returned %d tokens, want 2", len(list))
+	}
+
+	// tokens are sorted by issued time, newest first
+	if list[0].IsRestricted() {
+		t.Errorf("List()[0] scope = %+v, want unrestricted", list[0].TokenScope)
+	}
+	if list[1].LastUsed == nil || *list[1].LastUsed != 1234 || list[1].LastUsedIP != "10.0.0.1" {
+		t.Errorf("List()[1] last used = %v %q, want 1234 10.0.0.1", list[1].LastUsed, list[1].LastUsedIP)
+	}
+	if !list[1].HasPermission(enum.PermissionRepoView) || list[1].HasPermission(enum.PermissionRepoPush) {
+		t.Errorf("List()[1] permissions = %v, want only repo view", list[1].Permissions)
+	}
+}
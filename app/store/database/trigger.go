@@ -39,7 +39,7 @@ type trigger struct {
 	ID          int64              `db:"trigger_id"`
 	Identifier  string             `db:"trigger_uid"`
 	Description string             `db:"trigger_description"`
-	Type        string             `db:"trigger_type"`
+	Type        enum.TriggerType   `db:"trigger_type"`
 	Secret      string             `db:"trigger_secret"`
 	PipelineID  int64              `db:"trigger_pipeline_id"`
 	RepoID      int64              `db:"trigger_repo_id"`
@@ -49,6 +49,11 @@ type trigger struct {
 	Created     int64              `db:"trigger_created"`
 	Updated     int64              `db:"trigger_updated"`
 	Version     int64              `db:"trigger_version"`
+
+	CronExpression string `db:"trigger_cron_expression"`
+	CronTimezone   string `db:"trigger_cron_timezone"`
+	CronBranch     string `db:"trigger_cron_branch"`
+	CronNext       int64  `db:"trigger_cron_next"`
 }
 
 func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
@@ -72,6 +77,11 @@ func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
 		Created:     trigger.Created,
 		Updated:     trigger.Updated,
 		Version:     trigger.Version,
+
+		CronExpression: trigger.CronExpression,
+		CronTimezone:   trigger.CronTimezone,
+		CronBranch:     trigger.CronBranch,
+		CronNext:       trigger.CronNext,
 	}, nil
 }
 
@@ -102,6 +112,11 @@ func mapTriggerToInternal(t *types.Trigger) *trigger {
 		Created:     t.Created,
 		Updated:     t.Updated,
 		Version:     t.Version,
+
+		CronExpression: t.CronExpression,
+		CronTimezone:   t.CronTimezone,
+		CronBranch:     t.CronBranch,
+		CronNext:       t.CronNext,
 	}
 }
 
@@ -120,13 +135,20 @@ const (
 	triggerColumns = `
 		trigger_id
 		,trigger_uid
+		,trigger_type
 		,trigger_disabled
 		,trigger_actions
 		,trigger_description
 		,trigger_pipeline_id
+		,trigger_repo_id
+		,trigger_created_by
 		,trigger_created
 		,trigger_updated
 		,trigger_version
+		,trigger_cron_expression
+		,trigger_cron_timezone
+		,trigger_cron_branch
+		,trigger_cron_next
 	`
 )
 
@@ -165,6 +187,10 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,trigger_created
 		,trigger_updated
 		,trigger_version
+		,trigger_cron_expression
+		,trigger_cron_timezone
+		,trigger_cron_branch
+		,trigger_cron_next
 	) VALUES (
 		:trigger_uid
 		,:trigger_description
@@ -178,6 +204,10 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,:trigger_created
 		,:trigger_updated
 		,:trigger_version
+		,:trigger_cron_expression
+		,:trigger_cron_timezone
+		,:trigger_cron_branch
+		,:trigger_cron_next
 	) RETURNING trigger_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -191,6 +221,8 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		return database.ProcessSQLErrorf(ctx, err, "Trigger query failed")
 	}
 
+	t.ID = trigger.ID
+
 	return nil
 }
 
@@ -205,6 +237,10 @@ func (s *triggerStore) Update(ctx context.Context, t *types.Trigger) error {
 		,trigger_updated = :trigger_updated
 		,trigger_actions = :trigger_actions
 		,trigger_version = :trigger_version
+		,trigger_cron_expression = :trigger_cron_expression
+		,trigger_cron_timezone = :trigger_cron_timezone
+		,trigger_cron_branch = :trigger_cron_branch
+		,trigger_cron_next = :trigger_cron_next
 	WHERE trigger_id = :trigger_id AND trigger_version = :trigger_version - 1`
 	updatedAt := time.Now()
 	trigger := mapTriggerToInternal(t)
@@ -323,6 +359,32 @@ func (s *triggerStore) ListAllEnabled(
 	return mapInternalToTriggerList(dst)
 }
 
+// ListDueCron lists all enabled cron triggers which are due to fire at the provided time.
+func (s *triggerStore) ListDueCron(ctx context.Context, now int64) ([]*types.Trigger, error) {
+	stmt := database.Builder.
+		Select(triggerColumns).
+		From("triggers").
+		Where("trigger_type = ?", enum.TriggerTypeCron).
+		Where("trigger_disabled = false").
+		Where("trigger_cron_next > 0").
+		Where("trigger_cron_next <= ?", now).
+		OrderBy("trigger_cron_next ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*trigger{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list due cron triggers query")
+	}
+
+	return mapInternalToTriggerList(dst)
+}
+
 // Count of triggers under a given pipeline.
 func (s *triggerStore) Count(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) (int64, error) {
 	stmt := database.Builder.
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_ListDueCron(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pipelineStore := database.NewPipelineStore(db)
+	triggerStore := database.NewTriggerStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pipeline := &types.Pipeline{Identifier: "pipeline", RepoID: 1, CreatedBy: userID, ConfigPath: ".harness/ci.yaml"}
+	if err := pipelineStore.Create(ctx, pipeline); err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+
+	triggers := []*types.Trigger{
+		{Identifier: "due", Type: enum.TriggerTypeCron, CronExpression: "0 * * * *", CronNext: 100},
+		{Identifier: "later", Type: enum.TriggerTypeCron, CronExpression: "0 * * * *", CronNext: 300},
+		{Identifier: "disabled", Type: enum.TriggerTypeCron, CronExpression: "0 * * * *", CronNext: 100, Disabled: true},
+		{Identifier: "unscheduled", Type: enum.TriggerTypeCron, CronExpression: "0 * * * *"},
+		{Identifier: "hook", Type: enum.TriggerTypeHook, Actions: []enum.TriggerAction{enum.TriggerActionBranchUpdated}},
+	}
+	for _, trigger := range triggers {
+		trigger.PipelineID = pipeline.ID
+		trigger.RepoID = 1
+		trigger.CreatedBy = userID
+		if err := triggerStore.Create(ctx, trigger); err != nil {
+			t.Fatalf("failed to create trigger %q: %v", trigger.Identifier, err)
+		}
+	}
+
+	due, err := triggerStore.ListDueCron(ctx, 200)
+	if err != nil {
+		t.Fatalf("ListDueCron() error = %v", err)
+	}
+	if len(due) != 1 || due[0].Identifier != "due" {
+		t.Fatalf("ListDueCron() = %+v, want only trigger %q", due, "due")
+	}
+	if due[0].CronExpression != "0 * * * *" || due[0].RepoID != 1 || due[0].Type != enum.TriggerTypeCron {
+		t.Errorf("ListDueCron() returned incomplete trigger: %+v", due[0])
+	}
+
+	// advancing the next run of a stale copy must fail so that a run is fired only once.
+	first := *due[0]
+	first.CronNext = 400
+	if err = triggerStore.Update(ctx, &first); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	second := *due[0]
+	second.CronNext = 400
+	if err = triggerStore.Update(ctx, &second); !errors.Is(err, store.ErrVersionConflict) {
+		t.Errorf("Update() error = %v, want %v", err, store.ErrVersionConflict)
+	}
+
+	due, err = triggerStore.ListDueCron(ctx, 300)
+	if err != nil {
+		t.Fatalf("ListDueCron() error = %v", err)
+	}
+	if len(due) != 1 || due[0].Identifier != "later" {
+		t.Errorf("ListDueCron() = %+v, want only trigger %q", due, "later")
+	}
+}
@@ -16,19 +16,502 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
-	gitnessAppStore "github.com/harness/gitness/app/store"
-	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
 )
 
-var _ gitnessAppStore.UserGroupStore = (*UserGroupStore)(nil)
+var _ store.UserGroupStore = (*UserGroupStore)(nil)
+
+// NewUserGroupStore returns a new UserGroupStore.
+func NewUserGroupStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *UserGroupStore {
+	return &UserGroupStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
 
+// UserGroupStore implements store.UserGroupStore backed by a relational database.
 type UserGroupStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type userGroup struct {
+	ID          int64  `db:"usergroup_id"`
+	SpaceID     int64  `db:"usergroup_space_id"`
+	Identifier  string `db:"usergroup_identifier"`
+	Name        string `db:"usergroup_name"`
+	Description string `db:"usergroup_description"`
+	CreatedBy   int64  `db:"usergroup_created_by"`
+	Created     int64  `db:"usergroup_created"`
+	Updated     int64  `db:"usergroup_updated"`
+}
+
+type userGroupMember struct {
+	UserGroupID int64 `db:"usergroup_member_usergroup_id"`
+	PrincipalID int64 `db:"usergroup_member_principal_id"`
+	CreatedBy   int64 `db:"usergroup_member_created_by"`
+	Created     int64 `db:"usergroup_member_created"`
+}
+
+type userGroupMemberPrincipal struct {
+	userGroupMember
+	principalInfo
+}
+
+const (
+	userGroupColumns = `
+		 usergroup_id
+		,usergroup_space_id
+		,usergroup_identifier
+		,usergroup_name
+		,usergroup_description
+		,usergroup_created_by
+		,usergroup_created
+		,usergroup_updated`
+
+	userGroupSelectBase = `
+	SELECT` + userGroupColumns + `
+	FROM usergroups`
+
+	userGroupMemberColumns = `
+		 usergroup_member_usergroup_id
+		,usergroup_member_principal_id
+		,usergroup_member_created_by
+		,usergroup_member_created`
+)
+
+// Find returns a user group given its ID.
+func (s *UserGroupStore) Find(ctx context.Context, id int64) (*types.UserGroup, error) {
+	const sqlQuery = userGroupSelectBase + `
+	WHERE usergroup_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userGroup{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user group by id")
+	}
+
+	return mapToUserGroup(dst), nil
+}
+
+// FindByIdentifier returns a user group given a space ID and identifier.
+func (s *UserGroupStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.UserGroup, error) {
+	const sqlQuery = userGroupSelectBase + `
+	WHERE usergroup_space_id = $1 AND LOWER(usergroup_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userGroup{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user group by space and identifier")
+	}
+
+	return mapToUserGroup(dst), nil
+}
+
+// Create creates a new user group.
+func (s *UserGroupStore) Create(ctx context.Context, group *types.UserGroup) error {
+	const sqlQuery = `
+	INSERT INTO usergroups (
+		 usergroup_space_id
+		,usergroup_identifier
+		,usergroup_name
+		,usergroup_description
+		,usergroup_created_by
+		,usergroup_created
+		,usergroup_updated
+	) values (
+		 :usergroup_space_id
+		,:usergroup_identifier
+		,:usergroup_name
+		,:usergroup_description
+		,:usergroup_created_by
+		,:usergroup_created
+		,:usergroup_updated
+	) RETURNING usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalUserGroup(group))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user group object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&group.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert user group query failed")
+	}
+
+	return nil
+}
+
+// Update updates the name and the description of a user group.
+func (s *UserGroupStore) Update(ctx context.Context, group *types.UserGroup) error {
+	const sqlQuery = `
+	UPDATE usergroups
+	SET
+		 usergroup_name = :usergroup_name
+		,usergroup_description = :usergroup_description
+		,usergroup_updated = :usergroup_updated
+	WHERE usergroup_id = :usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbGroup := mapToInternalUserGroup(group)
+	dbGroup.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbGroup)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user group object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update user group")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated user group rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	group.Updated = dbGroup.Updated
+
+	return nil
+}
+
+// Delete deletes the user group with the given ID.
+// Members and space memberships of the user group are deleted by the foreign key cascade.
+func (s *UserGroupStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM usergroups
+	WHERE usergroup_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete user group query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of user groups of a space matching the provided filter.
+func (s *UserGroupStore) Count(ctx context.Context, spaceID int64, filter *types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("usergroups").
+		Where("usergroup_space_id = ?", spaceID)
+
+	stmt = applyUserGroupFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert user group count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing user group count query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of user groups of a space matching the provided filter.
+func (s *UserGroupStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.ListQueryFilter,
+) ([]*types.UserGroup, error) {
+	stmt := database.Builder.
+		Select(userGroupColumns).
+		From("usergroups").
+		Where("usergroup_space_id = ?", spaceID).
+		OrderBy("LOWER(usergroup_identifier) ASC")
+
+	stmt = applyUserGroupFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert user group list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*userGroup, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing user group list query")
+	}
+
+	result := make([]*types.UserGroup, len(dst))
+	for i := range dst {
+		result[i] = mapToUserGroup(dst[i])
+	}
+
+	return result, nil
+}
+
+func applyUserGroupFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.ListQueryFilter,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		searchTerm := "%%" + strings.ToLower(filter.Query) + "%%"
+		stmt = stmt.Where("(LOWER(usergroup_identifier) LIKE ? OR LOWER(usergroup_name) LIKE ?)",
+			searchTerm, searchTerm)
+	}
+
+	return stmt
+}
+
+// AddMember adds a user to a user group.
+func (s *UserGroupStore) AddMember(ctx context.Context, member *types.UserGroupMember) error {
+	const sqlQuery = `
+	INSERT INTO usergroup_members (` + userGroupMemberColumns + `
+	) values (
+		 :usergroup_member_usergroup_id
+		,:usergroup_member_principal_id
+		,:usergroup_member_created_by
+		,:usergroup_member_created
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, userGroupMember{
+		UserGroupID: member.UserGroupID,
+		PrincipalID: member.PrincipalID,
+		CreatedBy:   member.CreatedBy,
+		Created:     member.Created,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user group member object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert user group member")
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from a user group.
+func (s *UserGroupStore) RemoveMember(ctx context.Context, userGroupID, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM usergroup_members
+	WHERE usergroup_member_usergroup_id = $1 AND
+	      usergroup_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, userGroupID, principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete user group member query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted user group members")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// CountMembers returns the number of members of a user group matching the provided filter.
+func (s *UserGroupStore) CountMembers(
+	ctx context.Context,
+	userGroupID int64,
+	filter *types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("usergroup_members").
+		InnerJoin("principals ON usergroup_member_principal_id = principal_id").
+		Where("usergroup_member_usergroup_id = ?", userGroupID)
+
+	stmt = applyUserGroupMemberFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert user group member count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing user group member count query")
+	}
+
+	return count, nil
+}
+
+// ListMembers returns a list of members of a user group matching the provided filter.
+func (s *UserGroupStore) ListMembers(
+	ctx context.Context,
+	userGroupID int64,
+	filter *types.ListQueryFilter,
+) ([]types.UserGroupMember, error) {
+	const columns = userGroupMemberColumns + "," + principalInfoCommonColumns
+	stmt := database.Builder.
+		Select(columns).
+		From("usergroup_members").
+		InnerJoin("principals ON usergroup_member_principal_id = principal_id").
+		Where("usergroup_member_usergroup_id = ?", userGroupID).
+		OrderBy("principal_display_name ASC")
+
+	stmt = applyUserGroupMemberFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert user group member list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*userGroupMemberPrincipal, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing user group member list query")
+	}
+
+	// collect all principal IDs
+	ids := make([]int64, 0, len(dst))
+	for _, m := range dst {
+		ids = append(ids, m.userGroupMember.CreatedBy)
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user group member principal infos: %w", err)
+	}
+
+	result := make([]types.UserGroupMember, len(dst))
+	for i, m := range dst {
+		result[i] = types.UserGroupMember{
+			UserGroupID: m.UserGroupID,
+			PrincipalID: m.PrincipalID,
+			CreatedBy:   m.userGroupMember.CreatedBy,
+			Created:     m.userGroupMember.Created,
+			Principal:   mapToPrincipalInfo(&m.principalInfo),
+		}
+		if addedBy, ok := infoMap[m.userGroupMember.CreatedBy]; ok {
+			result[i].AddedBy = *addedBy
+		}
+	}
+
+	return result, nil
+}
+
+func applyUserGroupMemberFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.ListQueryFilter,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		searchTerm := "%%" + strings.ToLower(filter.Query) + "%%"
+		stmt = stmt.Where("LOWER(principal_display_name) LIKE ?", searchTerm)
+	}
+
+	return stmt
+}
+
+// ListMemberPrincipals returns the principal infos of all members of a user group.
+func (s *UserGroupStore) ListMemberPrincipals(
+	ctx context.Context,
+	userGroupID int64,
+) ([]*types.PrincipalInfo, error) {
+	const sqlQuery = `
+	SELECT` + principalInfoCommonColumns + `
+	FROM usergroup_members
+	INNER JOIN principals ON usergroup_member_principal_id = principal_id
+	WHERE usergroup_member_usergroup_id = $1
+	ORDER BY principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*principalInfo, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, userGroupID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing user group member principals query")
+	}
+
+	result := make([]*types.PrincipalInfo, len(dst))
+	for i := range dst {
+		info := mapToPrincipalInfo(dst[i])
+		result[i] = &info
+	}
+
+	return result, nil
+}
+
+// ListIDsForPrincipal returns the IDs of all user groups the principal is a member of.
+func (s *UserGroupStore) ListIDsForPrincipal(ctx context.Context, principalID int64) ([]int64, error) {
+	const sqlQuery = `
+	SELECT usergroup_member_usergroup_id
+	FROM usergroup_members
+	WHERE usergroup_member_principal_id = $1
+	ORDER BY usergroup_member_usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	ids := make([]int64, 0)
+	if err := db.SelectContext(ctx, &ids, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing user group ids for principal query")
+	}
+
+	return ids, nil
+}
+
+func mapToUserGroup(g *userGroup) *types.UserGroup {
+	return &types.UserGroup{
+		ID:          g.ID,
+		SpaceID:     g.SpaceID,
+		Identifier:  g.Identifier,
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedBy:   g.CreatedBy,
+		Created:     g.Created,
+		Updated:     g.Updated,
+	}
 }
 
-// Find Dummy Method: to be implemented later.
-func (s *UserGroupStore) FindByIdentifier(_ context.Context, _ int64, _ string) (*types.UserGroup, error) {
-	//nolint: nilnil
-	return nil, store.ErrResourceNotFound
+func mapToInternalUserGroup(g *types.UserGroup) *userGroup {
+	return &userGroup{
+		ID:          g.ID,
+		SpaceID:     g.SpaceID,
+		Identifier:  g.Identifier,
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedBy:   g.CreatedBy,
+		Created:     g.Created,
+		Updated:     g.Updated,
+	}
 }
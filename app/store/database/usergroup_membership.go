@@ -0,0 +1,316 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupMembershipStore = (*UserGroupMembershipStore)(nil)
+
+// NewUserGroupMembershipStore returns a new UserGroupMembershipStore.
+func NewUserGroupMembershipStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *UserGroupMembershipStore {
+	return &UserGroupMembershipStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// UserGroupMembershipStore implements store.UserGroupMembershipStore backed by a relational database.
+type UserGroupMembershipStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type userGroupMembership struct {
+	SpaceID     int64 `db:"usergroup_membership_space_id"`
+	UserGroupID int64 `db:"usergroup_membership_usergroup_id"`
+
+	CreatedBy int64 `db:"usergroup_membership_created_by"`
+	Created   int64 `db:"usergroup_membership_created"`
+	Updated   int64 `db:"usergroup_membership_updated"`
+
+	Role enum.MembershipRole `db:"usergroup_membership_role"`
+}
+
+type userGroupMembershipUserGroup struct {
+	userGroupMembership
+	userGroup
+}
+
+const (
+	userGroupMembershipColumns = `
+		 usergroup_membership_space_id
+		,usergroup_membership_usergroup_id
+		,usergroup_membership_created_by
+		,usergroup_membership_created
+		,usergroup_membership_updated
+		,usergroup_membership_role`
+)
+
+// Find finds the space membership of a user group.
+func (s *UserGroupMembershipStore) Find(
+	ctx context.Context,
+	key types.UserGroupMembershipKey,
+) (*types.UserGroupMembership, error) {
+	const sqlQuery = `
+	SELECT` + userGroupMembershipColumns + `
+	FROM usergroup_memberships
+	WHERE usergroup_membership_space_id = $1 AND usergroup_membership_usergroup_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userGroupMembership{}
+	if err := db.GetContext(ctx, dst, sqlQuery, key.SpaceID, key.UserGroupID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user group membership")
+	}
+
+	result := mapToUserGroupMembership(dst)
+
+	return &result, nil
+}
+
+// Create creates a new space membership of a user group.
+func (s *UserGroupMembershipStore) Create(ctx context.Context, membership *types.UserGroupMembership) error {
+	const sqlQuery = `
+	INSERT INTO usergroup_memberships (` + userGroupMembershipColumns + `
+	) values (
+		 :usergroup_membership_space_id
+		,:usergroup_membership_usergroup_id
+		,:usergroup_membership_created_by
+		,:usergroup_membership_created
+		,:usergroup_membership_updated
+		,:usergroup_membership_role
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalUserGroupMembership(membership))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user group membership object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert user group membership")
+	}
+
+	return nil
+}
+
+// Update updates the role of a space membership of a user group.
+func (s *UserGroupMembershipStore) Update(ctx context.Context, membership *types.UserGroupMembership) error {
+	const sqlQuery = `
+	UPDATE usergroup_memberships
+	SET
+		 usergroup_membership_updated = :usergroup_membership_updated
+		,usergroup_membership_role = :usergroup_membership_role
+	WHERE usergroup_membership_space_id = :usergroup_membership_space_id AND
+	      usergroup_membership_usergroup_id = :usergroup_membership_usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMembership := mapToInternalUserGroupMembership(membership)
+	dbMembership.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMembership)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user group membership object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update user group membership role")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated user group memberships")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	membership.Updated = dbMembership.Updated
+
+	return nil
+}
+
+// Delete deletes the space membership of a user group.
+func (s *UserGroupMembershipStore) Delete(ctx context.Context, key types.UserGroupMembershipKey) error {
+	const sqlQuery = `
+	DELETE FROM usergroup_memberships
+	WHERE usergroup_membership_space_id = $1 AND
+	      usergroup_membership_usergroup_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, key.SpaceID, key.UserGroupID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete user group membership query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted user group memberships")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Count returns the number of user group memberships of a space.
+func (s *UserGroupMembershipStore) Count(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("usergroup_memberships").
+		InnerJoin("usergroups ON usergroup_membership_usergroup_id = usergroup_id").
+		Where("usergroup_membership_space_id = ?", spaceID)
+
+	stmt = applyUserGroupFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert user group membership count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing user group membership count query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of user group memberships of a space.
+func (s *UserGroupMembershipStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.ListQueryFilter,
+) ([]types.UserGroupMembershipInfo, error) {
+	const columns = userGroupMembershipColumns + "," + userGroupColumns
+	stmt := database.Builder.
+		Select(columns).
+		From("usergroup_memberships").
+		InnerJoin("usergroups ON usergroup_membership_usergroup_id = usergroup_id").
+		Where("usergroup_membership_space_id = ?", spaceID).
+		OrderBy("LOWER(usergroup_identifier) ASC")
+
+	stmt = applyUserGroupFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert user group membership list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*userGroupMembershipUserGroup, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing user group membership list query")
+	}
+
+	// collect all principal IDs
+	ids := make([]int64, 0, len(dst))
+	for _, m := range dst {
+		ids = append(ids, m.userGroupMembership.CreatedBy)
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user group membership principal infos: %w", err)
+	}
+
+	result := make([]types.UserGroupMembershipInfo, len(dst))
+	for i, m := range dst {
+		result[i].UserGroupMembership = mapToUserGroupMembership(&m.userGroupMembership)
+		result[i].UserGroup = *mapToUserGroup(&m.userGroup)
+		if addedBy, ok := infoMap[m.userGroupMembership.CreatedBy]; ok {
+			result[i].AddedBy = *addedBy
+		}
+	}
+
+	return result, nil
+}
+
+// ListRolesForPrincipal returns the roles the principal got assigned in the space
+// through memberships of the user groups it is a member of.
+func (s *UserGroupMembershipStore) ListRolesForPrincipal(
+	ctx context.Context,
+	spaceID int64,
+	principalID int64,
+) ([]enum.MembershipRole, error) {
+	const sqlQuery = `
+	SELECT DISTINCT usergroup_membership_role
+	FROM usergroup_memberships
+	INNER JOIN usergroup_members ON usergroup_member_usergroup_id = usergroup_membership_usergroup_id
+	WHERE usergroup_membership_space_id = $1 AND usergroup_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	roles := make([]enum.MembershipRole, 0)
+	if err := db.SelectContext(ctx, &roles, sqlQuery, spaceID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing user group membership roles query")
+	}
+
+	return roles, nil
+}
+
+func mapToUserGroupMembership(m *userGroupMembership) types.UserGroupMembership {
+	return types.UserGroupMembership{
+		UserGroupMembershipKey: types.UserGroupMembershipKey{
+			SpaceID:     m.SpaceID,
+			UserGroupID: m.UserGroupID,
+		},
+		CreatedBy: m.CreatedBy,
+		Created:   m.Created,
+		Updated:   m.Updated,
+		Role:      m.Role,
+	}
+}
+
+func mapToInternalUserGroupMembership(m *types.UserGroupMembership) userGroupMembership {
+	return userGroupMembership{
+		SpaceID:     m.SpaceID,
+		UserGroupID: m.UserGroupID,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+		Role:        m.Role,
+	}
+}
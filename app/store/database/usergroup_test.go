@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/cache"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_UserGroups(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	pCache := cache.NewExtended[int64, *types.PrincipalInfo](database.NewPrincipalInfoView(db), time.Minute)
+	userGroupStore := database.NewUserGroupStore(db, pCache)
+	membershipStore := database.NewUserGroupMembershipStore(db, pCache)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	group := &types.UserGroup{
+		SpaceID:    1,
+		Identifier: "Developers",
+		Name:       "Developers",
+		CreatedBy:  userID,
+	}
+	if err := userGroupStore.Create(ctx, group); err != nil {
+		t.Fatalf("failed to create user group: %v", err)
+	}
+
+	err := userGroupStore.Create(ctx, &types.UserGroup{SpaceID: 1, Identifier: "developers", CreatedBy: userID})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("Create() with duplicate identifier error = %v, want %v", err, gitness_store.ErrDuplicate)
+	}
+
+	found, err := userGroupStore.FindByIdentifier(ctx, 1, "developers")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+	if found.ID != group.ID {
+		t.Errorf("FindByIdentifier() id = %d, want %d", found.ID, group.ID)
+	}
+
+	if err = userGroupStore.AddMember(ctx, &types.UserGroupMember{
+		UserGroupID: group.ID,
+		PrincipalID: userID,
+		CreatedBy:   userID,
+		Created:     1,
+	}); err != nil {
+		t.Fatalf("AddMember() error = %v", err)
+	}
+
+	members, err := userGroupStore.ListMembers(ctx, group.ID, &types.ListQueryFilter{})
+	if err != nil {
+		t.Fatalf("ListMembers() error = %v", err)
+	}
+	if len(members) != 1 || members[0].Principal.ID != userID || members[0].AddedBy.ID != userID {
+		t.Errorf("ListMembers() = %+v, want user %d added by itself", members, userID)
+	}
+
+	groupIDs, err := userGroupStore.ListIDsForPrincipal(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListIDsForPrincipal() error = %v", err)
+	}
+	if len(groupIDs) != 1 || groupIDs[0] != group.ID {
+		t.Errorf("ListIDsForPrincipal() = %v, want [%d]", groupIDs, group.ID)
+	}
+
+	if err = membershipStore.Create(ctx, &types.UserGroupMembership{
+		UserGroupMembershipKey: types.UserGroupMembershipKey{SpaceID: 1, UserGroupID: group.ID},
+		CreatedBy:              userID,
+		Role:                   enum.MembershipRoleContributor,
+	}); err != nil {
+		t.Fatalf("failed to create user group membership: %v", err)
+	}
+
+	roles, err := membershipStore.ListRolesForPrincipal(ctx, 1, userID)
+	if err != nil {
+		t.Fatalf("ListRolesForPrincipal() error = %v", err)
+	}
+	if len(roles) != 1 || roles[0] != enum.MembershipRoleContributor {
+		t.Errorf("ListRolesForPrincipal() = %v, want [%s]", roles, enum.MembershipRoleContributor)
+	}
+
+	if err = userGroupStore.RemoveMember(ctx, group.ID, userID); err != nil {
+		t.Fatalf("RemoveMember() error = %v", err)
+	}
+
+	roles, err = membershipStore.ListRolesForPrincipal(ctx, 1, userID)
+	if err != nil {
+		t.Fatalf("ListRolesForPrincipal() error = %v", err)
+	}
+	if len(roles) != 0 {
+		t.Errorf("ListRolesForPrincipal() after member removal = %v, want none", roles)
+	}
+
+	// deleting the group removes its space memberships
+	if err = userGroupStore.Delete(ctx, group.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	count, err := membershipStore.Count(ctx, 1, &types.ListQueryFilter{})
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Count() after group deletion = %d, want 0", count)
+	}
+}
@@ -65,6 +65,8 @@ type webhook struct {
 	Secret                string      `db:"webhook_secret"`
 	Enabled               bool        `db:"webhook_enabled"`
 	Insecure              bool        `db:"webhook_insecure"`
+	PayloadFormat         string      `db:"webhook_payload_format"`
+	PayloadTemplate       string      `db:"webhook_payload_template"`
 	Triggers              string      `db:"webhook_triggers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
 }
@@ -85,6 +87,8 @@ const (
 		,webhook_secret
 		,webhook_enabled
 		,webhook_insecure
+		,webhook_payload_format
+		,webhook_payload_template
 		,webhook_triggers
 		,webhook_latest_execution_result
 		,webhook_internal`
@@ -171,6 +175,8 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_secret
 			,webhook_enabled
 			,webhook_insecure
+			,webhook_payload_format
+			,webhook_payload_template
 			,webhook_triggers
 			,webhook_latest_execution_result
 			,webhook_internal
@@ -187,6 +193,8 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_secret
 			,:webhook_enabled
 			,:webhook_insecure
+			,:webhook_payload_format
+			,:webhook_payload_template
 			,:webhook_triggers
 			,:webhook_latest_execution_result
 			,:webhook_internal
@@ -225,6 +233,8 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_secret = :webhook_secret
 			,webhook_enabled = :webhook_enabled
 			,webhook_insecure = :webhook_insecure
+			,webhook_payload_format = :webhook_payload_format
+			,webhook_payload_template = :webhook_payload_template
 			,webhook_triggers = :webhook_triggers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
 			,webhook_internal = :webhook_internal
@@ -460,6 +470,8 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Secret:                hook.Secret,
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
+		PayloadFormat:         enum.WebhookPayloadFormat(hook.PayloadFormat),
+		PayloadTemplate:       hook.PayloadTemplate,
 		Triggers:              triggersFromString(hook.Triggers),
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Internal:              hook.Internal,
@@ -496,6 +508,8 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Secret:                hook.Secret,
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
+		PayloadFormat:         string(hook.PayloadFormat),
+		PayloadTemplate:       hook.PayloadTemplate,
 		Triggers:              triggersToString(hook.Triggers),
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Internal:              hook.Internal,
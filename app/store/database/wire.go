@@ -19,8 +19,10 @@ import (
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/stream"
 
 	"github.com/google/wire"
 	"github.com/jmoiron/sqlx"
@@ -34,8 +36,11 @@ var WireSet = wire.NewSet(
 	ProvideSpacePathStore,
 	ProvideSpaceStore,
 	ProvideRepoStore,
+	ProvideRepoMirrorStore,
+	ProvidePushMirrorStore,
 	ProvideRuleStore,
 	ProvideJobStore,
+	ProvideStreamStore,
 	ProvideExecutionStore,
 	ProvidePipelineStore,
 	ProvideStageStore,
@@ -43,6 +48,8 @@ var WireSet = wire.NewSet(
 	ProvideSecretStore,
 	ProvideRepoGitInfoView,
 	ProvideMembershipStore,
+	ProvideUserGroupStore,
+	ProvideUserGroupMembershipStore,
 	ProvideTokenStore,
 	ProvidePullReqStore,
 	ProvidePullReqActivityStore,
@@ -55,6 +62,7 @@ var WireSet = wire.NewSet(
 	ProvideSettingsStore,
 	ProvidePublicAccessStore,
 	ProvideCheckStore,
+	ProvideCheckAnnotationStore,
 	ProvideConnectorStore,
 	ProvideTemplateStore,
 	ProvideTriggerStore,
@@ -64,6 +72,10 @@ var WireSet = wire.NewSet(
 	ProvideInfraProviderResourceStore,
 	ProvideGitspaceConfigStore,
 	ProvideGitspaceInstanceStore,
+	ProvideAuditEventStore,
+	ProvideLFSObjectStore,
+	ProvideLFSLockStore,
+	ProvideMergeQueueStore,
 )
 
 // migrator is helper function to set up the database by performing automated
@@ -119,6 +131,16 @@ func ProvideRepoStore(
 	return NewRepoStore(db, spacePathCache, spacePathStore, spaceStore)
 }
 
+// ProvideRepoMirrorStore provides a repo mirror store.
+func ProvideRepoMirrorStore(db *sqlx.DB) store.RepoMirrorStore {
+	return NewRepoMirrorStore(db)
+}
+
+// ProvidePushMirrorStore provides a push mirror store.
+func ProvidePushMirrorStore(db *sqlx.DB) store.PushMirrorStore {
+	return NewPushMirrorStore(db)
+}
+
 // ProvideRuleStore provides a rule store.
 func ProvideRuleStore(
 	db *sqlx.DB,
@@ -132,6 +154,31 @@ func ProvideJobStore(db *sqlx.DB) job.Store {
 	return NewJobStore(db)
 }
 
+// ProvideStreamStore provides a stream store.
+func ProvideStreamStore(db *sqlx.DB) stream.Store {
+	return NewStreamStore(db)
+}
+
+// ProvideAuditEventStore provides an audit event store.
+func ProvideAuditEventStore(db *sqlx.DB, spacePathCache store.SpacePathCache) audit.Store {
+	return NewAuditEventStore(db, spacePathCache)
+}
+
+// ProvideLFSObjectStore provides an LFS object store.
+func ProvideLFSObjectStore(db *sqlx.DB) store.LFSObjectStore {
+	return NewLFSObjectStore(db)
+}
+
+// ProvideLFSLockStore provides an LFS lock store.
+func ProvideLFSLockStore(db *sqlx.DB) store.LFSLockStore {
+	return NewLFSLockStore(db)
+}
+
+// ProvideMergeQueueStore provides a merge queue store.
+func ProvideMergeQueueStore(db *sqlx.DB) store.MergeQueueStore {
+	return NewMergeQueueStore(db)
+}
+
 // ProvidePipelineStore provides a pipeline store.
 func ProvidePipelineStore(db *sqlx.DB) store.PipelineStore {
 	return NewPipelineStore(db)
@@ -211,6 +258,19 @@ func ProvideMembershipStore(
 	return NewMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
 }
 
+// ProvideUserGroupStore provides a user group store.
+func ProvideUserGroupStore(db *sqlx.DB, principalInfoCache store.PrincipalInfoCache) store.UserGroupStore {
+	return NewUserGroupStore(db, principalInfoCache)
+}
+
+// ProvideUserGroupMembershipStore provides a user group membership store.
+func ProvideUserGroupMembershipStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.UserGroupMembershipStore {
+	return NewUserGroupMembershipStore(db, principalInfoCache)
+}
+
 // ProvideTokenStore provides a token store.
 func ProvideTokenStore(db *sqlx.DB) store.TokenStore {
 	return NewTokenStore(db)
@@ -269,6 +329,11 @@ func ProvideCheckStore(db *sqlx.DB,
 	return NewCheckStore(db, principalInfoCache)
 }
 
+// ProvideCheckAnnotationStore provides a status check annotation store.
+func ProvideCheckAnnotationStore(db *sqlx.DB) store.CheckAnnotationStore {
+	return NewCheckAnnotationStore(db)
+}
+
 // ProvideSettingsStore provides a settings store.
 func ProvideSettingsStore(db *sqlx.DB) store.SettingsStore {
 	return NewSettingsStore(db)
@@ -48,6 +48,7 @@ func CreateUserSession(
 		principal,
 		identifier,
 		ptr.Duration(userSessionTokenLifeTime),
+		types.TokenScope{},
 	)
 }
 
@@ -58,6 +59,7 @@ func CreatePAT(
 	createdFor *types.User,
 	identifier string,
 	lifetime *time.Duration,
+	scope types.TokenScope,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
@@ -67,6 +69,7 @@ func CreatePAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		scope,
 	)
 }
 
@@ -77,6 +80,7 @@ func CreateSAT(
 	createdFor *types.ServiceAccount,
 	identifier string,
 	lifetime *time.Duration,
+	scope types.TokenScope,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
@@ -86,6 +90,7 @@ func CreateSAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		scope,
 	)
 }
 
@@ -97,6 +102,7 @@ func create(
 	createdFor *types.Principal,
 	identifier string,
 	lifetime *time.Duration,
+	scope types.TokenScope,
 ) (*types.Token, string, error) {
 	issuedAt := time.Now()
 
@@ -113,6 +119,7 @@ func create(
 		IssuedAt:    issuedAt.UnixMilli(),
 		ExpiresAt:   expiresAt,
 		CreatedBy:   createdBy.ID,
+		TokenScope:  scope,
 	}
 
 	err := tokenStore.Create(ctx, &token)
@@ -48,6 +48,10 @@ type Provider interface {
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GenerateGITCloneURL(repoPath string) string
 
+	// GetRepoPathFromGITCloneURL returns the repo path of the provided public git clone URL.
+	// It returns false if the URL isn't a git clone URL of this system.
+	GetRepoPathFromGITCloneURL(cloneURL string) (string, bool)
+
 	// GenerateGITCloneSSHURL generates the public git clone URL for the provided repo path.
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GenerateGITCloneSSHURL(repoPath string) string
@@ -61,6 +65,12 @@ type Provider interface {
 	// GenerateUICompareURL returns the url for the UI screen comparing two references.
 	GenerateUICompareURL(repoPath string, ref1 string, ref2 string) string
 
+	// GenerateUIWebhookURL returns the url for the UI screen of an existing webhook of a repository.
+	GenerateUIWebhookURL(repoPath string, webhookID int64) string
+
+	// GenerateUISpaceURL returns the url for the UI screen of a space.
+	GenerateUISpaceURL(spacePath string) string
+
 	// GetAPIHostname returns the host for the api endpoint.
 	GetAPIHostname() string
 
@@ -181,6 +191,25 @@ func (p *provider) GenerateGITCloneURL(repoPath string) string {
 	return p.gitURL.JoinPath(repoPath).String()
 }
 
+func (p *provider) GetRepoPathFromGITCloneURL(cloneURL string) (string, bool) {
+	u, err := url.Parse(cloneURL)
+	if err != nil || u.Scheme != p.gitURL.Scheme || u.Host != p.gitURL.Host {
+		return "", false
+	}
+
+	repoPath, ok := strings.CutPrefix(u.Path, p.gitURL.Path+"/")
+	if !ok {
+		return "", false
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), GITSuffix)
+	if repoPath == "" {
+		return "", false
+	}
+
+	return repoPath, true
+}
+
 func (p *provider) GenerateGITCloneSSHURL(repoPath string) string {
 	if !p.SSHEnabled {
 		return ""
@@ -206,6 +235,14 @@ func (p *provider) GenerateUIPRURL(repoPath string, prID int64) string {
 	return p.uiURL.JoinPath(repoPath, "pulls", fmt.Sprint(prID)).String()
 }
 
+func (p *provider) GenerateUIWebhookURL(repoPath string, webhookID int64) string {
+	return p.uiURL.JoinPath(repoPath, "webhook", fmt.Sprint(webhookID)).String()
+}
+
+func (p *provider) GenerateUISpaceURL(spacePath string) string {
+	return p.uiURL.JoinPath("spaces", spacePath).String()
+}
+
 func (p *provider) GenerateUICompareURL(repoPath string, ref1 string, ref2 string) string {
 	return p.uiURL.JoinPath(repoPath, "pulls/compare", ref1+"..."+ref2).String()
 }
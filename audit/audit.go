@@ -36,11 +36,16 @@ const (
 	ActionCreated Action = "created"
 	ActionUpdated Action = "updated" // update default branch, switching default branch, updating description
 	ActionDeleted Action = "deleted"
+
+	ActionBypassed    Action = "bypassed"     // bypassing protection rules
+	ActionLogin       Action = "login"        // successful user login
+	ActionForcePushed Action = "force_pushed" // rewriting the history of a branch
 )
 
 func (a Action) Validate() error {
 	switch a {
-	case ActionCreated, ActionUpdated, ActionDeleted:
+	case ActionCreated, ActionUpdated, ActionDeleted,
+		ActionBypassed, ActionLogin, ActionForcePushed:
 		return nil
 	default:
 		return ErrActionUndefined
@@ -53,22 +58,61 @@ const (
 	ResourceTypeRepository         ResourceType = "repository"
 	ResourceTypeBranchRule         ResourceType = "branch_rule"
 	ResourceTypeRepositorySettings ResourceType = "repository_settings"
+	ResourceTypeRepositoryMirror   ResourceType = "repository_mirror"
+	ResourceTypePushMirror         ResourceType = "push_mirror"
+	ResourceTypeBranch             ResourceType = "branch"
+	ResourceTypePullRequest        ResourceType = "pull_request"
+	ResourceTypeMembership         ResourceType = "membership"
+	ResourceTypeSecret             ResourceType = "secret"
+	ResourceTypeConnector          ResourceType = "connector"
+	ResourceTypeWebhook            ResourceType = "webhook"
+	ResourceTypeServiceAccount     ResourceType = "service_account"
+	ResourceTypeToken              ResourceType = "token"
+	ResourceTypePublicKey          ResourceType = "public_key"
+	ResourceTypeUser               ResourceType = "user"
+	ResourceTypeUserGroup          ResourceType = "user_group"
 )
 
 func (a ResourceType) Validate() error {
 	switch a {
 	case ResourceTypeRepository,
 		ResourceTypeBranchRule,
-		ResourceTypeRepositorySettings:
+		ResourceTypeRepositorySettings,
+		ResourceTypeRepositoryMirror,
+		ResourceTypePushMirror,
+		ResourceTypeBranch,
+		ResourceTypePullRequest,
+		ResourceTypeMembership,
+		ResourceTypeSecret,
+		ResourceTypeConnector,
+		ResourceTypeWebhook,
+		ResourceTypeServiceAccount,
+		ResourceTypeToken,
+		ResourceTypePublicKey,
+		ResourceTypeUser,
+		ResourceTypeUserGroup:
 		return nil
 	default:
 		return ErrResourceTypeUndefined
 	}
 }
 
+// IsPrincipalScoped returns true for resources that belong to a principal rather than to a space,
+// audit events of such resources aren't required to have a space path.
+func (a ResourceType) IsPrincipalScoped() bool {
+	switch a {
+	case ResourceTypeToken,
+		ResourceTypePublicKey,
+		ResourceTypeUser:
+		return true
+	default:
+		return false
+	}
+}
+
 type Resource struct {
-	Type       ResourceType
-	Identifier string
+	Type       ResourceType `json:"type"`
+	Identifier string       `json:"identifier"`
 }
 
 func NewResource(rtype ResourceType, identifier string) Resource {
@@ -89,21 +133,21 @@ func (r Resource) Validate() error {
 }
 
 type DiffObject struct {
-	OldObject any
-	NewObject any
+	OldObject any `json:"old_object,omitempty"`
+	NewObject any `json:"new_object,omitempty"`
 }
 
 type Event struct {
-	ID            string
-	Timestamp     int64
-	Action        Action          // example: ActionCreated
-	User          types.Principal // example: Admin
-	SpacePath     string          // example: /root/projects
-	Resource      Resource
-	DiffObject    DiffObject
-	ClientIP      string
-	RequestMethod string
-	Data          map[string]string // internal data like correlationID/requestID
+	ID            string            `json:"id"`
+	Timestamp     int64             `json:"timestamp"`
+	Action        Action            `json:"action"`     // example: ActionCreated
+	User          types.Principal   `json:"user"`       // example: Admin
+	SpacePath     string            `json:"space_path"` // example: /root/projects
+	Resource      Resource          `json:"resource"`
+	DiffObject    DiffObject        `json:"diff_object"`
+	ClientIP      string            `json:"client_ip"`
+	RequestMethod string            `json:"request_method"`
+	Data          map[string]string `json:"data,omitempty"` // internal data like correlationID/requestID
 }
 
 func (e *Event) Validate() error {
@@ -113,7 +157,7 @@ func (e *Event) Validate() error {
 	if e.User.UID == "" {
 		return ErrUserIsRequired
 	}
-	if e.SpacePath == "" {
+	if e.SpacePath == "" && !e.Resource.Type.IsPrincipalScoped() {
 		return ErrSpacePathIsRequired
 	}
 	if err := e.Resource.Validate(); err != nil {
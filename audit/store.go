@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+type Store interface {
+	// Create persists a new audit event.
+	Create(ctx context.Context, event *Event) error
+
+	// Count returns the number of audit events matching the filter.
+	Count(ctx context.Context, filter *ListFilter) (int64, error)
+
+	// List returns the audit events matching the filter, the most recent events come first.
+	List(ctx context.Context, filter *ListFilter) ([]Event, error)
+
+	// DeleteOld removes all audit events that were created before the provided time.
+	DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+}
+
+// ListFilter stores audit event query parameters.
+type ListFilter struct {
+	types.Pagination
+	types.CreatedFilter
+
+	// SpaceID is the ID of the space the events belong to.
+	// Events of principal scoped resources don't belong to any space,
+	// they are listed by leaving both SpaceID and SpacePath empty.
+	SpaceID int64 `json:"space_id"`
+	// SpacePath is the path of the space the events belong to,
+	// it's used for events that were stored without the ID of their space.
+	SpacePath string `json:"space_path"`
+	// Recursive includes the events of all subspaces of the space.
+	Recursive bool `json:"recursive"`
+
+	ResourceTypes []ResourceType `json:"resource_types"`
+	Actions       []Action       `json:"actions"`
+	PrincipalID   int64          `json:"principal_id"`
+}
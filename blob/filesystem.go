@@ -92,3 +92,32 @@ func (c *FileSystemStore) Download(_ context.Context, filePath string) (io.ReadC
 	}
 	return io.ReadCloser(file), nil
 }
+
+func (c *FileSystemStore) Move(_ context.Context, srcPath string, dstPath string) error {
+	srcDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, srcPath)
+	dstDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, dstPath)
+
+	dir, _ := path.Split(dstDiskPath)
+	if err := os.MkdirAll(dir, os.ModeDir|os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create parent directory for the file: %w", err)
+	}
+
+	// the rename is atomic, readers of the destination see either the old or the new file.
+	err := os.Rename(srcDiskPath, dstDiskPath)
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move file: %w", err)
+	}
+	return nil
+}
+
+func (c *FileSystemStore) DeletePrefix(_ context.Context, prefix string) error {
+	dirDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, prefix)
+
+	if err := os.RemoveAll(dirDiskPath); err != nil {
+		return fmt.Errorf("failed to remove directory: %w", err)
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/impersonate"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -108,8 +110,69 @@ func (c *GCSStore) GetSignedURL(ctx context.Context, filePath string) (string, e
 	return signedURL, nil
 }
 
-func (c *GCSStore) Download(_ context.Context, _ string) (io.ReadCloser, error) {
-	return nil, fmt.Errorf("not implemented")
+func (c *GCSStore) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	rc, err := gcsClient.Bucket(c.config.Bucket).Object(filePath).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reader for file: %s %w", filePath, err)
+	}
+	return rc, nil
+}
+
+func (c *GCSStore) Move(ctx context.Context, srcPath string, dstPath string) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	bkt := gcsClient.Bucket(c.config.Bucket)
+	src := bkt.Object(srcPath)
+
+	_, err = bkt.Object(dstPath).CopierFrom(src).Run(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy file: %s to %s %w", srcPath, dstPath, err)
+	}
+
+	err = src.Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", srcPath, c.config.Bucket, err)
+	}
+
+	return nil
+}
+
+func (c *GCSStore) DeletePrefix(ctx context.Context, prefix string) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	bkt := gcsClient.Bucket(c.config.Bucket)
+	it := bkt.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list files with prefix: %s %w", prefix, err)
+		}
+
+		err = bkt.Object(attrs.Name).Delete(ctx)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return fmt.Errorf("failed to delete file: %s from bucket: %s %w", attrs.Name, c.config.Bucket, err)
+		}
+	}
 }
 
 func createNewImpersonatedClient(ctx context.Context, cfg Config) (*storage.Client, error) {
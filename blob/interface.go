@@ -34,4 +34,10 @@ type Store interface {
 
 	// Download returns a reader for a file in the blob store.
 	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// Move moves a file within the blob store, replacing the file at the destination path if it exists.
+	Move(ctx context.Context, srcPath string, dstPath string) error
+
+	// DeletePrefix deletes all files in the blob store that are stored under the provided path prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
 }
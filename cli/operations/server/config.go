@@ -28,6 +28,7 @@ import (
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/pushmirror"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/blob"
@@ -35,6 +36,7 @@ import (
 	gittypes "github.com/harness/gitness/git/types"
 	"github.com/harness/gitness/infraprovider"
 	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/store/database"
@@ -52,6 +54,7 @@ const (
 	gitnessHomeDir = ".gitness"
 	blobDir        = "blob"
 	gitspacesDir   = "gitspaces"
+	searchIndexDir = "keywordsearch"
 )
 
 // LoadConfig returns the system configuration from the
@@ -298,6 +301,11 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 		MaxRetries:          config.Webhook.MaxRetries,
 		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 		AllowLoopback:       config.Webhook.AllowLoopback,
+
+		RedeliveryMaxAttempts: config.Webhook.RedeliveryMaxAttempts,
+		RedeliveryBackoff:     config.Webhook.RedeliveryBackoff,
+		RedeliveryBackoffMax:  config.Webhook.RedeliveryBackoffMax,
+		AutoDisableThreshold:  config.Webhook.AutoDisableThreshold,
 	}
 }
 
@@ -318,6 +326,15 @@ func ProvideTriggerConfig(config *types.Config) trigger.Config {
 	}
 }
 
+// ProvidePushMirrorConfig loads the push mirror service config from the main config.
+func ProvidePushMirrorConfig(config *types.Config) pushmirror.Config {
+	return pushmirror.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.PushMirror.Concurrency,
+		MaxRetries:      config.PushMirror.MaxRetries,
+	}
+}
+
 // ProvideLockConfig generates the `lock` package config from the gitness config.
 func ProvideLockConfig(config *types.Config) lock.Config {
 	return lock.Config{
@@ -344,11 +361,24 @@ func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 	}
 }
 
+// ProvideLiveLogConfig loads the livelog config from the main config.
+func ProvideLiveLogConfig(config *types.Config) livelog.Config {
+	return livelog.Config{
+		App:          config.LiveLog.AppNamespace,
+		Namespace:    config.LiveLog.DefaultNamespace,
+		Provider:     config.LiveLog.Provider,
+		MaxLines:     config.LiveLog.MaxLines,
+		Expiry:       config.LiveLog.Expiry,
+		BlockTimeout: config.LiveLog.BlockTimeout,
+	}
+}
+
 // ProvideCleanupConfig loads the cleanup service config from the main config.
 func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		AuditEventsRetentionTime:         config.Audit.RetentionTime,
 	}
 }
 
@@ -361,10 +391,17 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
 func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
+	indexPath := config.KeywordSearch.IndexPath
+	if indexPath == "" {
+		indexPath = filepath.Join(config.Git.Root, searchIndexDir)
+	}
+
 	return keywordsearch.Config{
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.KeywordSearch.Concurrency,
 		MaxRetries:      config.KeywordSearch.MaxRetries,
+		IndexPath:       indexPath,
+		MaxFileSize:     config.KeywordSearch.MaxFileSize,
 	}
 }
 
@@ -414,6 +451,8 @@ func ProvideGitspaceContainerOrchestratorConfig(config *types.Config) (*containe
 		DefaultBaseImage:               config.Gitspace.DefaultBaseImage,
 		DefaultBindMountTargetPath:     config.Gitspace.DefaultBindMountTargetPath,
 		DefaultBindMountSourceBasePath: bindMountSourceBasePath,
+		AllowedRunArgs:                 config.Gitspace.AllowedRunArgs,
+		AllowedBindMountSources:        config.Gitspace.AllowedBindMountSources,
 	}, nil
 }
 
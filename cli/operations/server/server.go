@@ -24,6 +24,7 @@ import (
 
 	"github.com/harness/gitness/app/pipeline/logger"
 	"github.com/harness/gitness/profiler"
+	"github.com/harness/gitness/tracing"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/version"
 
@@ -63,6 +64,12 @@ func (c *command) run(*kingpin.ParseContext) error {
 	// configure profiler
 	SetupProfiler(config)
 
+	// configure tracing
+	shutdownTracing, err := SetupTracing(ctx, config)
+	if err != nil {
+		return fmt.Errorf("encountered an error while setting up tracing: %w", err)
+	}
+
 	// add logger to context
 	log := log.Logger.With().Logger()
 	ctx = log.WithContext(ctx)
@@ -105,6 +112,21 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.CronTriggers.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register cron triggers")
+			return err
+		}
+
+		if err := system.services.RepoMirror.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register repo mirror service")
+			return err
+		}
+
+		if err := system.services.MergeQueue.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register merge queue service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
@@ -167,6 +189,10 @@ func (c *command) run(*kingpin.ParseContext) error {
 
 	system.services.JobScheduler.WaitJobsDone(shutdownCtx)
 
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Err(err).Msg("failed to shutdown tracing gracefully")
+	}
+
 	log.Info().Msg("wait for subroutines to complete")
 	err = g.Wait()
 
@@ -212,6 +238,32 @@ func SetupProfiler(config *types.Config) {
 	gitnessProfiler.StartProfiling(config.Profiler.ServiceName, version.Version.String())
 }
 
+// SetupTracing configures the opentelemetry tracing from the loaded configuration.
+// The returned function flushes the remaining spans and has to be called on shutdown.
+func SetupTracing(ctx context.Context, config *types.Config) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	if config.Tracing.Exporter == "" {
+		log.Info().Msg("No tracing exporter configured so skipping tracing")
+		return noop, nil
+	}
+
+	exporterType, parsed := tracing.ParseExporterType(config.Tracing.Exporter)
+	if !parsed {
+		return noop, fmt.Errorf("tracing exporter '%s' is not supported", config.Tracing.Exporter)
+	}
+
+	return tracing.Setup(ctx, tracing.Config{
+		Exporter:       exporterType,
+		Endpoint:       config.Tracing.Endpoint,
+		Insecure:       config.Tracing.Insecure,
+		FilePath:       config.Tracing.FilePath,
+		SampleRatio:    config.Tracing.SampleRatio,
+		ServiceName:    config.Tracing.ServiceName,
+		ServiceVersion: version.Version.String(),
+	})
+}
+
 // Register the server command.
 func Register(app *kingpin.Application, initializer func(context.Context, *types.Config) (*System, error)) {
 	c := new(command)
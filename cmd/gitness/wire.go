@@ -16,6 +16,7 @@ import (
 	githookCtrl "github.com/harness/gitness/app/api/controller/githook"
 	gitspacecontroller "github.com/harness/gitness/app/api/controller/gitspace"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
@@ -34,14 +35,17 @@ import (
 	controllertrigger "github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	controllerusergroup "github.com/harness/gitness/app/api/controller/usergroup"
 	controllerwebhook "github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/metrics"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
@@ -54,6 +58,7 @@ import (
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
@@ -61,6 +66,7 @@ import (
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
@@ -68,7 +74,9 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pushmirror"
 	reposervice "github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repomirror"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -113,7 +121,11 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		dbtx.WireSet,
 		cache.WireSet,
 		router.WireSet,
+		metrics.WireSet,
 		pullreqservice.WireSet,
+		automerge.WireSet,
+		mergequeue.WireSet,
+		repomirror.WireSet,
 		services.WireSet,
 		server.WireSet,
 		url.WireSet,
@@ -127,6 +139,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		serviceaccount.WireSet,
 		user.WireSet,
 		upload.WireSet,
+		lfs.WireSet,
 		service.WireSet,
 		principal.WireSet,
 		system.WireSet,
@@ -135,6 +148,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		gitevents.WireSet,
 		pullreqevents.WireSet,
 		repoevents.WireSet,
+		checkevents.WireSet,
 		storage.WireSet,
 		api.WireSet,
 		cliserver.ProvideGitConfig,
@@ -149,6 +163,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		webhook.WireSet,
 		cliserver.ProvideTriggerConfig,
 		trigger.WireSet,
+		cliserver.ProvidePushMirrorConfig,
+		pushmirror.WireSet,
 		githookCtrl.ExtenderWireSet,
 		githookCtrl.WireSet,
 		cliserver.ProvideLockConfig,
@@ -166,6 +182,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		execution.WireSet,
 		pipeline.WireSet,
 		logs.WireSet,
+		cliserver.ProvideLiveLogConfig,
 		livelog.WireSet,
 		controllerlogs.WireSet,
 		secret.WireSet,
@@ -194,6 +211,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		controllerkeywordsearch.WireSet,
 		settings.WireSet,
 		usergroup.WireSet,
+		controllerusergroup.WireSet,
 		openapi.WireSet,
 		repo.ProvideRepoCheck,
 		audit.WireSet,
@@ -15,6 +15,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
@@ -33,14 +34,17 @@ import (
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	usergroup2 "github.com/harness/gitness/app/api/controller/usergroup"
 	webhook2 "github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
-	events4 "github.com/harness/gitness/app/events/git"
-	events3 "github.com/harness/gitness/app/events/pullreq"
+	events4 "github.com/harness/gitness/app/events/check"
+	events3 "github.com/harness/gitness/app/events/git"
+	events5 "github.com/harness/gitness/app/events/pullreq"
 	events2 "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/metrics"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
@@ -53,6 +57,7 @@ import (
 	"github.com/harness/gitness/app/router"
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
@@ -60,6 +65,7 @@ import (
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
@@ -67,7 +73,9 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pushmirror"
 	repo2 "github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repomirror"
 	"github.com/harness/gitness/app/services/settings"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -118,16 +126,20 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalInfoView := database.ProvidePrincipalInfoView(db)
 	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
-	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore)
+	userGroupMembershipStore := database.ProvideUserGroupMembershipStore(db, principalInfoCache)
+	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore, userGroupMembershipStore)
 	publicAccessStore := database.ProvidePublicAccessStore(db)
 	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore)
 	publicaccessService := publicaccess.ProvidePublicAccess(config, publicAccessStore, repoStore, spaceStore)
-	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore, publicaccessService)
+	scopePathCache := authz.ProvideScopePathCache(spaceStore, repoStore)
+	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore, publicaccessService, scopePathCache)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
 	publicKeyStore := database.ProvidePublicKeyStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore)
+	auditStore := database.ProvideAuditEventStore(db, spacePathCache)
+	auditService := audit.ProvideAuditService(auditStore)
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, auditService, spaceStore, repoStore, auditStore)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
 	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
@@ -139,7 +151,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
 	settingsStore := database.ProvideSettingsStore(db)
 	settingsService := settings.ProvideService(settingsStore)
-	protectionManager, err := protection.ProvideManager(ruleStore)
+	userGroupStore := database.ProvideUserGroupStore(db, principalInfoCache)
+	protectionManager, err := protection.ProvideManager(ruleStore, userGroupStore)
 	if err != nil {
 		return nil, err
 	}
@@ -179,18 +192,22 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	streamer := sse.ProvideEventsStreaming(pubSub)
-	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
+	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
+	localIndexSearcher, err := keywordsearch.ProvideLocalIndexSearcher(keywordsearchConfig, gitInterface, repoStore)
+	if err != nil {
+		return nil, err
+	}
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
-	auditService := audit.ProvideAuditService()
 	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer, publicaccessService, auditService)
 	if err != nil {
 		return nil, err
 	}
 	codeownersConfig := server.ProvideCodeOwnerConfig(config)
-	usergroupResolver := usergroup.ProvideUserGroupResolver()
+	usergroupResolver := usergroup.ProvideUserGroupResolver(spaceStore, userGroupStore)
 	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, usergroupResolver)
 	eventsConfig := server.ProvideEventsConfig(config)
-	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient)
+	streamStore := database.ProvideStreamStore(db)
+	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient, streamStore)
 	if err != nil {
 		return nil, err
 	}
@@ -205,7 +222,30 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	lockerLocker := locker.ProvideLocker(mutexManager)
 	repoIdentifier := check.ProvideRepoIdentifierCheck()
 	repoCheck := repo.ProvideRepoCheck()
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService)
+	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	if err != nil {
+		return nil, err
+	}
+	publickeyService := publickey.ProvidePublicKey(publicKeyStore, principalInfoCache, principalStore)
+	repoMirrorStore := database.ProvideRepoMirrorStore(db)
+	connectorStore := database.ProvideConnectorStore(db)
+	repomirrorService := repomirror.ProvideService(jobScheduler, executor, provider, gitInterface, repoStore, repoMirrorStore, connectorStore, indexer)
+	pushMirrorStore := database.ProvidePushMirrorStore(db)
+	secretStore := database.ProvideSecretStore(db)
+	pushmirrorConfig := server.ProvidePushMirrorConfig(config)
+	readerFactory, err := events3.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	pushmirrorService, err := pushmirror.ProvideService(ctx, pushmirrorConfig, jobScheduler, executor, gitInterface, encrypter, repoStore, pushMirrorStore, connectorStore, secretStore, readerFactory)
+	if err != nil {
+		return nil, err
+	}
+	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, blobStore, publickeyService, repoMirrorStore, connectorStore, repomirrorService, pushMirrorStore, secretStore, pushmirrorService)
 	reposettingsController := reposettings.ProvideController(authorizer, repoStore, settingsService, auditService)
 	executionStore := database.ProvideExecutionStore(db)
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
@@ -221,24 +261,27 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	converterService := converter.ProvideService(fileService, publicaccessService)
 	templateStore := database.ProvideTemplateStore(db)
 	pluginStore := database.ProvidePluginStore(db)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService)
-	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
+	eventsReporter, err := events4.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService, eventsReporter)
+	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore, eventsReporter)
 	logStore := logs.ProvideLogStore(db, config)
-	logStream := livelog.ProvideLogStream()
+	livelogConfig := server.ProvideLiveLogConfig(config)
+	logStream := livelog.ProvideLogStream(livelogConfig, universalClient)
 	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
-	secretStore := database.ProvideSecretStore(db)
-	connectorStore := database.ProvideConnectorStore(db)
 	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
 	if err != nil {
 		return nil, err
 	}
 	gitspaceConfigStore := database.ProvideGitspaceConfigStore(db)
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceConfigStore)
+	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceConfigStore, auditStore)
 	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore)
-	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore)
+	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore, auditService)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoStore)
-	connectorController := connector.ProvideController(connectorStore, authorizer, spaceStore)
+	connectorController := connector.ProvideController(connectorStore, authorizer, spaceStore, auditService)
 	templateController := template.ProvideController(templateStore, authorizer, spaceStore)
 	pluginController := plugin.ProvideController(pluginStore)
 	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
@@ -247,87 +290,94 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pullReqReviewStore := database.ProvidePullReqReviewStore(db)
 	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
 	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
-	eventsReporter, err := events3.ProvideReporter(eventsSystem)
+	reporter2, err := events5.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	migrator := codecomments.ProvideMigrator(gitInterface)
-	readerFactory, err := events4.ProvideReaderFactory(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
-	eventsReaderFactory, err := events3.ProvideReaderFactory(eventsSystem)
+	eventsReaderFactory, err := events5.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	repoGitInfoView := database.ProvideRepoGitInfoView(db)
 	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter2, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
 	if err != nil {
 		return nil, err
 	}
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, migrator, pullreqService, protectionManager, streamer, codeownersService, lockerLocker)
+	mergeQueueStore := database.ProvideMergeQueueStore(db)
+	checkAnnotationStore := database.ProvideCheckAnnotationStore(db)
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, reporter2, migrator, pullreqService, protectionManager, streamer, codeownersService, lockerLocker, auditService, mergeQueueStore, publickeyService, checkAnnotationStore, spaceStore, userGroupStore)
 	webhookConfig := server.ProvideWebhookConfig(config)
 	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter)
+	mailerMailer := mailer.ProvideMailClient(config)
+	client := notification.ProvideMailClient(mailerMailer)
+	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter)
-	reporter2, err := events4.ProvideReporter(eventsSystem)
+	readerFactory3, err := events4.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	preReceiveExtender, err := githook.ProvidePreReceiveExtender()
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, spaceStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter, executor, jobScheduler, client, checkStore, readerFactory2, readerFactory3)
 	if err != nil {
 		return nil, err
 	}
-	updateExtender, err := githook.ProvideUpdateExtender()
+	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter, auditService)
+	reporter3, err := events3.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	postReceiveExtender, err := githook.ProvidePostReceiveExtender()
+	preReceiveExtender, err := githook.ProvidePreReceiveExtender()
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, reporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender)
-	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
-	principalController := principal.ProvideController(principalStore, authorizer)
-	v := check2.ProvideCheckSanitizers()
-	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
-	systemController := system.NewController(principalStore, config)
-	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	updateExtender, err := githook.ProvideUpdateExtender()
 	if err != nil {
 		return nil, err
 	}
-	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	postReceiveExtender, err := githook.ProvidePostReceiveExtender()
 	if err != nil {
 		return nil, err
 	}
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter3, reporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, auditService, publickeyService, repoMirrorStore)
+	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore, auditService)
+	principalController := principal.ProvideController(principalStore, authorizer)
+	v := check2.ProvideCheckSanitizers()
+	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v, eventsReporter, checkAnnotationStore)
+	systemController := system.NewController(principalStore, config)
 	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
 	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
 	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
 	infraProviderResourceStore := database.ProvideInfraProviderResourceStore(db)
 	gitspaceInstanceStore := database.ProvideGitspaceInstanceStore(db)
-	gitspaceController := gitspace.ProvideController(authorizer, infraProviderResourceStore, gitspaceConfigStore, gitspaceInstanceStore, spaceStore)
+	gitspaceController := gitspace.ProvideController(authorizer, infraProviderResourceStore, gitspaceConfigStore, gitspaceInstanceStore, spaceStore, repoStore, provider)
 	migrateController := migrate.ProvideController(authorizer, principalStore)
-	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController, gitspaceController, migrateController)
-	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController)
+	usergroupController := usergroup2.ProvideController(transactor, authorizer, spaceStore, principalStore, userGroupStore, userGroupMembershipStore, auditService)
+	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController, gitspaceController, migrateController, usergroupController)
+	lfsObjectStore := database.ProvideLFSObjectStore(db)
+	lfsLockStore := database.ProvideLFSLockStore(db)
+	lfsController := lfs.ProvideController(authorizer, provider, repoStore, principalInfoCache, lfsObjectStore, lfsLockStore, blobStore)
+	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController, lfsController)
 	openapiService := openapi.ProvideOpenAPIService()
 	webHandler := router.ProvideWebHandler(config, openapiService)
-	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, provider)
+	handler, err := metrics.ProvideHandler(config, db, logStream, stageStore)
+	if err != nil {
+		return nil, err
+	}
+	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, handler, provider)
 	serverServer := server2.ProvideServer(config, routerRouter)
-	publickeyService := publickey.ProvidePublicKey(publicKeyStore, principalInfoCache)
 	sshServer := ssh.ProvideServer(config, publickeyService, repoController)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService)
-	client := manager.ProvideExecutionClient(executionManager, provider, config)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, eventsReporter)
+	clientClient := manager.ProvideExecutionClient(executionManager, provider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
-	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
+	runtimeRunner, err := runner.ProvideExecutionRunner(config, clientClient, resolverManager)
 	if err != nil {
 		return nil, err
 	}
-	poller := runner.ProvideExecutionPoller(runtimeRunner, client)
+	poller := runner.ProvideExecutionPoller(runtimeRunner, clientClient)
 	triggerConfig := server.ProvideTriggerConfig(config)
 	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoStore, pipelineStore, triggererTriggerer, readerFactory, eventsReaderFactory)
 	if err != nil {
@@ -337,11 +387,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	sizeCalculator, err := repo2.ProvideCalculator(config, gitInterface, repoStore, jobScheduler, executor)
-	if err != nil {
-		return nil, err
-	}
-	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
+	sizeCalculator, err := repo2.ProvideCalculator(config, gitInterface, repoStore, jobScheduler, executor, lfsObjectStore)
 	if err != nil {
 		return nil, err
 	}
@@ -350,23 +396,32 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController, auditStore)
 	if err != nil {
 		return nil, err
 	}
-	mailerMailer := mailer.ProvideMailClient(config)
-	notificationClient := notification.ProvideMailClient(mailerMailer)
 	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	notificationService, err := notification.ProvideNotificationService(ctx, client, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
 	if err != nil {
 		return nil, err
 	}
-	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
 	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, readerFactory2, repoStore, indexer)
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService)
+	cronScheduler, err := trigger2.ProvideCronScheduler(triggerStore, repoStore, pipelineStore, triggererTriggerer, commitService, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	automergeService, err := automerge.ProvideService(ctx, config, eventsReaderFactory, readerFactory3, readerFactory, pullreqController, pullReqStore, pullReqActivityStore, repoStore, principalStore)
+	if err != nil {
+		return nil, err
+	}
+	mergequeueService, err := mergequeue.ProvideService(ctx, config, jobScheduler, executor, eventsReaderFactory, readerFactory3, readerFactory, gitInterface, provider, lockerLocker, mergeQueueStore, pullReqStore, pullReqActivityStore, repoStore, principalStore, checkStore, protectionManager, triggerService, reporter2, streamer)
+	if err != nil {
+		return nil, err
+	}
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, cronScheduler, automergeService, mergequeueService, repomirrorService, pushmirrorService)
 	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, resolverManager, servicesServices)
 	return serverSystem, nil
 }
@@ -18,6 +18,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"go.opentelemetry.io/otel"
 )
 
 const (
@@ -25,6 +27,8 @@ const (
 	streamPayloadKey = "event"
 )
 
+var tracer = otel.Tracer("github.com/harness/gitness/events")
+
 type Event[T interface{}] struct {
 	ID        string    `json:"id"`
 	Timestamp time.Time `json:"timestamp"`
@@ -45,6 +49,7 @@ type Mode string
 const (
 	ModeRedis    Mode = "redis"
 	ModeInMemory Mode = "inmemory"
+	ModeSQL      Mode = "sql"
 )
 
 // Config defines the config of the events system.
@@ -59,7 +64,7 @@ func (c *Config) Validate() error {
 	if c == nil {
 		return errors.New("config is required")
 	}
-	if c.Mode != ModeRedis && c.Mode != ModeInMemory {
+	if c.Mode != ModeRedis && c.Mode != ModeInMemory && c.Mode != ModeSQL {
 		return fmt.Errorf("config.Mode '%s' is not supported", c.Mode)
 	}
 	if c.MaxStreamLength < 1 {
@@ -21,7 +21,11 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/harness/gitness/tracing"
+
 	"github.com/rs/zerolog/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ReaderFactoryFunc is an abstraction of a factory method that creates customized Reader implementations (type [R]).
@@ -163,7 +167,17 @@ func ReaderRegisterEvent[T interface{}](reader *GenericReader,
 
 	// register handler for event specific stream.
 	return reader.streamConsumer.Register(streamID,
-		func(ctx context.Context, messageID string, streamPayload map[string]interface{}) error {
+		func(ctx context.Context, messageID string, streamPayload map[string]interface{}) (err error) {
+			// continue the trace of the event producer (if any).
+			ctx, span := tracer.Start(extractTraceContext(ctx, streamPayload), streamID+" process",
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					semconv.MessagingDestinationName(streamID),
+					semconv.MessagingMessageID(messageID),
+				),
+			)
+			defer func() { tracing.End(span, err) }()
+
 			if streamPayload == nil {
 				return fmt.Errorf("stream payload is nil for message '%s'", messageID)
 			}
@@ -190,7 +204,7 @@ func ReaderRegisterEvent[T interface{}](reader *GenericReader,
 			// decode event to correct type
 			var event Event[T]
 			decoder := gob.NewDecoder(bytes.NewReader(eventBytes))
-			err := decoder.Decode(&event)
+			err = decoder.Decode(&event)
 			if err != nil {
 				//nolint:gocritic // only way to achieve this AFAIK - lint proposal is not building
 				return fmt.Errorf("stream payload can't be decoded into type %T (message '%s')", *new(T), messageID)
@@ -220,6 +234,22 @@ func ReaderRegisterEvent[T interface{}](reader *GenericReader,
 		}, toStreamHandlerOptions(opts)...)
 }
 
+// extractTraceContext returns a copy of ctx with the trace context that got propagated with the stream payload.
+func extractTraceContext(ctx context.Context, streamPayload map[string]interface{}) context.Context {
+	traceContext := map[string]string{}
+	for _, key := range tracing.Fields() {
+		// NOTE: Redis returns all values as string - handle []byte for completeness.
+		switch v := streamPayload[key].(type) {
+		case string:
+			traceContext[key] = v
+		case []byte:
+			traceContext[key] = string(v)
+		}
+	}
+
+	return tracing.ExtractMap(ctx, traceContext)
+}
+
 func (r *GenericReader) Configure(opts ...ReaderOption) {
 	r.streamConsumer.Configure(toStreamConsumerOptions(opts)...)
 }
@@ -20,6 +20,11 @@ import (
 	"encoding/gob"
 	"fmt"
 	"time"
+
+	"github.com/harness/gitness/tracing"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // GenericReporter represents an event reporter that supports sending typesafe messages
@@ -36,8 +41,15 @@ type GenericReporter struct {
 //
 //nolint:revive // emphasize that this is meant to be an operation on *GenericReporter
 func ReporterSendEvent[T interface{}](reporter *GenericReporter, ctx context.Context,
-	eventType EventType, payload T) (string, error) {
+	eventType EventType, payload T) (_ string, err error) {
 	streamID := getStreamID(reporter.category, eventType)
+
+	ctx, span := tracer.Start(ctx, streamID+" send",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(semconv.MessagingDestinationName(streamID)),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	event := Event[T]{
 		ID:        "", // will be set by GenericReader
 		Timestamp: time.Now(),
@@ -55,6 +67,13 @@ func ReporterSendEvent[T interface{}](reporter *GenericReporter, ctx context.Con
 		streamPayloadKey: buff.Bytes(),
 	}
 
+	// propagate the trace context with the event so handlers can continue the trace.
+	traceContext := map[string]string{}
+	tracing.InjectMap(ctx, traceContext)
+	for key, value := range traceContext {
+		streamPayload[key] = value
+	}
+
 	// We are using the message ID as event ID.
 	return reporter.producer.Send(ctx, streamID, streamPayload)
 }
@@ -29,7 +29,7 @@ var WireSet = wire.NewSet(
 	ProvideSystem,
 )
 
-func ProvideSystem(config Config, redisClient redis.UniversalClient) (*System, error) {
+func ProvideSystem(config Config, redisClient redis.UniversalClient, streamStore stream.Store) (*System, error) {
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("provided config is invalid: %w", err)
 	}
@@ -41,6 +41,8 @@ func ProvideSystem(config Config, redisClient redis.UniversalClient) (*System, e
 		system, err = provideSystemInMemory(config)
 	case ModeRedis:
 		system, err = provideSystemRedis(config, redisClient)
+	case ModeSQL:
+		system, err = provideSystemSQL(config, streamStore)
 	default:
 		return nil, fmt.Errorf("events system mode '%s' is not supported", config.Mode)
 	}
@@ -76,6 +78,18 @@ func provideSystemRedis(config Config, redisClient redis.UniversalClient) (*Syst
 	)
 }
 
+func provideSystemSQL(config Config, streamStore stream.Store) (*System, error) {
+	if streamStore == nil {
+		return nil, errors.New("stream store required")
+	}
+
+	return NewSystem(
+		newSQLStreamConsumerFactoryMethod(streamStore, config.Namespace),
+		newSQLStreamProducer(streamStore, config.Namespace,
+			config.MaxStreamLength, config.ApproxMaxStreamLength),
+	)
+}
+
 func newMemoryStreamConsumerFactoryMethod(broker *stream.MemoryBroker, namespace string) StreamConsumerFactoryFunc {
 	return func(groupName string, _ string) (StreamConsumer, error) {
 		return stream.NewMemoryConsumer(broker, namespace, groupName)
@@ -99,3 +113,17 @@ func newRedisStreamProducer(redisClient redis.UniversalClient, namespace string,
 	maxStreamLength int64, approxMaxStreamLength bool) StreamProducer {
 	return stream.NewRedisProducer(redisClient, namespace, maxStreamLength, approxMaxStreamLength)
 }
+
+func newSQLStreamConsumerFactoryMethod(
+	streamStore stream.Store,
+	namespace string,
+) StreamConsumerFactoryFunc {
+	return func(groupName string, consumerName string) (StreamConsumer, error) {
+		return stream.NewSQLConsumer(streamStore, namespace, groupName, consumerName)
+	}
+}
+
+func newSQLStreamProducer(streamStore stream.Store, namespace string,
+	maxStreamLength int64, approxMaxStreamLength bool) StreamProducer {
+	return stream.NewSQLProducer(streamStore, namespace, maxStreamLength, approxMaxStreamLength)
+}
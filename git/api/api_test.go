@@ -16,6 +16,7 @@ package api
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -99,3 +100,31 @@ func testParseTagDataFromCatFileFor(t *testing.T, object string, typ GitObjectTy
 	require.Equal(t, tagger.Identity.Email, res.Tagger.Identity.Email, data)
 	require.Equal(t, tagger.When, res.Tagger.When, data)
 }
+
+func TestCommitFromReader_Signature(t *testing.T) {
+	data := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author Jane Doe <jane@example.com> 1704067200 +0000\n" +
+		"committer Jane Doe <jane@example.com> 1704067200 +0000\n" +
+		"encoding ISO-8859-1\n" +
+		"gpgsig -----BEGIN SSH SIGNATURE-----\n" +
+		" U1NIU0lH\n" +
+		" \n" +
+		" -----END SSH SIGNATURE-----\n" +
+		"\n" +
+		"some message\n"
+
+	commit, err := CommitFromReader(sha.EmptyTree, strings.NewReader(data))
+	require.NoError(t, err)
+	require.NotNil(t, commit.Signature)
+
+	require.Equal(t, "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n\n-----END SSH SIGNATURE-----\n",
+		commit.Signature.Signature)
+	require.Equal(t, "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n"+
+		"author Jane Doe <jane@example.com> 1704067200 +0000\n"+
+		"committer Jane Doe <jane@example.com> 1704067200 +0000\n"+
+		"encoding ISO-8859-1\n"+
+		"\n"+
+		"some message\n",
+		commit.Signature.Payload)
+	require.Equal(t, "some message\n", commit.Message)
+}
@@ -108,12 +108,13 @@ func (g *Git) GetLatestCommit(
 	}
 	treePath = cleanTreePath(treePath)
 
-	return getCommit(ctx, repoPath, rev, treePath)
+	return getCommit(ctx, repoPath, nil, rev, treePath)
 }
 
 func getCommits(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	commitIDs []string,
 ) ([]*Commit, error) {
 	if len(commitIDs) == 0 {
@@ -121,16 +122,53 @@ func getCommits(
 	}
 	commits := make([]*Commit, 0, len(commitIDs))
 	for _, commitID := range commitIDs {
-		commit, err := getCommit(ctx, repoPath, commitID, "")
+		commit, err := getCommit(ctx, repoPath, alternateObjectDirs, commitID, "")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get commit '%s': %w", commitID, err)
 		}
 		commits = append(commits, commit)
 	}
 
+	err := readCommitSignatures(ctx, repoPath, alternateObjectDirs, commits)
+	if err != nil {
+		return nil, err
+	}
+
 	return commits, nil
 }
 
+// readCommitSignatures reads the raw commit objects to populate the signatures of the provided commits.
+// git log can't output the raw signature without verifying it, so cat-file is used instead.
+func readCommitSignatures(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	commits []*Commit,
+) error {
+	if len(commits) == 0 {
+		return nil
+	}
+
+	wr, rd, cancel := CatFileBatch(ctx, repoPath, alternateObjectDirs)
+	defer cancel()
+
+	for _, commit := range commits {
+		_, err := wr.Write([]byte(commit.SHA.String() + "\n"))
+		if err != nil {
+			return fmt.Errorf("failed to write commit sha to cat-file stdin: %w", err)
+		}
+
+		rawCommit, err := getCommitFromBatchReader(ctx, repoPath, rd, commit.SHA.String())
+		if err != nil {
+			return fmt.Errorf("failed to read raw commit %s: %w", commit.SHA, err)
+		}
+
+		commit.Signature = rawCommit.Signature
+	}
+
+	return nil
+}
+
 func (g *Git) listCommitSHAs(
 	ctx context.Context,
 	repoPath string,
@@ -209,6 +247,7 @@ func (g *Git) ListCommitSHAs(
 func (g *Git) ListCommits(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	ref string,
 	page int,
 	limit int,
@@ -219,12 +258,12 @@ func (g *Git) ListCommits(
 		return nil, nil, ErrRepositoryPathEmpty
 	}
 
-	commitSHAs, err := g.listCommitSHAs(ctx, repoPath, nil, ref, page, limit, filter)
+	commitSHAs, err := g.listCommitSHAs(ctx, repoPath, alternateObjectDirs, ref, page, limit, filter)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	commits, err := getCommits(ctx, repoPath, commitSHAs)
+	commits, err := getCommits(ctx, repoPath, alternateObjectDirs, commitSHAs)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -538,7 +577,17 @@ func (g *Git) GetCommit(
 		return nil, ErrRepositoryPathEmpty
 	}
 
-	return getCommit(ctx, repoPath, rev, "")
+	commit, err := getCommit(ctx, repoPath, nil, rev, "")
+	if err != nil {
+		return nil, err
+	}
+
+	err = readCommitSignatures(ctx, repoPath, nil, []*Commit{commit})
+	if err != nil {
+		return nil, err
+	}
+
+	return commit, nil
 }
 
 func (g *Git) GetFullCommitID(
@@ -574,7 +623,7 @@ func (g *Git) GetCommits(
 		return nil, ErrRepositoryPathEmpty
 	}
 
-	return getCommits(ctx, repoPath, refs)
+	return getCommits(ctx, repoPath, nil, refs)
 }
 
 // GetCommitDivergences returns the count of the diverging commits for all branch pairs.
@@ -583,6 +632,7 @@ func (g *Git) GetCommits(
 func (g *Git) GetCommitDivergences(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	requests []CommitDivergenceRequest,
 	max int32,
 ) ([]CommitDivergence, error) {
@@ -592,7 +642,7 @@ func (g *Git) GetCommitDivergences(
 	var err error
 	res := make([]CommitDivergence, len(requests))
 	for i, req := range requests {
-		res[i], err = g.getCommitDivergence(ctx, repoPath, req, max)
+		res[i], err = g.getCommitDivergence(ctx, repoPath, alternateObjectDirs, req, max)
 		if errors.IsNotFound(err) {
 			res[i] = CommitDivergence{Ahead: -1, Behind: -1}
 			continue
@@ -611,12 +661,14 @@ func (g *Git) GetCommitDivergences(
 func (g *Git) getCommitDivergence(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	req CommitDivergenceRequest,
 	max int32,
 ) (CommitDivergence, error) {
 	cmd := command.New("rev-list",
 		command.WithFlag("--count"),
 		command.WithFlag("--left-right"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
 	)
 	// limit count if requested.
 	if max > 0 {
@@ -683,6 +735,7 @@ func parseLinesToSlice(output []byte) []string {
 func getCommit(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	rev string,
 	path string,
 ) (*Commit, error) {
@@ -702,6 +755,7 @@ func getCommit(
 		command.WithFlag("--max-count", "1"),
 		command.WithFlag("--format="+format), //nolint:goconst
 		command.WithArg(rev),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
 	)
 	if path != "" {
 		cmd.Add(command.WithPostSepArg(path))
@@ -886,6 +940,12 @@ readLoop:
 		}
 
 		if !message {
+			if len(line) > 0 && line[0] == ' ' {
+				// continuation line of a multi-line header other than the signature (e.g. mergetag)
+				_, _ = payloadSB.Write(line)
+				continue
+			}
+
 			// This is probably not correct but is copied from go-gits interpretation...
 			trimmed := bytes.TrimSpace(line)
 			if len(trimmed) == 0 {
@@ -918,10 +978,14 @@ readLoop:
 					return nil, fmt.Errorf("failed to parse committer signature: %w", err)
 				}
 				_, _ = payloadSB.Write(line)
-			case "gpgsig":
+			case "gpgsig", "gpgsig-sha256":
+				// the signature is excluded from the signed payload
 				_, _ = signatureSB.Write(data)
 				_ = signatureSB.WriteByte('\n')
 				pgpsig = true
+			default:
+				// other headers (e.g. encoding, mergetag) are part of the signed payload
+				_, _ = payloadSB.Write(line)
 			}
 		} else {
 			_, _ = messageSB.Write(line)
@@ -19,10 +19,13 @@ import (
 	"fmt"
 	"io"
 	"path"
+
+	"github.com/harness/gitness/git/sha"
 )
 
 type FileContent struct {
 	Path    string
+	SHA     sha.SHA
 	Content []byte
 }
 
@@ -34,8 +37,9 @@ func (g *Git) MatchFiles(
 	treePath string,
 	pattern string,
 	maxSize int,
+	recursive bool,
 ) ([]FileContent, error) {
-	nodes, err := lsDirectory(ctx, repoPath, rev, treePath, false)
+	nodes, err := lsDirectory(ctx, repoPath, rev, treePath, false, recursive)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list files in match files: %w", err)
 	}
@@ -92,6 +96,7 @@ func (g *Git) MatchFiles(
 
 		files = append(files, FileContent{
 			Path:    nodes[i].Path,
+			SHA:     nodes[i].SHA,
 			Content: data,
 		})
 	}
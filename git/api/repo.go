@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
 
 	"github.com/rs/zerolog/log"
 )
@@ -50,6 +51,8 @@ type PushOptions struct {
 	Env            []string
 	Timeout        time.Duration
 	Mirror         bool
+	Prune          bool
+	RefSpecs       []string
 }
 
 // ObjectCount represents the parsed information from the `git count-objects -v` command.
@@ -263,6 +266,48 @@ func (g *Git) Sync(
 	return nil
 }
 
+// FetchObjects fetches the provided objects (and everything reachable from them) from the source repository.
+// No references are created or updated in the repository.
+// NOTE: This is a read operation and doesn't trigger any server side hooks.
+func (g *Git) FetchObjects(
+	ctx context.Context,
+	repoPath string,
+	source string,
+	objectSHAs []sha.SHA,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if len(objectSHAs) == 0 {
+		return nil
+	}
+
+	cmd := command.New("fetch",
+		command.WithConfig("advice.fetchShowForcedUpdates", "false"),
+		command.WithConfig("credential.helper", ""),
+		command.WithConfig("protocol.version", "2"),
+		command.WithFlag(
+			"--quiet",
+			"--no-auto-gc",
+			"--no-tags",
+			"--no-write-fetch-head",
+			"--no-show-forced-updates",
+		),
+		command.WithArg(source),
+	)
+
+	for _, objectSHA := range objectSHAs {
+		cmd.Add(command.WithArg(objectSHA.String()))
+	}
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
+
 func (g *Git) AddFiles(
 	ctx context.Context,
 	repoPath string,
@@ -340,12 +385,19 @@ func (g *Git) Push(
 	if opts.Mirror {
 		cmd.Add(command.WithFlag("--mirror"))
 	}
+	if opts.Prune {
+		cmd.Add(command.WithFlag("--prune"))
+	}
 	cmd.Add(command.WithPostSepArg(opts.Remote))
 
 	if len(opts.Branch) > 0 {
 		cmd.Add(command.WithPostSepArg(opts.Branch))
 	}
 
+	for _, refSpec := range opts.RefSpecs {
+		cmd.Add(command.WithPostSepArg(refSpec))
+	}
+
 	if g.traceGit {
 		cmd.Add(command.WithEnv(command.GitTrace, "true"))
 	}
@@ -458,3 +510,25 @@ func parseGitCountObjectsOutput(ctx context.Context, output string) ObjectCount
 
 	return info
 }
+
+// RepackAll repacks all objects of the repository into a single pack, including objects that are
+// only accessible via the alternates of the repository, and removes redundant packs.
+func (g *Git) RepackAll(
+	ctx context.Context,
+	repoPath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("repack",
+		command.WithFlag("-a", "-d", "-q"),
+	)
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to repack repo")
+	}
+
+	return nil
+}
@@ -135,6 +135,7 @@ func lsTree(
 	rev string,
 	treePath string,
 	fetchSizes bool,
+	recursive bool,
 ) ([]TreeNode, error) {
 	if repoPath == "" {
 		return nil, ErrRepositoryPathEmpty
@@ -147,6 +148,9 @@ func lsTree(
 	if fetchSizes {
 		cmd.Add(command.WithFlag("-l"))
 	}
+	if recursive {
+		cmd.Add(command.WithFlag("-r"))
+	}
 
 	output := &bytes.Buffer{}
 	err := cmd.Run(ctx,
@@ -228,6 +232,7 @@ func lsDirectory(
 	rev string,
 	treePath string,
 	fetchSizes bool,
+	recursive bool,
 ) ([]TreeNode, error) {
 	treePath = path.Clean(treePath)
 	if treePath == "" {
@@ -236,7 +241,7 @@ func lsDirectory(
 		treePath += "/"
 	}
 
-	return lsTree(ctx, repoPath, rev, treePath, fetchSizes)
+	return lsTree(ctx, repoPath, rev, treePath, fetchSizes, recursive)
 }
 
 // lsFile returns one tree node entry.
@@ -249,7 +254,7 @@ func lsFile(
 ) (TreeNode, error) {
 	treePath = cleanTreePath(treePath)
 
-	list, err := lsTree(ctx, repoPath, rev, treePath, fetchSize)
+	list, err := lsTree(ctx, repoPath, rev, treePath, fetchSize, false)
 	if err != nil {
 		return TreeNode{}, fmt.Errorf("failed to ls file: %w", err)
 	}
@@ -315,7 +320,7 @@ func (g *Git) ListTreeNodes(ctx context.Context, repoPath, rev, treePath string)
 
 // ListTreeNodes lists the child nodes of a tree reachable from ref via the specified path.
 func ListTreeNodes(ctx context.Context, repoPath, rev, treePath string, fetchSizes bool) ([]TreeNode, error) {
-	list, err := lsDirectory(ctx, repoPath, rev, treePath, fetchSizes)
+	list, err := lsDirectory(ctx, repoPath, rev, treePath, fetchSizes, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tree nodes: %w", err)
 	}
@@ -22,12 +22,20 @@ import (
 	"io"
 	"os/exec"
 	"regexp"
+
+	"github.com/harness/gitness/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
 	GitExecutable = "git"
 
 	actionRegex = regexp.MustCompile(`^[[:alnum:]]+[-[:alnum:]]*$`)
+
+	tracer = otel.Tracer("github.com/harness/gitness/git/command")
 )
 
 // Command contains options for running a git command.
@@ -119,6 +127,9 @@ func (c *Command) Run(ctx context.Context, opts ...RunOptionFunc) (err error) {
 		f(options)
 	}
 
+	ctx, span := c.startSpan(ctx, options.Dir)
+	defer func() { tracing.End(span, err) }()
+
 	if options.Stdout == nil {
 		options.Stdout = io.Discard
 	}
@@ -173,6 +184,22 @@ func (c *Command) Run(ctx context.Context, opts ...RunOptionFunc) (err error) {
 	}
 }
 
+// startSpan starts the span of the command execution.
+// NOTE: Arguments aren't recorded as they can contain sensitive information (e.g. remote urls with credentials).
+func (c *Command) startSpan(ctx context.Context, dir string) (context.Context, trace.Span) {
+	name := "git " + c.Name
+	attrs := []attribute.KeyValue{
+		attribute.String("git.command", c.Name),
+		attribute.String("git.dir", dir),
+	}
+	if c.Action != "" {
+		name += " " + c.Action
+		attrs = append(attrs, attribute.String("git.action", c.Action))
+	}
+
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
 func (c *Command) makeArgs() ([]string, error) {
 	var safeArgs []string
 
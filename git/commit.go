@@ -42,6 +42,13 @@ type Commit struct {
 	Author     Signature         `json:"author"`
 	Committer  Signature         `json:"committer"`
 	FileStats  []CommitFileStats `json:"file_stats,omitempty"`
+	Signature  *CommitSignature  `json:"signature,omitempty"`
+}
+
+// CommitSignature is the raw signature of a commit (gpgsig header) and the payload it signs.
+type CommitSignature struct {
+	Signature string `json:"signature"`
+	Payload   string `json:"payload"`
 }
 
 type GetCommitOutput struct {
@@ -145,6 +152,7 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	gitCommits, renameDetails, err := s.git.ListCommits(
 		ctx,
 		repoPath,
+		params.AlternateObjectDirs,
 		params.GitREF,
 		int(params.Page),
 		int(params.Limit),
@@ -166,7 +174,7 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	if params.Page == 1 && len(gitCommits) < int(params.Limit) {
 		totalCommits = len(gitCommits)
 	} else if params.After != "" && params.GitREF != params.After {
-		div, err := s.git.GetCommitDivergences(ctx, repoPath, []api.CommitDivergenceRequest{
+		div, err := s.git.GetCommitDivergences(ctx, repoPath, params.AlternateObjectDirs, []api.CommitDivergenceRequest{
 			{From: params.GitREF, To: params.After},
 		}, 0)
 		if err != nil {
@@ -241,6 +249,7 @@ func (s *Service) GetCommitDivergences(
 	divergences, err := s.git.GetCommitDivergences(
 		ctx,
 		repoPath,
+		params.AlternateObjectDirs,
 		requests,
 		params.MaxCount,
 	)
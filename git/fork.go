@@ -0,0 +1,263 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// gitAlternatesFile is the path of the alternates file relative to the root of a bare repository.
+	gitAlternatesFile = "objects/info/alternates"
+	// gitObjectsDir is the path of the objects directory relative to the root of a bare repository.
+	gitObjectsDir = "objects"
+	// gitConfigGCPruneExpire is the config key controlling when unreachable objects are pruned by git gc.
+	gitConfigGCPruneExpire = "gc.pruneExpire"
+)
+
+type ForkRepositoryParams struct {
+	// Fork operation is similar to create - the UID of the new repository doesn't exist yet.
+	// Only take actor and envars as input and create WriteParams manually.
+	// RepoUID is optional, a new UID is generated in case it's not provided.
+	RepoUID string
+	Actor   Identity
+	EnvVars map[string]string
+
+	// UpstreamRepoUID is the UID of the repository that is being forked.
+	UpstreamRepoUID string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p.UpstreamRepoUID == "" {
+		return errors.InvalidArgument("upstream repository uid cannot be empty")
+	}
+
+	return p.Actor.Validate()
+}
+
+type ForkRepositoryOutput struct {
+	UID           string
+	DefaultBranch string
+}
+
+// ForkRepository creates a new repository that shares the objects of the upstream repository
+// via git alternates, and copies all branches and tags of the upstream repository.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*ForkRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	upstreamPath := getFullPathForRepo(s.reposRoot, params.UpstreamRepoUID)
+	if _, err := os.Stat(upstreamPath); os.IsNotExist(err) {
+		return nil, errors.NotFound("upstream repository not found")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to check the status of the upstream repository: %w", err)
+	}
+
+	defaultBranch, err := s.git.GetDefaultBranch(ctx, upstreamPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default branch of upstream repository: %w", err)
+	}
+
+	// forks borrow objects from the upstream repository, objects that become unreachable in the upstream
+	// (e.g. after a branch delete or a force push) can still be referenced by forks and must never be pruned.
+	err = s.git.Config(ctx, upstreamPath, gitConfigGCPruneExpire, "never")
+	if err != nil {
+		return nil, fmt.Errorf("failed to disable pruning of upstream repository objects: %w", err)
+	}
+	defaultBranch = strings.TrimPrefix(strings.TrimSpace(defaultBranch), gitReferenceNamePrefixBranch)
+
+	if params.RepoUID == "" {
+		uid, err := NewRepositoryUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new uid: %w", err)
+		}
+		params.RepoUID = uid
+	}
+
+	log := log.Ctx(ctx).With().
+		Str("repo_uid", params.RepoUID).
+		Str("upstream_repo_uid", params.UpstreamRepoUID).
+		Logger()
+
+	log.Info().Msg("fork git repository")
+
+	writeParams := WriteParams{
+		RepoUID: params.RepoUID,
+		Actor:   params.Actor,
+		EnvVars: params.EnvVars,
+	}
+
+	err = s.createRepositoryInternal(
+		ctx,
+		&writeParams,
+		defaultBranch,
+		nil,
+		nil,
+		time.Time{},
+		nil,
+		time.Time{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// delete repo dir on error
+	defer func() {
+		if err != nil {
+			cleanuperr := s.DeleteRepositoryBestEffort(ctx, params.RepoUID)
+			if cleanuperr != nil {
+				log.Warn().Err(cleanuperr).Msg("failed to cleanup forked repo dir")
+			}
+		}
+	}()
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	err = s.setAlternates(repoPath, upstreamPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up alternates of forked repository: %w", err)
+	}
+
+	// objects are shared with the upstream repo, so fetching the references only copies the references.
+	err = s.git.Sync(ctx, repoPath, upstreamPath, []string{
+		"+" + gitReferenceNamePrefixBranch + "*:" + gitReferenceNamePrefixBranch + "*",
+		"+" + gitReferenceNamePrefixTag + "*:" + gitReferenceNamePrefixTag + "*",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy references from upstream repository: %w", err)
+	}
+
+	log.Info().Msgf("repository forked. Path: %s", repoPath)
+
+	return &ForkRepositoryOutput{
+		UID:           params.RepoUID,
+		DefaultBranch: defaultBranch,
+	}, nil
+}
+
+type FetchObjectsParams struct {
+	WriteParams
+
+	// SourceRepoUID is the UID of the repository from which the objects are fetched.
+	SourceRepoUID string
+	// ObjectSHAs are the objects that are fetched together with everything reachable from them.
+	ObjectSHAs []sha.SHA
+}
+
+func (p *FetchObjectsParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository uid cannot be empty")
+	}
+
+	return nil
+}
+
+// FetchObjects copies the provided objects from the source repository into the repository,
+// without creating any references. It's used to make commits of a fork available in the upstream repository.
+// IMPORTANT: The fetched objects are unreferenced, a reference should be created right after calling this method.
+func (s *Service) FetchObjects(
+	ctx context.Context,
+	params *FetchObjectsParams,
+) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourcePath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+
+	err := s.git.FetchObjects(ctx, repoPath, sourcePath, params.ObjectSHAs)
+	if err != nil {
+		return fmt.Errorf("FetchObjects: failed to fetch objects: %w", err)
+	}
+
+	return nil
+}
+
+type DissociateRepositoryParams struct {
+	WriteParams
+}
+
+// DissociateRepository copies all objects the repository borrows from its alternates into the repository
+// and removes the alternates. It has to be called on all forks before the upstream repository is deleted.
+func (s *Service) DissociateRepository(
+	ctx context.Context,
+	params *DissociateRepositoryParams,
+) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	alternatesPath := filepath.Join(repoPath, gitAlternatesFile)
+
+	if _, err := os.Stat(alternatesPath); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to check the status of the alternates file: %w", err)
+	}
+
+	err := s.git.RepackAll(ctx, repoPath)
+	if err != nil {
+		return fmt.Errorf("DissociateRepository: failed to repack repository: %w", err)
+	}
+
+	err = os.Remove(alternatesPath)
+	if err != nil {
+		return fmt.Errorf("DissociateRepository: failed to remove alternates file: %w", err)
+	}
+
+	return nil
+}
+
+// setAlternates configures the objects directory of the upstream repo as alternate object store of the repo.
+func (s *Service) setAlternates(repoPath, upstreamPath string) error {
+	upstreamObjectsPath, err := filepath.Abs(filepath.Join(upstreamPath, gitObjectsDir))
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of upstream objects: %w", err)
+	}
+
+	alternatesPath := filepath.Join(repoPath, gitAlternatesFile)
+
+	err = os.MkdirAll(filepath.Dir(alternatesPath), fileMode700)
+	if err != nil {
+		return fmt.Errorf("failed to create info dir: %w", err)
+	}
+
+	err = os.WriteFile(alternatesPath, []byte(upstreamObjectsPath+"\n"), 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write alternates file: %w", err)
+	}
+
+	return nil
+}
@@ -49,6 +49,13 @@ type Interface interface {
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
 
+	/*
+	 * Fork service
+	 */
+	ForkRepository(ctx context.Context, params *ForkRepositoryParams) (*ForkRepositoryOutput, error)
+	FetchObjects(ctx context.Context, params *FetchObjectsParams) error
+	DissociateRepository(ctx context.Context, params *DissociateRepositoryParams) error
+
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
 
 	/*
@@ -65,9 +65,21 @@ func mapCommit(c *api.Commit) (*Commit, error) {
 		Author:     *author,
 		Committer:  *comitter,
 		FileStats:  mapFileStats(c.FileStats),
+		Signature:  mapCommitSignature(c.Signature),
 	}, nil
 }
 
+func mapCommitSignature(s *api.CommitGPGSignature) *CommitSignature {
+	if s == nil {
+		return nil
+	}
+
+	return &CommitSignature{
+		Signature: s.Signature,
+		Payload:   s.Payload,
+	}
+}
+
 func mapFileStats(typeStats []api.CommitFileStats) []CommitFileStats {
 	var stats = make([]CommitFileStats, len(typeStats))
 
@@ -27,6 +27,8 @@ type MatchFilesParams struct {
 	DirPath string
 	Pattern string
 	MaxSize int
+	// Recursive includes files from all subdirectories of DirPath.
+	Recursive bool
 }
 
 type MatchFilesOutput struct {
@@ -39,7 +41,7 @@ func (s *Service) MatchFiles(ctx context.Context,
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 
 	matchedFiles, err := s.git.MatchFiles(ctx, repoPath,
-		params.Ref, params.DirPath, params.Pattern, params.MaxSize)
+		params.Ref, params.DirPath, params.Pattern, params.MaxSize, params.Recursive)
 	if err != nil {
 		return nil, fmt.Errorf("MatchFiles: failed to open repo: %w", err)
 	}
@@ -35,7 +35,7 @@ type MergeParams struct {
 	WriteParams
 	BaseBranch string
 	// HeadRepoUID specifies the UID of the repo that contains the head branch (required for forking).
-	// WARNING: This field is currently not supported yet!
+	// If it's provided and differs from RepoUID, the head commit is fetched from the head repo first.
 	HeadRepoUID string
 	HeadBranch  string
 	Title       string
@@ -112,6 +112,7 @@ type MergeOutput struct {
 //	params.RefType = RefTypeBranch and params.RefName = "somebranch" -> merge and push to refs/heads/somebranch
 //	params.RefType = RefTypePullReqHead and params.RefName = "1" -> merge and push to refs/pullreq/1/head
 //	params.RefType = RefTypePullReqMerge and params.RefName = "1" -> merge and push to refs/pullreq/1/merge
+//	params.RefType = RefTypePullReqQueue and params.RefName = "1" -> merge and push to refs/pullreq/1/queue
 //
 // There are cases when you want to block merging and for that you will need to provide
 // params.HeadExpectedSHA which will be compared with the latest sha from head branch
@@ -142,6 +143,8 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		mergeFunc = merge.Squash
 	case enum.MergeMethodRebase:
 		mergeFunc = merge.Rebase
+	case enum.MergeMethodFastForward:
+		mergeFunc = merge.FastForward
 	default:
 		// should not happen, the call to Sanitize above should handle this case.
 		panic("unsupported merge method")
@@ -185,9 +188,21 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, fmt.Errorf("failed to get merge base branch commit SHA: %w", err)
 	}
 
-	headCommitSHA, err := s.git.GetFullCommitID(ctx, repoPath, params.HeadBranch)
+	headRepoPath := repoPath
+	if params.HeadRepoUID != "" && params.HeadRepoUID != params.RepoUID {
+		headRepoPath = getFullPathForRepo(s.reposRoot, params.HeadRepoUID)
+	}
+
+	headCommitSHA, err := s.git.GetFullCommitID(ctx, headRepoPath, params.HeadBranch)
 	if err != nil {
-		return MergeOutput{}, fmt.Errorf("failed to get merge base branch commit SHA: %w", err)
+		return MergeOutput{}, fmt.Errorf("failed to get merge head branch commit SHA: %w", err)
+	}
+
+	if headRepoPath != repoPath {
+		err = s.git.FetchObjects(ctx, repoPath, headRepoPath, []sha.SHA{headCommitSHA})
+		if err != nil {
+			return MergeOutput{}, fmt.Errorf("failed to fetch head commit from head repo: %w", err)
+		}
 	}
 
 	if !params.HeadExpectedSHA.IsEmpty() && !params.HeadExpectedSHA.Equal(headCommitSHA) {
@@ -245,6 +260,13 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		}, nil
 	}
 
+	if mergeMethod == enum.MergeMethodFastForward && !mergeBaseCommitSHA.Equal(baseCommitSHA) {
+		return MergeOutput{}, errors.PreconditionFailed(
+			"Base branch '%s' has diverged from head branch '%s'. "+
+				"Fast-forward merge is not possible, the head branch needs rebase.",
+			params.BaseBranch, params.HeadBranch)
+	}
+
 	// author and committer
 
 	now := time.Now().UTC()
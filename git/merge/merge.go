@@ -30,6 +30,9 @@ import (
 var (
 	// errConflict is used to error out of sharedrepo Run method without erroring out of merge in case of conflicts.
 	errConflict = errors.New("conflict")
+
+	// ErrNotFastForward is returned by the FastForward method if the target has diverged from the source.
+	ErrNotFastForward = errors.New("target has diverged from source, fast-forward is not possible")
 )
 
 // Func represents a merge method function. The concrete merge implementation functions must have this signature.
@@ -215,3 +218,31 @@ func Rebase(
 
 	return mergeSHA, conflicts, nil
 }
+
+// FastForward points the target to the source commit (sourceSHA) without creating any new commits.
+// It fails if the target commit (targetSHA) isn't an ancestor of the source commit.
+func FastForward(
+	ctx context.Context,
+	refUpdater *hook.RefUpdater,
+	repoPath, tmpDir string,
+	_, _ *api.Signature, // commit author and committer aren't used here - no commits are created
+	_ string, // commit message isn't used here
+	mergeBaseSHA, targetSHA, sourceSHA sha.SHA,
+) (mergeSHA sha.SHA, conflicts []string, err error) {
+	if !mergeBaseSHA.Equal(targetSHA) {
+		return sha.None, nil, ErrNotFastForward
+	}
+
+	err = sharedrepo.Run(ctx, refUpdater, tmpDir, repoPath, func(*sharedrepo.SharedRepo) error {
+		if err := refUpdater.InitNew(ctx, sourceSHA); err != nil {
+			return fmt.Errorf("refUpdater.InitNew failed: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("merge method=fast-forward: %w", err)
+	}
+
+	return sourceSHA, nil, nil
+}
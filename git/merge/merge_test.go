@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+)
+
+func TestFastForward_Diverged(t *testing.T) {
+	mergeBaseSHA := sha.Must("1111111111111111111111111111111111111111")
+	targetSHA := sha.Must("2222222222222222222222222222222222222222")
+	sourceSHA := sha.Must("3333333333333333333333333333333333333333")
+
+	// the target must be the merge base, otherwise it has commits that the source doesn't contain.
+	mergeSHA, conflicts, err := FastForward(context.Background(), nil, "", "", nil, nil, "",
+		mergeBaseSHA, targetSHA, sourceSHA)
+	if !errors.Is(err, ErrNotFastForward) {
+		t.Fatalf("want error %v, got %v", ErrNotFastForward, err)
+	}
+	if !mergeSHA.IsEmpty() || len(conflicts) != 0 {
+		t.Errorf("want no merge sha and conflicts, got %s %v", mergeSHA, conflicts)
+	}
+}
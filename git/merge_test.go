@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/types"
+)
+
+const testRepoUID = "testrepo"
+
+func TestService_MergeFastForward(t *testing.T) {
+	tests := []struct {
+		name        string
+		diverged    bool
+		wantErrFunc func(error) bool
+	}{
+		{
+			name: "fast-forward",
+		},
+		{
+			name:        "diverged target",
+			diverged:    true,
+			wantErrFunc: errors.IsPreconditionFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, repoPath := setupMergeTestRepo(t)
+
+			base := testCommit(t, repoPath, "", "file.txt", "base")
+			head := testCommit(t, repoPath, base, "file.txt", "feature")
+			target := base
+			if test.diverged {
+				target = testCommit(t, repoPath, base, "other.txt", "main")
+			}
+			testGit(t, repoPath, "", "update-ref", "refs/heads/main", target)
+			testGit(t, repoPath, "", "update-ref", "refs/heads/feature", head)
+
+			out, err := s.Merge(ctx, &MergeParams{
+				WriteParams: WriteParams{
+					RepoUID: testRepoUID,
+					Actor:   Identity{Name: "Tester", Email: "tester@example.com"},
+				},
+				BaseBranch: "main",
+				HeadBranch: "feature",
+				RefType:    enum.RefTypeBranch,
+				RefName:    "main",
+				Method:     enum.MergeMethodFastForward,
+			})
+
+			mainSHA := testGit(t, repoPath, "", "rev-parse", "refs/heads/main")
+
+			if test.wantErrFunc != nil {
+				if !test.wantErrFunc(err) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if mainSHA != target {
+					t.Errorf("want target branch unchanged at %s, got %s", target, mainSHA)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to merge: %v", err)
+			}
+
+			// fast-forward doesn't create any new commit, the target branch points to the head commit.
+			if out.MergeSHA.String() != head {
+				t.Errorf("want merge sha %s, got %s", head, out.MergeSHA)
+			}
+			if mainSHA != head {
+				t.Errorf("want target branch at %s, got %s", head, mainSHA)
+			}
+		})
+	}
+}
+
+func setupMergeTestRepo(t *testing.T) (*Service, string) {
+	t.Helper()
+
+	root := t.TempDir()
+	tmpDir := t.TempDir()
+
+	hookFactory := noopHookClientFactory{}
+
+	adapter, err := api.New(types.Config{}, nil, hookFactory)
+	if err != nil {
+		t.Fatalf("failed to create git adapter: %v", err)
+	}
+
+	s, err := New(types.Config{Root: root, TmpDir: tmpDir}, adapter, hookFactory, nil)
+	if err != nil {
+		t.Fatalf("failed to create git service: %v", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, testRepoUID)
+	if err = os.MkdirAll(filepath.Dir(repoPath), 0o700); err != nil {
+		t.Fatalf("failed to create repo directory: %v", err)
+	}
+	testGit(t, "", "", "init", "--bare", repoPath)
+
+	return s, repoPath
+}
+
+// testCommit creates a commit with a single file and the provided parent (if any) and returns its sha.
+func testCommit(t *testing.T, repoPath, parent, file, content string) string {
+	t.Helper()
+
+	blob := testGit(t, repoPath, content, "hash-object", "-w", "--stdin")
+	tree := testGit(t, repoPath, "100644 blob "+blob+"\t"+file+"\n", "mktree")
+
+	args := []string{"commit-tree", tree, "-m", content}
+	if parent != "" {
+		args = append(args, "-p", parent)
+	}
+
+	return testGit(t, repoPath, "", args...)
+}
+
+func testGit(t *testing.T, dir, stdin string, args ...string) string {
+	t.Helper()
+
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Tester", "GIT_AUTHOR_EMAIL=tester@example.com",
+		"GIT_COMMITTER_NAME=Tester", "GIT_COMMITTER_EMAIL=tester@example.com",
+	)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+type noopHookClientFactory struct{}
+
+func (noopHookClientFactory) NewClient(map[string]string) (hook.Client, error) {
+	return hook.NewNoopClient(nil), nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"io"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	servicePackDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "git",
+		Name:      "service_pack_duration_seconds",
+		Help:      "Duration of git service pack operations, partitioned by service and result.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300, 900},
+	}, []string{"service", "result"})
+
+	servicePackBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "git",
+		Name:      "service_pack_bytes_total",
+		Help:      "Number of bytes transferred by git service pack operations, partitioned by service and direction.",
+	}, []string{"service", "direction"})
+)
+
+// observeServicePack records the metrics of a finished git service pack operation.
+func observeServicePack(service enum.GitServiceType, duration time.Duration, received, sent int64, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+
+	servicePackDuration.WithLabelValues(string(service), result).Observe(duration.Seconds())
+	servicePackBytes.WithLabelValues(string(service), "received").Add(float64(received))
+	servicePackBytes.WithLabelValues(string(service), "sent").Add(float64(sent))
+}
+
+// countingReader counts the number of bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// countingWriter counts the number of bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
@@ -25,6 +25,15 @@ import (
 type PushRemoteParams struct {
 	ReadParams
 	RemoteURL string
+
+	// RefSpecs limits the push to the provided refspecs.
+	// If no refspecs are provided, all references are mirrored to the remote repository.
+	RefSpecs []string
+
+	// Force overwrites remote references that diverged from the local ones
+	// and deletes remote references matching the refspecs that don't exist locally.
+	// It's applicable only if RefSpecs are provided.
+	Force bool
 }
 
 func (p *PushRemoteParams) Validate() error {
@@ -55,11 +64,14 @@ func (s *Service) PushRemote(ctx context.Context, params *PushRemoteParams) erro
 		return errors.InvalidArgument("cannot push empty repo")
 	}
 
+	mirror := len(params.RefSpecs) == 0
 	err := s.git.Push(ctx, repoPath, api.PushOptions{
-		Remote: params.RemoteURL,
-		Force:  false,
-		Env:    nil,
-		Mirror: true,
+		Remote:   params.RemoteURL,
+		Force:    !mirror && params.Force,
+		Env:      nil,
+		Mirror:   mirror,
+		Prune:    !mirror && params.Force,
+		RefSpecs: params.RefSpecs,
 	})
 	if err != nil {
 		return fmt.Errorf("PushRemote: failed to push to remote repository: %w", err)
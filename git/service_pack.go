@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/api"
@@ -83,7 +84,19 @@ func (s *Service) ServicePack(ctx context.Context, params *ServicePackParams) er
 		return errors.InvalidArgument("unsupported service provided: %s", params.Service)
 	}
 
-	err := s.git.ServicePack(ctx, repoPath, params.ServicePackOptions)
+	options := params.ServicePackOptions
+	stdin := &countingReader{r: options.Stdin}
+	stdout := &countingWriter{w: options.Stdout}
+	if options.Stdin != nil {
+		options.Stdin = stdin
+	}
+	if options.Stdout != nil {
+		options.Stdout = stdout
+	}
+
+	start := time.Now()
+	err := s.git.ServicePack(ctx, repoPath, options)
+	observeServicePack(params.Service, time.Since(start), stdin.n, stdout.n, err)
 	if err != nil {
 		return fmt.Errorf("failed to execute git %s: %w", params.Service, err)
 	}
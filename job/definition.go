@@ -25,6 +25,9 @@ type Definition struct {
 	MaxRetries int
 	Timeout    time.Duration
 	Data       string
+
+	// Delay defines for how long the first execution of the job is postponed.
+	Delay time.Duration
 }
 
 func (def *Definition) Validate() error {
@@ -40,6 +43,10 @@ func (def *Definition) Validate() error {
 		return errors.New("job MaxRetries must be positive")
 	}
 
+	if def.Delay < 0 {
+		return errors.New("job Delay must not be negative")
+	}
+
 	if def.Timeout < time.Second {
 		return errors.New("job Timeout too short")
 	}
@@ -49,6 +56,7 @@ func (def *Definition) Validate() error {
 
 func (def *Definition) toNewJob() *Job {
 	nowMilli := time.Now().UnixMilli()
+	scheduledMilli := nowMilli + def.Delay.Milliseconds()
 	return &Job{
 		UID:                 def.UID,
 		Created:             nowMilli,
@@ -60,10 +68,10 @@ func (def *Definition) toNewJob() *Job {
 		MaxDurationSeconds:  int(def.Timeout / time.Second),
 		MaxRetries:          def.MaxRetries,
 		State:               JobStateScheduled,
-		Scheduled:           nowMilli,
+		Scheduled:           scheduledMilli,
 		TotalExecutions:     0,
 		RunBy:               "",
-		RunDeadline:         nowMilli,
+		RunDeadline:         scheduledMilli,
 		RunProgress:         ProgressMin,
 		LastExecuted:        0, // never executed
 		IsRecurring:         false,
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	jobExecutions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "job",
+		Name:      "executions_total",
+		Help:      "Number of job executions, partitioned by job type and result.",
+	}, []string{"type", "result"})
+
+	jobExecutionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "job",
+		Name:      "execution_duration_seconds",
+		Help:      "Duration of job executions, partitioned by job type.",
+		Buckets:   []float64{.1, .5, 1, 5, 15, 30, 60, 300, 900, 1800, 3600},
+	}, []string{"type"})
+
+	jobsReady = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gitness",
+		Subsystem: "job",
+		Name:      "queue_ready",
+		Help:      "Number of jobs that are ready for execution, as observed during the latest scheduling.",
+	})
+
+	jobsRunning = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gitness",
+		Subsystem: "job",
+		Name:      "running",
+		Help:      "Number of jobs that are being executed, as observed during the latest scheduling.",
+	})
+)
+
+// observeExecution records the metrics of a single job execution.
+func observeExecution(jobType string, duration time.Duration, execFailure string) {
+	result := "success"
+	if execFailure != "" {
+		result = "failure"
+	}
+
+	jobExecutions.WithLabelValues(jobType, result).Inc()
+	jobExecutionDuration.WithLabelValues(jobType).Observe(duration.Seconds())
+}
+
+// observeQueue records the number of jobs that are ready for execution.
+func (s *Scheduler) observeQueue(ctx context.Context, now time.Time) {
+	countReady, err := s.store.CountReady(ctx, now)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to count ready jobs")
+		return
+	}
+
+	jobsReady.Set(float64(countReady))
+}
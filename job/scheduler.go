@@ -347,6 +347,8 @@ func (s *Scheduler) processReadyJobs(ctx context.Context, now time.Time) (int, t
 			fmt.Errorf("failed to count available slots for job execution: %w", err)
 	}
 
+	s.observeQueue(ctx, now)
+
 	// get one over the limit to check if all ready jobs are fetched
 	jobs, err := s.store.ListReady(ctx, now, availableCount+1)
 	if err != nil {
@@ -407,6 +409,8 @@ func (s *Scheduler) availableSlots(ctx context.Context) (int, error) {
 		return 0, err
 	}
 
+	jobsRunning.Set(float64(countRunning))
+
 	availableCount := s.maxRunning - countRunning
 	if availableCount < 0 {
 		return 0, nil
@@ -432,6 +436,8 @@ func (s *Scheduler) runJob(ctx context.Context, j *Job) {
 		// Run the job
 		execResult, execFailure := s.doExec(ctx, jobUID, jobType, jobData, jobRunDeadline)
 
+		observeExecution(jobType, time.Since(timeStart), execFailure)
+
 		// Use the context.Background() because we want to update the job even if the job's context is done.
 		// The context can be done because the job exceeded its deadline or the server is shutting down.
 		backgroundCtx := context.Background()
@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import "time"
+
+type Provider string
+
+const (
+	ProviderMemory Provider = "inmemory"
+	ProviderRedis  Provider = "redis"
+)
+
+type Config struct {
+	App       string // app namespace prefix
+	Namespace string
+
+	Provider Provider
+
+	// MaxLines is the maximum number of lines retained per step.
+	MaxLines int64
+	// Expiry is the time after the last write after which a step's stream is removed.
+	Expiry time.Duration
+	// BlockTimeout is the maximum duration a tail blocks while waiting for new lines.
+	BlockTimeout time.Duration
+}
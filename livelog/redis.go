@@ -0,0 +1,288 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	redisFieldLine   = "line"
+	redisFieldCreate = "create"
+	redisFieldEOF    = "eof"
+
+	// redisReadCount is the maximum number of lines read from a stream at once.
+	redisReadCount = 100
+
+	// redisDeleteGracePeriod is the time a deleted stream is kept around,
+	// so tails on other instances can read the remaining lines and the end of the stream.
+	redisDeleteGracePeriod = time.Minute
+)
+
+// redisIncrSubscribers changes the number of subscribers of a stream, only if the stream is registered.
+var redisIncrSubscribers = redis.NewScript(`
+if redis.call("HEXISTS", KEYS[1], ARGV[1]) == 1 then
+	return redis.call("HINCRBY", KEYS[1], ARGV[1], ARGV[2])
+end
+return 0
+`)
+
+type redisStreamer struct {
+	client redis.UniversalClient
+	config Config
+}
+
+// NewRedis returns a new log streamer backed by Redis streams.
+// Each step is stored in its own Redis stream capped at config.MaxLines lines,
+// which makes logs written on one instance available to tails on all other instances.
+func NewRedis(client redis.UniversalClient, config Config) LogStream {
+	if config.MaxLines <= 0 {
+		config.MaxLines = bufferSize
+	}
+	if config.BlockTimeout <= 0 {
+		config.BlockTimeout = 5 * time.Second
+	}
+
+	return &redisStreamer{
+		client: client,
+		config: config,
+	}
+}
+
+func (s *redisStreamer) Create(ctx context.Context, id int64) error {
+	key := s.streamKey(id)
+
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: key,
+			Values: map[string]interface{}{redisFieldCreate: time.Now().UnixMilli()},
+		})
+		if s.config.Expiry > 0 {
+			pipe.Expire(ctx, key, s.config.Expiry)
+		}
+		pipe.HSet(ctx, s.registryKey(), strconv.FormatInt(id, 10), 0)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create log stream %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *redisStreamer) Delete(ctx context.Context, id int64) error {
+	key := s.streamKey(id)
+
+	var xadd *redis.StringCmd
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		// the end of stream marker terminates the tails on all instances.
+		xadd = pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream:     key,
+			NoMkStream: true,
+			Values:     map[string]interface{}{redisFieldEOF: time.Now().UnixMilli()},
+		})
+		pipe.Expire(ctx, key, redisDeleteGracePeriod)
+		pipe.HDel(ctx, s.registryKey(), strconv.FormatInt(id, 10))
+		return nil
+	})
+	if errors.Is(xadd.Err(), redis.Nil) {
+		return ErrStreamNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete log stream %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *redisStreamer) Write(ctx context.Context, id int64, line *Line) error {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log line: %w", err)
+	}
+
+	key := s.streamKey(id)
+
+	var xadd *redis.StringCmd
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		xadd = pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream:     key,
+			NoMkStream: true,
+			MaxLen:     s.config.MaxLines,
+			Approx:     true,
+			Values:     map[string]interface{}{redisFieldLine: data},
+		})
+		if s.config.Expiry > 0 {
+			pipe.Expire(ctx, key, s.config.Expiry)
+		}
+		return nil
+	})
+	if errors.Is(xadd.Err(), redis.Nil) {
+		return ErrStreamNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write to log stream %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *redisStreamer) Tail(ctx context.Context, id int64) (<-chan *Line, <-chan error) {
+	key := s.streamKey(id)
+
+	exists, err := s.client.Exists(ctx, key).Result()
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("id", id).Msg("failed to check if log stream exists")
+		return nil, nil
+	}
+	if exists == 0 {
+		return nil, nil
+	}
+
+	// The line channel is unbuffered, so all lines are received by the consumer before the error channel is closed.
+	linec := make(chan *Line)
+	errc := make(chan error)
+
+	go func() {
+		defer close(errc)
+
+		s.incrSubscribers(ctx, id, 1)
+		defer s.incrSubscribers(context.Background(), id, -1)
+
+		err := s.tail(ctx, key, linec)
+		if err != nil && ctx.Err() == nil {
+			select {
+			case errc <- err:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return linec, errc
+}
+
+// tail sends all lines of the stream to the channel, starting with the retained history.
+// It returns once the stream is deleted, expires or the context is done.
+func (s *redisStreamer) tail(ctx context.Context, key string, linec chan<- *Line) error {
+	lastID := "0"
+	for ctx.Err() == nil {
+		streams, err := s.client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{key, lastID},
+			Count:   redisReadCount,
+			Block:   s.config.BlockTimeout,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			// no new lines, stop in case the stream expired.
+			exists, err := s.client.Exists(ctx, key).Result()
+			if err != nil {
+				return fmt.Errorf("failed to check if log stream exists: %w", err)
+			}
+			if exists == 0 {
+				return nil
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read log stream: %w", err)
+		}
+
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				lastID = msg.ID
+
+				if _, ok := msg.Values[redisFieldEOF]; ok {
+					return nil
+				}
+
+				data, ok := msg.Values[redisFieldLine].(string)
+				if !ok {
+					continue
+				}
+
+				line := &Line{}
+				if err = json.Unmarshal([]byte(data), line); err != nil {
+					return fmt.Errorf("failed to unmarshal log line: %w", err)
+				}
+
+				select {
+				case linec <- line:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *redisStreamer) Info(ctx context.Context) *LogStreamInfo {
+	info := &LogStreamInfo{
+		Streams: map[int64]int{},
+	}
+
+	subscribers, err := s.client.HGetAll(ctx, s.registryKey()).Result()
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list log streams")
+		return info
+	}
+
+	for field, value := range subscribers {
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		// streams of steps that never got deleted (e.g. the instance crashed) eventually expire.
+		exists, err := s.client.Exists(ctx, s.streamKey(id)).Result()
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("id", id).Msg("failed to check if log stream exists")
+			continue
+		}
+		if exists == 0 {
+			s.client.HDel(ctx, s.registryKey(), field)
+			continue
+		}
+
+		count, _ := strconv.Atoi(value)
+		info.Streams[id] = count
+	}
+
+	return info
+}
+
+func (s *redisStreamer) incrSubscribers(ctx context.Context, id int64, delta int) {
+	err := redisIncrSubscribers.Run(ctx, s.client, []string{s.registryKey()}, strconv.FormatInt(id, 10), delta).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		log.Ctx(ctx).Warn().Err(err).Int64("id", id).Msg("failed to update number of log stream subscribers")
+	}
+}
+
+func (s *redisStreamer) registryKey() string {
+	return s.config.App + ":" + s.config.Namespace + ":livelog"
+}
+
+func (s *redisStreamer) streamKey(id int64) string {
+	return s.registryKey() + ":" + strconv.FormatInt(id, 10)
+}
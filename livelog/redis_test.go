@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+const testTimeout = 5 * time.Second
+
+func newTestRedisStreamer(t *testing.T) LogStream {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewRedis(client, Config{
+		App:          "gitness",
+		Namespace:    "test",
+		MaxLines:     100,
+		Expiry:       time.Hour,
+		BlockTimeout: 50 * time.Millisecond,
+	})
+}
+
+// receive reads the expected number of lines from the tail.
+func receive(t *testing.T, linec <-chan *Line, errc <-chan error, count int) []*Line {
+	t.Helper()
+
+	lines := make([]*Line, 0, count)
+	for len(lines) < count {
+		select {
+		case line := <-linec:
+			lines = append(lines, line)
+		case err, ok := <-errc:
+			if ok {
+				t.Fatalf("tail failed: %v", err)
+			}
+			t.Fatalf("tail ended after %d of %d lines", len(lines), count)
+		case <-time.After(testTimeout):
+			t.Fatalf("timed out after %d of %d lines", len(lines), count)
+		}
+	}
+
+	return lines
+}
+
+// waitEnd waits until the tail is ended.
+func waitEnd(t *testing.T, errc <-chan error) {
+	t.Helper()
+
+	select {
+	case err, ok := <-errc:
+		if ok {
+			t.Fatalf("tail failed: %v", err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for the tail to end")
+	}
+}
+
+func TestRedisWriteTail(t *testing.T) {
+	ctx := context.Background()
+	s := newTestRedisStreamer(t)
+
+	if err := s.Create(ctx, 1); err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := s.Write(ctx, 1, &Line{Number: i, Message: "history"}); err != nil {
+			t.Fatalf("failed to write line: %v", err)
+		}
+	}
+
+	linec, errc := s.Tail(ctx, 1)
+	if linec == nil {
+		t.Fatal("expected tail of existing stream")
+	}
+
+	lines := receive(t, linec, errc, 3)
+	for i, line := range lines {
+		if line.Number != i || line.Message != "history" {
+			t.Errorf("unexpected line %d: %+v", i, line)
+		}
+	}
+
+	if err := s.Write(ctx, 1, &Line{Number: 3, Message: "live"}); err != nil {
+		t.Fatalf("failed to write line: %v", err)
+	}
+
+	lines = receive(t, linec, errc, 1)
+	if lines[0].Number != 3 || lines[0].Message != "live" {
+		t.Errorf("unexpected live line: %+v", lines[0])
+	}
+
+	if err := s.Delete(ctx, 1); err != nil {
+		t.Fatalf("failed to delete stream: %v", err)
+	}
+
+	waitEnd(t, errc)
+}
+
+func TestRedisSubscribers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := newTestRedisStreamer(t)
+
+	if err := s.Create(ctx, 1); err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	linec1, errc1 := s.Tail(ctx, 1)
+	linec2, errc2 := s.Tail(ctx, 1)
+
+	deadline := time.Now().Add(testTimeout)
+	for s.Info(ctx).Streams[1] != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 2 subscribers, got %v", s.Info(ctx).Streams)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := s.Write(ctx, 1, &Line{Number: i}); err != nil {
+			t.Fatalf("failed to write line: %v", err)
+		}
+	}
+
+	for _, tail := range []struct {
+		linec <-chan *Line
+		errc  <-chan error
+	}{{linec1, errc1}, {linec2, errc2}} {
+		lines := receive(t, tail.linec, tail.errc, 2)
+		if lines[0].Number != 0 || lines[1].Number != 1 {
+			t.Errorf("unexpected lines: %+v, %+v", lines[0], lines[1])
+		}
+	}
+
+	if err := s.Delete(ctx, 1); err != nil {
+		t.Fatalf("failed to delete stream: %v", err)
+	}
+
+	waitEnd(t, errc1)
+	waitEnd(t, errc2)
+
+	if _, ok := s.Info(ctx).Streams[1]; ok {
+		t.Error("expected deleted stream to be unregistered")
+	}
+}
+
+func TestRedisDelete(t *testing.T) {
+	ctx := context.Background()
+	s := newTestRedisStreamer(t)
+
+	if err := s.Delete(ctx, 1); !errors.Is(err, ErrStreamNotFound) {
+		t.Errorf("expected ErrStreamNotFound deleting unknown stream, got %v", err)
+	}
+
+	if err := s.Write(ctx, 1, &Line{}); !errors.Is(err, ErrStreamNotFound) {
+		t.Errorf("expected ErrStreamNotFound writing to unknown stream, got %v", err)
+	}
+
+	if linec, errc := s.Tail(ctx, 1); linec != nil || errc != nil {
+		t.Error("expected no tail of unknown stream")
+	}
+
+	if err := s.Create(ctx, 1); err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	if err := s.Write(ctx, 1, &Line{Number: 0}); err != nil {
+		t.Fatalf("failed to write line: %v", err)
+	}
+	if err := s.Delete(ctx, 1); err != nil {
+		t.Fatalf("failed to delete stream: %v", err)
+	}
+
+	// a tail started within the grace period still receives the remaining lines.
+	linec, errc := s.Tail(ctx, 1)
+	if linec == nil {
+		t.Fatal("expected tail of deleted stream within the grace period")
+	}
+	receive(t, linec, errc, 1)
+	waitEnd(t, errc)
+
+	if len(s.Info(ctx).Streams) != 0 {
+		t.Errorf("expected no registered streams, got %v", s.Info(ctx).Streams)
+	}
+}
@@ -59,3 +59,10 @@ func WithRequestID(reqID string) Option {
 		return c.Str("request_id", reqID)
 	}
 }
+
+// WithTraceID can be used to annotate logs with the id of the trace.
+func WithTraceID(traceID string) Option {
+	return func(c zerolog.Context) zerolog.Context {
+		return c.Str("trace_id", traceID)
+	}
+}
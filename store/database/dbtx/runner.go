@@ -31,7 +31,7 @@ type runnerDB struct {
 
 var _ AccessorTx = runnerDB{}
 
-func (r runnerDB) WithTx(ctx context.Context, txFn func(context.Context) error, opts ...interface{}) error {
+func (r runnerDB) WithTx(ctx context.Context, txFn func(context.Context) error, opts ...interface{}) (err error) {
 	var txOpts *sql.TxOptions
 	for _, opt := range opts {
 		if v, ok := opt.(*sql.TxOptions); ok {
@@ -43,6 +43,9 @@ func (r runnerDB) WithTx(ctx context.Context, txFn func(context.Context) error,
 		txOpts = TxDefault
 	}
 
+	ctx, span := startTxSpan(ctx, txOpts.ReadOnly)
+	defer func() { endSpan(span, err) }()
+
 	if txOpts.ReadOnly {
 		r.mx.RLock()
 		defer r.mx.RUnlock()
@@ -100,33 +103,63 @@ func (r runnerDB) BindNamed(query string, arg interface{}) (string, []interface{
 }
 
 func (r runnerDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startQuerySpan(ctx, r.db.DriverName(), query)
+
 	r.mx.Lock()
 	defer r.mx.Unlock()
-	return r.db.QueryContext(ctx, query, args...)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	endSpan(span, err)
+
+	return rows, err
 }
 
 func (r runnerDB) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	ctx, span := startQuerySpan(ctx, r.db.DriverName(), query)
+
 	r.mx.Lock()
 	defer r.mx.Unlock()
-	return r.db.QueryxContext(ctx, query, args...)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	endSpan(span, err)
+
+	return rows, err
 }
 
 func (r runnerDB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	ctx, span := startQuerySpan(ctx, r.db.DriverName(), query)
+
 	r.mx.Lock()
 	defer r.mx.Unlock()
-	return r.db.QueryRowxContext(ctx, query, args...)
+
+	row := r.db.QueryRowxContext(ctx, query, args...)
+	endSpan(span, row.Err())
+
+	return row
 }
 
 func (r runnerDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, r.db.DriverName(), query)
+
 	r.mx.Lock()
 	defer r.mx.Unlock()
-	return r.db.ExecContext(ctx, query, args...)
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	endSpan(span, err)
+
+	return result, err
 }
 
 func (r runnerDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuerySpan(ctx, r.db.DriverName(), query)
+
 	r.mx.Lock()
 	defer r.mx.Unlock()
-	return r.db.QueryRowContext(ctx, query, args...)
+
+	row := r.db.QueryRowContext(ctx, query, args...)
+	endSpan(span, row.Err())
+
+	return row
 }
 
 func (r runnerDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
@@ -148,15 +181,27 @@ func (r runnerDB) PrepareNamedContext(ctx context.Context, query string) (*sqlx.
 }
 
 func (r runnerDB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startQuerySpan(ctx, r.db.DriverName(), query)
+
 	r.mx.Lock()
 	defer r.mx.Unlock()
-	return r.db.GetContext(ctx, dest, query, args...)
+
+	err := r.db.GetContext(ctx, dest, query, args...)
+	endSpan(span, err)
+
+	return err
 }
 
 func (r runnerDB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startQuerySpan(ctx, r.db.DriverName(), query)
+
 	r.mx.Lock()
 	defer r.mx.Unlock()
-	return r.db.SelectContext(ctx, dest, query, args...)
+
+	err := r.db.SelectContext(ctx, dest, query, args...)
+	endSpan(span, err)
+
+	return err
 }
 
 // runnerTx executes sqlx database transaction calls.
@@ -169,6 +214,55 @@ type runnerTx struct {
 
 var _ TransactionAccessor = (*runnerTx)(nil)
 
+func (r *runnerTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startQuerySpan(ctx, r.DriverName(), query)
+	rows, err := r.TransactionAccessor.QueryContext(ctx, query, args...)
+	endSpan(span, err)
+	return rows, err
+}
+
+func (r *runnerTx) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	ctx, span := startQuerySpan(ctx, r.DriverName(), query)
+	rows, err := r.TransactionAccessor.QueryxContext(ctx, query, args...)
+	endSpan(span, err)
+	return rows, err
+}
+
+func (r *runnerTx) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	ctx, span := startQuerySpan(ctx, r.DriverName(), query)
+	row := r.TransactionAccessor.QueryRowxContext(ctx, query, args...)
+	endSpan(span, row.Err())
+	return row
+}
+
+func (r *runnerTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, r.DriverName(), query)
+	result, err := r.TransactionAccessor.ExecContext(ctx, query, args...)
+	endSpan(span, err)
+	return result, err
+}
+
+func (r *runnerTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuerySpan(ctx, r.DriverName(), query)
+	row := r.TransactionAccessor.QueryRowContext(ctx, query, args...)
+	endSpan(span, row.Err())
+	return row
+}
+
+func (r *runnerTx) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startQuerySpan(ctx, r.DriverName(), query)
+	err := r.TransactionAccessor.GetContext(ctx, dest, query, args...)
+	endSpan(span, err)
+	return err
+}
+
+func (r *runnerTx) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startQuerySpan(ctx, r.DriverName(), query)
+	err := r.TransactionAccessor.SelectContext(ctx, dest, query, args...)
+	endSpan(span, err)
+	return err
+}
+
 func (r *runnerTx) Commit() error {
 	err := r.TransactionAccessor.Commit()
 	if err == nil {
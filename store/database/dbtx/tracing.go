@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/harness/gitness/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/harness/gitness/store/database/dbtx")
+
+// startQuerySpan starts the span of a single database query.
+// The span is named after the operation of the query (e.g. "db.select").
+func startQuerySpan(ctx context.Context, driverName string, query string) (context.Context, trace.Span) {
+	name := "db.query"
+	if fields := strings.Fields(query); len(fields) > 0 {
+		name = "db." + strings.ToLower(fields[0])
+	}
+
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(driverName),
+			semconv.DBStatement(query),
+		),
+	)
+}
+
+// startTxSpan starts the span of a database transaction.
+func startTxSpan(ctx context.Context, readOnly bool) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "db.transaction",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.Bool("db.transaction.read_only", readOnly),
+		),
+	)
+}
+
+// endSpan ends the span of a database operation. Not finding a row isn't considered an error.
+func endSpan(span trace.Span, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil
+	}
+
+	tracing.End(span, err)
+}
@@ -187,6 +187,8 @@ func (c *MemoryConsumer) consume(ctx context.Context) {
 				continue
 			}
 
+			observeLag(c.groupName, m.message)
+
 			err := func() (err error) {
 				// Ensure that handlers don't cause panic.
 				defer func() {
@@ -198,6 +200,7 @@ func (c *MemoryConsumer) consume(ctx context.Context) {
 
 				return handler.handle(ctx, m.id, m.values)
 			}()
+			observeConsumed(c.groupName, m.message, err)
 
 			if err != nil {
 				c.pushError(fmt.Errorf("failed to process message with id '%s' in stream '%s' (retries: %d): %w",
@@ -17,6 +17,7 @@ package stream
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // MemoryProducer sends messages to streams of a MemoryBroker.
@@ -44,6 +45,7 @@ func (p *MemoryProducer) Send(_ context.Context, streamID string, payload map[st
 		message{
 			streamID: transposedStreamID,
 			values:   payload,
+			created:  time.Now(),
 		})
 	if err != nil {
 		return "", fmt.Errorf("failed to write to stream '%s' (full stream '%s'). Error: %w",
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	metricsResultSuccess = "success"
+	metricsResultFailure = "failure"
+)
+
+var (
+	consumerLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "stream",
+		Name:      "consumer_lag_seconds",
+		Help:      "Time between a message being added to a stream and a consumer group picking it up.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 15, 30, 60, 300, 900},
+	}, []string{"group", "stream"})
+
+	consumerMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "stream",
+		Name:      "consumer_messages_total",
+		Help:      "Number of stream messages processed by a consumer group, partitioned by result.",
+	}, []string{"group", "stream", "result"})
+)
+
+// observeLag records the lag of a message that is about to be processed by a consumer group.
+func observeLag(groupName string, m message) {
+	if m.created.IsZero() {
+		return
+	}
+
+	consumerLag.WithLabelValues(groupName, m.streamID).Observe(time.Since(m.created).Seconds())
+}
+
+// observeConsumed records the result of a message that got processed by a consumer group.
+func observeConsumed(groupName string, m message, err error) {
+	result := metricsResultSuccess
+	if err != nil {
+		result = metricsResultFailure
+	}
+
+	consumerMessages.WithLabelValues(groupName, m.streamID, result).Inc()
+}
+
+// redisMessageTime returns the time a redis stream message was added to the stream.
+// Redis stream message ids are of the form "<unix milliseconds>-<sequence number>".
+func redisMessageTime(id string) time.Time {
+	ms, _, _ := strings.Cut(id, "-")
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(n)
+}
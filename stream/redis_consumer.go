@@ -317,6 +317,7 @@ func (c *RedisConsumer) reader(ctx context.Context) {
 						streamID: stream.Stream,
 						id:       m.ID,
 						values:   m.Values,
+						created:  redisMessageTime(m.ID),
 					}
 				}
 			}
@@ -441,6 +442,7 @@ func (c *RedisConsumer) reclaimer(ctx context.Context, reclaimInterval time.Dura
 						streamID: streamID,
 						id:       claimedMessage.ID,
 						values:   claimedMessage.Values,
+						created:  redisMessageTime(claimedMessage.ID),
 					}
 				}
 
@@ -483,6 +485,8 @@ func (c *RedisConsumer) consumer(ctx context.Context) {
 				continue
 			}
 
+			observeLag(c.groupName, m)
+
 			err := func() (err error) {
 				// Ensure that handlers don't cause panic.
 				defer func() {
@@ -494,6 +498,7 @@ func (c *RedisConsumer) consumer(ctx context.Context) {
 
 				return handler.handle(ctx, m.id, m.values)
 			}()
+			observeConsumed(c.groupName, m, err)
 			if err != nil {
 				c.pushError(fmt.Errorf("failed to process message '%s' in stream '%s': %w", m.id, m.streamID, err))
 				continue
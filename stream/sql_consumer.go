@@ -0,0 +1,471 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// SQLConsumer provides functionality to process streams persisted in a SQL database as part of a consumer group.
+type SQLConsumer struct {
+	store Store
+	// namespace specifies the namespace of the keys - any stream key will be prefixed with it
+	namespace string
+	// groupName specifies the name of the consumer group.
+	groupName string
+	// consumerName specifies the name of the consumer.
+	consumerName string
+
+	// Config is the generic consumer configuration.
+	Config ConsumerConfig
+
+	// streams is a map of all registered streams and their handlers.
+	streams map[string]handler
+
+	isStarted    bool
+	messageQueue chan message
+	errorCh      chan error
+	infoCh       chan string
+}
+
+// NewSQLConsumer creates new SQL stream consumer. Streams are polled from the database.
+// It returns channels of info messages and errors. The caller should not block on these channels for too long.
+// These channels are provided mainly for logging.
+func NewSQLConsumer(store Store, namespace string,
+	groupName string, consumerName string) (*SQLConsumer, error) {
+	if groupName == "" {
+		return nil, errors.New("groupName can't be empty")
+	}
+	if consumerName == "" {
+		return nil, errors.New("consumerName can't be empty")
+	}
+
+	const queueCapacity = 500
+	const errorChCapacity = 64
+	const infoChCapacity = 64
+
+	return &SQLConsumer{
+		store:        store,
+		namespace:    namespace,
+		groupName:    groupName,
+		consumerName: consumerName,
+		streams:      map[string]handler{},
+		Config:       defaultConfig,
+		isStarted:    false,
+		messageQueue: make(chan message, queueCapacity),
+		errorCh:      make(chan error, errorChCapacity),
+		infoCh:       make(chan string, infoChCapacity),
+	}, nil
+}
+
+func (c *SQLConsumer) Configure(opts ...ConsumerOption) {
+	if c.isStarted {
+		return
+	}
+
+	for _, opt := range opts {
+		opt.apply(&c.Config)
+	}
+}
+
+func (c *SQLConsumer) Register(streamID string, fn HandlerFunc, opts ...HandlerOption) error {
+	if c.isStarted {
+		return ErrAlreadyStarted
+	}
+	if streamID == "" {
+		return errors.New("streamID can't be empty")
+	}
+	if fn == nil {
+		return errors.New("fn can't be empty")
+	}
+
+	// transpose streamID to key namespace - no need to keep inner streamID
+	transposedStreamID := transposeStreamID(c.namespace, streamID)
+	if _, ok := c.streams[transposedStreamID]; ok {
+		return fmt.Errorf("consumer is already registered for '%s' (sql stream '%s')", streamID, transposedStreamID)
+	}
+
+	// create final config for handler
+	config := c.Config.DefaultHandlerConfig
+	for _, opt := range opts {
+		opt.apply(&config)
+	}
+
+	c.streams[transposedStreamID] = handler{
+		handle: fn,
+		config: config,
+	}
+
+	return nil
+}
+
+func (c *SQLConsumer) Start(ctx context.Context) error {
+	if c.isStarted {
+		return ErrAlreadyStarted
+	}
+
+	if len(c.streams) == 0 {
+		return errors.New("no streams registered")
+	}
+
+	// Create consumer group for all streams.
+	err := c.createGroupForAllStreams(ctx)
+	if err != nil {
+		return err
+	}
+
+	// mark as started before starting go routines (can't error out from here)
+	c.isStarted = true
+
+	wg := &sync.WaitGroup{}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// launch sql reader, it will finish when the ctx is done
+		const pollInterval = 1 * time.Second
+		c.reader(ctx, pollInterval)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// launch sql message reclaimer, it will finish when the ctx is done.
+		const reclaimInterval = 10 * time.Second
+		c.reclaimer(ctx, reclaimInterval)
+	}()
+
+	for i := 0; i < c.Config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// launch sql message consumer, it will finish when the ctx is done
+			c.consumer(ctx)
+		}()
+	}
+
+	go func() {
+		// wait for all go routines to complete
+		wg.Wait()
+
+		// close all channels
+		close(c.messageQueue)
+		close(c.errorCh)
+		close(c.infoCh)
+	}()
+
+	return nil
+}
+
+// reader method polls the streams for messages that weren't delivered to the consumer group yet.
+// The messages are then sent to a go channel for processing.
+// Before polling, the history of the consumer (messages read but not acknowledged) is delivered again.
+// The method terminates when the provided context finishes.
+//
+//nolint:gocognit // refactor if needed
+func (c *SQLConsumer) reader(ctx context.Context, pollInterval time.Duration) {
+	delays := []time.Duration{5 * time.Second, 15 * time.Second, 30 * time.Second, time.Minute}
+	consecutiveFailures := 0
+
+	// NOTE: for the first read ever we want to get the history of the consumer (to allow for seamless restarts)
+	// ASSUMPTION: only one consumer with a given groupName+consumerName is running at a time
+	scanHistory := true
+
+	readTimer := time.NewTimer(0)
+	defer readTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-readTimer.C:
+			if scanHistory {
+				if err := c.readHistory(ctx); err != nil {
+					c.pushError(fmt.Errorf("failed to read history of consumer: %w", err))
+				} else {
+					scanHistory = false
+					c.pushInfo("completed scan of history")
+				}
+			}
+
+			const count = 100
+			hasMore := false
+			failed := false
+
+			for streamID := range c.streams {
+				messages, err := c.store.ReadGroup(ctx, streamID, c.groupName, c.consumerName, count)
+
+				// if context is canceled, the next iteration will exit cleanly
+				if errors.Is(err, context.Canceled) {
+					break
+				}
+
+				// group doesn't exist anymore - recreate it
+				if errors.Is(err, ErrGroupNotFound) {
+					if cErr := c.createGroupForAllStreams(ctx); cErr != nil {
+						c.pushError(fmt.Errorf("failed to re-create group for at least one stream: %w", cErr))
+						failed = true
+					} else {
+						c.pushInfo(fmt.Sprintf("re-created group for all streams where it got removed, original error: %s",
+							err))
+					}
+					continue
+				}
+
+				if err != nil {
+					failed = true
+					c.pushError(fmt.Errorf("failed to read sql stream '%s' (consecutive fails: %d): %w",
+						streamID, consecutiveFailures+1, err))
+					continue
+				}
+
+				if len(messages) == count {
+					hasMore = true
+				}
+
+				c.enqueue(ctx, streamID, messages)
+			}
+
+			var delay time.Duration
+			switch {
+			case failed:
+				delay = delays[consecutiveFailures]
+				if consecutiveFailures < len(delays)-1 {
+					consecutiveFailures++
+				}
+			case hasMore:
+				consecutiveFailures = 0
+				delay = 0
+			default:
+				consecutiveFailures = 0
+				delay = pollInterval
+			}
+
+			readTimer.Reset(delay)
+		}
+	}
+}
+
+// readHistory enqueues all pending messages of the consumer for processing.
+func (c *SQLConsumer) readHistory(ctx context.Context) error {
+	const count = 100
+
+	for streamID := range c.streams {
+		afterID := int64(0)
+		for {
+			messages, err := c.store.ListPending(ctx, streamID, c.groupName, c.consumerName, afterID, count)
+			if err != nil {
+				return fmt.Errorf("failed to list pending messages of stream '%s': %w", streamID, err)
+			}
+
+			if len(messages) == 0 {
+				break
+			}
+
+			c.enqueue(ctx, streamID, messages)
+
+			afterID = messages[len(messages)-1].ID
+		}
+	}
+
+	return nil
+}
+
+// enqueue decodes the stored messages and puts them into the message queue.
+func (c *SQLConsumer) enqueue(ctx context.Context, streamID string, messages []*StoreMessage) {
+	for _, m := range messages {
+		values, err := decodeSQLPayload(m.Payload)
+		if err != nil {
+			// the message can never be processed - acknowledge (discard) it
+			c.pushError(fmt.Errorf("failed to decode message '%d' in stream '%s', discarding it: %w",
+				m.ID, streamID, err))
+			if errAck := c.store.Ack(ctx, streamID, c.groupName, m.ID); errAck != nil {
+				c.pushError(fmt.Errorf("failed to acknowledge (discard) message '%d' in stream '%s': %w",
+					m.ID, streamID, errAck))
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case c.messageQueue <- message{
+			streamID: streamID,
+			id:       formatSQLMessageID(m.ID),
+			values:   values,
+			created:  time.UnixMilli(m.Created),
+		}:
+		}
+	}
+}
+
+// reclaimer periodically inspects pending messages of the consumer group.
+// If a message sits longer than the idle timeout, we attempt to reclaim the message for this consumer
+// and enqueue it for processing.
+//
+//nolint:gocognit // refactor if needed
+func (c *SQLConsumer) reclaimer(ctx context.Context, reclaimInterval time.Duration) {
+	reclaimTimer := time.NewTimer(reclaimInterval)
+	defer func() {
+		reclaimTimer.Stop()
+	}()
+
+	const count = 64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reclaimTimer.C:
+			for streamID, handler := range c.streams {
+				resPending, errPending := c.store.ListIdle(ctx, streamID, c.groupName,
+					handler.config.idleTimeout, count)
+				if errPending != nil {
+					c.pushError(fmt.Errorf("failed to fetch pending messages: %w", errPending))
+					continue
+				}
+
+				for _, resMessage := range resPending {
+					if resMessage.DeliveryCount > handler.config.maxRetries {
+						// Delivery count gets increased after every claim.
+						// Large delivery count might mean there is something wrong with the message, so we'll ack it.
+						// WARNING this will discard the message!
+						errAck := c.store.Ack(ctx, streamID, c.groupName, resMessage.ID)
+						if errAck != nil {
+							c.pushError(fmt.Errorf(
+								"failed to force acknowledge (discard) message '%d' (Retries: %d) in stream '%s': %w",
+								resMessage.ID, resMessage.DeliveryCount-1, streamID, errAck))
+						} else {
+							c.pushError(fmt.Errorf(
+								"force acknowledged (discarded) message '%d' (Retries: %d) in stream '%s'",
+								resMessage.ID, resMessage.DeliveryCount-1, streamID))
+						}
+						continue
+					}
+
+					// Otherwise, claim the message so we can retry it.
+					claimedMessage, errClaim := c.store.Claim(ctx, streamID, c.groupName, c.consumerName,
+						resMessage.ID, handler.config.idleTimeout)
+					if errors.Is(errClaim, ErrMessageNotFound) {
+						// This can happen if two consumers try to claim the same message at once,
+						// or if the message got removed from the stream in the meantime.
+						c.pushInfo(fmt.Sprintf("message '%d' in stream '%s' couldn't be claimed",
+							resMessage.ID, streamID))
+						continue
+					}
+					if errClaim != nil {
+						c.pushError(fmt.Errorf("failed to claim message '%d' in stream '%s': %w",
+							resMessage.ID, streamID, errClaim))
+						continue
+					}
+
+					c.enqueue(ctx, streamID, []*StoreMessage{claimedMessage})
+				}
+			}
+
+			reclaimTimer.Reset(reclaimInterval)
+		}
+	}
+}
+
+// consumer method consumes messages coming from the database.
+// The method terminates when messageQueue channel closes.
+func (c *SQLConsumer) consumer(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m := <-c.messageQueue:
+			if m.id == "" {
+				// id should never be empty, if it is then the channel is closed
+				return
+			}
+
+			handler, ok := c.streams[m.streamID]
+			if !ok {
+				// we don't want to ack the message
+				// maybe someone else can claim and process it (worst case it expires)
+				c.pushError(fmt.Errorf("received message '%s' in stream '%s' that doesn't belong to us, skip",
+					m.id, m.streamID))
+				continue
+			}
+
+			observeLag(c.groupName, m)
+
+			err := func() (err error) {
+				// Ensure that handlers don't cause panic.
+				defer func() {
+					if r := recover(); r != nil {
+						c.pushError(fmt.Errorf("PANIC when processing message '%s' in stream '%s':\n%s",
+							m.id, m.streamID, debug.Stack()))
+					}
+				}()
+
+				return handler.handle(ctx, m.id, m.values)
+			}()
+			observeConsumed(c.groupName, m, err)
+			if err != nil {
+				c.pushError(fmt.Errorf("failed to process message '%s' in stream '%s': %w", m.id, m.streamID, err))
+				continue
+			}
+
+			id, err := parseSQLMessageID(m.id)
+			if err != nil {
+				c.pushError(fmt.Errorf("invalid id of message '%s' in stream '%s': %w", m.id, m.streamID, err))
+				continue
+			}
+
+			err = c.store.Ack(ctx, m.streamID, c.groupName, id)
+			if err != nil {
+				c.pushError(fmt.Errorf("failed to acknowledge message '%s' in stream '%s': %w", m.id, m.streamID, err))
+				continue
+			}
+		}
+	}
+}
+
+func (c *SQLConsumer) pushError(err error) {
+	select {
+	case c.errorCh <- err:
+	default:
+	}
+}
+
+func (c *SQLConsumer) pushInfo(s string) {
+	select {
+	case c.infoCh <- s:
+	default:
+	}
+}
+
+func (c *SQLConsumer) Errors() <-chan error { return c.errorCh }
+func (c *SQLConsumer) Infos() <-chan string { return c.infoCh }
+
+func (c *SQLConsumer) createGroupForAllStreams(ctx context.Context) error {
+	for streamID := range c.streams {
+		err := c.store.CreateGroup(ctx, streamID, c.groupName)
+		if err != nil {
+			return fmt.Errorf("failed to create consumer group '%s' for stream '%s': %w", c.groupName, streamID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"fmt"
+)
+
+// trimInterval defines after how many messages the stream gets trimmed in case the max length is approximated.
+const trimInterval = 100
+
+// SQLProducer sends messages to streams persisted in a SQL database.
+type SQLProducer struct {
+	store Store
+	// namespace defines the namespace of the stream keys - any stream key will be prefixed with it.
+	namespace string
+	// maxStreamLength defines the maximum number of entries in each stream (ring buffer).
+	maxStreamLength int64
+	// approxMaxStreamLength specifies whether the maxStreamLength should be approximated.
+	// NOTE: enabling approximation of stream length trims streams only periodically (reduces database load).
+	approxMaxStreamLength bool
+}
+
+func NewSQLProducer(store Store, namespace string,
+	maxStreamLength int64, approxMaxStreamLength bool) *SQLProducer {
+	return &SQLProducer{
+		store:                 store,
+		namespace:             namespace,
+		maxStreamLength:       maxStreamLength,
+		approxMaxStreamLength: approxMaxStreamLength,
+	}
+}
+
+// Send sends information to the SQL stream.
+// Returns the message ID in case of success.
+func (p *SQLProducer) Send(ctx context.Context, streamID string, payload map[string]interface{}) (string, error) {
+	// ensure we transpose streamID using the key namespace
+	transposedStreamID := transposeStreamID(p.namespace, streamID)
+
+	raw, err := encodeSQLPayload(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode payload for stream '%s': %w", streamID, err)
+	}
+
+	msgID, err := p.store.Add(ctx, transposedStreamID, raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to write to stream '%s' (sql stream '%s'). Error: %w",
+			streamID, transposedStreamID, err)
+	}
+
+	// trim the stream to keep it within the max length (the message was already sent, don't fail)
+	if p.maxStreamLength > 0 && (!p.approxMaxStreamLength || msgID%trimInterval == 0) {
+		_, _ = p.store.Trim(ctx, transposedStreamID, p.maxStreamLength)
+	}
+
+	return formatSQLMessageID(msgID), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrMessageNotFound is returned by the Store in case a message can't be found (or claimed).
+	ErrMessageNotFound = errors.New("stream message not found")
+
+	// ErrGroupNotFound is returned by the Store in case the consumer group of a stream doesn't exist.
+	ErrGroupNotFound = errors.New("stream consumer group not found")
+)
+
+// Store is an abstraction of the persistence layer used by the SQL stream producer and consumer.
+type Store interface {
+	// Add appends a new message with the provided payload to the stream and returns the message id.
+	Add(ctx context.Context, streamID string, payload []byte) (int64, error)
+
+	// Trim removes the oldest messages of the stream (and their pending entries),
+	// keeping at most maxLength messages in the stream.
+	Trim(ctx context.Context, streamID string, maxLength int64) (int64, error)
+
+	// CreateGroup creates a consumer group for the stream in case it doesn't exist yet.
+	// A new group only receives messages that are added to the stream after its creation.
+	CreateGroup(ctx context.Context, streamID string, groupName string) error
+
+	// ReadGroup reads up to count messages of the stream that haven't been delivered to the group yet.
+	// The returned messages are marked as pending for the consumer until they are acknowledged.
+	// It returns ErrGroupNotFound in case the consumer group doesn't exist.
+	ReadGroup(ctx context.Context, streamID string, groupName string, consumerName string,
+		count int) ([]*StoreMessage, error)
+
+	// ListPending lists up to count pending messages of the consumer with an id bigger than afterID.
+	ListPending(ctx context.Context, streamID string, groupName string, consumerName string,
+		afterID int64, count int) ([]*StoreMessage, error)
+
+	// ListIdle lists up to count pending messages of the group that weren't delivered for at least minIdle.
+	ListIdle(ctx context.Context, streamID string, groupName string,
+		minIdle time.Duration, count int) ([]*StorePendingMessage, error)
+
+	// Claim transfers a pending message that wasn't delivered for at least minIdle to the consumer.
+	// It returns ErrMessageNotFound in case the message got claimed by someone else or doesn't exist anymore.
+	Claim(ctx context.Context, streamID string, groupName string, consumerName string,
+		messageID int64, minIdle time.Duration) (*StoreMessage, error)
+
+	// Ack acknowledges the message for the group, removing it from the pending messages.
+	Ack(ctx context.Context, streamID string, groupName string, messageID int64) error
+}
+
+// StoreMessage is a stream message as persisted by the Store.
+type StoreMessage struct {
+	ID      int64
+	Payload []byte
+	// Created is the time (in unix milliseconds) the message was added to the stream.
+	Created int64
+}
+
+// StorePendingMessage contains the delivery details of a pending message of a consumer group.
+type StorePendingMessage struct {
+	ID            int64
+	Consumer      string
+	Delivered     int64
+	DeliveryCount int
+}
+
+// encodeSQLPayload encodes the payload of a stream message for persisting it in the Store.
+// NOTE: Similar to redis, all values are stored as strings.
+func encodeSQLPayload(payload map[string]interface{}) ([]byte, error) {
+	values := make(map[string][]byte, len(payload))
+	for key, value := range payload {
+		switch v := value.(type) {
+		case []byte:
+			values[key] = v
+		case string:
+			values[key] = []byte(v)
+		default:
+			values[key] = []byte(fmt.Sprint(v))
+		}
+	}
+
+	return json.Marshal(values)
+}
+
+// decodeSQLPayload decodes the payload of a stream message persisted in the Store.
+func decodeSQLPayload(raw []byte) (map[string]interface{}, error) {
+	values := map[string][]byte{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	payload := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		payload[key] = string(value)
+	}
+
+	return payload, nil
+}
+
+func formatSQLMessageID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func parseSQLMessageID(id string) (int64, error) {
+	return strconv.ParseInt(id, 10, 64)
+}
@@ -68,6 +68,8 @@ type message struct {
 	streamID string
 	id       string
 	values   map[string]interface{}
+	// created is the time the message was added to the stream (zero if unknown).
+	created time.Time
 }
 
 // transposeStreamID transposes the provided streamID based on the namespace.
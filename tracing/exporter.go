@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// jsonExporter is a span exporter that writes every span as a single line of JSON.
+type jsonExporter struct {
+	mx      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+	stopped bool
+}
+
+var _ sdktrace.SpanExporter = (*jsonExporter)(nil)
+
+// newJSONExporter returns a new span exporter writing to w. The closer (optional) is closed on shutdown.
+func newJSONExporter(w io.Writer, closer io.Closer) *jsonExporter {
+	return &jsonExporter{
+		encoder: json.NewEncoder(w),
+		closer:  closer,
+	}
+}
+
+type jsonSpan struct {
+	Name         string            `json:"name"`
+	TraceID      string            `json:"trace_id"`
+	SpanID       string            `json:"span_id"`
+	ParentSpanID string            `json:"parent_span_id,omitempty"`
+	Kind         string            `json:"kind"`
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	Duration     string            `json:"duration"`
+	Status       string            `json:"status"`
+	StatusDesc   string            `json:"status_description,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	Events       []jsonEvent       `json:"events,omitempty"`
+	Service      string            `json:"service,omitempty"`
+}
+
+type jsonEvent struct {
+	Name       string            `json:"name"`
+	Time       time.Time         `json:"time"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+func (e *jsonExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	if e.stopped {
+		return nil
+	}
+
+	for _, span := range spans {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := e.encoder.Encode(mapJSONSpan(span)); err != nil {
+			return fmt.Errorf("failed to write span: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (e *jsonExporter) Shutdown(context.Context) error {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	if e.stopped {
+		return nil
+	}
+	e.stopped = true
+
+	if e.closer == nil {
+		return nil
+	}
+
+	return e.closer.Close()
+}
+
+func mapJSONSpan(span sdktrace.ReadOnlySpan) jsonSpan {
+	s := jsonSpan{
+		Name:       span.Name(),
+		TraceID:    span.SpanContext().TraceID().String(),
+		SpanID:     span.SpanContext().SpanID().String(),
+		Kind:       span.SpanKind().String(),
+		Start:      span.StartTime(),
+		End:        span.EndTime(),
+		Duration:   span.EndTime().Sub(span.StartTime()).String(),
+		Status:     span.Status().Code.String(),
+		StatusDesc: span.Status().Description,
+		Attributes: map[string]string{},
+	}
+
+	if span.Parent().IsValid() {
+		s.ParentSpanID = span.Parent().SpanID().String()
+	}
+
+	for _, attr := range span.Attributes() {
+		s.Attributes[string(attr.Key)] = attr.Value.Emit()
+	}
+
+	for _, event := range span.Events() {
+		jEvent := jsonEvent{
+			Name:       event.Name,
+			Time:       event.Time,
+			Attributes: map[string]string{},
+		}
+		for _, attr := range event.Attributes {
+			jEvent.Attributes[string(attr.Key)] = attr.Value.Emit()
+		}
+		s.Events = append(s.Events, jEvent)
+	}
+
+	if res := span.Resource(); res != nil {
+		if name, ok := res.Set().Value("service.name"); ok {
+			s.Service = name.Emit()
+		}
+	}
+
+	return s
+}
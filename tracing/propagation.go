@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// propagator is used to propagate the trace context across process and message boundaries.
+// NOTE: The W3C trace context is used independent of the configured global propagator,
+// as the context is also propagated by processes that don't configure tracing (e.g. githook).
+var propagator = propagation.TraceContext{}
+
+// InjectMap writes the trace context of ctx to the provided map.
+func InjectMap(ctx context.Context, m map[string]string) {
+	propagator.Inject(ctx, propagation.MapCarrier(m))
+}
+
+// ExtractMap returns a copy of ctx with the trace context read from the provided map.
+func ExtractMap(ctx context.Context, m map[string]string) context.Context {
+	return propagator.Extract(ctx, propagation.MapCarrier(m))
+}
+
+// InjectHTTPHeader writes the trace context of ctx to the provided http header.
+func InjectHTTPHeader(ctx context.Context, header http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ExtractHTTPHeader returns a copy of ctx with the trace context read from the provided http header.
+func ExtractHTTPHeader(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Fields returns the keys used for propagating the trace context.
+func Fields() []string {
+	return propagator.Fields()
+}
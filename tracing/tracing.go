@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing configures the opentelemetry tracing of the application.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// ExporterType defines the type of exporter the spans are sent to.
+type ExporterType string
+
+const (
+	// ExporterTypeOTLPGRPC exports spans to an OTLP collector using gRPC.
+	ExporterTypeOTLPGRPC ExporterType = "otlpgrpc"
+	// ExporterTypeOTLPHTTP exports spans to an OTLP collector using HTTP.
+	ExporterTypeOTLPHTTP ExporterType = "otlphttp"
+	// ExporterTypeStdout writes spans as JSON lines to stdout (meant for local testing).
+	ExporterTypeStdout ExporterType = "stdout"
+	// ExporterTypeFile writes spans as JSON lines to a file (meant for local testing).
+	ExporterTypeFile ExporterType = "file"
+)
+
+func ParseExporterType(exporterType string) (ExporterType, bool) {
+	switch t := ExporterType(strings.ToLower(strings.TrimSpace(exporterType))); t {
+	case ExporterTypeOTLPGRPC, ExporterTypeOTLPHTTP, ExporterTypeStdout, ExporterTypeFile:
+		return t, true
+	default:
+		return "", false
+	}
+}
+
+// Config defines the configuration of the tracing.
+type Config struct {
+	Exporter ExporterType
+	// Endpoint is the address of the OTLP collector (the exporter defaults are used if empty).
+	Endpoint string
+	// Insecure disables the transport security of the connection to the OTLP collector.
+	Insecure bool
+	// FilePath is the path of the file the spans are written to by the file exporter.
+	FilePath string
+	// SampleRatio is the ratio of traces that are sampled (unless the parent span is sampled already).
+	SampleRatio float64
+
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Setup configures the global tracer provider and propagator using the provided configuration.
+// The returned function flushes all remaining spans and shuts the tracer provider down.
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	exporter, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s span exporter: %w", config.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+		semconv.ServiceVersion(config.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, error) {
+	switch config.Exporter {
+	case ExporterTypeOTLPGRPC:
+		var opts []otlptracegrpc.Option
+		if config.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+
+	case ExporterTypeOTLPHTTP:
+		var opts []otlptracehttp.Option
+		if config.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+
+	case ExporterTypeStdout:
+		return newJSONExporter(os.Stdout, nil), nil
+
+	case ExporterTypeFile:
+		if config.FilePath == "" {
+			return nil, errors.New("file path is required")
+		}
+		f, err := os.OpenFile(config.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		return newJSONExporter(f, f), nil
+
+	default:
+		return nil, fmt.Errorf("exporter '%s' not supported", config.Exporter)
+	}
+}
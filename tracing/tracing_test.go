@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestParseExporterType(t *testing.T) {
+	var tests = []struct {
+		raw          string
+		expectedType ExporterType
+		expectedOk   bool
+	}{
+		// basic invalid tests
+		{"", ExporterType(""), false},
+		{"a", ExporterType(""), false},
+		{"otlp", ExporterType(""), false},
+
+		// ensure case insensitivity
+		{"stdout", ExporterTypeStdout, true},
+		{"STDOUT", ExporterTypeStdout, true},
+
+		// ensure trim space works
+		{" file ", ExporterTypeFile, true},
+		{"	OTLPGRPC	", ExporterTypeOTLPGRPC, true},
+
+		// testing all valid values
+		{"otlpgrpc", ExporterTypeOTLPGRPC, true},
+		{"otlphttp", ExporterTypeOTLPHTTP, true},
+		{"stdout", ExporterTypeStdout, true},
+		{"file", ExporterTypeFile, true},
+	}
+
+	for i, test := range tests {
+		parsedType, ok := ParseExporterType(test.raw)
+
+		assert.Equal(t, test.expectedOk, ok, "test case %d with input '%s'", i, test.raw)
+		assert.Equal(t, test.expectedType, parsedType, "test case %d with input '%s'", i, test.raw)
+	}
+}
+
+func TestPropagationAndExport(t *testing.T) {
+	buff := &bytes.Buffer{}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(newJSONExporter(buff, nil)))
+	tracer := provider.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+
+	// propagate the trace context via a map (e.g. stream payload) and continue the trace.
+	traceContext := map[string]string{}
+	InjectMap(ctx, traceContext)
+	require.NotEmpty(t, traceContext)
+
+	remoteCtx := ExtractMap(context.Background(), traceContext)
+	_, child := tracer.Start(remoteCtx, "child", trace.WithSpanKind(trace.SpanKindConsumer))
+	child.End()
+	parent.End()
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	decoder := json.NewDecoder(buff)
+
+	var childSpan, parentSpan jsonSpan
+	require.NoError(t, decoder.Decode(&childSpan))
+	require.NoError(t, decoder.Decode(&parentSpan))
+
+	assert.Equal(t, "child", childSpan.Name)
+	assert.Equal(t, "consumer", childSpan.Kind)
+	assert.Equal(t, parentSpan.TraceID, childSpan.TraceID)
+	assert.Equal(t, parentSpan.SpanID, childSpan.ParentSpanID)
+	assert.Empty(t, parentSpan.ParentSpanID)
+}
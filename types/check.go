@@ -109,3 +109,23 @@ type PullReqCheck struct {
 	Bypassable bool  `json:"bypassable"`
 	Check      Check `json:"check"`
 }
+
+// CheckAnnotation is a finding of a status check attached to a line range of a file in the commit.
+type CheckAnnotation struct {
+	ID              int64                        `json:"id"`
+	CheckID         int64                        `json:"-"`
+	CheckIdentifier string                       `json:"check_identifier"`
+	Path            string                       `json:"path"`
+	StartLine       int                          `json:"start_line"`
+	EndLine         int                          `json:"end_line"`
+	Severity        enum.CheckAnnotationSeverity `json:"severity"`
+	Title           string                       `json:"title,omitempty"`
+	Message         string                       `json:"message"`
+	Created         int64                        `json:"created"`
+}
+
+// PullReqCheckAnnotations holds status check annotations that overlap the changes of a pull request.
+type PullReqCheckAnnotations struct {
+	CommitSHA   string            `json:"commit_sha"`
+	Annotations []CheckAnnotation `json:"annotations"`
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/events"
 	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 
@@ -50,6 +51,18 @@ type Config struct {
 		ServiceName string `envconfig:"GITNESS_PROFILER_SERVICE_NAME" default:"gitness"`
 	}
 
+	// Tracing defines the configuration of the opentelemetry tracing.
+	Tracing struct {
+		// Exporter defines where spans are exported to (otlpgrpc, otlphttp, stdout or file).
+		// Tracing is disabled if no exporter is configured.
+		Exporter    string  `envconfig:"GITNESS_TRACING_EXPORTER"`
+		Endpoint    string  `envconfig:"GITNESS_TRACING_ENDPOINT"`
+		Insecure    bool    `envconfig:"GITNESS_TRACING_INSECURE"`
+		FilePath    string  `envconfig:"GITNESS_TRACING_FILE_PATH" default:"traces.json"`
+		SampleRatio float64 `envconfig:"GITNESS_TRACING_SAMPLE_RATIO" default:"1"`
+		ServiceName string  `envconfig:"GITNESS_TRACING_SERVICE_NAME" default:"gitness"`
+	}
+
 	// URL defines the URLs via which the different parts of the service are reachable by.
 	URL struct {
 		// Base is used to generate external facing URLs in case they aren't provided explicitly.
@@ -294,6 +307,21 @@ type Config struct {
 		DefaultNamespace string `envconfig:"GITNESS_LOCK_DEFAULT_NAMESPACE" default:"default"`
 	}
 
+	LiveLog struct {
+		// Provider is the name of the service used to stream live logs of pipeline steps (inmemory or redis).
+		Provider livelog.Provider `envconfig:"GITNESS_LIVELOG_PROVIDER"         default:"inmemory"`
+		// AppNamespace is just service app prefix to avoid conflicts on key definition
+		AppNamespace string `envconfig:"GITNESS_LIVELOG_APP_NAMESPACE"       default:"gitness"`
+		// DefaultNamespace is custom namespace for their keys
+		DefaultNamespace string `envconfig:"GITNESS_LIVELOG_DEFAULT_NAMESPACE" default:"default"`
+		// MaxLines is the maximum number of lines retained per step.
+		MaxLines int64 `envconfig:"GITNESS_LIVELOG_MAX_LINES" default:"5000"`
+		// Expiry is the duration after the last write after which the live log of a step is removed.
+		Expiry time.Duration `envconfig:"GITNESS_LIVELOG_EXPIRY" default:"24h"`
+		// BlockTimeout is the maximum duration a tail waits for new lines before checking the stream again.
+		BlockTimeout time.Duration `envconfig:"GITNESS_LIVELOG_BLOCK_TIMEOUT" default:"5s"`
+	}
+
 	PubSub struct {
 		// Provider is a name of distributed lock service like redis, memory, file etc...
 		Provider pubsub.Provider `envconfig:"GITNESS_PUBSUB_PROVIDER"                default:"inmemory"`
@@ -328,6 +356,16 @@ type Config struct {
 		AllowLoopback       bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_LOOPBACK" default:"false"`
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
+		// RedeliveryMaxAttempts is the maximum number of automatic redeliveries of a retriable webhook execution.
+		// NOTE: 0 disables automatic redelivery.
+		RedeliveryMaxAttempts int `envconfig:"GITNESS_WEBHOOK_REDELIVERY_MAX_ATTEMPTS" default:"5"`
+		// RedeliveryBackoff is the delay before the first redelivery, it's doubled with every further attempt.
+		RedeliveryBackoff time.Duration `envconfig:"GITNESS_WEBHOOK_REDELIVERY_BACKOFF" default:"30s"`
+		// RedeliveryBackoffMax is the maximum delay between two redeliveries.
+		RedeliveryBackoffMax time.Duration `envconfig:"GITNESS_WEBHOOK_REDELIVERY_BACKOFF_MAX" default:"1h"`
+		// AutoDisableThreshold is the number of consecutive fatal executions after which a webhook gets disabled.
+		// NOTE: 0 disables the automatic disabling of webhooks.
+		AutoDisableThreshold int `envconfig:"GITNESS_WEBHOOK_AUTO_DISABLE_THRESHOLD" default:"10"`
 	}
 
 	Trigger struct {
@@ -335,12 +373,31 @@ type Config struct {
 		MaxRetries  int `envconfig:"GITNESS_TRIGGER_MAX_RETRIES" default:"3"`
 	}
 
+	PushMirror struct {
+		Concurrency int `envconfig:"GITNESS_PUSH_MIRROR_CONCURRENCY" default:"4"`
+		// MaxRetries is the number of times a failed push to a mirror is retried.
+		MaxRetries int `envconfig:"GITNESS_PUSH_MIRROR_MAX_RETRIES" default:"3"`
+	}
+
+	MergeQueue struct {
+		// CheckTimeout is the max time the required status checks of the head of a merge queue may take.
+		// Once exceeded, the pull request is removed from the queue so it doesn't block the pull requests behind it.
+		CheckTimeout time.Duration `envconfig:"GITNESS_MERGE_QUEUE_CHECK_TIMEOUT" default:"2h"`
+	}
+
 	Metric struct {
 		Enabled  bool   `envconfig:"GITNESS_METRIC_ENABLED" default:"true"`
 		Endpoint string `envconfig:"GITNESS_METRIC_ENDPOINT" default:"https://stats.drone.ci/api/v1/gitness"`
 		Token    string `envconfig:"GITNESS_METRIC_TOKEN"`
 	}
 
+	// Prometheus defines the configuration of the prometheus metrics endpoint.
+	Prometheus struct {
+		Enabled bool `envconfig:"GITNESS_PROMETHEUS_ENABLED" default:"false"`
+		// Token is an optional bearer token that is required for scraping the metrics endpoint.
+		Token string `envconfig:"GITNESS_PROMETHEUS_TOKEN"`
+	}
+
 	RepoSize struct {
 		Enabled     bool          `envconfig:"GITNESS_REPO_SIZE_ENABLED" default:"true"`
 		CRON        string        `envconfig:"GITNESS_REPO_SIZE_CRON" default:"0 0 * * *"`
@@ -369,6 +426,11 @@ type Config struct {
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+		// IndexPath is the directory the local search index is stored in (defaults to a folder in the git root).
+		// Every instance keeps its own index, outdated indices are rebuilt when they are searched.
+		IndexPath string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_PATH"`
+		// MaxFileSize is the maximum size of a file (in bytes) that is added to the search index.
+		MaxFileSize int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_FILE_SIZE" default:"1048576"`
 	}
 
 	Repos struct {
@@ -376,6 +438,11 @@ type Config struct {
 		DeletedRetentionTime time.Duration `envconfig:"GITNESS_REPOS_DELETED_RETENTION_TIME" default:"2160h"` // 90 days
 	}
 
+	Audit struct {
+		// RetentionTime is the duration after which audit events will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_AUDIT_RETENTION_TIME" default:"8760h"` // 365 days
+	}
+
 	Docker struct {
 		// Host sets the url to the docker server.
 		Host string `envconfig:"GITNESS_DOCKER_HOST" default:"unix:///var/run/docker.sock"`
@@ -403,6 +470,13 @@ type Config struct {
 		// Sub-directories will be created from this eg <DefaultBindMountSourceBasePath>/gitspace/space1/space2/config1
 		// If left blank, it will be set to $HOME/.gitness
 		DefaultBindMountSourceBasePath string `envconfig:"GITNESS_GITSPACE_DEFAULT_BIND_MOUNT_SOURCE_BASE_PATH"`
+		// AllowedRunArgs are the docker run arguments granting access to the host (--privileged, --cap-add,
+		// --security-opt, --network) the devcontainer config is allowed to use, e.g. "--cap-add=SYS_PTRACE".
+		// An entry without a value allows the argument with any value. By default, none are allowed.
+		AllowedRunArgs []string `envconfig:"GITNESS_GITSPACE_ALLOWED_RUN_ARGS"`
+		// AllowedBindMountSources are the host directories the devcontainer config is allowed to bind mount.
+		// By default, the devcontainer config can only use volume and tmpfs mounts.
+		AllowedBindMountSources []string `envconfig:"GITNESS_GITSPACE_ALLOWED_BIND_MOUNT_SOURCES"`
 
 		Events struct {
 			Concurrency int `envconfig:"GITNESS_GITSPACE_EVENTS_CONCURRENCY" default:"4"`
@@ -14,8 +14,195 @@
 
 package types
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 // DevcontainerConfig is parsed from code repos and follows the devcontainer.json spec. It uses camelCase.
+//
+//nolint:tagliatelle
 type DevcontainerConfig struct {
-	Image             string `json:"image"`
-	PostCreateCommand string `json:"postCreateCommand"` //nolint:tagliatelle
+	Image             string                                `json:"image"`
+	Build             *DevcontainerBuild                    `json:"build"`
+	ForwardPorts      []DevcontainerPort                    `json:"forwardPorts"`
+	ContainerEnv      map[string]string                     `json:"containerEnv"`
+	RemoteEnv         map[string]string                     `json:"remoteEnv"`
+	Mounts            []DevcontainerMount                   `json:"mounts"`
+	RunArgs           []string                              `json:"runArgs"`
+	RemoteUser        string                                `json:"remoteUser"`
+	OnCreateCommand   LifecycleCommand                      `json:"onCreateCommand"`
+	PostCreateCommand LifecycleCommand                      `json:"postCreateCommand"`
+	PostStartCommand  LifecycleCommand                      `json:"postStartCommand"`
+	Features          map[string]DevcontainerFeatureOptions `json:"features"`
+
+	// ConfigDir is the directory of the devcontainer.json file inside the repository.
+	// The paths of the config (e.g. the build context) are relative to it.
+	ConfigDir string `json:"-"`
+}
+
+// DevcontainerBuild describes how to build the image of the devcontainer from a Dockerfile.
+// Both Dockerfile and Context are relative to the devcontainer.json file.
+type DevcontainerBuild struct {
+	Dockerfile string            `json:"dockerfile"`
+	Context    string            `json:"context"`
+	Args       map[string]string `json:"args"`
+	Target     string            `json:"target"`
+}
+
+// DevcontainerPort is a port that should be forwarded from the devcontainer.
+// The spec allows a number (e.g. 3000) or a string (e.g. "db:5432").
+type DevcontainerPort string
+
+func (p *DevcontainerPort) UnmarshalJSON(data []byte) error {
+	var port int
+	if err := json.Unmarshal(data, &port); err == nil {
+		*p = DevcontainerPort(strconv.Itoa(port))
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("forwarded port must be a number or a string: %w", err)
+	}
+
+	*p = DevcontainerPort(s)
+
+	return nil
+}
+
+// DevcontainerMount is an additional mount of the devcontainer.
+// The spec allows a docker --mount formatted string or an object.
+type DevcontainerMount struct {
+	Type     string `json:"type"`
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readonly"`
+}
+
+func (m *DevcontainerMount) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		// alias avoids an infinite loop of unmarshaling.
+		type alias DevcontainerMount
+		return json.Unmarshal(data, (*alias)(m))
+	}
+
+	*m = DevcontainerMount{}
+	for _, part := range strings.Split(s, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch strings.ToLower(key) {
+		case "type":
+			m.Type = value
+		case "source", "src":
+			m.Source = value
+		case "target", "destination", "dst":
+			m.Target = value
+		case "readonly", "ro":
+			m.ReadOnly = value == "" || value == "true" || value == "1"
+		}
+	}
+
+	return nil
+}
+
+// LifecycleCommand is a list of shell commands executed during a lifecycle phase of the devcontainer.
+// The spec allows a string (run in a shell), an array (run without a shell)
+// or an object of named commands. Named commands are executed sequentially in the order of their names.
+type LifecycleCommand []string
+
+func (c *LifecycleCommand) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*c = nil
+		return nil
+	}
+
+	var named map[string]json.RawMessage
+	if err := json.Unmarshal(data, &named); err != nil {
+		command, err := parseLifecycleCommand(data)
+		if err != nil {
+			return err
+		}
+
+		*c = nil
+		if command != "" {
+			*c = LifecycleCommand{command}
+		}
+
+		return nil
+	}
+
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	commands := make(LifecycleCommand, 0, len(names))
+	for _, name := range names {
+		command, err := parseLifecycleCommand(named[name])
+		if err != nil {
+			return fmt.Errorf("invalid lifecycle command %q: %w", name, err)
+		}
+		if command != "" {
+			commands = append(commands, command)
+		}
+	}
+
+	*c = commands
+
+	return nil
+}
+
+// parseLifecycleCommand converts a lifecycle command given as a string or an array into a shell command.
+func parseLifecycleCommand(data []byte) (string, error) {
+	var command string
+	if err := json.Unmarshal(data, &command); err == nil {
+		return command, nil
+	}
+
+	var args []string
+	if err := json.Unmarshal(data, &args); err != nil {
+		return "", fmt.Errorf("lifecycle command must be a string, an array or an object: %w", err)
+	}
+
+	for i, arg := range args {
+		args[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+
+	return strings.Join(args, " "), nil
+}
+
+// DevcontainerFeatureOptions are the options of a devcontainer feature.
+// The spec allows an object of options, a string (used as the version option) or a boolean.
+type DevcontainerFeatureOptions map[string]string
+
+func (o *DevcontainerFeatureOptions) UnmarshalJSON(data []byte) error {
+	var version string
+	if err := json.Unmarshal(data, &version); err == nil {
+		*o = DevcontainerFeatureOptions{"version": version}
+		return nil
+	}
+
+	var enabled bool
+	if err := json.Unmarshal(data, &enabled); err == nil {
+		*o = DevcontainerFeatureOptions{}
+		return nil
+	}
+
+	var options map[string]interface{}
+	if err := json.Unmarshal(data, &options); err != nil {
+		return fmt.Errorf("feature options must be an object, a string or a boolean: %w", err)
+	}
+
+	*o = make(DevcontainerFeatureOptions, len(options))
+	for key, value := range options {
+		(*o)[key] = fmt.Sprint(value)
+	}
+
+	return nil
 }
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDevcontainerConfig_Unmarshal(t *testing.T) {
+	data := `{
+		"build": {"dockerfile": "Dockerfile", "context": "..", "args": {"VARIANT": "3.11"}},
+		"forwardPorts": [3000, "db:5432"],
+		"containerEnv": {"FOO": "bar"},
+		"mounts": [
+			"source=cache,target=/cache,type=volume",
+			{"source": "/tmp", "target": "/host-tmp", "type": "bind"}
+		],
+		"runArgs": ["--cap-add=SYS_PTRACE"],
+		"remoteUser": "vscode",
+		"onCreateCommand": "make deps",
+		"postCreateCommand": ["echo", "it's done"],
+		"postStartCommand": {"server": "npm start", "db": ["docker", "start", "db"]},
+		"features": {
+			"ghcr.io/devcontainers/features/go:1": "1.22",
+			"ghcr.io/devcontainers/features/node:1": {"version": "lts", "nodeGypDependencies": true},
+			"ghcr.io/devcontainers/features/git:1": true
+		}
+	}`
+
+	var config DevcontainerConfig
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+
+	want := DevcontainerConfig{
+		Build: &DevcontainerBuild{
+			Dockerfile: "Dockerfile",
+			Context:    "..",
+			Args:       map[string]string{"VARIANT": "3.11"},
+		},
+		ForwardPorts: []DevcontainerPort{"3000", "db:5432"},
+		ContainerEnv: map[string]string{"FOO": "bar"},
+		Mounts: []DevcontainerMount{
+			{Type: "volume", Source: "cache", Target: "/cache"},
+			{Type: "bind", Source: "/tmp", Target: "/host-tmp"},
+		},
+		RunArgs:           []string{"--cap-add=SYS_PTRACE"},
+		RemoteUser:        "vscode",
+		OnCreateCommand:   LifecycleCommand{"make deps"},
+		PostCreateCommand: LifecycleCommand{`'echo' 'it'\''s done'`},
+		PostStartCommand:  LifecycleCommand{`'docker' 'start' 'db'`, "npm start"},
+		Features: map[string]DevcontainerFeatureOptions{
+			"ghcr.io/devcontainers/features/go:1":   {"version": "1.22"},
+			"ghcr.io/devcontainers/features/node:1": {"version": "lts", "nodeGypDependencies": "true"},
+			"ghcr.io/devcontainers/features/git:1":  {},
+		},
+	}
+
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("unexpected config:\ngot:  %+v\nwant: %+v", config, want)
+	}
+}
@@ -132,3 +132,50 @@ const (
 	PermissionGitspaceDelete Permission = "gitspace_delete"
 	PermissionGitspaceAccess Permission = "gitspace_access"
 )
+
+func (Permission) Enum() []interface{}              { return toInterfaceSlice(permissions) }
+func (p Permission) Sanitize() (Permission, bool)   { return Sanitize(p, GetAllPermissions) }
+func GetAllPermissions() ([]Permission, Permission) { return permissions, "" }
+
+var permissions = sortEnum([]Permission{
+	PermissionSpaceView,
+	PermissionSpaceEdit,
+	PermissionSpaceDelete,
+	PermissionRepoView,
+	PermissionRepoEdit,
+	PermissionRepoDelete,
+	PermissionRepoPush,
+	PermissionRepoReview,
+	PermissionRepoReportCommitCheck,
+	PermissionUserView,
+	PermissionUserEdit,
+	PermissionUserDelete,
+	PermissionUserEditAdmin,
+	PermissionServiceAccountView,
+	PermissionServiceAccountEdit,
+	PermissionServiceAccountDelete,
+	PermissionServiceView,
+	PermissionServiceEdit,
+	PermissionServiceDelete,
+	PermissionServiceEditAdmin,
+	PermissionPipelineView,
+	PermissionPipelineEdit,
+	PermissionPipelineDelete,
+	PermissionPipelineExecute,
+	PermissionSecretView,
+	PermissionSecretEdit,
+	PermissionSecretDelete,
+	PermissionSecretAccess,
+	PermissionConnectorView,
+	PermissionConnectorEdit,
+	PermissionConnectorDelete,
+	PermissionConnectorAccess,
+	PermissionTemplateView,
+	PermissionTemplateEdit,
+	PermissionTemplateDelete,
+	PermissionTemplateAccess,
+	PermissionGitspaceView,
+	PermissionGitspaceEdit,
+	PermissionGitspaceDelete,
+	PermissionGitspaceAccess,
+})
@@ -25,6 +25,7 @@ const (
 
 var publicKeyTypes = sortEnum([]PublicKeyUsage{
 	PublicKeyUsageAuth,
+	PublicKeyUsageSign,
 })
 
 func (PublicKeyUsage) Enum() []interface{} { return toInterfaceSlice(publicKeyTypes) }
@@ -54,3 +55,40 @@ func (s PublicKeySort) Sanitize() (PublicKeySort, bool) { return Sanitize(s, Get
 func GetAllPublicKeySorts() ([]PublicKeySort, PublicKeySort) {
 	return publicKeySorts, PublicKeySortCreated
 }
+
+// CommitSignatureType represents the type of commit signature.
+type CommitSignatureType string
+
+// CommitSignatureType enumeration.
+const (
+	CommitSignatureTypeGPG CommitSignatureType = "gpg"
+	CommitSignatureTypeSSH CommitSignatureType = "ssh"
+)
+
+var commitSignatureTypes = sortEnum([]CommitSignatureType{
+	CommitSignatureTypeGPG,
+	CommitSignatureTypeSSH,
+})
+
+func (CommitSignatureType) Enum() []interface{} { return toInterfaceSlice(commitSignatureTypes) }
+
+// CommitSignatureStatus represents the result of the verification of a commit signature.
+type CommitSignatureStatus string
+
+// CommitSignatureStatus enumeration.
+const (
+	// CommitSignatureStatusVerified means the commit is signed with a sign key of the committer.
+	CommitSignatureStatusVerified CommitSignatureStatus = "verified"
+	// CommitSignatureStatusUnverified means the signature is invalid or can't be verified.
+	CommitSignatureStatusUnverified CommitSignatureStatus = "unverified"
+	// CommitSignatureStatusUnknownKey means the commit is signed with a key that isn't a sign key of the committer.
+	CommitSignatureStatusUnknownKey CommitSignatureStatus = "unknown_key"
+)
+
+var commitSignatureStatuses = sortEnum([]CommitSignatureStatus{
+	CommitSignatureStatusVerified,
+	CommitSignatureStatusUnverified,
+	CommitSignatureStatusUnknownKey,
+})
+
+func (CommitSignatureStatus) Enum() []interface{} { return toInterfaceSlice(commitSignatureStatuses) }
@@ -86,6 +86,8 @@ const (
 	PullReqActivityTypeBranchUpdate PullReqActivityType = "branch-update"
 	PullReqActivityTypeBranchDelete PullReqActivityType = "branch-delete"
 	PullReqActivityTypeMerge        PullReqActivityType = "merge"
+	PullReqActivityTypeAutoMerge    PullReqActivityType = "auto-merge"
+	PullReqActivityTypeMergeQueue   PullReqActivityType = "merge-queue"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -97,8 +99,54 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeBranchUpdate,
 	PullReqActivityTypeBranchDelete,
 	PullReqActivityTypeMerge,
+	PullReqActivityTypeAutoMerge,
+	PullReqActivityTypeMergeQueue,
 })
 
+// PullReqAutoMergeAction defines what happened with the auto-merge of a pull request.
+type PullReqAutoMergeAction string
+
+// PullReqAutoMergeAction enumeration.
+const (
+	// PullReqAutoMergeActionEnabled auto-merge has been enabled by a user.
+	PullReqAutoMergeActionEnabled PullReqAutoMergeAction = "enabled"
+	// PullReqAutoMergeActionDisabled auto-merge has been disabled by a user.
+	PullReqAutoMergeActionDisabled PullReqAutoMergeAction = "disabled"
+	// PullReqAutoMergeActionCanceled auto-merge has been canceled because new commits were pushed.
+	PullReqAutoMergeActionCanceled PullReqAutoMergeAction = "canceled"
+	// PullReqAutoMergeActionFailed auto-merge has been attempted but the pull request couldn't be merged.
+	PullReqAutoMergeActionFailed PullReqAutoMergeAction = "failed"
+	// PullReqAutoMergeActionMerged the pull request has been merged automatically.
+	PullReqAutoMergeActionMerged PullReqAutoMergeAction = "merged"
+	// PullReqAutoMergeActionQueued the pull request has been added to the merge queue automatically.
+	PullReqAutoMergeActionQueued PullReqAutoMergeAction = "queued"
+)
+
+// PullReqMergeQueueAction defines what happened with a pull request in the merge queue.
+type PullReqMergeQueueAction string
+
+// PullReqMergeQueueAction enumeration.
+const (
+	// PullReqMergeQueueActionAdded the pull request has been added to the merge queue by a user.
+	PullReqMergeQueueActionAdded PullReqMergeQueueAction = "added"
+	// PullReqMergeQueueActionRemoved the pull request has been removed from the merge queue by a user.
+	PullReqMergeQueueActionRemoved PullReqMergeQueueAction = "removed"
+	// PullReqMergeQueueActionFailed the pull request has been removed from the merge queue
+	// because it couldn't be merged or its required checks failed.
+	PullReqMergeQueueActionFailed PullReqMergeQueueAction = "failed"
+)
+
+// MergeQueueEntryState defines the state of a merge queue entry.
+type MergeQueueEntryState string
+
+// MergeQueueEntryState enumeration.
+const (
+	// MergeQueueEntryStateQueued the entry is waiting for its merge ref to be built.
+	MergeQueueEntryStateQueued MergeQueueEntryState = "queued"
+	// MergeQueueEntryStateChecking the merge ref of the entry is built and its checks are running.
+	MergeQueueEntryStateChecking MergeQueueEntryState = "checking"
+)
+
 // PullReqActivityKind defines kind of pull request activity system message.
 // Kind defines the source of the pull request activity entry:
 // Whether it's generated by the system, it's a user comment or a part of code review.
@@ -211,15 +259,17 @@ type MergeMethod gitenum.MergeMethod
 
 // MergeMethod enumeration.
 const (
-	MergeMethodMerge  = MergeMethod(gitenum.MergeMethodMerge)
-	MergeMethodSquash = MergeMethod(gitenum.MergeMethodSquash)
-	MergeMethodRebase = MergeMethod(gitenum.MergeMethodRebase)
+	MergeMethodMerge       = MergeMethod(gitenum.MergeMethodMerge)
+	MergeMethodSquash      = MergeMethod(gitenum.MergeMethodSquash)
+	MergeMethodRebase      = MergeMethod(gitenum.MergeMethodRebase)
+	MergeMethodFastForward = MergeMethod(gitenum.MergeMethodFastForward)
 )
 
 var MergeMethods = sortEnum([]MergeMethod{
 	MergeMethodMerge,
 	MergeMethodSquash,
 	MergeMethodRebase,
+	MergeMethodFastForward,
 })
 
 func (MergeMethod) Enum() []interface{} { return toInterfaceSlice(MergeMethods) }
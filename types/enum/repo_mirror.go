@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RepoMirrorSyncStatus defines the status of the latest synchronization of a repository mirror.
+type RepoMirrorSyncStatus string
+
+func (RepoMirrorSyncStatus) Enum() []interface{} { return toInterfaceSlice(repoMirrorSyncStatuses) }
+
+const (
+	// RepoMirrorSyncStatusPending means that the synchronization is scheduled, but hasn't started yet.
+	RepoMirrorSyncStatusPending RepoMirrorSyncStatus = "pending"
+
+	// RepoMirrorSyncStatusRunning means that the synchronization is in progress.
+	RepoMirrorSyncStatusRunning RepoMirrorSyncStatus = "running"
+
+	// RepoMirrorSyncStatusSuccess means that the latest synchronization succeeded.
+	RepoMirrorSyncStatusSuccess RepoMirrorSyncStatus = "success"
+
+	// RepoMirrorSyncStatusFailed means that the latest synchronization failed.
+	RepoMirrorSyncStatusFailed RepoMirrorSyncStatus = "failed"
+)
+
+var repoMirrorSyncStatuses = sortEnum([]RepoMirrorSyncStatus{
+	RepoMirrorSyncStatusPending,
+	RepoMirrorSyncStatusRunning,
+	RepoMirrorSyncStatusSuccess,
+	RepoMirrorSyncStatusFailed,
+})
+
+// IsInProgress returns true if the synchronization is scheduled or running.
+func (s RepoMirrorSyncStatus) IsInProgress() bool {
+	return s == RepoMirrorSyncStatusPending || s == RepoMirrorSyncStatusRunning
+}
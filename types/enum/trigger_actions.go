@@ -40,6 +40,8 @@ const (
 	TriggerActionPullReqClosed = "pullreq_closed"
 	// TriggerActionPullReqMerged gets triggered when a pull request is merged.
 	TriggerActionPullReqMerged = "pullreq_merged"
+	// TriggerActionPullReqMergeQueued gets triggered when the merge queue builds a merge ref for a pull request.
+	TriggerActionPullReqMergeQueued TriggerAction = "pullreq_merge_queued"
 )
 
 func (TriggerAction) Enum() []interface{}               { return toInterfaceSlice(triggerActions) }
@@ -49,7 +51,8 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 		t == TriggerActionPullReqBranchUpdated ||
 		t == TriggerActionPullReqReopened ||
 		t == TriggerActionPullReqClosed ||
-		t == TriggerActionPullReqMerged {
+		t == TriggerActionPullReqMerged ||
+		t == TriggerActionPullReqMergeQueued {
 		return TriggerEventPullRequest
 	}
 	if t == TriggerActionTagCreated || t == TriggerActionTagUpdated {
@@ -75,6 +78,7 @@ var triggerActions = sortEnum([]TriggerAction{
 	TriggerActionPullReqBranchUpdated,
 	TriggerActionPullReqClosed,
 	TriggerActionPullReqMerged,
+	TriggerActionPullReqMergeQueued,
 })
 
 // Trigger types.
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// TriggerType defines the different kinds of triggers.
+type TriggerType string
+
+const (
+	// TriggerTypeHook fires executions on git and pull request events.
+	TriggerTypeHook TriggerType = "hook"
+	// TriggerTypeCron fires executions periodically based on a cron expression.
+	TriggerTypeCron TriggerType = "cron"
+)
+
+func (TriggerType) Enum() []interface{}             { return toInterfaceSlice(triggerTypes) }
+func (t TriggerType) Sanitize() (TriggerType, bool) { return Sanitize(t, GetAllTriggerTypes) }
+
+func GetAllTriggerTypes() ([]TriggerType, TriggerType) {
+	return triggerTypes, TriggerTypeHook
+}
+
+var triggerTypes = sortEnum([]TriggerType{
+	TriggerTypeHook,
+	TriggerTypeCron,
+})
@@ -99,6 +99,38 @@ var webhookParents = sortEnum([]WebhookParent{
 	WebhookParentSpace,
 })
 
+// WebhookPayloadFormat defines the format of the request body sent by a webhook.
+type WebhookPayloadFormat string
+
+func (WebhookPayloadFormat) Enum() []interface{} { return toInterfaceSlice(webhookPayloadFormats) }
+func (f WebhookPayloadFormat) Sanitize() (WebhookPayloadFormat, bool) {
+	return Sanitize(f, GetAllWebhookPayloadFormats)
+}
+func GetAllWebhookPayloadFormats() ([]WebhookPayloadFormat, WebhookPayloadFormat) {
+	return webhookPayloadFormats, WebhookPayloadFormatJSON
+}
+
+const (
+	// WebhookPayloadFormatJSON describes the native JSON payload of the webhook.
+	WebhookPayloadFormatJSON WebhookPayloadFormat = "json"
+
+	// WebhookPayloadFormatSlack describes a payload compatible with Slack incoming webhooks.
+	WebhookPayloadFormatSlack WebhookPayloadFormat = "slack"
+
+	// WebhookPayloadFormatTeams describes a payload compatible with Microsoft Teams incoming webhooks.
+	WebhookPayloadFormatTeams WebhookPayloadFormat = "teams"
+
+	// WebhookPayloadFormatTemplate describes a payload generated from a user provided Go text template.
+	WebhookPayloadFormatTemplate WebhookPayloadFormat = "template"
+)
+
+var webhookPayloadFormats = sortEnum([]WebhookPayloadFormat{
+	WebhookPayloadFormatJSON,
+	WebhookPayloadFormatSlack,
+	WebhookPayloadFormatTeams,
+	WebhookPayloadFormatTemplate,
+})
+
 // WebhookExecutionResult defines the different results of a webhook execution.
 type WebhookExecutionResult string
 
@@ -158,6 +190,26 @@ const (
 	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
 	// WebhookTriggerPullReqMerged gets triggered when a pull request is merged.
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
+	// WebhookTriggerPullReqUpdated gets triggered when the title or description of a pull request gets edited.
+	WebhookTriggerPullReqUpdated WebhookTrigger = "pullreq_updated"
+	// WebhookTriggerPullReqReviewSubmitted gets triggered when a review of a pull request gets submitted.
+	WebhookTriggerPullReqReviewSubmitted WebhookTrigger = "pullreq_review_submitted"
+	// WebhookTriggerPullReqReviewerAdded gets triggered when a reviewer gets added to a pull request.
+	WebhookTriggerPullReqReviewerAdded WebhookTrigger = "pullreq_reviewer_added"
+	// WebhookTriggerPullReqReviewerRemoved gets triggered when a reviewer gets removed from a pull request.
+	WebhookTriggerPullReqReviewerRemoved WebhookTrigger = "pullreq_reviewer_removed"
+
+	// WebhookTriggerCheckStatusChanged gets triggered when the status of a status check gets reported.
+	WebhookTriggerCheckStatusChanged WebhookTrigger = "check_status_changed"
+
+	// WebhookTriggerRepoCreated gets triggered when a repository gets created.
+	WebhookTriggerRepoCreated WebhookTrigger = "repo_created"
+	// WebhookTriggerRepoDeleted gets triggered when a repository gets deleted.
+	WebhookTriggerRepoDeleted WebhookTrigger = "repo_deleted"
+	// WebhookTriggerRepoRenamed gets triggered when a repository gets renamed.
+	WebhookTriggerRepoRenamed WebhookTrigger = "repo_renamed"
+	// WebhookTriggerRepoDefaultBranchChanged gets triggered when the default branch of a repository gets changed.
+	WebhookTriggerRepoDefaultBranchChanged WebhookTrigger = "repo_default_branch_changed"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -173,4 +225,13 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerPullReqUpdated,
+	WebhookTriggerPullReqReviewSubmitted,
+	WebhookTriggerPullReqReviewerAdded,
+	WebhookTriggerPullReqReviewerRemoved,
+	WebhookTriggerCheckStatusChanged,
+	WebhookTriggerRepoCreated,
+	WebhookTriggerRepoDeleted,
+	WebhookTriggerRepoRenamed,
+	WebhookTriggerRepoDefaultBranchChanged,
 })
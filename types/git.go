@@ -84,6 +84,17 @@ type Commit struct {
 	Author     Signature    `json:"author"`
 	Committer  Signature    `json:"committer"`
 	Stats      *CommitStats `json:"stats,omitempty"`
+
+	// Verification is the result of the verification of the commit signature. It's nil for unsigned commits.
+	Verification *CommitVerification `json:"verification,omitempty"`
+}
+
+// CommitVerification contains the result of the verification of a commit signature.
+type CommitVerification struct {
+	Status         enum.CommitSignatureStatus `json:"status"`
+	Type           enum.CommitSignatureType   `json:"type,omitempty"`
+	KeyFingerprint string                     `json:"key_fingerprint,omitempty"`
+	Signer         *PrincipalInfo             `json:"signer,omitempty"`
 }
 
 type Signature struct {
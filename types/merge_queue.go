@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its target branch.
+type MergeQueueEntry struct {
+	ID           int64                     `json:"id"`
+	Version      int64                     `json:"-"`
+	RepoID       int64                     `json:"repo_id"`
+	PullReqID    int64                     `json:"pullreq_id"`
+	TargetBranch string                    `json:"target_branch"`
+	Method       enum.MergeMethod          `json:"method"`
+	State        enum.MergeQueueEntryState `json:"state"`
+
+	// BaseSHA is the commit the merge ref of the entry has been built on:
+	// Either the target branch or the merge ref of the previous entry in the queue.
+	BaseSHA string `json:"base_sha,omitempty"`
+	// HeadSHA is the source commit of the pull request that has been used to build the merge ref.
+	HeadSHA string `json:"head_sha,omitempty"`
+	// MergeSHA is the commit the merge ref of the entry points at.
+	MergeSHA string `json:"merge_sha,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
@@ -33,4 +33,5 @@ type PublicKeyFilter struct {
 	ListQueryFilter
 	Sort  enum.PublicKeySort
 	Order enum.Order
+	Usage enum.PublicKeyUsage
 }
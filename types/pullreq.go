@@ -51,6 +51,10 @@ type PullReq struct {
 	Merged      *int64            `json:"merged"`
 	MergeMethod *enum.MergeMethod `json:"merge_method"`
 
+	// AutoMergeMethod is set if the pull request should be merged automatically once all rules are satisfied.
+	AutoMergeMethod *enum.MergeMethod `json:"auto_merge_method,omitempty"`
+	AutoMergeBy     *int64            `json:"-"` // not returned, it's the principal on whose behalf the PR gets merged
+
 	MergeCheckStatus enum.MergeCheckStatus `json:"merge_check_status"`
 	MergeTargetSHA   *string               `json:"merge_target_sha"`
 	MergeBaseSHA     string                `json:"merge_base_sha"`
@@ -95,6 +99,8 @@ type PullReqFilter struct {
 	SourceBranch  string              `json:"source_branch"`
 	TargetRepoID  int64               `json:"-"`
 	TargetBranch  string              `json:"target_branch"`
+	SourceSHA     string              `json:"-"`
+	AutoMerge     bool                `json:"-"` // only pull requests with auto-merge enabled
 	States        []enum.PullReqState `json:"state"`
 	Sort          enum.PullReqSort    `json:"sort"`
 	Order         enum.Order          `json:"order"`
@@ -157,6 +163,7 @@ type MergeResponse struct {
 	// values only returned on dryrun
 	DryRun                              bool               `json:"dry_run,omitempty"`
 	ConflictFiles                       []string           `json:"conflict_files,omitempty"`
+	RequiresRebase                      bool               `json:"requires_rebase,omitempty"`
 	AllowedMethods                      []enum.MergeMethod `json:"allowed_methods,omitempty"`
 	MinimumRequiredApprovalsCount       int                `json:"minimum_required_approvals_count,omitempty"`
 	MinimumRequiredApprovalsCountLatest int                `json:"minimum_required_approvals_count_latest,omitempty"`
@@ -60,6 +60,8 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewSubmit{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMerge{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueue{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -101,6 +103,29 @@ func (a *PullRequestActivityPayloadMerge) ActivityType() enum.PullReqActivityTyp
 	return enum.PullReqActivityTypeMerge
 }
 
+type PullRequestActivityPayloadAutoMerge struct {
+	Action      enum.PullReqAutoMergeAction `json:"action"`
+	MergeMethod enum.MergeMethod            `json:"merge_method"`
+	SourceSHA   string                      `json:"source_sha,omitempty"`
+	Reason      string                      `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadAutoMerge) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMerge
+}
+
+type PullRequestActivityPayloadMergeQueue struct {
+	Action       enum.PullReqMergeQueueAction `json:"action"`
+	TargetBranch string                       `json:"target_branch"`
+	SourceSHA    string                       `json:"source_sha,omitempty"`
+	MergeSHA     string                       `json:"merge_sha,omitempty"`
+	Reason       string                       `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueue) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueue
+}
+
 type PullRequestActivityPayloadStateChange struct {
 	Old      enum.PullReqState `json:"old"`
 	New      enum.PullReqState `json:"new"`
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PushMirror is an external git repository to which branches and tags of a repository
+// are pushed after every change, e.g. to keep a disaster recovery copy on another git provider.
+type PushMirror struct {
+	ID         int64  `json:"id"`
+	Version    int64  `json:"-"`
+	RepoID     int64  `json:"repo_id"`
+	Identifier string `json:"identifier"`
+
+	// URL is the clone URL of the external repository.
+	URL string `json:"url"`
+
+	// Connector is the identifier of a connector in the parent space of the repository
+	// which holds the credentials used to access the external repository.
+	Connector string `json:"connector"`
+
+	// Secret is the identifier of a secret in the parent space of the repository
+	// which holds the password or token used to access the external repository as Username.
+	Username string `json:"username"`
+	Secret   string `json:"secret"`
+
+	// RefFilter lists the patterns of the references that are pushed (e.g. "refs/heads/*").
+	// A pattern can contain at most one wildcard "*".
+	RefFilter []string `json:"ref_filter"`
+
+	// Force makes the filtered references of the external repository an exact copy of the local ones:
+	// diverged references are overwritten and the references deleted locally are deleted remotely.
+	Force bool `json:"force"`
+
+	Enabled bool `json:"enabled"`
+
+	LastSuccess int64  `json:"last_success"`
+	LastError   string `json:"last_error"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RepoMirror holds the configuration and the synchronization state of a repository
+// that is a pull mirror of an external git repository.
+// Branches and tags of a mirror are read-only, they are updated only by synchronizing with the source.
+type RepoMirror struct {
+	RepoID  int64 `json:"-"`
+	Version int64 `json:"-"`
+
+	// SourceURL is the clone URL of the external repository.
+	SourceURL string `json:"source_url"`
+
+	// Connector is the identifier of a connector in the parent space of the repository
+	// which holds the credentials used to access the source repository. It's empty for public repositories.
+	Connector string `json:"connector"`
+
+	// Interval is the number of minutes between two scheduled synchronizations.
+	// Zero disables scheduled synchronization, the mirror is then synchronized only on request.
+	Interval int64 `json:"interval"`
+
+	NextSync   int64                     `json:"next_sync"`
+	LastSync   int64                     `json:"last_sync"`
+	SyncStatus enum.RepoMirrorSyncStatus `json:"sync_status"`
+	LastError  string                    `json:"last_error"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
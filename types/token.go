@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 
 	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
 )
 
 // Represents server side infos stored for tokens we distribute.
@@ -32,6 +34,39 @@ type Token struct {
 	// IssuedAt is the unix time at which the token was issued.
 	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
 	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+
+	TokenScope
+
+	// LastUsed is the unix time at which the token was last used for authentication.
+	LastUsed *int64 `db:"token_last_used"          json:"last_used,omitempty"`
+	// LastUsedIP is the client IP address from which the token was last used.
+	LastUsedIP string `db:"token_last_used_ip"       json:"last_used_ip,omitempty"`
+}
+
+// TokenScope optionally restricts a token to a subset of the permissions of its principal
+// and to resources inside of a set of spaces and repositories.
+type TokenScope struct {
+	// Permissions is the list of permissions granted to the token. Empty list means no restriction.
+	Permissions []enum.Permission `json:"permissions,omitempty"`
+	// SpaceIDs and RepoIDs list the spaces and repositories the token is restricted to.
+	// If both are empty, the token isn't restricted to any resource.
+	SpaceIDs []int64 `json:"space_ids,omitempty"`
+	RepoIDs  []int64 `json:"repo_ids,omitempty"`
+}
+
+// HasPermission returns true if the token scope doesn't restrict the permission.
+func (s TokenScope) HasPermission(permission enum.Permission) bool {
+	return len(s.Permissions) == 0 || slices.Contains(s.Permissions, permission)
+}
+
+// IsResourceRestricted returns true if the token scope is restricted to a set of spaces or repositories.
+func (s TokenScope) IsResourceRestricted() bool {
+	return len(s.SpaceIDs) > 0 || len(s.RepoIDs) > 0
+}
+
+// IsRestricted returns true if the token scope restricts the token in any way.
+func (s TokenScope) IsRestricted() bool {
+	return len(s.Permissions) > 0 || s.IsResourceRestricted()
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
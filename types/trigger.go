@@ -23,7 +23,7 @@ import (
 type Trigger struct {
 	ID          int64                `json:"-"`
 	Description string               `json:"description"`
-	Type        string               `json:"trigger_type"`
+	Type        enum.TriggerType     `json:"trigger_type"`
 	PipelineID  int64                `json:"pipeline_id"`
 	Secret      string               `json:"-"`
 	RepoID      int64                `json:"repo_id"`
@@ -34,6 +34,13 @@ type Trigger struct {
 	Created     int64                `json:"created"`
 	Updated     int64                `json:"updated"`
 	Version     int64                `json:"-"`
+
+	// Cron trigger configuration, only used for triggers of type cron.
+	CronExpression string `json:"cron_expression,omitempty"`
+	CronTimezone   string `json:"cron_timezone,omitempty"`
+	CronBranch     string `json:"cron_branch,omitempty"`
+	// CronNext is the time (unix millis) at which the cron trigger fires next.
+	CronNext int64 `json:"cron_next,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -15,9 +15,56 @@
 // Package types defines common data structures.
 package types
 
+import "github.com/harness/gitness/types/enum"
+
+// UserGroup represents a space scoped group of users.
 type UserGroup struct {
+	ID          int64  `json:"id"`
+	SpaceID     int64  `json:"space_id"`
 	Identifier  string `json:"identifier"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
-	Users       []string
+	CreatedBy   int64  `json:"created_by"`
+	Created     int64  `json:"created"`
+	Updated     int64  `json:"updated"`
+
+	// Users contains the UIDs of the group members.
+	// It's only populated when the group gets resolved (e.g. for CODEOWNERS).
+	Users []string `json:"-"`
+}
+
+// UserGroupMember represents a user's membership of a user group.
+type UserGroupMember struct {
+	UserGroupID int64 `json:"-"`
+	PrincipalID int64 `json:"-"`
+	CreatedBy   int64 `json:"-"`
+	Created     int64 `json:"created"`
+
+	Principal PrincipalInfo `json:"principal"`
+	AddedBy   PrincipalInfo `json:"added_by"`
+}
+
+// UserGroupMembershipKey can be used as a key for finding a user group's space membership info.
+type UserGroupMembershipKey struct {
+	SpaceID     int64
+	UserGroupID int64
+}
+
+// UserGroupMembership represents a user group's membership of a space.
+// All members of the user group get the role of the membership in the space.
+type UserGroupMembership struct {
+	UserGroupMembershipKey `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Role enum.MembershipRole `json:"role"`
+}
+
+// UserGroupMembershipInfo adds user group info to the UserGroupMembership data.
+type UserGroupMembershipInfo struct {
+	UserGroupMembership
+	UserGroup UserGroup     `json:"usergroup"`
+	AddedBy   PrincipalInfo `json:"added_by"`
 }
@@ -40,6 +40,8 @@ type Webhook struct {
 	Secret                string                       `json:"-"`
 	Enabled               bool                         `json:"enabled"`
 	Insecure              bool                         `json:"insecure"`
+	PayloadFormat         enum.WebhookPayloadFormat    `json:"payload_format"`
+	PayloadTemplate       string                       `json:"payload_template,omitempty"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty"`
 }